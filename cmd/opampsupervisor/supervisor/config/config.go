@@ -81,6 +81,12 @@ func (s Supervisor) Validate() error {
 		return err
 	}
 
+	if s.Capabilities.AcceptsPackages {
+		if err := s.Agent.validatePackages(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -101,6 +107,7 @@ type Capabilities struct {
 	ReportsHealth                  bool `mapstructure:"reports_health"`
 	ReportsRemoteConfig            bool `mapstructure:"reports_remote_config"`
 	ReportsAvailableComponents     bool `mapstructure:"reports_available_components"`
+	AcceptsPackages                bool `mapstructure:"accepts_packages"`
 }
 
 func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
@@ -146,6 +153,11 @@ func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
 		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsAvailableComponents
 	}
 
+	if c.AcceptsPackages {
+		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+	}
+
 	return supportedCapabilities
 }
 
@@ -190,6 +202,8 @@ type Agent struct {
 	ConfigFiles             []string          `mapstructure:"config_files"`
 	Arguments               []string          `mapstructure:"args"`
 	Env                     map[string]string `mapstructure:"env"`
+	PackageApplyTimeout     time.Duration     `mapstructure:"package_apply_timeout"`
+	Signature               AgentSignature    `mapstructure:"signature"`
 }
 
 func (a Agent) Validate() error {
@@ -221,6 +235,39 @@ func (a Agent) Validate() error {
 	return nil
 }
 
+// validatePackages validates the settings used to install the packages offered by the Server.
+func (a Agent) validatePackages() error {
+	if a.PackageApplyTimeout <= 0 {
+		return errors.New("agent::package_apply_timeout must be positive")
+	}
+
+	if a.Signature.SkipVerify {
+		return nil
+	}
+
+	if len(a.Signature.PublicKeyFiles) == 0 {
+		return errors.New("agent::signature::public_key_files must be specified when capabilities::accepts_packages is enabled, unless agent::signature::skip_verify is set")
+	}
+
+	for _, file := range a.Signature.PublicKeyFiles {
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("could not stat agent::signature::public_key_files path: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// AgentSignature configures the verification of the signature of the packages offered by the Server.
+type AgentSignature struct {
+	// PublicKeyFiles are the paths to PEM encoded public keys. A package is accepted
+	// if its signature was made by any of them.
+	PublicKeyFiles []string `mapstructure:"public_key_files"`
+	// SkipVerify disables the verification of the signature of the packages.
+	// The content hash of the packages is still verified.
+	SkipVerify bool `mapstructure:"skip_verify"`
+}
+
 type AgentDescription struct {
 	IdentifyingAttributes    map[string]string `mapstructure:"identifying_attributes"`
 	NonIdentifyingAttributes map[string]string `mapstructure:"non_identifying_attributes"`
@@ -277,6 +324,7 @@ func DefaultSupervisor() Supervisor {
 			ReportsHealth:                  true,
 			ReportsRemoteConfig:            false,
 			ReportsAvailableComponents:     false,
+			AcceptsPackages:                false,
 		},
 		Storage: Storage{
 			Directory: defaultStorageDir,
@@ -285,6 +333,7 @@ func DefaultSupervisor() Supervisor {
 			OrphanDetectionInterval: 5 * time.Second,
 			ConfigApplyTimeout:      5 * time.Second,
 			BootstrapTimeout:        3 * time.Second,
+			PackageApplyTimeout:     30 * time.Second,
 			PassthroughLogs:         false,
 		},
 		Telemetry: Telemetry{
@@ -403,6 +403,85 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidatePackages(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	filePath := filepath.Join(tmpDir, "file")
+	require.NoError(t, os.WriteFile(filePath, []byte{}, 0o600))
+
+	testCases := []struct {
+		name          string
+		agent         Agent
+		expectedError string
+	}{
+		{
+			name: "Public key files",
+			agent: Agent{
+				PackageApplyTimeout: 30 * time.Second,
+				Signature:           AgentSignature{PublicKeyFiles: []string{filePath}},
+			},
+		},
+		{
+			name: "Skip verify",
+			agent: Agent{
+				PackageApplyTimeout: 30 * time.Second,
+				Signature:           AgentSignature{SkipVerify: true},
+			},
+		},
+		{
+			name: "No public key files",
+			agent: Agent{
+				PackageApplyTimeout: 30 * time.Second,
+			},
+			expectedError: "agent::signature::public_key_files must be specified",
+		},
+		{
+			name: "Public key file does not exist",
+			agent: Agent{
+				PackageApplyTimeout: 30 * time.Second,
+				Signature:           AgentSignature{PublicKeyFiles: []string{filepath.Join(tmpDir, "missing")}},
+			},
+			expectedError: "could not stat agent::signature::public_key_files path",
+		},
+		{
+			name: "Invalid package apply timeout",
+			agent: Agent{
+				Signature: AgentSignature{SkipVerify: true},
+			},
+			expectedError: "agent::package_apply_timeout must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "ws://localhost:9090/opamp",
+				},
+				Agent: tc.agent,
+				Capabilities: Capabilities{
+					AcceptsPackages: true,
+				},
+			}
+			cfg.Agent.Executable = filePath
+			cfg.Agent.OrphanDetectionInterval = 5 * time.Second
+			cfg.Agent.ConfigApplyTimeout = 2 * time.Second
+			cfg.Agent.BootstrapTimeout = 5 * time.Second
+
+			err := cfg.Validate()
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedError)
+			}
+
+			// The package settings are only validated when packages are accepted.
+			cfg.Capabilities.AcceptsPackages = false
+			require.NoError(t, cfg.Validate())
+		})
+	}
+}
+
 func TestCapabilities_SupportedCapabilities(t *testing.T) {
 	testCases := []struct {
 		name                      string
@@ -435,6 +514,7 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 				ReportsHealth:                  true,
 				ReportsRemoteConfig:            true,
 				ReportsAvailableComponents:     true,
+				AcceptsPackages:                true,
 			},
 			expectedAgentCapabilities: protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus |
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig |
@@ -446,7 +526,9 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig |
 				protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand |
 				protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings |
-				protobufs.AgentCapabilities_AgentCapabilities_ReportsAvailableComponents,
+				protobufs.AgentCapabilities_AgentCapabilities_ReportsAvailableComponents |
+				protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+				protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses,
 		},
 	}
 
@@ -496,6 +578,7 @@ agent:
 						OrphanDetectionInterval: DefaultSupervisor().Agent.OrphanDetectionInterval,
 						ConfigApplyTimeout:      DefaultSupervisor().Agent.ConfigApplyTimeout,
 						BootstrapTimeout:        DefaultSupervisor().Agent.BootstrapTimeout,
+						PackageApplyTimeout:     DefaultSupervisor().Agent.PackageApplyTimeout,
 					},
 					Telemetry: DefaultSupervisor().Telemetry,
 				}
@@ -580,6 +663,7 @@ telemetry:
 						OrphanDetectionInterval: 10 * time.Second,
 						ConfigApplyTimeout:      8 * time.Second,
 						BootstrapTimeout:        8 * time.Second,
+						PackageApplyTimeout:     DefaultSupervisor().Agent.PackageApplyTimeout,
 						OpAMPServerPort:         8090,
 						PassthroughLogs:         true,
 					},
@@ -617,6 +701,7 @@ agent:
 						OrphanDetectionInterval: DefaultSupervisor().Agent.OrphanDetectionInterval,
 						ConfigApplyTimeout:      DefaultSupervisor().Agent.ConfigApplyTimeout,
 						BootstrapTimeout:        DefaultSupervisor().Agent.BootstrapTimeout,
+						PackageApplyTimeout:     DefaultSupervisor().Agent.PackageApplyTimeout,
 					},
 					Telemetry: DefaultSupervisor().Telemetry,
 				}
@@ -0,0 +1,633 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+const (
+	packagesDirName                 = "packages"
+	packagesStateFileName           = "packages.yaml"
+	lastReportedPackageStatusesFile = "last_reported_package_statuses.dat"
+	agentBackupFileName             = "agent.bak"
+)
+
+// errAgentRolledBack is returned when the agent did not become healthy after its executable
+// was updated, and the previous executable was restored.
+var errAgentRolledBack = errors.New("the agent was rolled back to its previous executable")
+
+// packageState is the persisted state of a package.
+type packageState struct {
+	Type    protobufs.PackageType `yaml:"type"`
+	Hash    string                `yaml:"hash"`
+	Version string                `yaml:"version"`
+	// ContentHash is the hex encoded SHA-256 hash of the content of the package file.
+	ContentHash string `yaml:"content_hash"`
+}
+
+// packagesState is the persisted state of the packages installed by the Supervisor.
+type packagesState struct {
+	AllPackagesHash string                   `yaml:"all_packages_hash"`
+	Packages        map[string]*packageState `yaml:"packages"`
+	// BadAgentHashes are the hex encoded content hashes of the agent packages that were rolled back,
+	// which are not installed again even if offered by the Server.
+	BadAgentHashes []string `yaml:"bad_agent_hashes"`
+}
+
+// packageManager implements types.PackagesStateProvider. The top-level package is the agent
+// itself and is installed by replacing the agent executable, while addon packages are stored
+// as files in the packages directory of the storage directory.
+type packageManager struct {
+	logger   *zap.Logger
+	dir      string
+	verifier *signatureVerifier
+	// installAgent replaces the agent executable with the one of the package staged at path.
+	installAgent func(ctx context.Context, path string) error
+
+	mux   sync.Mutex
+	state *packagesState
+}
+
+var _ types.PackagesStateProvider = (*packageManager)(nil)
+
+func newPackageManager(logger *zap.Logger, storageDir string, cfg config.AgentSignature, installAgent func(ctx context.Context, path string) error) (*packageManager, error) {
+	verifier, err := newSignatureVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &packageManager{
+		logger:       logger,
+		dir:          filepath.Join(storageDir, packagesDirName),
+		verifier:     verifier,
+		installAgent: installAgent,
+		state:        &packagesState{Packages: map[string]*packageState{}},
+	}
+
+	for _, dir := range []string{m.filesDir(), m.stagingDir()} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("error creating packages dir: %w", err)
+		}
+	}
+
+	by, err := os.ReadFile(m.stateFilePath())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := yaml.Unmarshal(by, m.state); err != nil {
+			return nil, fmt.Errorf("cannot parse packages state: %w", err)
+		}
+		if m.state.Packages == nil {
+			m.state.Packages = map[string]*packageState{}
+		}
+	}
+
+	return m, nil
+}
+
+func (m *packageManager) AllPackagesHash() ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return hex.DecodeString(m.state.AllPackagesHash)
+}
+
+func (m *packageManager) SetAllPackagesHash(hash []byte) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.state.AllPackagesHash = hex.EncodeToString(hash)
+	return m.writeState()
+}
+
+func (m *packageManager) Packages() ([]string, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	names := make([]string, 0, len(m.state.Packages))
+	for name := range m.state.Packages {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func (m *packageManager) PackageState(packageName string) (types.PackageState, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	pkg, ok := m.state.Packages[packageName]
+	if !ok {
+		return types.PackageState{Exists: false}, nil
+	}
+	hash, err := hex.DecodeString(pkg.Hash)
+	if err != nil {
+		return types.PackageState{}, fmt.Errorf("cannot decode the hash of package %q: %w", packageName, err)
+	}
+	return types.PackageState{
+		Exists:  true,
+		Type:    pkg.Type,
+		Hash:    hash,
+		Version: pkg.Version,
+	}, nil
+}
+
+func (m *packageManager) SetPackageState(packageName string, state types.PackageState) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	pkg, ok := m.state.Packages[packageName]
+	if !ok {
+		return fmt.Errorf("package %q does not exist", packageName)
+	}
+	if pkg.Type != state.Type {
+		return fmt.Errorf("package %q is of type %s, not %s", packageName, pkg.Type, state.Type)
+	}
+	pkg.Hash = hex.EncodeToString(state.Hash)
+	pkg.Version = state.Version
+	return m.writeState()
+}
+
+func (m *packageManager) CreatePackage(packageName string, typ protobufs.PackageType) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if _, ok := m.state.Packages[packageName]; ok {
+		return fmt.Errorf("package %q already exists", packageName)
+	}
+	switch typ {
+	case protobufs.PackageType_PackageType_TopLevel:
+		for name, pkg := range m.state.Packages {
+			if pkg.Type == protobufs.PackageType_PackageType_TopLevel {
+				return fmt.Errorf("only one top-level package is supported, package %q already exists", name)
+			}
+		}
+	default:
+		if err := validateAddonName(packageName); err != nil {
+			return err
+		}
+	}
+	m.state.Packages[packageName] = &packageState{Type: typ}
+	return m.writeState()
+}
+
+func (m *packageManager) FileContentHash(packageName string) ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	pkg, ok := m.state.Packages[packageName]
+	if !ok || pkg.ContentHash == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(pkg.ContentHash)
+}
+
+// UpdateContent stages the content of the package, verifies its content hash and signature,
+// then installs it.
+func (m *packageManager) UpdateContent(ctx context.Context, packageName string, data io.Reader, contentHash, signature []byte) error {
+	m.mux.Lock()
+	pkg, ok := m.state.Packages[packageName]
+	m.mux.Unlock()
+	if !ok {
+		return fmt.Errorf("package %q does not exist", packageName)
+	}
+
+	staged, hash, err := m.stage(ctx, data)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(staged)
+	}()
+
+	if !bytes.Equal(hash, contentHash) {
+		return fmt.Errorf("content hash of package %q does not match: expected %x, got %x", packageName, contentHash, hash)
+	}
+
+	if err = m.verifier.verify(hash, signature); err != nil {
+		return fmt.Errorf("cannot verify the signature of package %q: %w", packageName, err)
+	}
+
+	encodedHash := hex.EncodeToString(hash)
+	if pkg.Type == protobufs.PackageType_PackageType_TopLevel {
+		if m.isBadAgent(encodedHash) {
+			return fmt.Errorf("package %q was previously rolled back and will not be installed again", packageName)
+		}
+		if err = m.installAgent(ctx, staged); err != nil {
+			if errors.Is(err, errAgentRolledBack) {
+				m.markBadAgent(encodedHash)
+			}
+			return err
+		}
+	} else if err = os.Rename(staged, m.addonFilePath(packageName)); err != nil {
+		return fmt.Errorf("cannot install package %q: %w", packageName, err)
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	pkg.ContentHash = encodedHash
+	return m.writeState()
+}
+
+// stage writes the content of a package to a file in the staging directory and returns
+// its path along with the SHA-256 hash of the content.
+func (m *packageManager) stage(ctx context.Context, data io.Reader) (string, []byte, error) {
+	f, err := os.CreateTemp(m.stagingDir(), "package-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot create staging file: %w", err)
+	}
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), data)
+	err = errors.Join(err, f.Close(), ctx.Err())
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", nil, fmt.Errorf("cannot download package content: %w", err)
+	}
+
+	return f.Name(), h.Sum(nil), nil
+}
+
+func (m *packageManager) DeletePackage(packageName string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	pkg, ok := m.state.Packages[packageName]
+	if !ok {
+		return nil
+	}
+	// The agent executable is never deleted, only the addon files are.
+	if pkg.Type != protobufs.PackageType_PackageType_TopLevel {
+		if err := os.Remove(m.addonFilePath(packageName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot delete package %q: %w", packageName, err)
+		}
+	}
+	delete(m.state.Packages, packageName)
+	return m.writeState()
+}
+
+func (m *packageManager) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	by, err := os.ReadFile(filepath.Join(m.dir, lastReportedPackageStatusesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := &protobufs.PackageStatuses{}
+	if err := proto.Unmarshal(by, statuses); err != nil {
+		m.logger.Error("Cannot parse last reported package statuses", zap.Error(err))
+		return nil, nil
+	}
+	return statuses, nil
+}
+
+func (m *packageManager) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	by, err := proto.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.dir, lastReportedPackageStatusesFile), by, 0o600)
+}
+
+func (m *packageManager) isBadAgent(hash string) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return slices.Contains(m.state.BadAgentHashes, hash)
+}
+
+func (m *packageManager) markBadAgent(hash string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.state.BadAgentHashes = append(m.state.BadAgentHashes, hash)
+	if err := m.writeState(); err != nil {
+		m.logger.Error("Could not save the rolled back agent package", zap.Error(err))
+	}
+}
+
+func (m *packageManager) writeState() error {
+	by, err := yaml.Marshal(m.state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.stateFilePath(), by, 0o600)
+}
+
+func (m *packageManager) stateFilePath() string {
+	return filepath.Join(m.dir, packagesStateFileName)
+}
+
+func (m *packageManager) filesDir() string {
+	return filepath.Join(m.dir, "files")
+}
+
+func (m *packageManager) stagingDir() string {
+	return filepath.Join(m.dir, "staging")
+}
+
+func (m *packageManager) addonFilePath(packageName string) string {
+	return filepath.Join(m.filesDir(), packageName)
+}
+
+// validateAddonName ensures that the name of an addon package can be used as a file name.
+func validateAddonName(name string) error {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return fmt.Errorf("invalid addon package name %q", name)
+	}
+	return nil
+}
+
+// signatureVerifier verifies that the SHA-256 hash of the content of a package was signed by
+// one of the configured public keys. ECDSA signatures are ASN.1 encoded, RSA signatures use
+// PKCS #1 v1.5, and Ed25519 signatures sign the hash itself.
+type signatureVerifier struct {
+	keys       []crypto.PublicKey
+	skipVerify bool
+}
+
+func newSignatureVerifier(cfg config.AgentSignature) (*signatureVerifier, error) {
+	v := &signatureVerifier{skipVerify: cfg.SkipVerify}
+	for _, file := range cfg.PublicKeyFiles {
+		by, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read public key: %w", err)
+		}
+		block, _ := pem.Decode(by)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM data found in public key %s", file)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse public key %s: %w", file, err)
+		}
+		v.keys = append(v.keys, key)
+	}
+	return v, nil
+}
+
+func (v *signatureVerifier) verify(hash, signature []byte) error {
+	if v.skipVerify {
+		return nil
+	}
+	if len(signature) == 0 {
+		return errors.New("the package is not signed")
+	}
+	for _, key := range v.keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, hash, signature) {
+				return nil
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, hash, signature) {
+				return nil
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash, signature) == nil {
+				return nil
+			}
+		}
+	}
+	return errors.New("the signature does not match any of the public keys")
+}
+
+// agentUpdate is a request to the agent loop to replace the agent executable.
+type agentUpdate struct {
+	ctx    context.Context
+	path   string
+	result chan error
+}
+
+// installAgentPackage replaces the agent executable with the one of the package staged at path.
+// The update is run by the agent loop, as it manages the agent process.
+func (s *Supervisor) installAgentPackage(ctx context.Context, path string) error {
+	update := agentUpdate{ctx: ctx, path: path, result: make(chan error, 1)}
+	select {
+	case s.agentUpdates <- update:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.doneChan:
+		return errors.New("the supervisor is shutting down")
+	}
+	return <-update.result
+}
+
+// updateAgentExecutable replaces the agent executable with the one of the package staged at path,
+// and checks that the new agent becomes healthy within the package apply timeout. The previous
+// executable is restored if it does not. It must only be called from the agent loop.
+func (s *Supervisor) updateAgentExecutable(ctx context.Context, path string) error {
+	executable := s.config.Agent.Executable
+	info, err := os.Stat(executable)
+	if err != nil {
+		return fmt.Errorf("cannot stat the agent executable: %w", err)
+	}
+
+	backup := filepath.Join(s.config.Storage.Directory, packagesDirName, agentBackupFileName)
+	if err = copyFile(executable, backup, info.Mode()); err != nil {
+		return fmt.Errorf("cannot back up the agent executable: %w", err)
+	}
+
+	s.telemetrySettings.Logger.Info("Updating the agent executable", zap.String("executable", executable))
+	wasRunning := s.commander.IsRunning()
+	if err = s.commander.Stop(context.Background()); err != nil {
+		return fmt.Errorf("cannot stop the agent: %w", err)
+	}
+	if wasRunning {
+		// Consume the exit of the agent, so that the agent loop does not handle it as a crash.
+		<-s.commander.Exited()
+	}
+
+	if err = replaceExecutable(executable, info.Mode(), func(w io.Writer) error {
+		return extractAgentExecutable(path, w)
+	}); err != nil {
+		s.restartAgentAfterPackage()
+		return fmt.Errorf("cannot update the agent executable: %w", err)
+	}
+
+	if err = s.checkAgentHealth(ctx); err != nil {
+		s.telemetrySettings.Logger.Error("The agent did not become healthy after its update, rolling back", zap.Error(err))
+		if restoreErr := replaceExecutable(executable, info.Mode(), func(w io.Writer) error {
+			return copyContent(backup, w)
+		}); restoreErr != nil {
+			return fmt.Errorf("cannot restore the agent executable: %w", errors.Join(err, restoreErr))
+		}
+		s.restartAgentAfterPackage()
+		return fmt.Errorf("%w: %w", errAgentRolledBack, err)
+	}
+
+	// The agent reports its new description, e.g. its version, when it connects to the Supervisor.
+	if ad, ok := s.agentDescription.Load().(*protobufs.AgentDescription); ok {
+		if err = s.opampClient.SetAgentDescription(ad); err != nil {
+			s.telemetrySettings.Logger.Error("Failed to send agent description to OpAMP server", zap.Error(err))
+		}
+	}
+	s.telemetrySettings.Logger.Info("The agent executable was updated")
+	return nil
+}
+
+// checkAgentHealth starts the agent and waits until it reports a healthy status. The agent is
+// left running only if it is healthy and has a config to run. Without a config, it is started
+// with the noop config for the check, and stopped afterwards.
+func (s *Supervisor) checkAgentHealth(ctx context.Context) error {
+	cfgState := s.cfgState.Load().(*configState)
+	if cfgState.configMapIsEmpty {
+		noopConfig, err := s.composeNoopConfig()
+		if err != nil {
+			return fmt.Errorf("cannot compose the noop config: %w", err)
+		}
+		if err = os.WriteFile(s.agentConfigFilePath(), noopConfig, 0o600); err != nil {
+			return fmt.Errorf("cannot write the noop config: %w", err)
+		}
+		defer func() {
+			if err := os.WriteFile(s.agentConfigFilePath(), []byte(cfgState.mergedConfig), 0o600); err != nil {
+				s.telemetrySettings.Logger.Error("Failed to write agent config.", zap.Error(err))
+			}
+		}()
+	}
+
+	s.lastHealthFromClient.Store(nil)
+	if err := s.commander.Start(context.Background()); err != nil {
+		return fmt.Errorf("cannot start the agent: %w", err)
+	}
+
+	err := s.waitAgentHealthy(ctx)
+	if err != nil || cfgState.configMapIsEmpty {
+		if stopErr := s.commander.Stop(context.Background()); stopErr != nil {
+			s.telemetrySettings.Logger.Error("Could not stop agent process", zap.Error(stopErr))
+		} else {
+			// Consume the exit of the agent, so that the agent loop does not handle it as a crash.
+			<-s.commander.Exited()
+		}
+	}
+	return err
+}
+
+// waitAgentHealthy waits until the agent reports a healthy status.
+func (s *Supervisor) waitAgentHealthy(ctx context.Context) error {
+	timeout := time.NewTimer(s.config.Agent.PackageApplyTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return errors.New("timed out waiting for the agent to report a healthy status")
+		case <-ticker.C:
+			if health := s.lastHealthFromClient.Load(); health != nil && health.Healthy {
+				return nil
+			}
+			if !s.commander.IsRunning() {
+				return fmt.Errorf("the agent exited with code %d", s.commander.ExitCode())
+			}
+		}
+	}
+}
+
+// restartAgentAfterPackage starts the agent again after its executable was replaced or restored,
+// unless there is no config to run.
+func (s *Supervisor) restartAgentAfterPackage() {
+	if _, err := s.startAgent(); err != nil {
+		s.telemetrySettings.Logger.Error("starting agent after updating its executable failed", zap.Error(err))
+	}
+}
+
+// replaceExecutable atomically replaces the executable with the content written by write.
+func replaceExecutable(executable string, mode os.FileMode, write func(w io.Writer) error) error {
+	tmp := executable + ".new"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm())
+	if err != nil {
+		return err
+	}
+	err = errors.Join(write(f), f.Close())
+	if err == nil {
+		err = os.Rename(tmp, executable)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+	}
+	return err
+}
+
+// extractAgentExecutable writes the agent executable of a package to w. The package is either
+// the executable itself, or a gzipped tarball holding the executable as its first regular file.
+func extractAgentExecutable(path string, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	magic, err := br.Peek(2)
+	if err != nil || !bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		_, err = io.Copy(w, br)
+		return err
+	}
+
+	gr, err := gzip.NewReader(br)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return errors.New("no executable found in the package archive")
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			_, err = io.Copy(w, tr) // #nosec G110 - the package was verified
+			return err
+		}
+	}
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm())
+	if err != nil {
+		return err
+	}
+	return errors.Join(copyContent(src, f), f.Close())
+}
+
+func copyContent(src string, w io.Writer) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
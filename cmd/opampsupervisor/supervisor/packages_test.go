@@ -0,0 +1,464 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/commander"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+func writePublicKey(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func newTestPackageManager(t *testing.T, cfg config.AgentSignature, installAgent func(ctx context.Context, path string) error) (*packageManager, string) {
+	t.Helper()
+	storageDir := t.TempDir()
+	m, err := newPackageManager(zap.NewNop(), storageDir, cfg, installAgent)
+	require.NoError(t, err)
+	return m, storageDir
+}
+
+func TestPackageManager_State(t *testing.T) {
+	m, storageDir := newTestPackageManager(t, config.AgentSignature{SkipVerify: true}, nil)
+
+	state, err := m.PackageState("addon")
+	require.NoError(t, err)
+	require.False(t, state.Exists)
+
+	require.NoError(t, m.CreatePackage("addon", protobufs.PackageType_PackageType_Addon))
+	require.NoError(t, m.CreatePackage("agent", protobufs.PackageType_PackageType_TopLevel))
+	require.ErrorContains(t, m.CreatePackage("addon", protobufs.PackageType_PackageType_Addon), `package "addon" already exists`)
+	require.ErrorContains(t, m.CreatePackage("other", protobufs.PackageType_PackageType_TopLevel), "only one top-level package is supported")
+	require.ErrorContains(t, m.CreatePackage("../addon", protobufs.PackageType_PackageType_Addon), "invalid addon package name")
+
+	require.NoError(t, m.SetPackageState("addon", types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_Addon,
+		Hash:    []byte{1, 2},
+		Version: "1.0.0",
+	}))
+	require.ErrorContains(t, m.SetPackageState("addon", types.PackageState{Type: protobufs.PackageType_PackageType_TopLevel}), "is of type")
+	require.NoError(t, m.SetAllPackagesHash([]byte{3, 4}))
+	require.NoError(t, m.SetLastReportedStatuses(&protobufs.PackageStatuses{ServerProvidedAllPackagesHash: []byte{3, 4}}))
+
+	// The state is loaded back from the storage directory.
+	m, err = newPackageManager(zap.NewNop(), storageDir, config.AgentSignature{SkipVerify: true}, nil)
+	require.NoError(t, err)
+
+	names, err := m.Packages()
+	require.NoError(t, err)
+	require.Equal(t, []string{"addon", "agent"}, names)
+
+	state, err = m.PackageState("addon")
+	require.NoError(t, err)
+	require.Equal(t, types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_Addon,
+		Hash:    []byte{1, 2},
+		Version: "1.0.0",
+	}, state)
+
+	hash, err := m.AllPackagesHash()
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 4}, hash)
+
+	statuses, err := m.LastReportedStatuses()
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 4}, statuses.ServerProvidedAllPackagesHash)
+
+	require.NoError(t, m.DeletePackage("addon"))
+	require.NoError(t, m.DeletePackage("unknown"))
+	names, err = m.Packages()
+	require.NoError(t, err)
+	require.Equal(t, []string{"agent"}, names)
+}
+
+func TestPackageManager_UpdateContent(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signatureCfg := config.AgentSignature{
+		PublicKeyFiles: []string{writePublicKey(t, &ecdsaKey.PublicKey), writePublicKey(t, edPublicKey)},
+	}
+
+	content := []byte("addon content")
+	hash := sha256.Sum256(content)
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, hash[:])
+	require.NoError(t, err)
+	otherSignature, err := ecdsa.SignASN1(rand.Reader, otherKey, hash[:])
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		cfg           config.AgentSignature
+		contentHash   []byte
+		signature     []byte
+		expectedError string
+	}{
+		{
+			name:        "ECDSA signature",
+			cfg:         signatureCfg,
+			contentHash: hash[:],
+			signature:   ecdsaSignature,
+		},
+		{
+			name:        "Ed25519 signature",
+			cfg:         signatureCfg,
+			contentHash: hash[:],
+			signature:   ed25519.Sign(edKey, hash[:]),
+		},
+		{
+			name:        "Skip verify",
+			cfg:         config.AgentSignature{SkipVerify: true},
+			contentHash: hash[:],
+		},
+		{
+			name:          "Content hash mismatch",
+			cfg:           config.AgentSignature{SkipVerify: true},
+			contentHash:   []byte{1, 2, 3},
+			expectedError: "content hash of package \"addon\" does not match",
+		},
+		{
+			name:          "Missing signature",
+			cfg:           signatureCfg,
+			contentHash:   hash[:],
+			expectedError: "the package is not signed",
+		},
+		{
+			name:          "Unknown signer",
+			cfg:           signatureCfg,
+			contentHash:   hash[:],
+			signature:     otherSignature,
+			expectedError: "the signature does not match any of the public keys",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, _ := newTestPackageManager(t, tc.cfg, nil)
+			require.NoError(t, m.CreatePackage("addon", protobufs.PackageType_PackageType_Addon))
+
+			err := m.UpdateContent(context.Background(), "addon", bytes.NewReader(content), tc.contentHash, tc.signature)
+			fileHash, hashErr := m.FileContentHash("addon")
+			require.NoError(t, hashErr)
+			staged, readErr := os.ReadDir(m.stagingDir())
+			require.NoError(t, readErr)
+			require.Empty(t, staged)
+
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				require.Nil(t, fileHash)
+				require.NoFileExists(t, m.addonFilePath("addon"))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, hash[:], fileHash)
+			installed, err := os.ReadFile(m.addonFilePath("addon"))
+			require.NoError(t, err)
+			require.Equal(t, content, installed)
+
+			require.NoError(t, m.DeletePackage("addon"))
+			require.NoFileExists(t, m.addonFilePath("addon"))
+		})
+	}
+}
+
+func TestPackageManager_UpdateContentAgent(t *testing.T) {
+	content := []byte("agent executable")
+	hash := sha256.Sum256(content)
+
+	var installed []byte
+	installErr := errAgentRolledBack
+	m, _ := newTestPackageManager(t, config.AgentSignature{SkipVerify: true}, func(_ context.Context, path string) error {
+		var err error
+		installed, err = os.ReadFile(path)
+		require.NoError(t, err)
+		return installErr
+	})
+	require.NoError(t, m.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+
+	err := m.UpdateContent(context.Background(), "", bytes.NewReader(content), hash[:], nil)
+	require.ErrorIs(t, err, errAgentRolledBack)
+	require.Equal(t, content, installed)
+
+	// A rolled back package is not installed again.
+	installed = nil
+	installErr = nil
+	err = m.UpdateContent(context.Background(), "", bytes.NewReader(content), hash[:], nil)
+	require.ErrorContains(t, err, "was previously rolled back")
+	require.Nil(t, installed)
+
+	other := []byte("other agent executable")
+	otherHash := sha256.Sum256(other)
+	require.NoError(t, m.UpdateContent(context.Background(), "", bytes.NewReader(other), otherHash[:], nil))
+	require.Equal(t, other, installed)
+	fileHash, err := m.FileContentHash("")
+	require.NoError(t, err)
+	require.Equal(t, otherHash[:], fileHash)
+}
+
+func TestNewPackageManagerInvalidPublicKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a key"), 0o600))
+
+	_, err := newPackageManager(zap.NewNop(), t.TempDir(), config.AgentSignature{PublicKeyFiles: []string{path}}, nil)
+	require.ErrorContains(t, err, "no PEM data found in public key")
+}
+
+func TestExtractAgentExecutable(t *testing.T) {
+	executable := []byte("#!/bin/sh\necho collector\n")
+
+	var archive bytes.Buffer
+	gw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "otelcol/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "otelcol/otelcol", Typeflag: tar.TypeReg, Mode: 0o755, Size: int64(len(executable))}))
+	_, err := tw.Write(executable)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	for name, content := range map[string][]byte{"raw": executable, "tarball": archive.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "package")
+			require.NoError(t, os.WriteFile(path, content, 0o600))
+
+			var out bytes.Buffer
+			require.NoError(t, extractAgentExecutable(path, &out))
+			require.Equal(t, executable, out.Bytes())
+		})
+	}
+}
+
+func TestSupervisor_installAgentPackage(t *testing.T) {
+	s := &Supervisor{
+		agentUpdates: make(chan agentUpdate),
+		doneChan:     make(chan struct{}),
+	}
+
+	// The update is run by the agent loop.
+	go func() {
+		update := <-s.agentUpdates
+		assert.Equal(t, "package", update.path)
+		update.result <- errAgentRolledBack
+	}()
+	require.ErrorIs(t, s.installAgentPackage(context.Background(), "package"), errAgentRolledBack)
+
+	close(s.doneChan)
+	require.EqualError(t, s.installAgentPackage(context.Background(), "package"), "the supervisor is shutting down")
+}
+
+func TestSupervisor_updateAgentExecutable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows because it uses shell scripts as the agent executable.")
+	}
+
+	const (
+		oldExecutable     = "#!/bin/sh\nexec sleep 30\n"
+		healthyExecutable = "#!/bin/sh\nexec sleep 60\n"
+		crashExecutable   = "#!/bin/sh\nexit 3\n"
+		mergedConfig      = "receivers:\n  nop:\n"
+	)
+
+	newSupervisor := func(t *testing.T, configMapIsEmpty bool) *Supervisor {
+		dir := t.TempDir()
+		executable := filepath.Join(dir, "otelcol")
+		require.NoError(t, os.WriteFile(executable, []byte(oldExecutable), 0o700))
+
+		storageDir := filepath.Join(dir, "storage")
+		require.NoError(t, os.MkdirAll(filepath.Join(storageDir, packagesDirName), 0o700))
+
+		agentCfg := config.Agent{
+			Executable:          executable,
+			PackageApplyTimeout: 5 * time.Second,
+		}
+		cmd, err := commander.NewCommander(zap.NewNop(), storageDir, agentCfg)
+		require.NoError(t, err)
+		if !configMapIsEmpty {
+			require.NoError(t, cmd.Start(context.Background()))
+		}
+		t.Cleanup(func() {
+			assert.NoError(t, cmd.Stop(context.Background()))
+		})
+
+		s := &Supervisor{
+			telemetrySettings: newNopTelemetrySettings(),
+			config: config.Supervisor{
+				Agent:   agentCfg,
+				Storage: config.Storage{Directory: storageDir},
+			},
+			commander: cmd,
+			opampClient: &mockOpAMPClient{
+				setHealthFunc:             func(*protobufs.ComponentHealth) {},
+				updateEffectiveConfigFunc: func(context.Context) error { return nil },
+			},
+			cfgState:         &atomic.Value{},
+			agentDescription: &atomic.Value{},
+			persistentState:  &persistentState{InstanceID: uuid.MustParse("018fee23-4a51-7303-a441-73faed7d9deb")},
+			pidProvider:      staticPIDProvider(1234),
+		}
+		s.cfgState.Store(&configState{mergedConfig: mergedConfig, configMapIsEmpty: configMapIsEmpty})
+		require.NoError(t, s.createTemplates())
+		return s
+	}
+
+	stage := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "package")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	reportHealthy := func(t *testing.T, s *Supervisor) {
+		done := make(chan struct{})
+		t.Cleanup(func() { close(done) })
+		go func() {
+			ticker := time.NewTicker(50 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					s.lastHealthFromClient.Store(&protobufs.ComponentHealth{Healthy: true})
+				}
+			}
+		}()
+	}
+
+	t.Run("Healthy agent", func(t *testing.T) {
+		s := newSupervisor(t, false)
+		reportHealthy(t, s)
+
+		require.NoError(t, s.updateAgentExecutable(context.Background(), stage(t, healthyExecutable)))
+		installed, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		require.Equal(t, healthyExecutable, string(installed))
+		require.True(t, s.commander.IsRunning())
+		require.Empty(t, s.commander.Exited())
+	})
+
+	t.Run("Crashing agent is rolled back", func(t *testing.T) {
+		s := newSupervisor(t, false)
+
+		err := s.updateAgentExecutable(context.Background(), stage(t, crashExecutable))
+		require.ErrorIs(t, err, errAgentRolledBack)
+		require.ErrorContains(t, err, "the agent exited with code 3")
+
+		restored, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		require.Equal(t, oldExecutable, string(restored))
+		info, err := os.Stat(s.config.Agent.Executable)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+		require.True(t, s.commander.IsRunning())
+	})
+
+	t.Run("Unhealthy agent is rolled back", func(t *testing.T) {
+		s := newSupervisor(t, false)
+		s.config.Agent.PackageApplyTimeout = 500 * time.Millisecond
+
+		err := s.updateAgentExecutable(context.Background(), stage(t, healthyExecutable))
+		require.ErrorIs(t, err, errAgentRolledBack)
+		require.ErrorContains(t, err, "timed out waiting for the agent to report a healthy status")
+
+		restored, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		require.Equal(t, oldExecutable, string(restored))
+	})
+
+	t.Run("Agent without config is checked with the noop config", func(t *testing.T) {
+		s := newSupervisor(t, true)
+		reportHealthy(t, s)
+
+		require.NoError(t, s.updateAgentExecutable(context.Background(), stage(t, healthyExecutable)))
+		installed, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		require.Equal(t, healthyExecutable, string(installed))
+		require.False(t, s.commander.IsRunning())
+		require.Empty(t, s.commander.Exited())
+
+		agentCfg, err := os.ReadFile(s.agentConfigFilePath())
+		require.NoError(t, err)
+		require.Equal(t, mergedConfig, string(agentCfg))
+	})
+
+	t.Run("Crashing agent without config is rolled back", func(t *testing.T) {
+		s := newSupervisor(t, true)
+
+		err := s.updateAgentExecutable(context.Background(), stage(t, crashExecutable))
+		require.ErrorIs(t, err, errAgentRolledBack)
+
+		restored, err := os.ReadFile(s.config.Agent.Executable)
+		require.NoError(t, err)
+		require.Equal(t, oldExecutable, string(restored))
+		require.False(t, s.commander.IsRunning())
+	})
+}
+
+func TestSupervisor_processPackagesAvailableMessage(t *testing.T) {
+	s := &Supervisor{telemetrySettings: newNopTelemetrySettings()}
+	syncer := &mockPackagesSyncer{}
+
+	// Offered packages are ignored when the Supervisor does not accept packages.
+	s.processPackagesAvailableMessage(context.Background(), &protobufs.PackagesAvailable{}, syncer)
+	require.False(t, syncer.synced)
+
+	s.packageManager, _ = newTestPackageManager(t, config.AgentSignature{SkipVerify: true}, nil)
+	s.processPackagesAvailableMessage(context.Background(), &protobufs.PackagesAvailable{}, syncer)
+	require.True(t, syncer.synced)
+
+	syncer.err = errors.New("sync failed")
+	s.processPackagesAvailableMessage(context.Background(), &protobufs.PackagesAvailable{}, syncer)
+}
+
+type mockPackagesSyncer struct {
+	synced bool
+	err    error
+}
+
+func (m *mockPackagesSyncer) Sync(context.Context) error {
+	m.synced = true
+	return m.err
+}
+
+func (m *mockPackagesSyncer) Done() <-chan struct{} {
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"os"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/open-telemetry/opamp-go/protobufs"
@@ -14,10 +15,16 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// maxBadRemoteConfigHashes is the number of rolled back remote configs that are remembered.
+const maxBadRemoteConfigHashes = 10
+
 // persistentState represents persistent state for the supervisor
 type persistentState struct {
 	InstanceID             uuid.UUID           `yaml:"instance_id"`
 	LastRemoteConfigStatus *RemoteConfigStatus `yaml:"last_remote_config_status"`
+	// BadRemoteConfigHashes are the hex encoded hashes of the remote configs that were rolled back,
+	// which are not applied again if sent by the Server until it sends another remote config.
+	BadRemoteConfigHashes []string `yaml:"bad_remote_config_hashes,omitempty"`
 
 	// Path to the config file that the state should be saved to.
 	// This is not marshaled.
@@ -66,6 +73,33 @@ func (p *persistentState) GetLastRemoteConfigStatus() *protobufs.RemoteConfigSta
 	}
 }
 
+// IsBadRemoteConfig returns true if the remote config with the given hash was rolled back.
+func (p *persistentState) IsBadRemoteConfig(hash []byte) bool {
+	return slices.Contains(p.BadRemoteConfigHashes, hex.EncodeToString(hash))
+}
+
+// AddBadRemoteConfig records the hash of a remote config that was rolled back.
+// Only the maxBadRemoteConfigHashes most recent hashes are kept.
+func (p *persistentState) AddBadRemoteConfig(hash []byte) error {
+	if p.IsBadRemoteConfig(hash) {
+		return nil
+	}
+	p.BadRemoteConfigHashes = append(p.BadRemoteConfigHashes, hex.EncodeToString(hash))
+	if n := len(p.BadRemoteConfigHashes) - maxBadRemoteConfigHashes; n > 0 {
+		p.BadRemoteConfigHashes = slices.Delete(p.BadRemoteConfigHashes, 0, n)
+	}
+	return p.writeState()
+}
+
+// ClearBadRemoteConfigs forgets the remote configs that were rolled back.
+func (p *persistentState) ClearBadRemoteConfigs() error {
+	if len(p.BadRemoteConfigHashes) == 0 {
+		return nil
+	}
+	p.BadRemoteConfigHashes = nil
+	return p.writeState()
+}
+
 func (p *persistentState) writeState() error {
 	by, err := yaml.Marshal(p)
 	if err != nil {
@@ -105,3 +105,28 @@ func TestPersistentState_SetLastRemoteConfigStatus(t *testing.T) {
 	}, loadedState.GetLastRemoteConfigStatus())
 	require.FileExists(t, f)
 }
+
+func TestPersistentState_BadRemoteConfigs(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "state.yaml")
+	state, err := createNewPersistentState(f, zap.NewNop())
+	require.NoError(t, err)
+
+	for i := 0; i < maxBadRemoteConfigHashes+2; i++ {
+		require.NoError(t, state.AddBadRemoteConfig([]byte{byte(i)}))
+	}
+	require.NoError(t, state.AddBadRemoteConfig([]byte{byte(maxBadRemoteConfigHashes + 1)}))
+
+	// Only the most recent hashes are kept.
+	loadedState, err := loadPersistentState(f, zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, loadedState.BadRemoteConfigHashes, maxBadRemoteConfigHashes)
+	require.False(t, loadedState.IsBadRemoteConfig([]byte{0}))
+	require.False(t, loadedState.IsBadRemoteConfig([]byte{1}))
+	require.True(t, loadedState.IsBadRemoteConfig([]byte{2}))
+	require.True(t, loadedState.IsBadRemoteConfig([]byte{byte(maxBadRemoteConfigHashes + 1)}))
+
+	require.NoError(t, state.ClearBadRemoteConfigs())
+	loadedState, err = loadPersistentState(f, zap.NewNop())
+	require.NoError(t, err)
+	require.Empty(t, loadedState.BadRemoteConfigHashes)
+}
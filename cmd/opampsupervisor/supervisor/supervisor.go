@@ -69,6 +69,7 @@ var (
 	ownTelemetryTpl string
 
 	lastRecvRemoteConfigFile       = "last_recv_remote_config.dat"
+	lastGoodRemoteConfigFile       = "last_good_remote_config.dat"
 	lastRecvOwnTelemetryConfigFile = "last_recv_own_telemetry_config.dat"
 
 	errNonMatchingInstanceUID = errors.New("received collector instance UID does not match expected UID set by the supervisor")
@@ -143,8 +144,15 @@ type Supervisor struct {
 	// Final effective config of the Collector.
 	effectiveConfig *atomic.Value
 
+	// remoteConfigMu guards remoteConfig and lastGoodRemoteConfig, which are updated both when
+	// a remote config is received and when the agent loop rolls back a failed one. It also
+	// serializes composing the merged config from them and writing the persistent state.
+	remoteConfigMu sync.Mutex
 	// Last received remote config.
 	remoteConfig *protobufs.AgentRemoteConfig
+	// Last remote config that was applied successfully, which the agent is rolled back to
+	// when a new remote config fails to apply.
+	lastGoodRemoteConfig *protobufs.AgentRemoteConfig
 
 	// A channel to indicate there is a new config to apply.
 	hasNewConfig chan struct{}
@@ -157,6 +165,12 @@ type Supervisor struct {
 	// The OpAMP client to connect to the OpAMP Server.
 	opampClient client.OpAMPClient
 
+	// packageManager installs the packages offered by the OpAMP Server, or is nil
+	// if the Supervisor does not accept packages.
+	packageManager *packageManager
+	// agentUpdates sends the updates of the agent executable to the agent loop.
+	agentUpdates chan agentUpdate
+
 	doneChan chan struct{}
 	agentWG  sync.WaitGroup
 
@@ -183,6 +197,7 @@ func NewSupervisor(logger *zap.Logger, cfg config.Supervisor) (*Supervisor, erro
 	s := &Supervisor{
 		pidProvider:                  defaultPIDProvider{},
 		hasNewConfig:                 make(chan struct{}, 1),
+		agentUpdates:                 make(chan agentUpdate),
 		agentConfigOwnMetricsSection: &atomic.Value{},
 		cfgState:                     &atomic.Value{},
 		effectiveConfig:              &atomic.Value{},
@@ -308,6 +323,13 @@ func (s *Supervisor) Start() error {
 		return err
 	}
 
+	if s.config.Capabilities.AcceptsPackages {
+		s.packageManager, err = newPackageManager(s.telemetrySettings.Logger, s.config.Storage.Directory, s.config.Agent.Signature, s.installAgentPackage)
+		if err != nil {
+			return fmt.Errorf("could not create the package manager: %w", err)
+		}
+	}
+
 	if err = s.getFeatureGates(); err != nil {
 		return fmt.Errorf("could not get feature gates from the Collector: %w", err)
 	}
@@ -664,6 +686,9 @@ func (s *Supervisor) startOpAMPClient() error {
 		},
 		Capabilities: s.config.Capabilities.SupportedCapabilities(),
 	}
+	if s.packageManager != nil {
+		settings.PackagesStateProvider = s.packageManager
+	}
 	ad := s.agentDescription.Load().(*protobufs.AgentDescription)
 	if err = s.opampClient.SetAgentDescription(ad); err != nil {
 		return err
@@ -1054,6 +1079,9 @@ func (s *Supervisor) loadAndWriteInitialMergedConfig() error {
 	// load the last received remote config
 	s.loadRemoteConfig()
 
+	// load the last good remote config, in case the last received one failed to apply
+	s.loadLastGoodRemoteConfig()
+
 	// load the last received own telemetry config
 	s.loadLastReceivedOwnTelemetryConfig()
 
@@ -1099,6 +1127,41 @@ func (s *Supervisor) loadRemoteConfig() {
 	}
 }
 
+// loadLastGoodRemoteConfig loads the last remote config that was applied successfully from file
+// if the capability is supported. It replaces the last received remote config if that one failed to apply.
+func (s *Supervisor) loadLastGoodRemoteConfig() {
+	if !s.config.Capabilities.AcceptsRemoteConfig {
+		return
+	}
+
+	lastGoodRemoteConfig, err := os.ReadFile(filepath.Join(s.config.Storage.Directory, lastGoodRemoteConfigFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return
+	case err != nil:
+		s.telemetrySettings.Logger.Error("error while reading last good config", zap.Error(err))
+		return
+	}
+
+	config := &protobufs.AgentRemoteConfig{}
+	if err = proto.Unmarshal(lastGoodRemoteConfig, config); err != nil {
+		s.telemetrySettings.Logger.Error("Cannot parse last good remote config", zap.Error(err))
+		return
+	}
+	s.lastGoodRemoteConfig = config
+
+	lastStatus := s.persistentState.GetLastRemoteConfigStatus()
+	lastReceivedFailed := lastStatus != nil &&
+		lastStatus.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED &&
+		bytes.Equal(lastStatus.LastRemoteConfigHash, s.remoteConfig.GetConfigHash())
+	if s.remoteConfig != nil && (lastReceivedFailed || s.persistentState.IsBadRemoteConfig(s.remoteConfig.GetConfigHash())) {
+		s.telemetrySettings.Logger.Info("Last received remote config failed to apply, using the last good remote config",
+			zap.String("failed_hash", fmt.Sprintf("%x", s.remoteConfig.GetConfigHash())),
+			zap.String("hash", fmt.Sprintf("%x", config.GetConfigHash())))
+		s.remoteConfig = config
+	}
+}
+
 // loadLastReceivedOwnTelemetryConfig loads the last received own telemetry config from file if the capability is supported.
 func (s *Supervisor) loadLastReceivedOwnTelemetryConfig() {
 	// If none of the own telemetry capabilities are supported, do nothing.
@@ -1178,7 +1241,9 @@ func (s *Supervisor) setupOwnTelemetry(_ context.Context, settings *protobufs.Co
 	s.agentConfigOwnMetricsSection.Store(cfg.String())
 
 	// Need to recalculate the Agent config so that the metric config is included in it.
+	s.remoteConfigMu.Lock()
 	configChanged, err := s.composeMergedConfig(s.remoteConfig)
+	s.remoteConfigMu.Unlock()
 	if err != nil {
 		s.telemetrySettings.Logger.Error("Error composing merged config for own metrics. Ignoring agent self metrics config", zap.Error(err))
 		return
@@ -1346,6 +1411,11 @@ func (s *Supervisor) runAgentProcess() {
 	configApplyTimeoutTimer := time.NewTimer(0)
 	configApplyTimeoutTimer.Stop()
 
+	// applyingConfig is true while waiting for the agent to report its health after a new config was applied.
+	applyingConfig := false
+	// applyingConfigHash is the hash of the remote config being applied.
+	var applyingConfigHash []byte
+
 	for {
 		select {
 		case <-s.hasNewConfig:
@@ -1361,6 +1431,10 @@ func (s *Supervisor) runAgentProcess() {
 			s.telemetrySettings.Logger.Debug("Restarting agent due to new config")
 			restartTimer.Stop()
 			s.stopAgentApplyConfig()
+			applyingConfig = true
+			s.remoteConfigMu.Lock()
+			applyingConfigHash = s.remoteConfig.GetConfigHash()
+			s.remoteConfigMu.Unlock()
 			status, err := s.startAgent()
 			if err != nil {
 				s.telemetrySettings.Logger.Error("starting agent with new config failed", zap.Error(err))
@@ -1370,7 +1444,9 @@ func (s *Supervisor) runAgentProcess() {
 			if status == agentNotStarting {
 				// not starting agent because of nop config, clear timer, report applied status, report healthy status
 				configApplyTimeoutTimer.Stop()
+				applyingConfig = false
 				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+				s.saveLastGoodRemoteConfig()
 				if err := s.opampClient.SetHealth(&protobufs.ComponentHealth{Healthy: true, LastError: ""}); err != nil {
 					s.telemetrySettings.Logger.Error("Could not report healthy status to OpAMP server", zap.Error(err))
 				}
@@ -1401,8 +1477,14 @@ func (s *Supervisor) runAgentProcess() {
 				s.telemetrySettings.Logger.Error("Could not report health to OpAMP server", zap.Error(err))
 			}
 
-			// TODO: decide why the agent stopped. If it was due to bad config, report it to server.
-			// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21079
+			// The agent exiting while applying a new config is due to the config, so there is
+			// no need to wait for the config apply timeout to roll it back.
+			if applyingConfig && s.canRollbackRemoteConfig() {
+				configApplyTimeoutTimer.Stop()
+				applyingConfig = false
+				s.rollbackRemoteConfig(applyingConfigHash, fmt.Sprintf("Agent process exited unexpectedly while applying the config, exit code=%d", s.commander.ExitCode()))
+				continue
+			}
 
 			// Wait 5 seconds before starting again.
 			if !restartTimer.Stop() {
@@ -1413,6 +1495,9 @@ func (s *Supervisor) runAgentProcess() {
 			}
 			restartTimer.Reset(5 * time.Second)
 
+		case update := <-s.agentUpdates:
+			update.result <- s.updateAgentExecutable(update.ctx, update.path)
+
 		case <-restartTimer.C:
 			s.telemetrySettings.Logger.Debug("Agent starting after start backoff")
 			_, err := s.startAgent()
@@ -1422,11 +1507,13 @@ func (s *Supervisor) runAgentProcess() {
 			}
 
 		case <-configApplyTimeoutTimer.C:
+			applyingConfig = false
 			lastHealth := s.lastHealthFromClient.Load()
 			if lastHealth == nil || !lastHealth.Healthy {
-				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, "Config apply timeout exceeded")
+				s.rollbackRemoteConfig(applyingConfigHash, "Config apply timeout exceeded")
 			} else {
 				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+				s.saveLastGoodRemoteConfig()
 			}
 
 		case <-s.doneChan:
@@ -1439,6 +1526,69 @@ func (s *Supervisor) runAgentProcess() {
 	}
 }
 
+// canRollbackRemoteConfig returns true if there is a last good remote config to roll back to,
+// that is different from the current remote config.
+func (s *Supervisor) canRollbackRemoteConfig() bool {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+	return s.canRollbackRemoteConfigLocked()
+}
+
+func (s *Supervisor) canRollbackRemoteConfigLocked() bool {
+	return s.config.Capabilities.AcceptsRemoteConfig &&
+		s.remoteConfig != nil &&
+		s.lastGoodRemoteConfig != nil &&
+		!bytes.Equal(s.remoteConfig.GetConfigHash(), s.lastGoodRemoteConfig.GetConfigHash())
+}
+
+// rollbackRemoteConfig reports the remote config with hash failedHash as failed, and restarts
+// the agent with the last good remote config if there is one. The failed config is recorded so
+// that it is not applied again if the Server sends it again.
+func (s *Supervisor) rollbackRemoteConfig(failedHash []byte, reason string) {
+	s.remoteConfigMu.Lock()
+	if !bytes.Equal(s.remoteConfig.GetConfigHash(), failedHash) {
+		// A new remote config was received while the failed one was being applied, and
+		// is about to be applied instead, so there is nothing to roll back.
+		s.remoteConfigMu.Unlock()
+		s.telemetrySettings.Logger.Warn("Remote config failed to apply, but was already replaced by a new remote config",
+			zap.String("reason", reason),
+			zap.String("failed_hash", fmt.Sprintf("%x", failedHash)))
+		return
+	}
+	if !s.canRollbackRemoteConfigLocked() {
+		s.saveAndReportConfigStatusLocked(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, reason)
+		s.remoteConfigMu.Unlock()
+		return
+	}
+
+	s.telemetrySettings.Logger.Warn("Remote config failed to apply, rolling back to the last good remote config",
+		zap.String("reason", reason),
+		zap.String("failed_hash", fmt.Sprintf("%x", failedHash)),
+		zap.String("hash", fmt.Sprintf("%x", s.lastGoodRemoteConfig.GetConfigHash())))
+	s.saveAndReportConfigStatusLocked(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+		reason+", rolled back to the last good remote config")
+	if err := s.persistentState.AddBadRemoteConfig(failedHash); err != nil {
+		s.telemetrySettings.Logger.Error("Could not save the rolled back remote config", zap.Error(err))
+	}
+
+	s.remoteConfig = s.lastGoodRemoteConfig
+	_, err := s.composeMergedConfig(s.remoteConfig)
+	s.remoteConfigMu.Unlock()
+	if err != nil {
+		s.telemetrySettings.Logger.Error("Error composing merged config with the last good remote config", zap.Error(err))
+		return
+	}
+
+	s.stopAgentApplyConfig()
+	if _, err := s.startAgent(); err != nil {
+		s.telemetrySettings.Logger.Error("starting agent with the last good remote config failed", zap.Error(err))
+	}
+
+	if err := s.opampClient.UpdateEffectiveConfig(context.Background()); err != nil {
+		s.telemetrySettings.Logger.Error("The OpAMP client failed to update the effective config", zap.Error(err))
+	}
+}
+
 func (s *Supervisor) stopAgentApplyConfig() {
 	s.telemetrySettings.Logger.Debug("Stopping the agent to apply new config")
 	cfgState := s.cfgState.Load().(*configState)
@@ -1535,6 +1685,26 @@ func (s *Supervisor) saveLastReceivedConfig(config *protobufs.AgentRemoteConfig)
 	return os.WriteFile(filepath.Join(s.config.Storage.Directory, lastRecvRemoteConfigFile), cfg, 0o600)
 }
 
+// saveLastGoodRemoteConfig saves the current remote config as the one to roll back to
+// if a new remote config fails to apply.
+func (s *Supervisor) saveLastGoodRemoteConfig() {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+	if !s.config.Capabilities.AcceptsRemoteConfig || s.remoteConfig == nil {
+		return
+	}
+
+	cfg, err := proto.Marshal(s.remoteConfig)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(s.config.Storage.Directory, lastGoodRemoteConfigFile), cfg, 0o600)
+	}
+	if err != nil {
+		s.telemetrySettings.Logger.Error("Could not save last good remote config", zap.Error(err))
+		return
+	}
+	s.lastGoodRemoteConfig = s.remoteConfig
+}
+
 func (s *Supervisor) saveLastReceivedOwnTelemetrySettings(set *protobufs.ConnectionSettingsOffers, filePath string) error {
 	cfg, err := proto.Marshal(set)
 	if err != nil {
@@ -1546,11 +1716,24 @@ func (s *Supervisor) saveLastReceivedOwnTelemetrySettings(set *protobufs.Connect
 
 // saveAndReportConfigStatus saves the config status to the persistent state and reports it to the server.
 func (s *Supervisor) saveAndReportConfigStatus(status protobufs.RemoteConfigStatuses, errorMessage string) {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+	s.saveAndReportConfigStatusLocked(status, errorMessage)
+}
+
+// saveAndReportConfigStatusLocked is saveAndReportConfigStatus for callers holding remoteConfigMu.
+func (s *Supervisor) saveAndReportConfigStatusLocked(status protobufs.RemoteConfigStatuses, errorMessage string) {
+	s.reportConfigStatusLocked(s.remoteConfig.GetConfigHash(), status, errorMessage)
+}
+
+// reportConfigStatusLocked saves the status of the remote config with the given hash to the
+// persistent state and reports it to the server. The caller must hold remoteConfigMu.
+func (s *Supervisor) reportConfigStatusLocked(hash []byte, status protobufs.RemoteConfigStatuses, errorMessage string) {
 	if !s.config.Capabilities.ReportsRemoteConfig {
 		s.telemetrySettings.Logger.Debug("supervisor is not configured to report remote config status")
 	}
 	rcs := &protobufs.RemoteConfigStatus{
-		LastRemoteConfigHash: s.remoteConfig.GetConfigHash(),
+		LastRemoteConfigHash: hash,
 		Status:               status,
 		ErrorMessage:         errorMessage,
 	}
@@ -1584,6 +1767,10 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 		}) || configChanged
 	}
 
+	if msg.PackagesAvailable != nil && msg.PackageSyncer != nil {
+		s.processPackagesAvailableMessage(ctx, msg.PackagesAvailable, msg.PackageSyncer)
+	}
+
 	// Update the agent config if any messages have touched the config
 	if configChanged {
 		err := s.opampClient.UpdateEffectiveConfig(ctx)
@@ -1629,6 +1816,21 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 
 // processRemoteConfigMessage processes an AgentRemoteConfig message, returning true if the agent config has changed.
 func (s *Supervisor) processRemoteConfigMessage(msg *protobufs.AgentRemoteConfig) bool {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+
+	if s.persistentState.IsBadRemoteConfig(msg.GetConfigHash()) {
+		s.telemetrySettings.Logger.Warn("Ignoring remote config that was previously rolled back", zap.String("hash", fmt.Sprintf("%x", msg.GetConfigHash())))
+		s.reportConfigStatusLocked(msg.GetConfigHash(), protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+			"Remote config previously failed to apply and was rolled back")
+		return false
+	}
+	// The Server moved on to another remote config, so a rolled back config that it sends
+	// afterwards is sent on purpose and is applied again.
+	if err := s.persistentState.ClearBadRemoteConfigs(); err != nil {
+		s.telemetrySettings.Logger.Error("Could not clear the rolled back remote configs", zap.Error(err))
+	}
+
 	if err := s.saveLastReceivedConfig(msg); err != nil {
 		s.telemetrySettings.Logger.Error("Could not save last received remote config", zap.Error(err))
 	}
@@ -1640,11 +1842,11 @@ func (s *Supervisor) processRemoteConfigMessage(msg *protobufs.AgentRemoteConfig
 	configChanged, err := s.composeMergedConfig(s.remoteConfig)
 	if err != nil {
 		s.telemetrySettings.Logger.Error("Error composing merged config. Reporting failed remote config status.", zap.Error(err))
-		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
+		s.saveAndReportConfigStatusLocked(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
 	}
 	if configChanged {
 		// only report applying if the config has changed and will run agent with new config
-		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, "")
+		s.saveAndReportConfigStatusLocked(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, "")
 	}
 
 	return configChanged
@@ -1658,6 +1860,19 @@ func (s *Supervisor) processOwnTelemetryConnSettingsMessage(ctx context.Context,
 	return s.setupOwnTelemetry(ctx, msg)
 }
 
+// processPackagesAvailableMessage starts syncing the packages offered by the Server in the background.
+func (s *Supervisor) processPackagesAvailableMessage(ctx context.Context, msg *protobufs.PackagesAvailable, syncer types.PackagesSyncer) {
+	if s.packageManager == nil {
+		s.telemetrySettings.Logger.Debug("Packages are not supported, ignoring offered packages")
+		return
+	}
+
+	s.telemetrySettings.Logger.Debug("Received packages from server", zap.String("hash", fmt.Sprintf("%x", msg.AllPackagesHash)))
+	if err := syncer.Sync(ctx); err != nil {
+		s.telemetrySettings.Logger.Error("Could not sync packages", zap.Error(err))
+	}
+}
+
 // processAgentIdentificationMessage processes an AgentIdentification message, returning true if the agent config has changed.
 func (s *Supervisor) processAgentIdentificationMessage(msg *protobufs.AgentIdentification) bool {
 	newInstanceID, err := uuid.FromBytes(msg.NewInstanceUid)
@@ -1670,7 +1885,9 @@ func (s *Supervisor) processAgentIdentificationMessage(msg *protobufs.AgentIdent
 		zap.String("old_id", s.persistentState.InstanceID.String()),
 		zap.String("new_id", newInstanceID.String()))
 
+	s.remoteConfigMu.Lock()
 	err = s.persistentState.SetInstanceID(newInstanceID)
+	s.remoteConfigMu.Unlock()
 	if err != nil {
 		s.telemetrySettings.Logger.Error("Failed to persist new instance ID, instance ID will revert on restart.", zap.String("new_id", newInstanceID.String()), zap.Error(err))
 	}
@@ -1681,7 +1898,9 @@ func (s *Supervisor) processAgentIdentificationMessage(msg *protobufs.AgentIdent
 	}
 
 	// Need to recalculate the Agent config so that the new agent identification is included in it.
+	s.remoteConfigMu.Lock()
 	configChanged, err := s.composeMergedConfig(s.remoteConfig)
+	s.remoteConfigMu.Unlock()
 	if err != nil {
 		s.telemetrySettings.Logger.Error("Error composing merged config with new instance ID", zap.Error(err))
 		return false
@@ -5,6 +5,7 @@ package supervisor
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -32,6 +33,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/commander"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/testbed"
 )
@@ -1616,6 +1618,260 @@ service:
 	})
 }
 
+func TestSupervisor_lastGoodRemoteConfig(t *testing.T) {
+	goodCfg := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: []byte("receivers:\n  nop:\n")},
+			},
+		},
+		ConfigHash: []byte("good"),
+	}
+	badCfg := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: []byte("receivers:\n  doesntexist:\n")},
+			},
+		},
+		ConfigHash: []byte("bad"),
+	}
+
+	newSupervisor := func(storageDir string, status *RemoteConfigStatus) *Supervisor {
+		return &Supervisor{
+			telemetrySettings: newNopTelemetrySettings(),
+			config: config.Supervisor{
+				Capabilities: config.Capabilities{
+					AcceptsRemoteConfig: true,
+				},
+				Storage: config.Storage{
+					Directory: storageDir,
+				},
+			},
+			persistentState: &persistentState{
+				InstanceID:             uuid.MustParse("018fee23-4a51-7303-a441-73faed7d9deb"),
+				LastRemoteConfigStatus: status,
+				logger:                 zap.NewNop(),
+			},
+		}
+	}
+
+	t.Run("Last received config failed", func(t *testing.T) {
+		storageDir := t.TempDir()
+		s := newSupervisor(storageDir, nil)
+		s.remoteConfig = goodCfg
+		s.saveLastGoodRemoteConfig()
+		require.NoError(t, s.saveLastReceivedConfig(badCfg))
+
+		s = newSupervisor(storageDir, &RemoteConfigStatus{
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+			LastRemoteConfigHash: hex.EncodeToString(badCfg.ConfigHash),
+		})
+		s.loadRemoteConfig()
+		s.loadLastGoodRemoteConfig()
+
+		assert.Equal(t, goodCfg.String(), s.remoteConfig.String())
+		assert.Equal(t, goodCfg.String(), s.lastGoodRemoteConfig.String())
+	})
+
+	t.Run("Last received config applied", func(t *testing.T) {
+		storageDir := t.TempDir()
+		s := newSupervisor(storageDir, nil)
+		s.remoteConfig = goodCfg
+		s.saveLastGoodRemoteConfig()
+		require.NoError(t, s.saveLastReceivedConfig(badCfg))
+
+		s = newSupervisor(storageDir, &RemoteConfigStatus{
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+			LastRemoteConfigHash: hex.EncodeToString(badCfg.ConfigHash),
+		})
+		s.loadRemoteConfig()
+		s.loadLastGoodRemoteConfig()
+
+		assert.Equal(t, badCfg.String(), s.remoteConfig.String())
+		assert.Equal(t, goodCfg.String(), s.lastGoodRemoteConfig.String())
+	})
+
+	t.Run("Last received config rolled back", func(t *testing.T) {
+		storageDir := t.TempDir()
+		s := newSupervisor(storageDir, nil)
+		s.remoteConfig = goodCfg
+		s.saveLastGoodRemoteConfig()
+		require.NoError(t, s.saveLastReceivedConfig(badCfg))
+
+		s = newSupervisor(storageDir, &RemoteConfigStatus{
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+			LastRemoteConfigHash: hex.EncodeToString(goodCfg.ConfigHash),
+		})
+		s.persistentState.BadRemoteConfigHashes = []string{hex.EncodeToString(badCfg.ConfigHash)}
+		s.loadRemoteConfig()
+		s.loadLastGoodRemoteConfig()
+
+		assert.Equal(t, goodCfg.String(), s.remoteConfig.String())
+	})
+
+	t.Run("No last good config", func(t *testing.T) {
+		storageDir := t.TempDir()
+		s := newSupervisor(storageDir, &RemoteConfigStatus{
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+			LastRemoteConfigHash: hex.EncodeToString(badCfg.ConfigHash),
+		})
+		require.NoError(t, s.saveLastReceivedConfig(badCfg))
+		s.loadRemoteConfig()
+		s.loadLastGoodRemoteConfig()
+
+		assert.Equal(t, badCfg.String(), s.remoteConfig.String())
+		assert.Nil(t, s.lastGoodRemoteConfig)
+	})
+}
+
+func TestSupervisor_rollbackRemoteConfig(t *testing.T) {
+	// The last good config has no config map, so the agent is not started after rolling back.
+	goodCfg := &protobufs.AgentRemoteConfig{
+		Config:     &protobufs.AgentConfigMap{},
+		ConfigHash: []byte("good"),
+	}
+	badCfg := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: []byte("receivers:\n  doesntexist:\n")},
+			},
+		},
+		ConfigHash: []byte("bad"),
+	}
+
+	newSupervisor := func(t *testing.T, mc *mockOpAMPClient) *Supervisor {
+		storageDir := t.TempDir()
+		cmd, err := commander.NewCommander(zap.NewNop(), storageDir, config.Agent{})
+		require.NoError(t, err)
+
+		s := &Supervisor{
+			telemetrySettings: newNopTelemetrySettings(),
+			config: config.Supervisor{
+				Capabilities: config.Capabilities{
+					AcceptsRemoteConfig: true,
+					ReportsRemoteConfig: true,
+				},
+				Storage: config.Storage{
+					Directory: storageDir,
+				},
+			},
+			commander:                    cmd,
+			opampClient:                  mc,
+			agentConfigOwnMetricsSection: &atomic.Value{},
+			cfgState:                     &atomic.Value{},
+			persistentState: &persistentState{
+				InstanceID: uuid.MustParse("018fee23-4a51-7303-a441-73faed7d9deb"),
+				configPath: filepath.Join(storageDir, persistentStateFileName),
+			},
+			pidProvider:      staticPIDProvider(1234),
+			agentDescription: &atomic.Value{},
+			remoteConfig:     badCfg,
+		}
+		s.agentDescription.Store(&protobufs.AgentDescription{})
+		require.NoError(t, s.createTemplates())
+		return s
+	}
+
+	t.Run("No last good config", func(t *testing.T) {
+		var statuses []*protobufs.RemoteConfigStatus
+		mc := &mockOpAMPClient{
+			setRemoteConfigStatusFunc: func(rcs *protobufs.RemoteConfigStatus) error {
+				statuses = append(statuses, rcs)
+				return nil
+			},
+		}
+		s := newSupervisor(t, mc)
+
+		s.rollbackRemoteConfig(badCfg.ConfigHash, "Config apply timeout exceeded")
+
+		require.Len(t, statuses, 1)
+		assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, statuses[0].Status)
+		assert.Equal(t, "Config apply timeout exceeded", statuses[0].ErrorMessage)
+		assert.Equal(t, badCfg.ConfigHash, statuses[0].LastRemoteConfigHash)
+		assert.Equal(t, badCfg, s.remoteConfig)
+	})
+
+	t.Run("Rolls back to the last good config", func(t *testing.T) {
+		var statuses []*protobufs.RemoteConfigStatus
+		effectiveConfigUpdated := false
+		mc := &mockOpAMPClient{
+			setRemoteConfigStatusFunc: func(rcs *protobufs.RemoteConfigStatus) error {
+				statuses = append(statuses, rcs)
+				return nil
+			},
+			updateEffectiveConfigFunc: func(_ context.Context) error {
+				effectiveConfigUpdated = true
+				return nil
+			},
+		}
+		s := newSupervisor(t, mc)
+		s.lastGoodRemoteConfig = goodCfg
+
+		s.rollbackRemoteConfig(badCfg.ConfigHash, "Config apply timeout exceeded")
+
+		require.Len(t, statuses, 1)
+		assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, statuses[0].Status)
+		assert.Equal(t, "Config apply timeout exceeded, rolled back to the last good remote config", statuses[0].ErrorMessage)
+		assert.Equal(t, badCfg.ConfigHash, statuses[0].LastRemoteConfigHash)
+		assert.Equal(t, goodCfg, s.remoteConfig)
+		assert.True(t, effectiveConfigUpdated)
+		assert.True(t, s.persistentState.IsBadRemoteConfig(badCfg.ConfigHash))
+
+		agentCfg, err := os.ReadFile(s.agentConfigFilePath())
+		require.NoError(t, err)
+		assert.Equal(t, s.cfgState.Load().(*configState).mergedConfig, string(agentCfg))
+		assert.NotContains(t, string(agentCfg), "doesntexist")
+
+		// The Server sending the rolled back config again does not restart the agent with it.
+		statuses = nil
+		assert.False(t, s.processRemoteConfigMessage(badCfg))
+		require.Len(t, statuses, 1)
+		assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, statuses[0].Status)
+		assert.Equal(t, badCfg.ConfigHash, statuses[0].LastRemoteConfigHash)
+		assert.Equal(t, goodCfg, s.remoteConfig)
+
+		// The rolled back config is still refused after a restart.
+		loaded, err := loadPersistentState(s.persistentState.configPath, zap.NewNop())
+		require.NoError(t, err)
+		assert.True(t, loaded.IsBadRemoteConfig(badCfg.ConfigHash))
+
+		// Once the Server sends another config, the rolled back config is applied if it is sent again.
+		otherCfg := &protobufs.AgentRemoteConfig{
+			Config: &protobufs.AgentConfigMap{
+				ConfigMap: map[string]*protobufs.AgentConfigFile{
+					"": {Body: []byte("processors:\n  batch:\n")},
+				},
+			},
+			ConfigHash: []byte("other"),
+		}
+		s.processRemoteConfigMessage(otherCfg)
+		assert.False(t, s.persistentState.IsBadRemoteConfig(badCfg.ConfigHash))
+		statuses = nil
+		assert.True(t, s.processRemoteConfigMessage(badCfg))
+		require.Len(t, statuses, 1)
+		assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, statuses[0].Status)
+		assert.Equal(t, badCfg, s.remoteConfig)
+	})
+
+	t.Run("Config replaced while applying", func(t *testing.T) {
+		var statuses []*protobufs.RemoteConfigStatus
+		mc := &mockOpAMPClient{
+			setRemoteConfigStatusFunc: func(rcs *protobufs.RemoteConfigStatus) error {
+				statuses = append(statuses, rcs)
+				return nil
+			},
+		}
+		s := newSupervisor(t, mc)
+		s.lastGoodRemoteConfig = goodCfg
+
+		s.rollbackRemoteConfig([]byte("replaced"), "Config apply timeout exceeded")
+
+		assert.Empty(t, statuses)
+		assert.Equal(t, badCfg, s.remoteConfig)
+		assert.False(t, s.persistentState.IsBadRemoteConfig([]byte("replaced")))
+	})
+}
+
 func TestSupervisor_composeNoopConfig(t *testing.T) {
 	const expectedConfig = `exporters:
     nop: null
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// ottlcheck validates OTTL statements and conditions offline, and evaluates them against
+// OTLP JSON files, printing the changes they make to the data.
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck"
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// executor evaluates parsed OTTL statements or conditions against telemetry data.
+type executor interface {
+	executeTraces(ctx context.Context, td ptrace.Traces) error
+	executeMetrics(ctx context.Context, md pmetric.Metrics) error
+	executeLogs(ctx context.Context, ld plog.Logs) error
+}
+
+// contextExecutor is the executor of a single OTTL context. When only conditions are set,
+// the items matching any of them are removed, as done by the filter processor. Otherwise,
+// the statements are executed for the items matching any of the conditions, as done by
+// the transform processor.
+type contextExecutor[K any] struct {
+	context    string
+	walker     walker[K]
+	statements *ottl.StatementSequence[K]
+	conditions *ottl.ConditionSequence[K]
+}
+
+func (e *contextExecutor[K]) visit(ctx context.Context) visitFunc[K] {
+	return func(tCtx K) (bool, error) {
+		if e.conditions != nil {
+			match, err := e.conditions.Eval(ctx, tCtx)
+			if err != nil || !match {
+				return false, err
+			}
+			if e.statements == nil {
+				return true, nil
+			}
+		}
+		return false, e.statements.Execute(ctx, tCtx)
+	}
+}
+
+func (e *contextExecutor[K]) executeTraces(ctx context.Context, td ptrace.Traces) error {
+	if e.walker.traces == nil {
+		return fmt.Errorf("context %q cannot be evaluated against traces", e.context)
+	}
+	return e.walker.traces(td, e.visit(ctx))
+}
+
+func (e *contextExecutor[K]) executeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.walker.metrics == nil {
+		return fmt.Errorf("context %q cannot be evaluated against metrics", e.context)
+	}
+	return e.walker.metrics(md, e.visit(ctx))
+}
+
+func (e *contextExecutor[K]) executeLogs(ctx context.Context, ld plog.Logs) error {
+	if e.walker.logs == nil {
+		return fmt.Errorf("context %q cannot be evaluated against logs", e.context)
+	}
+	return e.walker.logs(ld, e.visit(ctx))
+}
+
+// group is a list of statements and conditions sharing the same OTTL context, with the
+// same semantics as the transform processor's statement groups. If the context is empty,
+// it is inferred from the statements and conditions paths.
+type group struct {
+	Context    string   `json:"context"`
+	Conditions []string `json:"conditions"`
+	Statements []string `json:"statements"`
+}
+
+func (g group) GetStatements() []string {
+	return g.Statements
+}
+
+func (g group) GetConditions() []string {
+	return g.Conditions
+}
+
+func (g group) parse(pc *ottl.ParserCollection[executor]) (executor, error) {
+	switch {
+	case len(g.Statements) > 0 && g.Context != "":
+		return pc.ParseStatementsWithContext(g.Context, g, true)
+	case len(g.Statements) > 0:
+		return pc.ParseStatements(g, ottl.WithContextInferenceConditions(g.Conditions))
+	case len(g.Conditions) > 0 && g.Context != "":
+		return pc.ParseConditionsWithContext(g.Context, g, true)
+	case len(g.Conditions) > 0:
+		return pc.ParseConditions(g)
+	default:
+		return nil, errors.New("at least one statement or condition must be provided")
+	}
+}
+
+// newParserCollection creates a parser collection supporting all OTTL contexts with the
+// full set of ottlfuncs editors and converters.
+func newParserCollection(settings component.TelemetrySettings) (*ottl.ParserCollection[executor], error) {
+	return ottl.NewParserCollection(
+		settings,
+		ottl.WithParserCollectionErrorMode[executor](ottl.PropagateError),
+		withContext(ottlresource.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottlresource.TransformContext], error) {
+			return ottlresource.NewParser(ottlfuncs.StandardFuncs[ottlresource.TransformContext](), set, ottlresource.EnablePathContextNames())
+		}, resourceWalker),
+		withContext(ottlscope.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottlscope.TransformContext], error) {
+			return ottlscope.NewParser(ottlfuncs.StandardFuncs[ottlscope.TransformContext](), set, ottlscope.EnablePathContextNames())
+		}, scopeWalker),
+		withContext(ottlspan.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottlspan.TransformContext], error) {
+			return ottlspan.NewParser(ottlfuncs.StandardFuncs[ottlspan.TransformContext](), set, ottlspan.EnablePathContextNames())
+		}, spanWalker),
+		withContext(ottlspanevent.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottlspanevent.TransformContext], error) {
+			return ottlspanevent.NewParser(ottlfuncs.StandardFuncs[ottlspanevent.TransformContext](), set, ottlspanevent.EnablePathContextNames())
+		}, spanEventWalker),
+		withContext(ottlmetric.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottlmetric.TransformContext], error) {
+			return ottlmetric.NewParser(ottlfuncs.StandardFuncs[ottlmetric.TransformContext](), set, ottlmetric.EnablePathContextNames())
+		}, metricWalker),
+		withContext(ottldatapoint.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottldatapoint.TransformContext], error) {
+			return ottldatapoint.NewParser(ottlfuncs.StandardFuncs[ottldatapoint.TransformContext](), set, ottldatapoint.EnablePathContextNames())
+		}, dataPointWalker),
+		withContext(ottlexemplar.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottlexemplar.TransformContext], error) {
+			return ottlexemplar.NewParser(ottlfuncs.StandardFuncs[ottlexemplar.TransformContext](), set, ottlexemplar.EnablePathContextNames())
+		}, exemplarWalker),
+		withContext(ottllog.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottllog.TransformContext], error) {
+			return ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), set, ottllog.EnablePathContextNames())
+		}, logWalker),
+		// Profiles can be validated, but OTLP JSON profiles are not supported as input yet.
+		withContext(ottlprofile.ContextName, func(set component.TelemetrySettings) (ottl.Parser[ottlprofile.TransformContext], error) {
+			return ottlprofile.NewParser(ottlfuncs.StandardFuncs[ottlprofile.TransformContext](), set, ottlprofile.EnablePathContextNames())
+		}, walker[ottlprofile.TransformContext]{}),
+	)
+}
+
+func withContext[K any](name string, newParser func(component.TelemetrySettings) (ottl.Parser[K], error), w walker[K]) ottl.ParserCollectionOption[executor] {
+	return func(pc *ottl.ParserCollection[executor]) error {
+		parser, err := newParser(pc.Settings)
+		if err != nil {
+			return err
+		}
+		return ottl.WithParserCollectionContext(
+			name,
+			&parser,
+			ottl.WithStatementConverter(newStatementsConverter(name, w)),
+			ottl.WithConditionConverter(newConditionsConverter(name, w)),
+		)(pc)
+	}
+}
+
+func newStatementsConverter[K any](name string, w walker[K]) ottl.ParsedStatementsConverter[K, executor] {
+	return func(pc *ottl.ParserCollection[executor], statements ottl.StatementsGetter, parsedStatements []*ottl.Statement[K]) (executor, error) {
+		sequence := ottl.NewStatementSequence(parsedStatements, pc.Settings, ottl.WithStatementSequenceErrorMode[K](pc.ErrorMode))
+		e := &contextExecutor[K]{context: name, walker: w, statements: &sequence}
+		if g, ok := statements.(group); ok && len(g.Conditions) > 0 {
+			conditions, err := pc.ParseConditionsWithContext(name, g, g.Context != "")
+			if err != nil {
+				return nil, err
+			}
+			e.conditions = conditions.(*contextExecutor[K]).conditions
+		}
+		return e, nil
+	}
+}
+
+func newConditionsConverter[K any](name string, w walker[K]) ottl.ParsedConditionsConverter[K, executor] {
+	return func(pc *ottl.ParserCollection[executor], _ ottl.ConditionsGetter, parsedConditions []*ottl.Condition[K]) (executor, error) {
+		sequence := ottl.NewConditionSequence(parsedConditions, pc.Settings, ottl.WithConditionSequenceErrorMode[K](pc.ErrorMode))
+		return &contextExecutor[K]{context: name, walker: w, conditions: &sequence}, nil
+	}
+}
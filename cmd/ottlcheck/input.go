@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// input is telemetry data read from an OTLP JSON file.
+type input interface {
+	execute(ctx context.Context, e executor) error
+	marshal() ([]byte, error)
+}
+
+type tracesInput struct {
+	ptrace.Traces
+}
+
+func (i tracesInput) execute(ctx context.Context, e executor) error {
+	return e.executeTraces(ctx, i.Traces)
+}
+
+func (i tracesInput) marshal() ([]byte, error) {
+	return (&ptrace.JSONMarshaler{}).MarshalTraces(i.Traces)
+}
+
+type metricsInput struct {
+	pmetric.Metrics
+}
+
+func (i metricsInput) execute(ctx context.Context, e executor) error {
+	return e.executeMetrics(ctx, i.Metrics)
+}
+
+func (i metricsInput) marshal() ([]byte, error) {
+	return (&pmetric.JSONMarshaler{}).MarshalMetrics(i.Metrics)
+}
+
+type logsInput struct {
+	plog.Logs
+}
+
+func (i logsInput) execute(ctx context.Context, e executor) error {
+	return e.executeLogs(ctx, i.Logs)
+}
+
+func (i logsInput) marshal() ([]byte, error) {
+	return (&plog.JSONMarshaler{}).MarshalLogs(i.Logs)
+}
+
+// readInput reads an OTLP JSON file, detecting the signal from its top-level field.
+func readInput(path string) (input, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	switch {
+	case fields["resourceSpans"] != nil:
+		td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(data)
+		return tracesInput{td}, err
+	case fields["resourceMetrics"] != nil:
+		md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(data)
+		return metricsInput{md}, err
+	case fields["resourceLogs"] != nil:
+		ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(data)
+		return logsInput{ld}, err
+	default:
+		return nil, errors.New(`expected an OTLP JSON document with a "resourceSpans", "resourceMetrics" or "resourceLogs" field`)
+	}
+}
+
+// diff returns the unified diff between the indented JSON representations of before and after,
+// or an empty string if they are equal.
+func diff(path string, before, after []byte) (string, error) {
+	var a, b bytes.Buffer
+	if err := json.Indent(&a, before, "", "  "); err != nil {
+		return "", err
+	}
+	if err := json.Indent(&b, after, "", "  "); err != nil {
+		return "", err
+	}
+	a.WriteByte('\n')
+	b.WriteByte('\n')
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a.String()),
+		B:        difflib.SplitLines(b.String()),
+		FromFile: fmt.Sprintf("%s (before)", path),
+		ToFile:   fmt.Sprintf("%s (after)", path),
+		Context:  3,
+	})
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// stringsFlag is a flag that can be repeated to collect multiple values.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// namedGroup is a group of statements or conditions along with where it was defined.
+type namedGroup struct {
+	name string
+	group
+}
+
+type config struct {
+	groups []namedGroup
+	inputs []string
+}
+
+func parseArgs(args []string, output io.Writer) (*config, error) {
+	var contextName string
+	var statements, conditions, rulesFiles stringsFlag
+
+	fs := flag.NewFlagSet("ottlcheck", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprintln(output, "Usage: ottlcheck [flags] [OTLP JSON file...]")
+		fmt.Fprintln(output, "\nValidates OTTL statements and conditions, and evaluates them against the given OTLP JSON files.")
+		fmt.Fprintln(output, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&contextName, "context", "", "OTTL context of the -statement and -condition values, inferred from their paths if empty")
+	fs.Var(&statements, "statement", "OTTL statement to validate and execute, can be repeated")
+	fs.Var(&conditions, "condition", "OTTL condition to validate; it drops the matching items, or guards the -statement values if any; can be repeated")
+	fs.Var(&rulesFiles, "rules", "YAML file with a list of statement groups, using the transform processor's context, conditions and statements fields; can be repeated")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := &config{inputs: fs.Args()}
+	if len(statements) > 0 || len(conditions) > 0 {
+		cfg.groups = append(cfg.groups, namedGroup{
+			name:  "flags",
+			group: group{Context: contextName, Conditions: conditions, Statements: statements},
+		})
+	} else if contextName != "" {
+		return nil, errors.New("-context requires at least one -statement or -condition")
+	}
+
+	for _, path := range rulesFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var groups []group
+		if err = yaml.UnmarshalStrict(data, &groups); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for i, g := range groups {
+			cfg.groups = append(cfg.groups, namedGroup{name: fmt.Sprintf("%s[%d]", path, i), group: g})
+		}
+	}
+
+	if len(cfg.groups) == 0 {
+		return nil, errors.New("at least one -statement, -condition or -rules must be provided")
+	}
+	return cfg, nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run validates the configured OTTL groups, and evaluates them in order against each input file,
+// printing the resulting diff. It returns a non-zero exit code if any validation or evaluation fails.
+func run(args []string, stdout, stderr io.Writer) int {
+	cfg, err := parseArgs(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	pc, err := newParserCollection(componenttest.NewNopTelemetrySettings())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	executors := make([]executor, 0, len(cfg.groups))
+	failed := false
+	for _, g := range cfg.groups {
+		e, parseErr := g.parse(pc)
+		if parseErr != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", g.name, parseErr)
+			failed = true
+			continue
+		}
+		executors = append(executors, e)
+	}
+	if failed {
+		return exitFailure
+	}
+
+	for _, path := range cfg.inputs {
+		if evalErr := evaluate(path, cfg.groups, executors, stdout); evalErr != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", path, evalErr)
+			failed = true
+		}
+	}
+	if failed {
+		return exitFailure
+	}
+	return exitOK
+}
+
+func evaluate(path string, groups []namedGroup, executors []executor, stdout io.Writer) error {
+	in, err := readInput(path)
+	if err != nil {
+		return err
+	}
+	before, err := in.marshal()
+	if err != nil {
+		return err
+	}
+	for i, e := range executors {
+		if err = in.execute(context.Background(), e); err != nil {
+			return fmt.Errorf("%s: %w", groups[i].name, err)
+		}
+	}
+	after, err := in.marshal()
+	if err != nil {
+		return err
+	}
+	d, err := diff(path, before, after)
+	if err != nil {
+		return err
+	}
+	if d == "" {
+		fmt.Fprintf(stdout, "%s: no changes\n", path)
+		return nil
+	}
+	_, err = io.WriteString(stdout, d)
+	return err
+}
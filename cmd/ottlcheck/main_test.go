@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck"
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		expectedFile string
+	}{
+		{
+			name: "statements",
+			args: []string{
+				"-context", "span",
+				"-statement", `set(attributes["user.email"], SHA256(attributes["user.email"]))`,
+				"-statement", `set(name, "GET") where attributes["http.method"] == "GET"`,
+				"testdata/traces.json",
+			},
+			expectedFile: "traces.diff",
+		},
+		{
+			name: "inferred context",
+			args: []string{
+				"-statement", `delete_key(resource.attributes, "service.name")`,
+				"testdata/traces.json",
+			},
+			expectedFile: "traces_resource.diff",
+		},
+		{
+			name: "conditions",
+			args: []string{
+				"-condition", `log.severity_number < SEVERITY_NUMBER_INFO`,
+				"testdata/logs.json",
+			},
+			expectedFile: "logs.diff",
+		},
+		{
+			name: "rules",
+			args: []string{
+				"-rules", "testdata/rules.yaml",
+				"testdata/metrics.json",
+			},
+			expectedFile: "metrics.diff",
+		},
+		{
+			name: "no changes",
+			args: []string{
+				"-condition", `log.severity_number < SEVERITY_NUMBER_TRACE`,
+				"testdata/logs.json",
+			},
+			expectedFile: "no_changes.txt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, &stdout, &stderr)
+			require.Equal(t, exitOK, code, stderr.String())
+
+			expected, err := os.ReadFile(filepath.Join("testdata", tt.expectedFile))
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), stdout.String())
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStderr string
+	}{
+		{
+			name:           "no statements",
+			args:           []string{"testdata/traces.json"},
+			expectedCode:   exitUsage,
+			expectedStderr: "at least one -statement, -condition or -rules must be provided\n",
+		},
+		{
+			name:           "context without statements",
+			args:           []string{"-context", "span"},
+			expectedCode:   exitUsage,
+			expectedStderr: "-context requires at least one -statement or -condition\n",
+		},
+		{
+			name: "invalid rules",
+			args: []string{"-rules", "testdata/invalid_rules.yaml"},
+			expectedStderr: `testdata/invalid_rules.yaml[0]: unable to parse OTTL statement "Set(attributes[\"b\"], 2)": 1:1: editor names must start with a lowercase letter but got 'Set'` + "\n" +
+				`unable to parse OTTL statement "set(attributes[\"c\"], 1 + int(name))": 1:26: converter names must start with an uppercase letter but got 'int'` + "\n" +
+				`testdata/invalid_rules.yaml[1]: unable to parse OTTL statement "set(span.attributes[\"d\"], Concat(span.name))": error while parsing arguments for call to "set": invalid argument at position 1: error while parsing arguments for call to "Concat": incorrect number of arguments. Expected: 2 Received: 1` + "\n",
+			expectedCode: exitFailure,
+		},
+		{
+			name: "unsupported signal",
+			args: []string{
+				"-statement", `set(span.name, "foo")`,
+				"testdata/logs.json",
+			},
+			expectedStderr: "testdata/logs.json: flags: context \"span\" cannot be evaluated against logs\n",
+			expectedCode:   exitFailure,
+		},
+		{
+			name: "evaluation error",
+			args: []string{
+				"-statement", `set(log.attributes["length"], Len(log.attributes["missing"]))`,
+				"testdata/logs.json",
+			},
+			expectedStderr: "testdata/logs.json: flags: failed to execute statement: set(log.attributes[\"length\"], Len(log.attributes[\"missing\"])), " +
+				"target arg must be of type string, []any, map[string]any, pcommon.Map, pcommon.Slice, pcommon.Value (of type String, Map, Slice) or a supported slice type from the plog, pmetric or ptrace packages\n",
+			expectedCode: exitFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, &stdout, &stderr)
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedStderr, stderr.String())
+		})
+	}
+}
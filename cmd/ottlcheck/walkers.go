@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottlcheck"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+)
+
+// visitFunc is called for each telemetry item of a context, and returns whether the
+// item must be removed from the data.
+type visitFunc[K any] func(tCtx K) (bool, error)
+
+// walker iterates over the telemetry items of a context for each signal.
+// A nil function means the context cannot be evaluated against that signal.
+type walker[K any] struct {
+	traces  func(td ptrace.Traces, visit visitFunc[K]) error
+	metrics func(md pmetric.Metrics, visit visitFunc[K]) error
+	logs    func(ld plog.Logs, visit visitFunc[K]) error
+}
+
+// removeIf removes the items for which visit returns true, keeping the first visit error.
+func removeIf[T any, K any](remove func(func(T) bool), newTransformContext func(T) K, visit visitFunc[K]) error {
+	var visitErr error
+	remove(func(item T) bool {
+		if visitErr != nil {
+			return false
+		}
+		drop, err := visit(newTransformContext(item))
+		if err != nil {
+			visitErr = err
+			return false
+		}
+		return drop
+	})
+	return visitErr
+}
+
+var resourceWalker = walker[ottlresource.TransformContext]{
+	traces: func(td ptrace.Traces, visit visitFunc[ottlresource.TransformContext]) error {
+		return removeIf(td.ResourceSpans().RemoveIf, func(rspans ptrace.ResourceSpans) ottlresource.TransformContext {
+			return ottlresource.NewTransformContext(rspans.Resource(), rspans)
+		}, visit)
+	},
+	metrics: func(md pmetric.Metrics, visit visitFunc[ottlresource.TransformContext]) error {
+		return removeIf(md.ResourceMetrics().RemoveIf, func(rmetrics pmetric.ResourceMetrics) ottlresource.TransformContext {
+			return ottlresource.NewTransformContext(rmetrics.Resource(), rmetrics)
+		}, visit)
+	},
+	logs: func(ld plog.Logs, visit visitFunc[ottlresource.TransformContext]) error {
+		return removeIf(ld.ResourceLogs().RemoveIf, func(rlogs plog.ResourceLogs) ottlresource.TransformContext {
+			return ottlresource.NewTransformContext(rlogs.Resource(), rlogs)
+		}, visit)
+	},
+}
+
+var scopeWalker = walker[ottlscope.TransformContext]{
+	traces: func(td ptrace.Traces, visit visitFunc[ottlscope.TransformContext]) error {
+		for _, rspans := range td.ResourceSpans().All() {
+			err := removeIf(rspans.ScopeSpans().RemoveIf, func(sspans ptrace.ScopeSpans) ottlscope.TransformContext {
+				return ottlscope.NewTransformContext(sspans.Scope(), rspans.Resource(), sspans)
+			}, visit)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	metrics: func(md pmetric.Metrics, visit visitFunc[ottlscope.TransformContext]) error {
+		for _, rmetrics := range md.ResourceMetrics().All() {
+			err := removeIf(rmetrics.ScopeMetrics().RemoveIf, func(smetrics pmetric.ScopeMetrics) ottlscope.TransformContext {
+				return ottlscope.NewTransformContext(smetrics.Scope(), rmetrics.Resource(), smetrics)
+			}, visit)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	logs: func(ld plog.Logs, visit visitFunc[ottlscope.TransformContext]) error {
+		for _, rlogs := range ld.ResourceLogs().All() {
+			err := removeIf(rlogs.ScopeLogs().RemoveIf, func(slogs plog.ScopeLogs) ottlscope.TransformContext {
+				return ottlscope.NewTransformContext(slogs.Scope(), rlogs.Resource(), slogs)
+			}, visit)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var spanWalker = walker[ottlspan.TransformContext]{
+	traces: func(td ptrace.Traces, visit visitFunc[ottlspan.TransformContext]) error {
+		for _, rspans := range td.ResourceSpans().All() {
+			for _, sspans := range rspans.ScopeSpans().All() {
+				err := removeIf(sspans.Spans().RemoveIf, func(span ptrace.Span) ottlspan.TransformContext {
+					return ottlspan.NewTransformContext(span, sspans.Scope(), rspans.Resource(), sspans, rspans)
+				}, visit)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var spanEventWalker = walker[ottlspanevent.TransformContext]{
+	traces: func(td ptrace.Traces, visit visitFunc[ottlspanevent.TransformContext]) error {
+		for _, rspans := range td.ResourceSpans().All() {
+			for _, sspans := range rspans.ScopeSpans().All() {
+				for _, span := range sspans.Spans().All() {
+					err := removeIf(span.Events().RemoveIf, func(event ptrace.SpanEvent) ottlspanevent.TransformContext {
+						return ottlspanevent.NewTransformContext(event, span, sspans.Scope(), rspans.Resource(), sspans, rspans)
+					}, visit)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var metricWalker = walker[ottlmetric.TransformContext]{
+	metrics: func(md pmetric.Metrics, visit visitFunc[ottlmetric.TransformContext]) error {
+		for _, rmetrics := range md.ResourceMetrics().All() {
+			for _, smetrics := range rmetrics.ScopeMetrics().All() {
+				err := removeIf(smetrics.Metrics().RemoveIf, func(metric pmetric.Metric) ottlmetric.TransformContext {
+					return ottlmetric.NewTransformContext(metric, smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics)
+				}, visit)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var dataPointWalker = walker[ottldatapoint.TransformContext]{
+	metrics: func(md pmetric.Metrics, visit visitFunc[ottldatapoint.TransformContext]) error {
+		for _, rmetrics := range md.ResourceMetrics().All() {
+			for _, smetrics := range rmetrics.ScopeMetrics().All() {
+				for _, metric := range smetrics.Metrics().All() {
+					newTransformContext := func(dataPoint any) ottldatapoint.TransformContext {
+						return ottldatapoint.NewTransformContext(dataPoint, metric, smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics)
+					}
+					var err error
+					switch metric.Type() {
+					case pmetric.MetricTypeSum:
+						err = removeIf(metric.Sum().DataPoints().RemoveIf, func(dp pmetric.NumberDataPoint) ottldatapoint.TransformContext {
+							return newTransformContext(dp)
+						}, visit)
+					case pmetric.MetricTypeGauge:
+						err = removeIf(metric.Gauge().DataPoints().RemoveIf, func(dp pmetric.NumberDataPoint) ottldatapoint.TransformContext {
+							return newTransformContext(dp)
+						}, visit)
+					case pmetric.MetricTypeHistogram:
+						err = removeIf(metric.Histogram().DataPoints().RemoveIf, func(dp pmetric.HistogramDataPoint) ottldatapoint.TransformContext {
+							return newTransformContext(dp)
+						}, visit)
+					case pmetric.MetricTypeExponentialHistogram:
+						err = removeIf(metric.ExponentialHistogram().DataPoints().RemoveIf, func(dp pmetric.ExponentialHistogramDataPoint) ottldatapoint.TransformContext {
+							return newTransformContext(dp)
+						}, visit)
+					case pmetric.MetricTypeSummary:
+						err = removeIf(metric.Summary().DataPoints().RemoveIf, func(dp pmetric.SummaryDataPoint) ottldatapoint.TransformContext {
+							return newTransformContext(dp)
+						}, visit)
+					}
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var exemplarWalker = walker[ottlexemplar.TransformContext]{
+	metrics: func(md pmetric.Metrics, visit visitFunc[ottlexemplar.TransformContext]) error {
+		for _, rmetrics := range md.ResourceMetrics().All() {
+			for _, smetrics := range rmetrics.ScopeMetrics().All() {
+				for _, metric := range smetrics.Metrics().All() {
+					visitExemplars := func(exemplars pmetric.ExemplarSlice, dataPoint any) error {
+						return removeIf(exemplars.RemoveIf, func(exemplar pmetric.Exemplar) ottlexemplar.TransformContext {
+							return ottlexemplar.NewTransformContext(exemplar, dataPoint, metric, smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics)
+						}, visit)
+					}
+					// Summary datapoints have no exemplars.
+					switch metric.Type() {
+					case pmetric.MetricTypeSum:
+						for _, dp := range metric.Sum().DataPoints().All() {
+							if err := visitExemplars(dp.Exemplars(), dp); err != nil {
+								return err
+							}
+						}
+					case pmetric.MetricTypeGauge:
+						for _, dp := range metric.Gauge().DataPoints().All() {
+							if err := visitExemplars(dp.Exemplars(), dp); err != nil {
+								return err
+							}
+						}
+					case pmetric.MetricTypeHistogram:
+						for _, dp := range metric.Histogram().DataPoints().All() {
+							if err := visitExemplars(dp.Exemplars(), dp); err != nil {
+								return err
+							}
+						}
+					case pmetric.MetricTypeExponentialHistogram:
+						for _, dp := range metric.ExponentialHistogram().DataPoints().All() {
+							if err := visitExemplars(dp.Exemplars(), dp); err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+		}
+		return nil
+	},
+}
+
+var logWalker = walker[ottllog.TransformContext]{
+	logs: func(ld plog.Logs, visit visitFunc[ottllog.TransformContext]) error {
+		for _, rlogs := range ld.ResourceLogs().All() {
+			for _, slogs := range rlogs.ScopeLogs().All() {
+				err := removeIf(slogs.LogRecords().RemoveIf, func(logRecord plog.LogRecord) ottllog.TransformContext {
+					return ottllog.NewTransformContext(logRecord, slogs.Scope(), rlogs.Resource(), slogs, rlogs)
+				}, visit)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
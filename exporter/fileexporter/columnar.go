@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/columnar"
+)
+
+// The columnar formats write all the telemetry of a file as a single Parquet file or Arrow IPC stream.
+// Each batch is marshaled to an Arrow IPC stream holding a single record, which the file writer
+// decodes and appends to the stream of the file.
+
+const megabyte = 1024 * 1024
+
+func isColumnarFormat(formatType string) bool {
+	return formatType == formatTypeParquet || formatType == formatTypeArrow
+}
+
+// isColumnar returns whether the telemetry is written with a columnar format,
+// which is overridden by an encoding extension.
+func (cfg *Config) isColumnar() bool {
+	return cfg.Encoding == nil && isColumnarFormat(cfg.FormatType)
+}
+
+// recordMarshaler marshals telemetry to an Arrow IPC stream holding a single record.
+type recordMarshaler struct{}
+
+func (recordMarshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	return marshalRecord(columnar.NewTracesRecord(memory.DefaultAllocator, td))
+}
+
+func (recordMarshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	return marshalRecord(columnar.NewMetricsRecord(memory.DefaultAllocator, md))
+}
+
+func (recordMarshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return marshalRecord(columnar.NewLogsRecord(memory.DefaultAllocator, ld))
+}
+
+func marshalRecord(rec arrow.Record) ([]byte, error) {
+	defer rec.Release()
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(rec.Schema()))
+	if err := w.Write(rec); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// recordWriter writes records to a Parquet file or an Arrow IPC stream.
+type recordWriter interface {
+	Write(rec arrow.Record) error
+	Close() error
+}
+
+// recordStream is the record writer of the file currently written by a fileWriter.
+// The writer is nil once the stream is complete and the file must be rotated.
+type recordStream struct {
+	writer  recordWriter
+	schema  *arrow.Schema
+	counter *countingWriter
+}
+
+// countingWriter counts the bytes written to the file. It does not implement io.Closer,
+// so that closing a record writer does not close the file.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type recordsExporter struct {
+	newRecordWriter func(w io.Writer, schema *arrow.Schema) (recordWriter, error)
+	// maxBytes is the size after which the file is rotated, or zero when rotation is disabled.
+	maxBytes int64
+}
+
+func newRecordsExporter(cfg *Config) *recordsExporter {
+	e := &recordsExporter{}
+	// rotation is ignored when group_by is enabled.
+	if cfg.Rotation != nil && (cfg.GroupBy == nil || !cfg.GroupBy.Enabled) {
+		maxMegabytes := cfg.Rotation.MaxMegabytes
+		if maxMegabytes <= 0 {
+			maxMegabytes = 100
+		}
+		e.maxBytes = int64(maxMegabytes) * megabyte
+	}
+
+	switch cfg.FormatType {
+	case formatTypeParquet:
+		codec := compress.Codecs.Uncompressed
+		if cfg.Compression == compressionZSTD {
+			codec = compress.Codecs.Zstd
+		}
+		props := parquet.NewWriterProperties(parquet.WithCompression(codec))
+		e.newRecordWriter = func(w io.Writer, schema *arrow.Schema) (recordWriter, error) {
+			return pqarrow.NewFileWriter(schema, w, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+		}
+	case formatTypeArrow:
+		opts := []ipc.Option{}
+		if cfg.Compression == compressionZSTD {
+			opts = append(opts, ipc.WithZstd())
+		}
+		e.newRecordWriter = func(w io.Writer, schema *arrow.Schema) (recordWriter, error) {
+			return ipc.NewWriter(w, append(opts, ipc.WithSchema(schema))...), nil
+		}
+	}
+	return e
+}
+
+// export appends the record marshaled in buf to the stream of the file. Once the file is larger
+// than maxBytes its stream is completed, and the file is rotated before the next record is written.
+func (e *recordsExporter) export(w *fileWriter, buf []byte) error {
+	// Ensure only one write operation happens at a time.
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	reader, err := ipc.NewReader(bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	defer reader.Release()
+
+	for reader.Next() {
+		rec := reader.Record()
+		if w.records != nil && w.records.writer == nil {
+			if err := rotate(w); err != nil {
+				return err
+			}
+		}
+		if w.records == nil {
+			counter := &countingWriter{w: w.file}
+			writer, err := e.newRecordWriter(counter, rec.Schema())
+			if err != nil {
+				return err
+			}
+			w.records = &recordStream{writer: writer, schema: rec.Schema(), counter: counter}
+		} else if !w.records.schema.Equal(rec.Schema()) {
+			return errors.New("a file with a columnar format can only hold a single signal type, use one exporter per signal")
+		}
+		if err := w.records.writer.Write(rec); err != nil {
+			return err
+		}
+	}
+	if err := reader.Err(); err != nil {
+		return err
+	}
+
+	if e.maxBytes > 0 && w.records != nil && w.records.counter.n >= e.maxBytes {
+		return w.completeRecords()
+	}
+	return nil
+}
+
+// completeRecords completes the stream of the file, if any, so that the file is readable.
+// The file is rotated before the next record is written.
+func (w *fileWriter) completeRecords() error {
+	if w.records == nil || w.records.writer == nil {
+		return nil
+	}
+	err := w.records.writer.Close()
+	w.records.writer = nil
+	return err
+}
+
+// rotate starts a new file once the stream of the file is complete.
+func rotate(w *fileWriter) error {
+	w.records = nil
+	rotator, ok := w.file.(interface{ Rotate() error })
+	if !ok {
+		return nil
+	}
+	if err := rotator.Rotate(); err != nil {
+		return fmt.Errorf("failed to rotate file: %w", err)
+	}
+	return nil
+}
+
+// closeRecords completes the stream of the file, if any.
+func (w *fileWriter) closeRecords() error {
+	err := w.completeRecords()
+	w.records = nil
+	return err
+}
+
+// completesRecordsOnFlush returns whether the stream of the file is completed at each flush interval.
+// A Parquet file is only readable once its footer is written, so with rotation the file is completed
+// periodically rather than only once it is larger than max_megabytes.
+func completesRecordsOnFlush(cfg *Config) bool {
+	return cfg.isColumnar() && cfg.FormatType == formatTypeParquet && cfg.Rotation != nil
+}
+
+// fileRotation returns the rotation settings of the file. The columnar formats rotate the files
+// themselves once a stream is complete, so the size based rotation of the file is disabled.
+func fileRotation(cfg *Config) *Rotation {
+	if cfg.Rotation == nil || !cfg.isColumnar() {
+		return cfg.Rotation
+	}
+	rotation := *cfg.Rotation
+	rotation.MaxMegabytes = math.MaxInt32
+	return &rotation
+}
@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/columnar"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func fieldNames(schema *arrow.Schema) []string {
+	names := make([]string, 0, schema.NumFields())
+	for _, field := range schema.Fields() {
+		names = append(names, field.Name)
+	}
+	return names
+}
+
+// readColumnarFile returns the schema and the number of rows of a file written with a columnar format.
+func readColumnarFile(t *testing.T, formatType, path string) (*arrow.Schema, int64) {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	if formatType == formatTypeParquet {
+		pf, err := file.NewParquetReader(f)
+		require.NoError(t, err)
+		defer pf.Close()
+		reader, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+		require.NoError(t, err)
+		schema, err := reader.Schema()
+		require.NoError(t, err)
+		return schema, pf.NumRows()
+	}
+
+	reader, err := ipc.NewReader(f)
+	require.NoError(t, err)
+	defer reader.Release()
+	var rows int64
+	for reader.Next() {
+		rows += reader.Record().NumRows()
+	}
+	require.NoError(t, reader.Err())
+	return reader.Schema(), rows
+}
+
+func TestColumnarExporter(t *testing.T) {
+	tests := []struct {
+		formatType  string
+		compression string
+	}{
+		{formatType: formatTypeParquet},
+		{formatType: formatTypeParquet, compression: compressionZSTD},
+		{formatType: formatTypeArrow},
+		{formatType: formatTypeArrow, compression: compressionZSTD},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formatType+"/"+tt.compression, func(t *testing.T) {
+			t.Run("traces", func(t *testing.T) {
+				conf := &Config{Path: tempFileName(t), FormatType: tt.formatType, Compression: tt.compression}
+				fe := newFileExporter(conf, zap.NewNop())
+				require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+				require.NoError(t, fe.consumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+				require.NoError(t, fe.consumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+				require.NoError(t, fe.Shutdown(context.Background()))
+
+				schema, rows := readColumnarFile(t, tt.formatType, conf.Path)
+				assert.Equal(t, fieldNames(columnar.TracesSchema), fieldNames(schema))
+				assert.Equal(t, int64(3), rows)
+			})
+			t.Run("logs", func(t *testing.T) {
+				conf := &Config{Path: tempFileName(t), FormatType: tt.formatType, Compression: tt.compression}
+				fe := newFileExporter(conf, zap.NewNop())
+				require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+				require.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsManyLogRecordsSameResource(10)))
+				require.NoError(t, fe.Shutdown(context.Background()))
+
+				schema, rows := readColumnarFile(t, tt.formatType, conf.Path)
+				assert.Equal(t, fieldNames(columnar.LogsSchema), fieldNames(schema))
+				assert.Equal(t, int64(10), rows)
+			})
+			t.Run("metrics", func(t *testing.T) {
+				conf := &Config{Path: tempFileName(t), FormatType: tt.formatType, Compression: tt.compression}
+				md := testdata.GenerateMetricsManyMetricsSameResource(5)
+				fe := newFileExporter(conf, zap.NewNop())
+				require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+				require.NoError(t, fe.consumeMetrics(context.Background(), md))
+				require.NoError(t, fe.Shutdown(context.Background()))
+
+				schema, rows := readColumnarFile(t, tt.formatType, conf.Path)
+				assert.Equal(t, fieldNames(columnar.MetricsSchema), fieldNames(schema))
+				assert.Equal(t, int64(md.DataPointCount()), rows)
+			})
+		})
+	}
+}
+
+func TestColumnarExporterSignalMismatch(t *testing.T) {
+	conf := &Config{Path: tempFileName(t), FormatType: formatTypeArrow}
+	fe := newFileExporter(conf, zap.NewNop())
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, fe.consumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.EqualError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()),
+		"a file with a columnar format can only hold a single signal type, use one exporter per signal")
+	assert.EqualError(t, fe.consumeProfiles(context.Background(), testdata.GenerateProfilesOneProfile()),
+		"profiles are not supported by encoding")
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	_, rows := readColumnarFile(t, formatTypeArrow, conf.Path)
+	assert.Equal(t, int64(1), rows)
+}
+
+func TestColumnarExporterRotation(t *testing.T) {
+	for _, formatType := range []string{formatTypeParquet, formatTypeArrow} {
+		t.Run(formatType, func(t *testing.T) {
+			conf := &Config{
+				Path:       filepath.Join(t.TempDir(), "logs."+formatType),
+				FormatType: formatType,
+				Rotation:   &Rotation{MaxMegabytes: 1, MaxBackups: defaultMaxBackups},
+			}
+			fe := newFileExporter(conf, zap.NewNop())
+			require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+			for i := 0; i < 20; i++ {
+				ld := testdata.GenerateLogsManyLogRecordsSameResource(2000)
+				logs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+				for j := 0; j < logs.Len(); j++ {
+					// unique bodies are not shrunk by the dictionary encoding of parquet.
+					logs.At(j).Body().SetStr(fmt.Sprintf("%0100d", i*logs.Len()+j))
+				}
+				require.NoError(t, fe.consumeLogs(context.Background(), ld))
+			}
+			require.NoError(t, fe.Shutdown(context.Background()))
+
+			entries, err := os.ReadDir(filepath.Dir(conf.Path))
+			require.NoError(t, err)
+			require.Greater(t, len(entries), 1)
+			var rows int64
+			for _, entry := range entries {
+				_, n := readColumnarFile(t, formatType, filepath.Join(filepath.Dir(conf.Path), entry.Name()))
+				rows += n
+			}
+			assert.Equal(t, int64(20*2000), rows)
+		})
+	}
+}
+
+func TestColumnarExporterCompleteOnFlush(t *testing.T) {
+	conf := &Config{
+		Path:          filepath.Join(t.TempDir(), "logs.parquet"),
+		FormatType:    formatTypeParquet,
+		FlushInterval: 10 * time.Millisecond,
+		Rotation:      &Rotation{MaxMegabytes: 10, MaxBackups: defaultMaxBackups},
+	}
+	fe := newFileExporter(conf, zap.NewNop()).(*fileExporter)
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, fe.Shutdown(context.Background()))
+	}()
+	require.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsManyLogRecordsSameResource(10)))
+
+	// the file is readable once it is completed by the flusher, before the exporter shuts down.
+	require.Eventually(t, func() bool {
+		w := fe.writer
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		return w.records.writer == nil
+	}, time.Second, 10*time.Millisecond)
+	_, rows := readColumnarFile(t, formatTypeParquet, conf.Path)
+	assert.Equal(t, int64(10), rows)
+
+	// the next batch is written to a new file.
+	require.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsManyLogRecordsSameResource(5)))
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(filepath.Dir(conf.Path))
+		return err == nil && len(entries) == 2
+	}, time.Second, 10*time.Millisecond)
+}
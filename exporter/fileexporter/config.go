@@ -36,6 +36,8 @@ type Config struct {
 	// Options:
 	// - json[default]:  OTLP json bytes.
 	// - proto:  OTLP binary protobuf bytes.
+	// - parquet:  Parquet file with a flattened schema per signal.
+	// - arrow:  Arrow IPC stream with a flattened schema per signal.
 	FormatType string `mapstructure:"format"`
 
 	// Encoding defines the encoding of the telemetry data.
@@ -105,9 +107,12 @@ func (cfg *Config) Validate() error {
 	if cfg.Append && cfg.Rotation != nil {
 		return errors.New("append and rotation enabled at the same time is not supported")
 	}
-	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto && !isColumnarFormat(cfg.FormatType) {
 		return errors.New("format type is not supported")
 	}
+	if cfg.Append && cfg.isColumnar() {
+		return errors.New("append is not supported with the parquet and arrow formats")
+	}
 	if cfg.Compression != "" && cfg.Compression != compressionZSTD {
 		return errors.New("compression is not supported")
 	}
@@ -186,6 +186,27 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "group_by_empty_resource_attribute"),
 			errorMessage: "resource_attribute must not be empty when group_by is enabled",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "parquet"),
+			expected: &Config{
+				Path: "./filename.parquet",
+				Rotation: &Rotation{
+					MaxMegabytes: 10,
+					MaxBackups:   defaultMaxBackups,
+				},
+				FormatType:    formatTypeParquet,
+				Compression:   compressionZSTD,
+				FlushInterval: time.Second,
+				GroupBy: &GroupBy{
+					MaxOpenFiles:      defaultMaxOpenFiles,
+					ResourceAttribute: defaultResourceAttribute,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "arrow_append_error"),
+			errorMessage: "append is not supported with the parquet and arrow formats",
+		},
 	}
 
 	for _, tt := range tests {
@@ -31,8 +31,10 @@ const (
 	defaultMaxBackups = 100
 
 	// the format of encoded telemetry data
-	formatTypeJSON  = "json"
-	formatTypeProto = "proto"
+	formatTypeJSON    = "json"
+	formatTypeProto   = "proto"
+	formatTypeParquet = "parquet"
+	formatTypeArrow   = "arrow"
 
 	// the type of compression codec
 	compressionZSTD = "zstd"
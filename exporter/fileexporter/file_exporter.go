@@ -61,10 +61,11 @@ func (e *fileExporter) Start(_ context.Context, host component.Host) error {
 	}
 	export := buildExportFunc(e.conf)
 
-	e.writer, err = newFileWriter(e.conf.Path, e.conf.Append, e.conf.Rotation, e.conf.FlushInterval, export)
+	e.writer, err = newFileWriter(e.conf.Path, e.conf.Append, fileRotation(e.conf), e.conf.FlushInterval, export)
 	if err != nil {
 		return err
 	}
+	e.writer.completeOnFlush = completesRecordsOnFlush(e.conf)
 	e.writer.start()
 	return nil
 }
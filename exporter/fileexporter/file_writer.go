@@ -5,6 +5,7 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"encoding/binary"
+	"errors"
 	"io"
 	"sync"
 	"time"
@@ -19,6 +20,10 @@ type fileWriter struct {
 	mutex sync.Mutex
 
 	exporter exportFunc
+	// records is the record stream of the file, when written with a columnar format.
+	records *recordStream
+	// completeOnFlush completes the record stream of the file at each flush interval.
+	completeOnFlush bool
 
 	flushInterval time.Duration
 	flushTicker   *time.Ticker
@@ -60,7 +65,7 @@ func (w *fileWriter) startFlusher() {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 	ff, ok := w.file.(interface{ flush() error })
-	if !ok {
+	if !ok && !w.completeOnFlush {
 		// Just in case.
 		return
 	}
@@ -74,7 +79,12 @@ func (w *fileWriter) startFlusher() {
 			select {
 			case <-w.flushTicker.C:
 				w.mutex.Lock()
-				ff.flush()
+				if w.completeOnFlush {
+					_ = w.completeRecords()
+				}
+				if ok {
+					ff.flush()
+				}
 				w.mutex.Unlock()
 			case <-w.stopTicker:
 				w.flushTicker.Stop()
@@ -102,10 +112,16 @@ func (w *fileWriter) shutdown() error {
 		close(w.stopTicker)
 		w.mutex.Unlock()
 	}
-	return w.file.Close()
+	w.mutex.Lock()
+	err := w.closeRecords()
+	w.mutex.Unlock()
+	return errors.Join(err, w.file.Close())
 }
 
 func buildExportFunc(cfg *Config) func(w *fileWriter, buf []byte) error {
+	if cfg.isColumnar() {
+		return newRecordsExporter(cfg).export
+	}
 	if cfg.FormatType == formatTypeProto {
 		return exportMessageAsBuffer
 	}
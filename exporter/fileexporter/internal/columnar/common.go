@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package columnar converts telemetry data to Arrow records with a flattened schema per signal,
+// which can be written to Parquet files or Arrow IPC streams.
+package columnar // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/columnar"
+
+import (
+	"encoding/hex"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var (
+	// attributesType holds attributes as a map of strings. Values that are not strings are
+	// converted with pcommon.Value.AsString, which encodes maps and slices as JSON.
+	attributesType = arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)
+	timestampType  = arrow.FixedWidthTypes.Timestamp_ns
+)
+
+// commonFields are the leading fields of the schema of every signal.
+var commonFields = []arrow.Field{
+	{Name: "resource_attributes", Type: attributesType},
+	{Name: "scope_name", Type: arrow.BinaryTypes.String},
+	{Name: "scope_version", Type: arrow.BinaryTypes.String},
+	{Name: "scope_attributes", Type: attributesType},
+}
+
+func newSchema(fields ...arrow.Field) *arrow.Schema {
+	return arrow.NewSchema(append(append([]arrow.Field{}, commonFields...), fields...), nil)
+}
+
+// commonBuilders appends the resource and scope of a row.
+type commonBuilders struct {
+	resourceAttributes *array.MapBuilder
+	scopeName          *array.StringBuilder
+	scopeVersion       *array.StringBuilder
+	scopeAttributes    *array.MapBuilder
+}
+
+func newCommonBuilders(rb *array.RecordBuilder) commonBuilders {
+	return commonBuilders{
+		resourceAttributes: rb.Field(0).(*array.MapBuilder),
+		scopeName:          rb.Field(1).(*array.StringBuilder),
+		scopeVersion:       rb.Field(2).(*array.StringBuilder),
+		scopeAttributes:    rb.Field(3).(*array.MapBuilder),
+	}
+}
+
+func (b commonBuilders) append(resource pcommon.Resource, scope pcommon.InstrumentationScope) {
+	appendAttributes(b.resourceAttributes, resource.Attributes())
+	b.scopeName.Append(scope.Name())
+	b.scopeVersion.Append(scope.Version())
+	appendAttributes(b.scopeAttributes, scope.Attributes())
+}
+
+func appendAttributes(b *array.MapBuilder, attrs pcommon.Map) {
+	b.Append(true)
+	keys := b.KeyBuilder().(*array.StringBuilder)
+	values := b.ItemBuilder().(*array.StringBuilder)
+	for k, v := range attrs.All() {
+		keys.Append(k)
+		values.Append(v.AsString())
+	}
+}
+
+// appendTimestamp appends a timestamp, or null when it is not set.
+func appendTimestamp(b *array.TimestampBuilder, ts pcommon.Timestamp) {
+	if ts == 0 {
+		b.AppendNull()
+		return
+	}
+	b.Append(arrow.Timestamp(ts))
+}
+
+// appendTraceID appends a hex encoded trace ID, or null when it is empty.
+func appendTraceID(b *array.StringBuilder, id pcommon.TraceID) {
+	if id.IsEmpty() {
+		b.AppendNull()
+		return
+	}
+	b.Append(hex.EncodeToString(id[:]))
+}
+
+// appendSpanID appends a hex encoded span ID, or null when it is empty.
+func appendSpanID(b *array.StringBuilder, id pcommon.SpanID) {
+	if id.IsEmpty() {
+		b.AppendNull()
+		return
+	}
+	b.Append(hex.EncodeToString(id[:]))
+}
+
+// appendString appends s, or null when it is empty.
+func appendString(b *array.StringBuilder, s string) {
+	if s == "" {
+		b.AppendNull()
+		return
+	}
+	b.Append(s)
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCheckedAllocator(t *testing.T) memory.Allocator {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	t.Cleanup(func() { mem.AssertSize(t, 0) })
+	return mem
+}
+
+func column(t *testing.T, rec arrow.Record, name string) arrow.Array {
+	indices := rec.Schema().FieldIndices(name)
+	require.Len(t, indices, 1, "column %q", name)
+	return rec.Column(indices[0])
+}
+
+func stringValue(t *testing.T, rec arrow.Record, name string, row int) any {
+	col := column(t, rec, name).(*array.String)
+	if col.IsNull(row) {
+		return nil
+	}
+	return col.Value(row)
+}
+
+// attributes returns the attributes of a row as a map.
+func attributes(t *testing.T, rec arrow.Record, name string, row int) map[string]string {
+	col := column(t, rec, name).(*array.Map)
+	keys := col.Keys().(*array.String)
+	items := col.Items().(*array.String)
+	start, end := col.ValueOffsets(row)
+	attrs := map[string]string{}
+	for i := start; i < end; i++ {
+		attrs[keys.Value(int(i))] = items.Value(int(i))
+	}
+	return attrs
+}
+
+func TestSchemasStartWithCommonFields(t *testing.T) {
+	for _, schema := range []*arrow.Schema{TracesSchema, LogsSchema, MetricsSchema} {
+		for i, field := range commonFields {
+			assert.Equal(t, field.Name, schema.Field(i).Name)
+		}
+	}
+}
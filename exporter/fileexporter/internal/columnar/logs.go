@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/columnar"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// LogsSchema is the schema of the records holding logs, with one row per log record.
+// The body is converted to a string with pcommon.Value.AsString.
+var LogsSchema = newSchema(
+	arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "observed_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "severity_number", Type: arrow.PrimitiveTypes.Int32},
+	arrow.Field{Name: "severity_text", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "event_name", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "body", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+)
+
+// NewLogsRecord returns a record holding the log records of ld. It must be released by the caller.
+func NewLogsRecord(mem memory.Allocator, ld plog.Logs) arrow.Record {
+	rb := array.NewRecordBuilder(mem, LogsSchema)
+	defer rb.Release()
+	rb.Reserve(ld.LogRecordCount())
+
+	common := newCommonBuilders(rb)
+	timestamp := rb.Field(4).(*array.TimestampBuilder)
+	observedTimestamp := rb.Field(5).(*array.TimestampBuilder)
+	severityNumber := rb.Field(6).(*array.Int32Builder)
+	severityText := rb.Field(7).(*array.StringBuilder)
+	eventName := rb.Field(8).(*array.StringBuilder)
+	body := rb.Field(9).(*array.StringBuilder)
+	attributes := rb.Field(10).(*array.MapBuilder)
+	traceID := rb.Field(11).(*array.StringBuilder)
+	spanID := rb.Field(12).(*array.StringBuilder)
+	flags := rb.Field(13).(*array.Uint32Builder)
+
+	for _, rl := range ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				common.append(rl.Resource(), sl.Scope())
+				appendTimestamp(timestamp, lr.Timestamp())
+				appendTimestamp(observedTimestamp, lr.ObservedTimestamp())
+				severityNumber.Append(int32(lr.SeverityNumber()))
+				appendString(severityText, lr.SeverityText())
+				appendString(eventName, lr.EventName())
+				appendString(body, lr.Body().AsString())
+				appendAttributes(attributes, lr.Attributes())
+				appendTraceID(traceID, lr.TraceID())
+				appendSpanID(spanID, lr.SpanID())
+				flags.Append(uint32(lr.Flags()))
+			}
+		}
+	}
+	return rb.NewRecord()
+}
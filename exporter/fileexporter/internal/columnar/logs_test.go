@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestNewLogsRecord(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "node-1")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("logger")
+
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(1000)
+	lr.SetObservedTimestamp(2000)
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("WARN")
+	lr.SetEventName("disk.full")
+	lr.Body().SetStr("disk is full")
+	lr.Attributes().PutBool("retry", true)
+	lr.SetTraceID(pcommon.TraceID{1})
+	lr.SetSpanID(pcommon.SpanID{2})
+	lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+
+	structured := sl.LogRecords().AppendEmpty()
+	structured.Body().SetEmptyMap().PutStr("msg", "hello")
+
+	rec := NewLogsRecord(newCheckedAllocator(t), ld)
+	defer rec.Release()
+
+	require.Equal(t, int64(2), rec.NumRows())
+	assert.True(t, LogsSchema.Equal(rec.Schema()))
+
+	assert.Equal(t, map[string]string{"host.name": "node-1"}, attributes(t, rec, "resource_attributes", 0))
+	assert.Equal(t, "logger", stringValue(t, rec, "scope_name", 0))
+	assert.Equal(t, arrow.Timestamp(1000), column(t, rec, "time").(*array.Timestamp).Value(0))
+	assert.Equal(t, arrow.Timestamp(2000), column(t, rec, "observed_time").(*array.Timestamp).Value(0))
+	assert.Equal(t, int32(plog.SeverityNumberWarn), column(t, rec, "severity_number").(*array.Int32).Value(0))
+	assert.Equal(t, "WARN", stringValue(t, rec, "severity_text", 0))
+	assert.Equal(t, "disk.full", stringValue(t, rec, "event_name", 0))
+	assert.Equal(t, "disk is full", stringValue(t, rec, "body", 0))
+	assert.Equal(t, map[string]string{"retry": "true"}, attributes(t, rec, "attributes", 0))
+	assert.Equal(t, "01000000000000000000000000000000", stringValue(t, rec, "trace_id", 0))
+	assert.Equal(t, "0200000000000000", stringValue(t, rec, "span_id", 0))
+	assert.Equal(t, uint32(1), column(t, rec, "flags").(*array.Uint32).Value(0))
+
+	assert.True(t, column(t, rec, "time").IsNull(1))
+	assert.Nil(t, stringValue(t, rec, "severity_text", 1))
+	assert.JSONEq(t, `{"msg":"hello"}`, stringValue(t, rec, "body", 1).(string))
+	assert.Nil(t, stringValue(t, rec, "trace_id", 1))
+}
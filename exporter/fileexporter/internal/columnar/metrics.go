@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/columnar"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var quantileValueType = arrow.StructOf(
+	arrow.Field{Name: "quantile", Type: arrow.PrimitiveTypes.Float64},
+	arrow.Field{Name: "value", Type: arrow.PrimitiveTypes.Float64},
+)
+
+// MetricsSchema is the schema of the records holding metrics, with one row per data point.
+// The columns that do not apply to the type of the metric are null.
+var MetricsSchema = newSchema(
+	arrow.Field{Name: "metric_name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "metric_description", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "metric_unit", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "metric_type", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "aggregation_temporality", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "is_monotonic", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	arrow.Field{Name: "start_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "int_value", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	arrow.Field{Name: "double_value", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	arrow.Field{Name: "sum", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "min", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "max", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "explicit_bounds", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64), Nullable: true},
+	arrow.Field{Name: "scale", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "zero_count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	arrow.Field{Name: "positive_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "positive_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "negative_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "negative_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "quantile_values", Type: arrow.ListOf(quantileValueType), Nullable: true},
+)
+
+type metricsBuilder struct {
+	common                 commonBuilders
+	name                   *array.StringBuilder
+	description            *array.StringBuilder
+	unit                   *array.StringBuilder
+	metricType             *array.StringBuilder
+	aggregationTemporality *array.StringBuilder
+	isMonotonic            *array.BooleanBuilder
+	startTime              *array.TimestampBuilder
+	time                   *array.TimestampBuilder
+	attributes             *array.MapBuilder
+	flags                  *array.Uint32Builder
+	intValue               *array.Int64Builder
+	doubleValue            *array.Float64Builder
+	count                  *array.Uint64Builder
+	sum                    *array.Float64Builder
+	min                    *array.Float64Builder
+	max                    *array.Float64Builder
+	bucketCounts           *array.ListBuilder
+	explicitBounds         *array.ListBuilder
+	scale                  *array.Int32Builder
+	zeroCount              *array.Uint64Builder
+	positiveOffset         *array.Int32Builder
+	positiveBucketCounts   *array.ListBuilder
+	negativeOffset         *array.Int32Builder
+	negativeBucketCounts   *array.ListBuilder
+	quantileValues         *array.ListBuilder
+}
+
+// NewMetricsRecord returns a record holding the data points of md. It must be released by the caller.
+func NewMetricsRecord(mem memory.Allocator, md pmetric.Metrics) arrow.Record {
+	rb := array.NewRecordBuilder(mem, MetricsSchema)
+	defer rb.Release()
+	rb.Reserve(md.DataPointCount())
+
+	b := metricsBuilder{
+		common:                 newCommonBuilders(rb),
+		name:                   rb.Field(4).(*array.StringBuilder),
+		description:            rb.Field(5).(*array.StringBuilder),
+		unit:                   rb.Field(6).(*array.StringBuilder),
+		metricType:             rb.Field(7).(*array.StringBuilder),
+		aggregationTemporality: rb.Field(8).(*array.StringBuilder),
+		isMonotonic:            rb.Field(9).(*array.BooleanBuilder),
+		startTime:              rb.Field(10).(*array.TimestampBuilder),
+		time:                   rb.Field(11).(*array.TimestampBuilder),
+		attributes:             rb.Field(12).(*array.MapBuilder),
+		flags:                  rb.Field(13).(*array.Uint32Builder),
+		intValue:               rb.Field(14).(*array.Int64Builder),
+		doubleValue:            rb.Field(15).(*array.Float64Builder),
+		count:                  rb.Field(16).(*array.Uint64Builder),
+		sum:                    rb.Field(17).(*array.Float64Builder),
+		min:                    rb.Field(18).(*array.Float64Builder),
+		max:                    rb.Field(19).(*array.Float64Builder),
+		bucketCounts:           rb.Field(20).(*array.ListBuilder),
+		explicitBounds:         rb.Field(21).(*array.ListBuilder),
+		scale:                  rb.Field(22).(*array.Int32Builder),
+		zeroCount:              rb.Field(23).(*array.Uint64Builder),
+		positiveOffset:         rb.Field(24).(*array.Int32Builder),
+		positiveBucketCounts:   rb.Field(25).(*array.ListBuilder),
+		negativeOffset:         rb.Field(26).(*array.Int32Builder),
+		negativeBucketCounts:   rb.Field(27).(*array.ListBuilder),
+		quantileValues:         rb.Field(28).(*array.ListBuilder),
+	}
+
+	for _, rm := range md.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				b.appendMetric(rm.Resource(), sm.Scope(), m)
+			}
+		}
+	}
+	return rb.NewRecord()
+}
+
+func (b *metricsBuilder) appendMetric(resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for _, dp := range m.Gauge().DataPoints().All() {
+			b.appendRow(resource, scope, m, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), uint32(dp.Flags()))
+			b.aggregationTemporality.AppendNull()
+			b.isMonotonic.AppendNull()
+			b.appendNumberValue(dp)
+			b.appendNullHistogram()
+			b.appendNullExponentialHistogram()
+			b.quantileValues.AppendNull()
+		}
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		for _, dp := range sum.DataPoints().All() {
+			b.appendRow(resource, scope, m, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), uint32(dp.Flags()))
+			b.aggregationTemporality.Append(sum.AggregationTemporality().String())
+			b.isMonotonic.Append(sum.IsMonotonic())
+			b.appendNumberValue(dp)
+			b.appendNullHistogram()
+			b.appendNullExponentialHistogram()
+			b.quantileValues.AppendNull()
+		}
+	case pmetric.MetricTypeHistogram:
+		histogram := m.Histogram()
+		for _, dp := range histogram.DataPoints().All() {
+			b.appendRow(resource, scope, m, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), uint32(dp.Flags()))
+			b.aggregationTemporality.Append(histogram.AggregationTemporality().String())
+			b.isMonotonic.AppendNull()
+			b.intValue.AppendNull()
+			b.doubleValue.AppendNull()
+			b.count.Append(dp.Count())
+			appendOptionalDouble(b.sum, dp.HasSum(), dp.Sum())
+			appendOptionalDouble(b.min, dp.HasMin(), dp.Min())
+			appendOptionalDouble(b.max, dp.HasMax(), dp.Max())
+			appendUint64s(b.bucketCounts, dp.BucketCounts())
+			b.explicitBounds.Append(true)
+			b.explicitBounds.ValueBuilder().(*array.Float64Builder).AppendValues(dp.ExplicitBounds().AsRaw(), nil)
+			b.appendNullExponentialHistogram()
+			b.quantileValues.AppendNull()
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := m.ExponentialHistogram()
+		for _, dp := range histogram.DataPoints().All() {
+			b.appendRow(resource, scope, m, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), uint32(dp.Flags()))
+			b.aggregationTemporality.Append(histogram.AggregationTemporality().String())
+			b.isMonotonic.AppendNull()
+			b.intValue.AppendNull()
+			b.doubleValue.AppendNull()
+			b.count.Append(dp.Count())
+			appendOptionalDouble(b.sum, dp.HasSum(), dp.Sum())
+			appendOptionalDouble(b.min, dp.HasMin(), dp.Min())
+			appendOptionalDouble(b.max, dp.HasMax(), dp.Max())
+			b.bucketCounts.AppendNull()
+			b.explicitBounds.AppendNull()
+			b.scale.Append(dp.Scale())
+			b.zeroCount.Append(dp.ZeroCount())
+			b.positiveOffset.Append(dp.Positive().Offset())
+			appendUint64s(b.positiveBucketCounts, dp.Positive().BucketCounts())
+			b.negativeOffset.Append(dp.Negative().Offset())
+			appendUint64s(b.negativeBucketCounts, dp.Negative().BucketCounts())
+			b.quantileValues.AppendNull()
+		}
+	case pmetric.MetricTypeSummary:
+		for _, dp := range m.Summary().DataPoints().All() {
+			b.appendRow(resource, scope, m, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), uint32(dp.Flags()))
+			b.aggregationTemporality.AppendNull()
+			b.isMonotonic.AppendNull()
+			b.intValue.AppendNull()
+			b.doubleValue.AppendNull()
+			b.count.Append(dp.Count())
+			b.sum.Append(dp.Sum())
+			b.min.AppendNull()
+			b.max.AppendNull()
+			b.bucketCounts.AppendNull()
+			b.explicitBounds.AppendNull()
+			b.appendNullExponentialHistogram()
+			b.quantileValues.Append(true)
+			quantile := b.quantileValues.ValueBuilder().(*array.StructBuilder)
+			for _, qv := range dp.QuantileValues().All() {
+				quantile.Append(true)
+				quantile.FieldBuilder(0).(*array.Float64Builder).Append(qv.Quantile())
+				quantile.FieldBuilder(1).(*array.Float64Builder).Append(qv.Value())
+			}
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+// appendRow appends the columns shared by all the metric types.
+func (b *metricsBuilder) appendRow(resource pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric, start, ts pcommon.Timestamp, attrs pcommon.Map, flags uint32) {
+	b.common.append(resource, scope)
+	b.name.Append(m.Name())
+	appendString(b.description, m.Description())
+	appendString(b.unit, m.Unit())
+	b.metricType.Append(m.Type().String())
+	appendTimestamp(b.startTime, start)
+	appendTimestamp(b.time, ts)
+	appendAttributes(b.attributes, attrs)
+	b.flags.Append(flags)
+}
+
+func (b *metricsBuilder) appendNumberValue(dp pmetric.NumberDataPoint) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		b.intValue.Append(dp.IntValue())
+		b.doubleValue.AppendNull()
+	case pmetric.NumberDataPointValueTypeDouble:
+		b.intValue.AppendNull()
+		b.doubleValue.Append(dp.DoubleValue())
+	default:
+		b.intValue.AppendNull()
+		b.doubleValue.AppendNull()
+	}
+}
+
+func (b *metricsBuilder) appendNullHistogram() {
+	b.count.AppendNull()
+	b.sum.AppendNull()
+	b.min.AppendNull()
+	b.max.AppendNull()
+	b.bucketCounts.AppendNull()
+	b.explicitBounds.AppendNull()
+}
+
+func (b *metricsBuilder) appendNullExponentialHistogram() {
+	b.scale.AppendNull()
+	b.zeroCount.AppendNull()
+	b.positiveOffset.AppendNull()
+	b.positiveBucketCounts.AppendNull()
+	b.negativeOffset.AppendNull()
+	b.negativeBucketCounts.AppendNull()
+}
+
+func appendOptionalDouble(b *array.Float64Builder, ok bool, v float64) {
+	if !ok {
+		b.AppendNull()
+		return
+	}
+	b.Append(v)
+}
+
+func appendUint64s(b *array.ListBuilder, values pcommon.UInt64Slice) {
+	b.Append(true)
+	b.ValueBuilder().(*array.Uint64Builder).AppendValues(values.AsRaw(), nil)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsRecord(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("meter")
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("queue.size")
+	gauge.SetUnit("{item}")
+	gdp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	gdp.SetIntValue(7)
+	gdp.SetTimestamp(1000)
+	gdp.Attributes().PutStr("queue", "orders")
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().DataPoints().AppendEmpty().SetDoubleValue(1.5)
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(12)
+	hdp.SetMin(1)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2})
+	hdp.ExplicitBounds().FromRaw([]float64{5})
+
+	exponential := sm.Metrics().AppendEmpty()
+	exponential.SetName("size")
+	edp := exponential.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{4, 5})
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("duration")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetCount(2)
+	sdp.SetSum(3)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(2.5)
+
+	rec := NewMetricsRecord(newCheckedAllocator(t), md)
+	defer rec.Release()
+
+	require.Equal(t, int64(5), rec.NumRows())
+	assert.True(t, MetricsSchema.Equal(rec.Schema()))
+
+	for row, metricType := range []string{"Gauge", "Sum", "Histogram", "ExponentialHistogram", "Summary"} {
+		assert.Equal(t, metricType, stringValue(t, rec, "metric_type", row))
+		assert.Equal(t, map[string]string{"service.name": "checkout"}, attributes(t, rec, "resource_attributes", row))
+	}
+
+	// gauge
+	assert.Equal(t, "queue.size", stringValue(t, rec, "metric_name", 0))
+	assert.Equal(t, "{item}", stringValue(t, rec, "metric_unit", 0))
+	assert.Nil(t, stringValue(t, rec, "aggregation_temporality", 0))
+	assert.Equal(t, int64(7), column(t, rec, "int_value").(*array.Int64).Value(0))
+	assert.True(t, column(t, rec, "double_value").IsNull(0))
+	assert.True(t, column(t, rec, "count").IsNull(0))
+	assert.Equal(t, map[string]string{"queue": "orders"}, attributes(t, rec, "attributes", 0))
+
+	// sum
+	assert.Equal(t, "Cumulative", stringValue(t, rec, "aggregation_temporality", 1))
+	assert.True(t, column(t, rec, "is_monotonic").(*array.Boolean).Value(1))
+	assert.True(t, column(t, rec, "int_value").IsNull(1))
+	assert.Equal(t, 1.5, column(t, rec, "double_value").(*array.Float64).Value(1))
+
+	// histogram
+	assert.Equal(t, "Delta", stringValue(t, rec, "aggregation_temporality", 2))
+	assert.Equal(t, uint64(3), column(t, rec, "count").(*array.Uint64).Value(2))
+	assert.Equal(t, 12.0, column(t, rec, "sum").(*array.Float64).Value(2))
+	assert.Equal(t, 1.0, column(t, rec, "min").(*array.Float64).Value(2))
+	assert.True(t, column(t, rec, "max").IsNull(2))
+	bucketCounts := column(t, rec, "bucket_counts").(*array.List)
+	start, end := bucketCounts.ValueOffsets(2)
+	assert.Equal(t, []uint64{1, 2}, bucketCounts.ListValues().(*array.Uint64).Uint64Values()[start:end])
+	assert.True(t, column(t, rec, "scale").IsNull(2))
+
+	// exponential histogram
+	assert.Equal(t, int32(2), column(t, rec, "scale").(*array.Int32).Value(3))
+	assert.Equal(t, uint64(1), column(t, rec, "zero_count").(*array.Uint64).Value(3))
+	assert.Equal(t, int32(-1), column(t, rec, "positive_offset").(*array.Int32).Value(3))
+	positive := column(t, rec, "positive_bucket_counts").(*array.List)
+	start, end = positive.ValueOffsets(3)
+	assert.Equal(t, []uint64{4, 5}, positive.ListValues().(*array.Uint64).Uint64Values()[start:end])
+	assert.True(t, column(t, rec, "bucket_counts").IsNull(3))
+
+	// summary
+	assert.Equal(t, uint64(2), column(t, rec, "count").(*array.Uint64).Value(4))
+	assert.Equal(t, 3.0, column(t, rec, "sum").(*array.Float64).Value(4))
+	quantiles := column(t, rec, "quantile_values").(*array.List)
+	start, end = quantiles.ValueOffsets(4)
+	require.Equal(t, int64(1), end-start)
+	quantileValues := quantiles.ListValues().(*array.Struct)
+	assert.Equal(t, 0.99, quantileValues.Field(0).(*array.Float64).Value(int(start)))
+	assert.Equal(t, 2.5, quantileValues.Field(1).(*array.Float64).Value(int(start)))
+	assert.True(t, quantiles.IsNull(0))
+}
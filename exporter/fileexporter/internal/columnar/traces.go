@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/columnar"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	spanEventType = arrow.StructOf(
+		arrow.Field{Name: "time", Type: timestampType, Nullable: true},
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "attributes", Type: attributesType},
+	)
+	spanLinkType = arrow.StructOf(
+		arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "attributes", Type: attributesType},
+	)
+)
+
+// TracesSchema is the schema of the records holding spans, with one row per span.
+var TracesSchema = newSchema(
+	arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "parent_span_id", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "kind", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "start_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "end_time", Type: timestampType, Nullable: true},
+	arrow.Field{Name: "status_code", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "status_message", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "events", Type: arrow.ListOf(spanEventType)},
+	arrow.Field{Name: "links", Type: arrow.ListOf(spanLinkType)},
+)
+
+// NewTracesRecord returns a record holding the spans of td. It must be released by the caller.
+func NewTracesRecord(mem memory.Allocator, td ptrace.Traces) arrow.Record {
+	rb := array.NewRecordBuilder(mem, TracesSchema)
+	defer rb.Release()
+	rb.Reserve(td.SpanCount())
+
+	common := newCommonBuilders(rb)
+	traceID := rb.Field(4).(*array.StringBuilder)
+	spanID := rb.Field(5).(*array.StringBuilder)
+	parentSpanID := rb.Field(6).(*array.StringBuilder)
+	traceState := rb.Field(7).(*array.StringBuilder)
+	name := rb.Field(8).(*array.StringBuilder)
+	kind := rb.Field(9).(*array.StringBuilder)
+	startTime := rb.Field(10).(*array.TimestampBuilder)
+	endTime := rb.Field(11).(*array.TimestampBuilder)
+	statusCode := rb.Field(12).(*array.StringBuilder)
+	statusMessage := rb.Field(13).(*array.StringBuilder)
+	attributes := rb.Field(14).(*array.MapBuilder)
+	events := rb.Field(15).(*array.ListBuilder)
+	event := events.ValueBuilder().(*array.StructBuilder)
+	links := rb.Field(16).(*array.ListBuilder)
+	link := links.ValueBuilder().(*array.StructBuilder)
+
+	for _, rs := range td.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				common.append(rs.Resource(), ss.Scope())
+				appendTraceID(traceID, span.TraceID())
+				appendSpanID(spanID, span.SpanID())
+				appendSpanID(parentSpanID, span.ParentSpanID())
+				appendString(traceState, span.TraceState().AsRaw())
+				name.Append(span.Name())
+				kind.Append(span.Kind().String())
+				appendTimestamp(startTime, span.StartTimestamp())
+				appendTimestamp(endTime, span.EndTimestamp())
+				statusCode.Append(span.Status().Code().String())
+				appendString(statusMessage, span.Status().Message())
+				appendAttributes(attributes, span.Attributes())
+
+				events.Append(true)
+				for _, e := range span.Events().All() {
+					event.Append(true)
+					appendTimestamp(event.FieldBuilder(0).(*array.TimestampBuilder), e.Timestamp())
+					event.FieldBuilder(1).(*array.StringBuilder).Append(e.Name())
+					appendAttributes(event.FieldBuilder(2).(*array.MapBuilder), e.Attributes())
+				}
+
+				links.Append(true)
+				for _, l := range span.Links().All() {
+					link.Append(true)
+					appendTraceID(link.FieldBuilder(0).(*array.StringBuilder), l.TraceID())
+					appendSpanID(link.FieldBuilder(1).(*array.StringBuilder), l.SpanID())
+					appendString(link.FieldBuilder(2).(*array.StringBuilder), l.TraceState().AsRaw())
+					appendAttributes(link.FieldBuilder(3).(*array.MapBuilder), l.Attributes())
+				}
+			}
+		}
+	}
+	return rb.NewRecord()
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package columnar
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNewTracesRecord(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("tracer")
+	ss.Scope().SetVersion("1.0.0")
+
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(1000)
+	span.SetEndTimestamp(2000)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("timeout")
+	span.Attributes().PutInt("http.status_code", 504)
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(1500)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID{16})
+	link.SetSpanID(pcommon.SpanID{8})
+
+	child := ss.Spans().AppendEmpty()
+	child.SetName("SELECT")
+	child.SetParentSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+
+	rec := NewTracesRecord(newCheckedAllocator(t), td)
+	defer rec.Release()
+
+	require.Equal(t, int64(2), rec.NumRows())
+	assert.True(t, TracesSchema.Equal(rec.Schema()))
+
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, attributes(t, rec, "resource_attributes", 0))
+	assert.Equal(t, "tracer", stringValue(t, rec, "scope_name", 0))
+	assert.Equal(t, "1.0.0", stringValue(t, rec, "scope_version", 0))
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", stringValue(t, rec, "trace_id", 0))
+	assert.Equal(t, "0102030405060708", stringValue(t, rec, "span_id", 0))
+	assert.Nil(t, stringValue(t, rec, "parent_span_id", 0))
+	assert.Equal(t, "GET /cart", stringValue(t, rec, "name", 0))
+	assert.Equal(t, "Server", stringValue(t, rec, "kind", 0))
+	assert.Equal(t, "Error", stringValue(t, rec, "status_code", 0))
+	assert.Equal(t, "timeout", stringValue(t, rec, "status_message", 0))
+	assert.Equal(t, map[string]string{"http.status_code": "504"}, attributes(t, rec, "attributes", 0))
+
+	startTime := column(t, rec, "start_time").(*array.Timestamp)
+	assert.Equal(t, arrow.Timestamp(1000), startTime.Value(0))
+	assert.True(t, startTime.IsNull(1))
+
+	events := column(t, rec, "events").(*array.List)
+	start, end := events.ValueOffsets(0)
+	assert.Equal(t, int64(1), end-start)
+	eventNames := events.ListValues().(*array.Struct).Field(1).(*array.String)
+	assert.Equal(t, "exception", eventNames.Value(int(start)))
+	start, end = events.ValueOffsets(1)
+	assert.Equal(t, int64(0), end-start)
+
+	links := column(t, rec, "links").(*array.List)
+	start, end = links.ValueOffsets(0)
+	assert.Equal(t, int64(1), end-start)
+	linkTraceIDs := links.ListValues().(*array.Struct).Field(0).(*array.String)
+	assert.Equal(t, "10000000000000000000000000000000", linkTraceIDs.Value(int(start)))
+
+	assert.Nil(t, stringValue(t, rec, "trace_id", 1))
+	assert.Equal(t, "0102030405060708", stringValue(t, rec, "parent_span_id", 1))
+	assert.Equal(t, "Unset", stringValue(t, rec, "status_code", 1))
+}
@@ -16,18 +16,24 @@ import (
 
 // Marshaler configuration used for marshaling Protobuf
 var tracesMarshalers = map[string]ptrace.Marshaler{
-	formatTypeJSON:  &ptrace.JSONMarshaler{},
-	formatTypeProto: &ptrace.ProtoMarshaler{},
+	formatTypeJSON:    &ptrace.JSONMarshaler{},
+	formatTypeProto:   &ptrace.ProtoMarshaler{},
+	formatTypeParquet: recordMarshaler{},
+	formatTypeArrow:   recordMarshaler{},
 }
 
 var metricsMarshalers = map[string]pmetric.Marshaler{
-	formatTypeJSON:  &pmetric.JSONMarshaler{},
-	formatTypeProto: &pmetric.ProtoMarshaler{},
+	formatTypeJSON:    &pmetric.JSONMarshaler{},
+	formatTypeProto:   &pmetric.ProtoMarshaler{},
+	formatTypeParquet: recordMarshaler{},
+	formatTypeArrow:   recordMarshaler{},
 }
 
 var logsMarshalers = map[string]plog.Marshaler{
-	formatTypeJSON:  &plog.JSONMarshaler{},
-	formatTypeProto: &plog.ProtoMarshaler{},
+	formatTypeJSON:    &plog.JSONMarshaler{},
+	formatTypeProto:   &plog.ProtoMarshaler{},
+	formatTypeParquet: recordMarshaler{},
+	formatTypeArrow:   recordMarshaler{},
 }
 
 var profilesMarshalers = map[string]pprofile.Marshaler{
@@ -67,6 +73,11 @@ func newMarshaller(conf *Config, host component.Host) (*marshaller, error) {
 			compressor:        buildCompressor(conf.Compression),
 		}, nil
 	}
+	compressor := buildCompressor(conf.Compression)
+	if conf.isColumnar() {
+		// the columnar formats compress the data themselves.
+		compressor = noneCompress
+	}
 	return &marshaller{
 		formatType:        conf.FormatType,
 		tracesMarshaler:   tracesMarshalers[conf.FormatType],
@@ -74,7 +85,7 @@ func newMarshaller(conf *Config, host component.Host) (*marshaller, error) {
 		logsMarshaler:     logsMarshalers[conf.FormatType],
 		profilesMarshaler: profilesMarshalers[conf.FormatType],
 		compression:       conf.Compression,
-		compressor:        buildCompressor(conf.Compression),
+		compressor:        compressor,
 	}, nil
 }
 
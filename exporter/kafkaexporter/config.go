@@ -4,10 +4,14 @@
 package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/kafka/configkafka"
 )
@@ -68,6 +72,54 @@ type Config struct {
 	// If this is true, then the message key will be set to a hash of the resource's identifying
 	// attributes.
 	PartitionLogsByResourceAttributes bool `mapstructure:"partition_logs_by_resource_attributes"`
+
+	// Transaction holds configuration about the transactional producer.
+	Transaction TransactionConfig `mapstructure:"transaction"`
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	if c.PartitionTracesByID && c.Traces.PartitionKey != "" {
+		errs = append(errs, errors.New("partition_traces_by_id and traces::partition_key cannot be used together"))
+	}
+	if c.PartitionMetricsByResourceAttributes && c.Metrics.PartitionKey != "" {
+		errs = append(errs, errors.New("partition_metrics_by_resource_attributes and metrics::partition_key cannot be used together"))
+	}
+	if c.PartitionLogsByResourceAttributes && c.Logs.PartitionKey != "" {
+		errs = append(errs, errors.New("partition_logs_by_resource_attributes and logs::partition_key cannot be used together"))
+	}
+	for _, signal := range []struct {
+		name string
+		cfg  SignalConfig
+	}{{"logs", c.Logs}, {"metrics", c.Metrics}, {"traces", c.Traces}} {
+		if signal.cfg.PartitionKey == "" {
+			continue
+		}
+		if _, err := newPartitionKeyExpression(signal.cfg.PartitionKey, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s::partition_key: %w", signal.name, err))
+		}
+	}
+
+	if c.Transaction.Enabled {
+		if c.Transaction.ID == "" {
+			errs = append(errs, errors.New("transaction::id must be specified when transactions are enabled"))
+		}
+		if c.Producer.RequiredAcks != configkafka.WaitForAll {
+			errs = append(errs, errors.New("transactions require producer::required_acks to be 'all' (-1)"))
+		}
+		if !c.QueueSettings.Enabled || c.QueueSettings.StorageID == nil {
+			errs = append(errs, errors.New("transactions require a persistent sending queue, sending_queue::storage must be specified"))
+		}
+		// Only the last committed batch can be in flight when the batches are sent one at a time,
+		// and it is only recognized after a restart if it is not rebuilt from the queue.
+		if c.QueueSettings.NumConsumers != 1 {
+			errs = append(errs, errors.New("transactions require sending_queue::num_consumers to be 1"))
+		}
+		if c.QueueSettings.Batch != nil {
+			errs = append(errs, errors.New("transactions require sending_queue::batch to be disabled"))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func (c *Config) Unmarshal(conf *confmap.Conf) error {
@@ -126,4 +178,29 @@ type SignalConfig struct {
 	//
 	// Defaults to "otlp_proto".
 	Encoding string `mapstructure:"encoding"`
+
+	// PartitionKey holds an OTTL value expression, evaluated in the resource
+	// context, whose value is used as the message key. Resources with the same
+	// key are sent in the same message, and resources for which the expression
+	// evaluates to nil or an empty value are sent without a message key.
+	//
+	// NOTE: this does not have any effect for Jaeger encodings, which always
+	// use the trace ID for the message key.
+	PartitionKey string `mapstructure:"partition_key"`
+}
+
+// TransactionConfig holds configuration about the transactional producer.
+type TransactionConfig struct {
+	// Enabled makes the exporter produce each batch in a single Kafka
+	// transaction, so that consumers reading committed messages never see
+	// partially produced batches. It requires a persistent sending queue
+	// with a single consumer and no batching, and is not supported by the
+	// franz-go client.
+	Enabled bool `mapstructure:"enabled"`
+
+	// ID holds the transactional ID of the producers, suffixed with the
+	// signal type. It must be unique to the exporter, and must not change
+	// across restarts. It is also used as the name of the consumer group
+	// recording the last committed batch.
+	ID string `mapstructure:"id"`
 }
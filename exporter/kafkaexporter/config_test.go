@@ -125,6 +125,44 @@ func TestLoadConfig(t *testing.T) {
 				Encoding: "legacy_encoding",
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partition_key_transaction"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.NewDefaultTimeoutConfig(),
+				BackOffConfig:   configretry.NewDefaultBackOffConfig(),
+				QueueSettings: func() exporterhelper.QueueBatchConfig {
+					config := exporterhelper.NewDefaultQueueConfig()
+					storageID := component.MustNewID("file_storage")
+					config.StorageID = &storageID
+					config.NumConsumers = 1
+					return config
+				}(),
+				ClientConfig: configkafka.NewDefaultClientConfig(),
+				Producer: func() configkafka.ProducerConfig {
+					config := configkafka.NewDefaultProducerConfig()
+					config.RequiredAcks = configkafka.WaitForAll
+					return config
+				}(),
+				Logs: SignalConfig{
+					Topic:        "otlp_logs",
+					Encoding:     "otlp_proto",
+					PartitionKey: `resource.attributes["service.name"]`,
+				},
+				Metrics: SignalConfig{
+					Topic:    "otlp_metrics",
+					Encoding: "otlp_proto",
+				},
+				Traces: SignalConfig{
+					Topic:        "otlp_spans",
+					Encoding:     "otlp_proto",
+					PartitionKey: `Concat([resource.attributes["service.namespace"], resource.attributes["service.name"]], "/")`,
+				},
+				Transaction: TransactionConfig{
+					Enabled: true,
+					ID:      "otelcol-0",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -140,3 +178,90 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		modify      func(*Config)
+		expectedErr string
+	}{
+		"partition_traces_by_id_and_partition_key": {
+			modify: func(cfg *Config) {
+				cfg.PartitionTracesByID = true
+				cfg.Traces.PartitionKey = `resource.attributes["service.name"]`
+			},
+			expectedErr: "partition_traces_by_id and traces::partition_key cannot be used together",
+		},
+		"partition_metrics_by_resource_attributes_and_partition_key": {
+			modify: func(cfg *Config) {
+				cfg.PartitionMetricsByResourceAttributes = true
+				cfg.Metrics.PartitionKey = `resource.attributes["service.name"]`
+			},
+			expectedErr: "partition_metrics_by_resource_attributes and metrics::partition_key cannot be used together",
+		},
+		"partition_logs_by_resource_attributes_and_partition_key": {
+			modify: func(cfg *Config) {
+				cfg.PartitionLogsByResourceAttributes = true
+				cfg.Logs.PartitionKey = `resource.attributes["service.name"]`
+			},
+			expectedErr: "partition_logs_by_resource_attributes and logs::partition_key cannot be used together",
+		},
+		"invalid_partition_key": {
+			modify: func(cfg *Config) {
+				cfg.Logs.PartitionKey = `span.name`
+			},
+			expectedErr: `invalid logs::partition_key`,
+		},
+		"transaction_without_id": {
+			modify: func(cfg *Config) {
+				cfg.Transaction.Enabled = true
+				cfg.Producer.RequiredAcks = configkafka.WaitForAll
+				storageID := component.MustNewID("file_storage")
+				cfg.QueueSettings.StorageID = &storageID
+			},
+			expectedErr: "transaction::id must be specified when transactions are enabled",
+		},
+		"transaction_without_required_acks_all": {
+			modify: func(cfg *Config) {
+				cfg.Transaction = TransactionConfig{Enabled: true, ID: "otelcol-0"}
+				storageID := component.MustNewID("file_storage")
+				cfg.QueueSettings.StorageID = &storageID
+			},
+			expectedErr: "transactions require producer::required_acks to be 'all' (-1)",
+		},
+		"transaction_without_persistent_queue": {
+			modify: func(cfg *Config) {
+				cfg.Transaction = TransactionConfig{Enabled: true, ID: "otelcol-0"}
+				cfg.Producer.RequiredAcks = configkafka.WaitForAll
+			},
+			expectedErr: "transactions require a persistent sending queue, sending_queue::storage must be specified",
+		},
+		"transaction_with_concurrent_consumers": {
+			modify: func(cfg *Config) {
+				cfg.Transaction = TransactionConfig{Enabled: true, ID: "otelcol-0"}
+				cfg.Producer.RequiredAcks = configkafka.WaitForAll
+				storageID := component.MustNewID("file_storage")
+				cfg.QueueSettings.StorageID = &storageID
+			},
+			expectedErr: "transactions require sending_queue::num_consumers to be 1",
+		},
+		"transaction_with_batching": {
+			modify: func(cfg *Config) {
+				cfg.Transaction = TransactionConfig{Enabled: true, ID: "otelcol-0"}
+				cfg.Producer.RequiredAcks = configkafka.WaitForAll
+				storageID := component.MustNewID("file_storage")
+				cfg.QueueSettings.StorageID = &storageID
+				cfg.QueueSettings.NumConsumers = 1
+				cfg.QueueSettings.Batch = &exporterhelper.BatchConfig{FlushTimeout: 200 * time.Millisecond, MinSize: 8192}
+			},
+			expectedErr: "transactions require sending_queue::batch to be disabled",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.ErrorContains(t, cfg.Validate(), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaclient // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/kafkaclient"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// ConsumerGroupOffsetsLister lists the committed offsets of a consumer group.
+// It is implemented by sarama.ClusterAdmin.
+type ConsumerGroupOffsetsLister interface {
+	ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error)
+}
+
+// maxRecoveredBatches is the maximum number of recovered batches recorded by the marker.
+// One batch may be recovered on each restart, so the marker only holds more than one
+// batch when the producer restarts again before a recovered batch is sent again.
+const maxRecoveredBatches = 32
+
+// SaramaTransactionalProducer is a wrapper around a transactional sarama.SyncProducer,
+// which produces each batch of messages in a single Kafka transaction.
+//
+// Along with the messages, each transaction commits a marker as the offset of the consumer
+// group named after the transactional ID, for partition 0 of the marker topic: the offset
+// holds the sequence number of the batch, and the offset metadata holds the hashes of the
+// batch and of the recovered batches that were not sent again yet. When the producer is
+// created, the batches of the marker are recovered, and each of them is skipped once, when
+// it is sent again. This prevents a batch committed right before a crash, but which was not
+// yet removed from the persistent sending queue, from being produced twice, whatever the
+// position of the batch in the queue after the restart, as long as the batches are sent
+// one at a time.
+//
+// Delivery is still at least once: for instance, a batch is produced again if committing
+// its transaction fails after the broker has committed it.
+type SaramaTransactionalProducer struct {
+	producer     sarama.SyncProducer
+	metadataKeys []string
+	groupID      string
+	markerTopic  string
+
+	// mu serializes the transactions, as a producer can only have a single ongoing transaction.
+	mu sync.Mutex
+	// sequence is the sequence number of the last committed batch.
+	sequence int64
+	// recoveredBatches are the hashes of the batches committed before the producer was
+	// created, and which were not sent again since, from the oldest to the newest.
+	recoveredBatches []string
+}
+
+// NewSaramaTransactionalProducer creates a new SaramaTransactionalProducer that wraps a
+// transactional sarama.SyncProducer, and loads the marker of the consumer group groupID
+// committed for the topic markerTopic.
+func NewSaramaTransactionalProducer(producer sarama.SyncProducer,
+	offsets ConsumerGroupOffsetsLister,
+	groupID string,
+	markerTopic string,
+	metadataKeys []string,
+) (*SaramaTransactionalProducer, error) {
+	if !producer.IsTransactional() {
+		return nil, errors.New("producer is not transactional")
+	}
+	p := &SaramaTransactionalProducer{
+		producer:     producer,
+		metadataKeys: metadataKeys,
+		groupID:      groupID,
+		markerTopic:  markerTopic,
+	}
+
+	response, err := offsets.ListConsumerGroupOffsets(groupID, map[string][]int32{markerTopic: {0}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the last committed batch of group %q: %w", groupID, err)
+	}
+	// The offset is -1 when the group has no marker yet.
+	if block := response.GetBlock(markerTopic, 0); block != nil && errors.Is(block.Err, sarama.ErrNoError) && block.Offset > 0 {
+		p.sequence = block.Offset
+		if block.Metadata != "" {
+			p.recoveredBatches = strings.Split(block.Metadata, ",")
+		}
+	}
+	return p, nil
+}
+
+// ExportData sends the messages to the Kafka broker in a single transaction.
+func (p *SaramaTransactionalProducer) ExportData(ctx context.Context, msgs Messages) error {
+	messages := makeSaramaMessages(msgs)
+	if len(messages) == 0 {
+		return nil
+	}
+	setMessageHeaders(ctx, messages, p.metadataKeys,
+		func(key string, value []byte) sarama.RecordHeader {
+			return sarama.RecordHeader{Key: []byte(key), Value: value}
+		},
+		func(m *sarama.ProducerMessage) []sarama.RecordHeader { return m.Headers },
+		func(m *sarama.ProducerMessage, h []sarama.RecordHeader) { m.Headers = h },
+	)
+	batch, err := hashSaramaMessages(messages)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if i := slices.Index(p.recoveredBatches, batch); i >= 0 {
+		// The batch was already committed: only commit a marker without it,
+		// so that a later batch with the same content is not skipped.
+		recovered := slices.Delete(slices.Clone(p.recoveredBatches), i, i+1)
+		if err := p.commitTxn(nil, recovered); err != nil {
+			return err
+		}
+		p.recoveredBatches = recovered
+		return nil
+	}
+
+	// The batches committed before this one were removed from the queue, as batches
+	// are sent one at a time, so only the recovered batches are kept in the marker.
+	return p.commitTxn(messages, append(slices.Clone(p.recoveredBatches), batch))
+}
+
+// commitTxn produces the messages and commits the marker of the batches in a single transaction,
+// and increases the sequence number if the transaction is committed.
+func (p *SaramaTransactionalProducer) commitTxn(messages []*sarama.ProducerMessage, batches []string) error {
+	if len(batches) > maxRecoveredBatches {
+		batches = batches[len(batches)-maxRecoveredBatches:]
+	}
+	if err := p.producer.BeginTxn(); err != nil {
+		return p.txnError(err)
+	}
+	if len(messages) > 0 {
+		if err := p.producer.SendMessages(messages); err != nil {
+			return p.abortTxn(wrapKafkaProducerError(err))
+		}
+	}
+	metadata := strings.Join(batches, ",")
+	offsets := map[string][]*sarama.PartitionOffsetMetadata{
+		p.markerTopic: {{
+			Partition:   0,
+			Offset:      p.sequence + 1,
+			LeaderEpoch: -1,
+			Metadata:    &metadata,
+		}},
+	}
+	if err := p.producer.AddOffsetsToTxn(offsets, p.groupID); err != nil {
+		return p.abortTxn(err)
+	}
+	if err := p.producer.CommitTxn(); err != nil {
+		return p.abortTxn(err)
+	}
+	p.sequence++
+	return nil
+}
+
+// Close shuts down the producer, aborting the ongoing transaction if any.
+func (p *SaramaTransactionalProducer) Close() error {
+	return p.producer.Close()
+}
+
+// abortTxn aborts the ongoing transaction after err occurred, so that the batch can be retried.
+func (p *SaramaTransactionalProducer) abortTxn(err error) error {
+	if p.producer.TxnStatus()&sarama.ProducerTxnFlagFatalError == 0 {
+		if abortErr := p.producer.AbortTxn(); abortErr != nil {
+			err = errors.Join(err, abortErr)
+		}
+	}
+	return p.txnError(err)
+}
+
+// txnError returns a permanent error if the producer is in a fatal state, e.g. because it
+// was fenced by another producer with the same transactional ID, as retrying is then useless.
+func (p *SaramaTransactionalProducer) txnError(err error) error {
+	if p.producer.TxnStatus()&sarama.ProducerTxnFlagFatalError != 0 {
+		return consumererror.NewPermanent(fmt.Errorf("transactional producer is in a fatal state: %w", err))
+	}
+	return err
+}
+
+// hashSaramaMessages returns the hex-encoded SHA-256 hash of the topics, keys
+// and values of the messages. The headers are not part of the hash, as the client
+// metadata they are created from may not be available when the batch is retried.
+func hashSaramaMessages(messages []*sarama.ProducerMessage) (string, error) {
+	h := sha256.New()
+	write := func(b []byte) {
+		_ = binary.Write(h, binary.BigEndian, int64(len(b)))
+		h.Write(b)
+	}
+	for _, msg := range messages {
+		write([]byte(msg.Topic))
+		for _, encoder := range []sarama.Encoder{msg.Key, msg.Value} {
+			if encoder == nil {
+				_ = binary.Write(h, binary.BigEndian, int64(-1))
+				continue
+			}
+			b, err := encoder.Encode()
+			if err != nil {
+				return "", err
+			}
+			write(b)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
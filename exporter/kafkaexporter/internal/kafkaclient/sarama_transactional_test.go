@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaclient
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/marshaler"
+)
+
+type offsetsListerFunc func(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error)
+
+func (f offsetsListerFunc) ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	return f(group, topicPartitions)
+}
+
+func newTransactionalMockProducer(t *testing.T) *mocks.SyncProducer {
+	config := sarama.NewConfig()
+	config.Producer.Idempotent = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Net.MaxOpenRequests = 1
+	config.Producer.Transaction.ID = "txn"
+	producer := mocks.NewSyncProducer(t, config)
+	t.Cleanup(func() { assert.NoError(t, producer.Close()) })
+	return producer
+}
+
+// markerRecorder records the markers committed by the transactions of the producer.
+type markerRecorder struct {
+	*mocks.SyncProducer
+	pending   map[string][]*sarama.PartitionOffsetMetadata
+	committed []map[string][]*sarama.PartitionOffsetMetadata
+}
+
+func (r *markerRecorder) AddOffsetsToTxn(offsets map[string][]*sarama.PartitionOffsetMetadata, groupID string) error {
+	r.pending = offsets
+	return r.SyncProducer.AddOffsetsToTxn(offsets, groupID)
+}
+
+func (r *markerRecorder) CommitTxn() error {
+	if err := r.SyncProducer.CommitTxn(); err != nil {
+		return err
+	}
+	r.committed = append(r.committed, r.pending)
+	return nil
+}
+
+// lastMarker returns the lister of the offsets of the last committed marker.
+func (r *markerRecorder) lastMarker() ConsumerGroupOffsetsLister {
+	response := &sarama.OffsetFetchResponse{}
+	for topic, partitions := range r.committed[len(r.committed)-1] {
+		for _, partition := range partitions {
+			response.AddBlock(topic, partition.Partition, &sarama.OffsetFetchResponseBlock{
+				Offset:   partition.Offset,
+				Metadata: *partition.Metadata,
+			})
+		}
+	}
+	return offsetsListerFunc(func(string, map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+		return response, nil
+	})
+}
+
+func testMessages(values ...string) Messages {
+	return testTopicMessages("topic", values...)
+}
+
+func testTopicMessages(topic string, values ...string) Messages {
+	messages := Messages{Count: len(values)}
+	topicMessages := TopicMessages{Topic: topic}
+	for _, value := range values {
+		topicMessages.Messages = append(topicMessages.Messages, marshaler.Message{Value: []byte(value)})
+	}
+	messages.TopicMessages = append(messages.TopicMessages, topicMessages)
+	return messages
+}
+
+func hashTestMessages(t *testing.T, messages Messages) string {
+	hash, err := hashSaramaMessages(makeSaramaMessages(messages))
+	require.NoError(t, err)
+	return hash
+}
+
+func TestSaramaTransactionalProducer(t *testing.T) {
+	recovered := hashTestMessages(t, testMessages("recovered"))
+	older := hashTestMessages(t, testMessages("older"))
+
+	producer := &markerRecorder{SyncProducer: newTransactionalMockProducer(t)}
+	p, err := NewSaramaTransactionalProducer(producer, offsetsListerFunc(
+		func(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+			assert.Equal(t, "txn", group)
+			assert.Equal(t, map[string][]int32{"marker": {0}}, topicPartitions)
+			response := &sarama.OffsetFetchResponse{}
+			response.AddBlock("marker", 0, &sarama.OffsetFetchResponseBlock{Offset: 5, Metadata: older + "," + recovered})
+			return response, nil
+		},
+	), "txn", "marker", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), p.sequence)
+	assert.Equal(t, []string{older, recovered}, p.recoveredBatches)
+
+	// A recovered batch is skipped, and a marker without it is committed.
+	require.NoError(t, p.ExportData(context.Background(), testMessages("recovered")))
+	assert.Equal(t, []string{older}, p.recoveredBatches)
+	assert.Equal(t, int64(6), p.sequence)
+	require.Len(t, producer.committed, 1)
+	assert.Equal(t, older, *producer.committed[0]["marker"][0].Metadata)
+
+	// A batch with the same content is only skipped once.
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testMessages("recovered")))
+	assert.Equal(t, int64(7), p.sequence)
+	assert.Equal(t, sarama.ProducerTxnFlagReady, producer.TxnStatus())
+	require.Len(t, producer.committed, 2)
+	assert.Equal(t, int64(7), producer.committed[1]["marker"][0].Offset)
+	assert.Equal(t, older+","+recovered, *producer.committed[1]["marker"][0].Metadata)
+
+	// A failed batch is aborted, and does not increase the sequence number.
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	require.ErrorIs(t, p.ExportData(context.Background(), testMessages("failed")), sarama.ErrOutOfBrokers)
+	assert.Equal(t, int64(7), p.sequence)
+	assert.Equal(t, sarama.ProducerTxnFlagReady, producer.TxnStatus())
+	assert.Len(t, producer.committed, 2)
+}
+
+func TestNewSaramaTransactionalProducer_Errors(t *testing.T) {
+	_, err := NewSaramaTransactionalProducer(mocks.NewSyncProducer(t, sarama.NewConfig()), nil, "txn", "marker", nil)
+	assert.EqualError(t, err, "producer is not transactional")
+
+	_, err = NewSaramaTransactionalProducer(newTransactionalMockProducer(t), offsetsListerFunc(
+		func(string, map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+			return nil, errors.New("no coordinator")
+		},
+	), "txn", "marker", nil)
+	assert.EqualError(t, err, `failed to load the last committed batch of group "txn": no coordinator`)
+}
+
+func TestNewSaramaTransactionalProducer_NoMarker(t *testing.T) {
+	p, err := NewSaramaTransactionalProducer(newTransactionalMockProducer(t), offsetsListerFunc(
+		func(string, map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+			response := &sarama.OffsetFetchResponse{}
+			response.AddBlock("marker", 0, &sarama.OffsetFetchResponseBlock{Offset: -1})
+			return response, nil
+		},
+	), "txn", "marker", nil)
+	require.NoError(t, err)
+	assert.Zero(t, p.sequence)
+	assert.Empty(t, p.recoveredBatches)
+}
+
+// TestSaramaTransactionalProducer_RestartWithBacklog tests that a batch committed right before a crash
+// is not produced again when the queue redelivers it after a backlog of other batches.
+func TestSaramaTransactionalProducer_RestartWithBacklog(t *testing.T) {
+	producer := &markerRecorder{SyncProducer: newTransactionalMockProducer(t)}
+	p, err := NewSaramaTransactionalProducer(producer, offsetsListerFunc(
+		func(string, map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+			return &sarama.OffsetFetchResponse{}, nil
+		},
+	), "txn", "marker", nil)
+	require.NoError(t, err)
+
+	// The last batch is committed, but the collector crashes before removing it from the queue.
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testMessages("first")))
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testMessages("inflight")))
+
+	// After the restart, the queue sends a backlog before the in-flight batch, and the collector
+	// crashes again after committing the last batch of the backlog.
+	p, err = NewSaramaTransactionalProducer(producer, producer.lastMarker(), "txn", "marker", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{hashTestMessages(t, testMessages("inflight"))}, p.recoveredBatches)
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testMessages("backlog1")))
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testMessages("backlog2")))
+
+	// After the second restart, both in-flight batches are redelivered after another backlog.
+	p, err = NewSaramaTransactionalProducer(producer, producer.lastMarker(), "txn", "marker", nil)
+	require.NoError(t, err)
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testMessages("backlog3")))
+	require.NoError(t, p.ExportData(context.Background(), testMessages("inflight")))
+	require.NoError(t, p.ExportData(context.Background(), testMessages("backlog2")))
+	assert.Empty(t, p.recoveredBatches)
+
+	// Once sent again, the in-flight batch is forgotten.
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testMessages("inflight")))
+	assert.Equal(t, "", *producer.committed[len(producer.committed)-2]["marker"][0].Metadata)
+}
+
+// TestSaramaTransactionalProducer_TopicChange tests that the marker is always committed for the
+// marker topic, so that it is recovered when the batches are produced to different topics.
+func TestSaramaTransactionalProducer_TopicChange(t *testing.T) {
+	producer := &markerRecorder{SyncProducer: newTransactionalMockProducer(t)}
+	p, err := NewSaramaTransactionalProducer(producer, offsetsListerFunc(
+		func(string, map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+			return &sarama.OffsetFetchResponse{}, nil
+		},
+	), "txn", "marker", nil)
+	require.NoError(t, err)
+
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testTopicMessages("topic1", "a")))
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.ExportData(context.Background(), testTopicMessages("topic2", "b")))
+	for _, offsets := range producer.committed {
+		assert.Equal(t, []string{"marker"}, slices.Collect(maps.Keys(offsets)))
+	}
+
+	p, err = NewSaramaTransactionalProducer(producer, producer.lastMarker(), "txn", "marker", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), p.sequence)
+	assert.Equal(t, []string{hashTestMessages(t, testTopicMessages("topic2", "b"))}, p.recoveredBatches)
+	require.NoError(t, p.ExportData(context.Background(), testTopicMessages("topic2", "b")))
+	assert.Empty(t, p.recoveredBatches)
+}
+
+func TestHashSaramaMessages(t *testing.T) {
+	hash := func(msgs ...*sarama.ProducerMessage) string {
+		h, err := hashSaramaMessages(msgs)
+		require.NoError(t, err)
+		return h
+	}
+
+	msg := &sarama.ProducerMessage{Topic: "topic", Key: sarama.StringEncoder("key"), Value: sarama.StringEncoder("value")}
+	assert.Equal(t, hash(msg), hash(&sarama.ProducerMessage{
+		Topic:   "topic",
+		Key:     sarama.ByteEncoder("key"),
+		Value:   sarama.ByteEncoder("value"),
+		Headers: []sarama.RecordHeader{{Key: []byte("k"), Value: []byte("v")}},
+	}))
+	assert.NotEqual(t, hash(msg), hash(&sarama.ProducerMessage{Topic: "topic", Value: sarama.StringEncoder("keyvalue")}))
+	assert.NotEqual(t, hash(msg), hash(&sarama.ProducerMessage{Topic: "topic2", Key: sarama.StringEncoder("key"), Value: sarama.StringEncoder("value")}))
+	assert.NotEqual(t, hash(msg), hash(msg, msg))
+}
@@ -5,6 +5,7 @@ package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"context"
+	"errors"
 	"iter"
 
 	"go.opentelemetry.io/collector/client"
@@ -52,7 +53,7 @@ type messenger[T any] interface {
 	// partitionData returns an iterator that yields key-value pairs
 	// where the key is the partition key, and the value is the pdata
 	// type (plog.Logs, etc.)
-	partitionData(T) iter.Seq2[[]byte, T]
+	partitionData(context.Context, T) iter.Seq2[[]byte, T]
 
 	// marshalData marshals a pdata type into one or more messages.
 	marshalData(T) ([]marshaler.Message, error)
@@ -62,7 +63,9 @@ type messenger[T any] interface {
 }
 
 type kafkaExporter[T any] struct {
-	cfg          Config
+	cfg Config
+	// topic is the configured topic of the signal.
+	topic        string
 	logger       *zap.Logger
 	newMessenger func(host component.Host) (messenger[T], error)
 	messenger    messenger[T]
@@ -72,10 +75,18 @@ type kafkaExporter[T any] struct {
 func newKafkaExporter[T any](
 	config Config,
 	set exporter.Settings,
+	signal string,
+	topic string,
 	newMessenger func(component.Host) (messenger[T], error),
 ) *kafkaExporter[T] {
+	if config.Transaction.Enabled {
+		// Each signal has its own producer, which must not
+		// fence the producers of the other signals.
+		config.Transaction.ID += "-" + signal
+	}
 	return &kafkaExporter[T]{
 		cfg:          config,
+		topic:        topic,
 		logger:       set.Logger,
 		newMessenger: newMessenger,
 	}
@@ -86,6 +97,13 @@ func (e *kafkaExporter[T]) Start(ctx context.Context, host component.Host) (err
 		return err
 	}
 
+	if e.cfg.Transaction.Enabled {
+		if franzGoClientFeatureGate.IsEnabled() {
+			return errors.New("transactions are not supported by the franz-go client")
+		}
+		return e.startTransactionalProducer(ctx)
+	}
+
 	if franzGoClientFeatureGate.IsEnabled() {
 		producer, ferr := kafka.NewFranzSyncProducer(ctx, e.cfg.ClientConfig,
 			e.cfg.Producer, e.cfg.TimeoutSettings.Timeout, e.logger,
@@ -110,6 +128,31 @@ func (e *kafkaExporter[T]) Start(ctx context.Context, host component.Host) (err
 	return nil
 }
 
+// startTransactionalProducer creates the transactional producer, and loads the last committed batch.
+func (e *kafkaExporter[T]) startTransactionalProducer(ctx context.Context) error {
+	producer, err := kafka.NewSaramaTransactionalProducer(ctx, e.cfg.ClientConfig,
+		e.cfg.Producer, e.cfg.TimeoutSettings.Timeout, e.cfg.Transaction.ID,
+	)
+	if err != nil {
+		return err
+	}
+	admin, err := kafka.NewSaramaClusterAdminClient(ctx, e.cfg.ClientConfig)
+	if err != nil {
+		return errors.Join(err, producer.Close())
+	}
+	defer func() { _ = admin.Close() }()
+
+	// The marker is committed for the configured topic of the signal, whatever the topics of the batches.
+	transactionalProducer, err := kafkaclient.NewSaramaTransactionalProducer(producer, admin,
+		e.cfg.Transaction.ID, e.topic, e.cfg.IncludeMetadataKeys,
+	)
+	if err != nil {
+		return errors.Join(err, producer.Close())
+	}
+	e.producer = transactionalProducer
+	return nil
+}
+
 func (e *kafkaExporter[T]) Close(context.Context) (err error) {
 	if e.producer == nil {
 		return nil
@@ -121,7 +164,7 @@ func (e *kafkaExporter[T]) Close(context.Context) (err error) {
 
 func (e *kafkaExporter[T]) exportData(ctx context.Context, data T) error {
 	var m kafkaclient.Messages
-	for key, data := range e.messenger.partitionData(data) {
+	for key, data := range e.messenger.partitionData(ctx, data) {
 		partitionMessages, err := e.messenger.marshalData(data)
 		if err != nil {
 			return consumererror.NewPermanent(err)
@@ -148,22 +191,31 @@ func newTracesExporter(config Config, set exporter.Settings) *kafkaExporter[ptra
 	switch config.Traces.Encoding {
 	case "jaeger_proto", "jaeger_json":
 		config.PartitionTracesByID = false
+		config.Traces.PartitionKey = ""
 	}
-	return newKafkaExporter(config, set, func(host component.Host) (messenger[ptrace.Traces], error) {
+	return newKafkaExporter(config, set, "traces", config.Traces.Topic, func(host component.Host) (messenger[ptrace.Traces], error) {
 		marshaler, err := getTracesMarshaler(config.Traces.Encoding, host)
 		if err != nil {
 			return nil, err
 		}
+		partitionKey, err := newSignalPartitionKeyExpression(config.Traces, set)
+		if err != nil {
+			return nil, err
+		}
 		return &kafkaTracesMessenger{
-			config:    config,
-			marshaler: marshaler,
+			config:       config,
+			marshaler:    marshaler,
+			partitionKey: partitionKey,
+			logger:       set.Logger,
 		}, nil
 	})
 }
 
 type kafkaTracesMessenger struct {
-	config    Config
-	marshaler marshaler.TracesMarshaler
+	config       Config
+	marshaler    marshaler.TracesMarshaler
+	partitionKey *partitionKeyExpression
+	logger       *zap.Logger
 }
 
 func (e *kafkaTracesMessenger) marshalData(td ptrace.Traces) ([]marshaler.Message, error) {
@@ -174,7 +226,12 @@ func (e *kafkaTracesMessenger) getTopic(ctx context.Context, td ptrace.Traces) s
 	return getTopic(ctx, e.config.Traces, e.config.TopicFromAttribute, td.ResourceSpans())
 }
 
-func (e *kafkaTracesMessenger) partitionData(td ptrace.Traces) iter.Seq2[[]byte, ptrace.Traces] {
+func (e *kafkaTracesMessenger) partitionData(ctx context.Context, td ptrace.Traces) iter.Seq2[[]byte, ptrace.Traces] {
+	if e.partitionKey != nil {
+		return partitionByKey(ctx, e.partitionKey, e.logger, td.ResourceSpans(), ptrace.NewTraces,
+			func(td ptrace.Traces) ptrace.ResourceSpans { return td.ResourceSpans().AppendEmpty() },
+		)
+	}
 	return func(yield func([]byte, ptrace.Traces) bool) {
 		if !e.config.PartitionTracesByID {
 			yield(nil, td)
@@ -194,21 +251,29 @@ func (e *kafkaTracesMessenger) partitionData(td ptrace.Traces) iter.Seq2[[]byte,
 }
 
 func newLogsExporter(config Config, set exporter.Settings) *kafkaExporter[plog.Logs] {
-	return newKafkaExporter(config, set, func(host component.Host) (messenger[plog.Logs], error) {
+	return newKafkaExporter(config, set, "logs", config.Logs.Topic, func(host component.Host) (messenger[plog.Logs], error) {
 		marshaler, err := getLogsMarshaler(config.Logs.Encoding, host)
 		if err != nil {
 			return nil, err
 		}
+		partitionKey, err := newSignalPartitionKeyExpression(config.Logs, set)
+		if err != nil {
+			return nil, err
+		}
 		return &kafkaLogsMessenger{
-			config:    config,
-			marshaler: marshaler,
+			config:       config,
+			marshaler:    marshaler,
+			partitionKey: partitionKey,
+			logger:       set.Logger,
 		}, nil
 	})
 }
 
 type kafkaLogsMessenger struct {
-	config    Config
-	marshaler marshaler.LogsMarshaler
+	config       Config
+	marshaler    marshaler.LogsMarshaler
+	partitionKey *partitionKeyExpression
+	logger       *zap.Logger
 }
 
 func (e *kafkaLogsMessenger) marshalData(ld plog.Logs) ([]marshaler.Message, error) {
@@ -219,7 +284,12 @@ func (e *kafkaLogsMessenger) getTopic(ctx context.Context, ld plog.Logs) string
 	return getTopic(ctx, e.config.Logs, e.config.TopicFromAttribute, ld.ResourceLogs())
 }
 
-func (e *kafkaLogsMessenger) partitionData(ld plog.Logs) iter.Seq2[[]byte, plog.Logs] {
+func (e *kafkaLogsMessenger) partitionData(ctx context.Context, ld plog.Logs) iter.Seq2[[]byte, plog.Logs] {
+	if e.partitionKey != nil {
+		return partitionByKey(ctx, e.partitionKey, e.logger, ld.ResourceLogs(), plog.NewLogs,
+			func(ld plog.Logs) plog.ResourceLogs { return ld.ResourceLogs().AppendEmpty() },
+		)
+	}
 	return func(yield func([]byte, plog.Logs) bool) {
 		if !e.config.PartitionLogsByResourceAttributes {
 			yield(nil, ld)
@@ -237,21 +307,29 @@ func (e *kafkaLogsMessenger) partitionData(ld plog.Logs) iter.Seq2[[]byte, plog.
 }
 
 func newMetricsExporter(config Config, set exporter.Settings) *kafkaExporter[pmetric.Metrics] {
-	return newKafkaExporter(config, set, func(host component.Host) (messenger[pmetric.Metrics], error) {
+	return newKafkaExporter(config, set, "metrics", config.Metrics.Topic, func(host component.Host) (messenger[pmetric.Metrics], error) {
 		marshaler, err := getMetricsMarshaler(config.Metrics.Encoding, host)
 		if err != nil {
 			return nil, err
 		}
+		partitionKey, err := newSignalPartitionKeyExpression(config.Metrics, set)
+		if err != nil {
+			return nil, err
+		}
 		return &kafkaMetricsMessenger{
-			config:    config,
-			marshaler: marshaler,
+			config:       config,
+			marshaler:    marshaler,
+			partitionKey: partitionKey,
+			logger:       set.Logger,
 		}, nil
 	})
 }
 
 type kafkaMetricsMessenger struct {
-	config    Config
-	marshaler marshaler.MetricsMarshaler
+	config       Config
+	marshaler    marshaler.MetricsMarshaler
+	partitionKey *partitionKeyExpression
+	logger       *zap.Logger
 }
 
 func (e *kafkaMetricsMessenger) marshalData(md pmetric.Metrics) ([]marshaler.Message, error) {
@@ -262,7 +340,12 @@ func (e *kafkaMetricsMessenger) getTopic(ctx context.Context, md pmetric.Metrics
 	return getTopic(ctx, e.config.Metrics, e.config.TopicFromAttribute, md.ResourceMetrics())
 }
 
-func (e *kafkaMetricsMessenger) partitionData(md pmetric.Metrics) iter.Seq2[[]byte, pmetric.Metrics] {
+func (e *kafkaMetricsMessenger) partitionData(ctx context.Context, md pmetric.Metrics) iter.Seq2[[]byte, pmetric.Metrics] {
+	if e.partitionKey != nil {
+		return partitionByKey(ctx, e.partitionKey, e.logger, md.ResourceMetrics(), pmetric.NewMetrics,
+			func(md pmetric.Metrics) pmetric.ResourceMetrics { return md.ResourceMetrics().AppendEmpty() },
+		)
+	}
 	return func(yield func([]byte, pmetric.Metrics) bool) {
 		if !e.config.PartitionMetricsByResourceAttributes {
 			yield(nil, md)
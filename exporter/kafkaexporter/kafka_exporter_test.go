@@ -569,6 +569,43 @@ func TestMetricsPusher_partitioning(t *testing.T) {
 		assert.Equal(t, keys[0], keys[1])
 		assert.NotEqual(t, keys[0], keys[2])
 	})
+	t.Run("partition_key", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.Metrics.PartitionKey = `resource.attributes["service.name"]`
+		exp, producer := newMockMetricsExporter(t, *config, componenttest.NewNopHost())
+
+		// We should get one message per service name, with
+		// the resources in the order they were found.
+		expected := map[string][]int{"service1": {0, 1}, "service2": {2}}
+		var keys []string
+		for i := 0; i < 2; i++ {
+			producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(
+				func(msg *sarama.ProducerMessage) error {
+					key, err := msg.Key.Encode()
+					require.NoError(t, err)
+					keys = append(keys, string(key))
+
+					value, err := msg.Value.Encode()
+					require.NoError(t, err)
+					output, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(value)
+					require.NoError(t, err)
+
+					require.Equal(t, len(expected[string(key)]), output.ResourceMetrics().Len())
+					for j, index := range expected[string(key)] {
+						assert.NoError(t, pmetrictest.CompareResourceMetrics(
+							input.ResourceMetrics().At(index),
+							output.ResourceMetrics().At(j),
+						))
+					}
+					return nil
+				},
+			)
+		}
+
+		err := exp.exportData(context.Background(), input)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"service1", "service2"}, keys)
+	})
 }
 
 func TestMetricsDataPusher_Kgo(t *testing.T) {
@@ -982,6 +1019,66 @@ func TestLogsPusher_partitioning(t *testing.T) {
 		assert.Equal(t, keys[0], keys[1])
 		assert.NotEqual(t, keys[0], keys[2])
 	})
+	t.Run("partition_key", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.Logs.PartitionKey = `resource.attributes["service.name"]`
+		exp, producer := newMockLogsExporter(t, *config, componenttest.NewNopHost())
+
+		// We should get one message per service name, with
+		// the resources in the order they were found.
+		expected := map[string][]int{"service1": {0, 1}, "service2": {2}}
+		var keys []string
+		for i := 0; i < 2; i++ {
+			producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(
+				func(msg *sarama.ProducerMessage) error {
+					key, err := msg.Key.Encode()
+					require.NoError(t, err)
+					keys = append(keys, string(key))
+
+					value, err := msg.Value.Encode()
+					require.NoError(t, err)
+					output, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(value)
+					require.NoError(t, err)
+
+					require.Equal(t, len(expected[string(key)]), output.ResourceLogs().Len())
+					for j, index := range expected[string(key)] {
+						assert.NoError(t, plogtest.CompareResourceLogs(
+							input.ResourceLogs().At(index),
+							output.ResourceLogs().At(j),
+						))
+					}
+					return nil
+				},
+			)
+		}
+
+		err := exp.exportData(context.Background(), input)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"service1", "service2"}, keys)
+	})
+}
+
+func TestTransactionalExporter(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Transaction = TransactionConfig{Enabled: true, ID: "otelcol-0"}
+
+	// Each signal uses its own transactional ID.
+	assert.Equal(t, "otelcol-0-traces", newTracesExporter(*config, exportertest.NewNopSettings(metadata.Type)).cfg.Transaction.ID)
+	assert.Equal(t, "otelcol-0-metrics", newMetricsExporter(*config, exportertest.NewNopSettings(metadata.Type)).cfg.Transaction.ID)
+	assert.Equal(t, "otelcol-0-logs", newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type)).cfg.Transaction.ID)
+	assert.Equal(t, "otelcol-0", config.Transaction.ID)
+
+	t.Run("franz-go", func(t *testing.T) {
+		require.NoError(t, featuregate.GlobalRegistry().Set(franzGoClientFeatureGateName, true))
+		defer func() {
+			require.NoError(t, featuregate.GlobalRegistry().Set(franzGoClientFeatureGateName, false))
+		}()
+
+		exp := newLogsExporter(*config, exportertest.NewNopSettings(metadata.Type))
+		err := exp.Start(context.Background(), componenttest.NewNopHost())
+		assert.EqualError(t, err, "transactions are not supported by the franz-go client")
+		assert.NoError(t, exp.Close(context.Background()))
+	})
 }
 
 func Test_GetTopic(t *testing.T) {
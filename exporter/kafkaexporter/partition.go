@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"context"
+	"iter"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+type partitionKeyExpression = ottl.ValueExpression[ottlresource.TransformContext]
+
+// newPartitionKeyExpression parses the OTTL value expression of a partition key.
+func newPartitionKeyExpression(expr string, set component.TelemetrySettings) (*partitionKeyExpression, error) {
+	parser, err := ottlresource.NewParser(ottlfuncs.StandardConverters[ottlresource.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ParseValueExpression(expr)
+}
+
+// newSignalPartitionKeyExpression parses the partition key expression of the signal, if any.
+func newSignalPartitionKeyExpression(signalCfg SignalConfig, set exporter.Settings) (*partitionKeyExpression, error) {
+	if signalCfg.PartitionKey == "" {
+		return nil, nil
+	}
+	return newPartitionKeyExpression(signalCfg.PartitionKey, set.TelemetrySettings)
+}
+
+// partitionedResource is implemented by plog.ResourceLogs, pmetric.ResourceMetrics and ptrace.ResourceSpans.
+type partitionedResource[R any] interface {
+	resource
+	SchemaUrl() string
+	SetSchemaUrl(string)
+	CopyTo(R)
+}
+
+// partitionByKey returns an iterator that yields the resources grouped by the value of the
+// partition key expression, in the order in which each key is first found. The resources for
+// which the key cannot be evaluated are grouped with the ones without a key.
+func partitionByKey[T any, R partitionedResource[R]](
+	ctx context.Context,
+	partitionKey *partitionKeyExpression,
+	logger *zap.Logger,
+	resources resourceSlice[R],
+	newData func() T,
+	appendResource func(T) R,
+) iter.Seq2[[]byte, T] {
+	return func(yield func([]byte, T) bool) {
+		var keys [][]byte
+		partitions := make(map[string]T)
+		for i := 0; i < resources.Len(); i++ {
+			rs := resources.At(i)
+			key, err := evalPartitionKey(ctx, partitionKey, rs)
+			if err != nil {
+				logger.Warn("failed to evaluate the partition key, sending the resource without a message key", zap.Error(err))
+				key = nil
+			}
+			data, ok := partitions[string(key)]
+			if !ok {
+				data = newData()
+				partitions[string(key)] = data
+				keys = append(keys, key)
+			}
+			rs.CopyTo(appendResource(data))
+		}
+		for _, key := range keys {
+			if !yield(key, partitions[string(key)]) {
+				return
+			}
+		}
+	}
+}
+
+// evalPartitionKey returns the message key of the resource, or nil if the key evaluates to nil or an empty value.
+func evalPartitionKey[R partitionedResource[R]](ctx context.Context, partitionKey *partitionKeyExpression, rs R) ([]byte, error) {
+	val, err := partitionKey.Eval(ctx, ottlresource.NewTransformContext(rs.Resource(), rs))
+	if err != nil {
+		return nil, err
+	}
+
+	var key []byte
+	switch v := val.(type) {
+	case nil:
+	case string:
+		key = []byte(v)
+	case []byte:
+		key = v
+	case pcommon.Map:
+		hash := pdatautil.MapHash(v)
+		key = hash[:]
+	case pcommon.Slice:
+		value := pcommon.NewValueEmpty()
+		v.CopyTo(value.SetEmptySlice())
+		key = []byte(value.AsString())
+	case pcommon.Value:
+		key = []byte(v.AsString())
+	default:
+		value := pcommon.NewValueEmpty()
+		if err := value.FromRaw(v); err != nil {
+			return nil, err
+		}
+		key = []byte(value.AsString())
+	}
+	if len(key) == 0 {
+		return nil, nil
+	}
+	return key, nil
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+func TestEvalPartitionKey(t *testing.T) {
+	rl := plog.NewResourceLogs()
+	rl.Resource().Attributes().PutStr("service.name", "service1")
+	rl.Resource().Attributes().PutInt("shard", 3)
+	rl.Resource().Attributes().PutEmptyBytes("bytes").FromRaw([]byte{1, 2})
+	rl.Resource().Attributes().PutEmptySlice("slice").AppendEmpty().SetStr("a")
+	rl.Resource().Attributes().PutStr("empty", "")
+	mapHash := pdatautil.MapHash(rl.Resource().Attributes())
+
+	tests := []struct {
+		expr     string
+		expected []byte
+	}{
+		{expr: `resource.attributes["service.name"]`, expected: []byte("service1")},
+		{expr: `resource.attributes["shard"]`, expected: []byte("3")},
+		{expr: `resource.attributes["bytes"]`, expected: []byte{1, 2}},
+		{expr: `resource.attributes["slice"]`, expected: []byte(`["a"]`)},
+		{expr: `resource.attributes`, expected: mapHash[:]},
+		{expr: `Concat([resource.attributes["service.name"], "x"], "-")`, expected: []byte("service1-x")},
+		{expr: `resource.attributes["missing"]`, expected: nil},
+		{expr: `resource.attributes["empty"]`, expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			partitionKey, err := newPartitionKeyExpression(tt.expr, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			key, err := evalPartitionKey(context.Background(), partitionKey, rl)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, key)
+		})
+	}
+}
+
+func TestPartitionByKey_EvalError(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, shard := range []string{"shard1", "x", "shard1"} {
+		ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("shard", shard)
+	}
+	partitionKey, err := newPartitionKeyExpression(`Substring(resource.attributes["shard"], 0, 5)`, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.WarnLevel)
+	var keys [][]byte
+	var counts []int
+	for key, ld := range partitionByKey(context.Background(), partitionKey, zap.New(core), ld.ResourceLogs(), plog.NewLogs,
+		func(ld plog.Logs) plog.ResourceLogs { return ld.ResourceLogs().AppendEmpty() },
+	) {
+		keys = append(keys, key)
+		counts = append(counts, ld.ResourceLogs().Len())
+	}
+
+	// The resource whose key cannot be evaluated is sent without a message key.
+	assert.Equal(t, [][]byte{[]byte("shard"), nil}, keys)
+	assert.Equal(t, []int{2, 1}, counts)
+	assert.Equal(t, 1, logs.FilterMessage("failed to evaluate the partition key, sending the resource without a message key").Len())
+}
@@ -4,10 +4,12 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
 	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 )
@@ -49,6 +51,34 @@ type Config struct {
 	// Supports all attributes available (both resource and span), as well as the pseudo attributes "span.kind" and
 	// "span.name".
 	RoutingAttributes []string `mapstructure:"routing_attributes"`
+
+	// BoundedLoad enables consistent hashing with bounded loads, limiting the share of routing keys
+	// a single backend can receive.
+	BoundedLoad *BoundedLoadSettings `mapstructure:"bounded_load"`
+
+	// Ejection enables the temporary removal of backends that repeatedly fail to export data.
+	Ejection *EjectionSettings `mapstructure:"ejection"`
+}
+
+// BoundedLoadSettings defines the configuration for consistent hashing with bounded loads
+type BoundedLoadSettings struct {
+	// LoadFactor is the maximum load of a backend, relative to the average load of all the backends.
+	// Routing keys whose backend is above this bound are sent to the next backend in the ring.
+	LoadFactor float64 `mapstructure:"load_factor"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// EjectionSettings defines the configuration for ejecting failing backends from the ring
+type EjectionSettings struct {
+	// ConsecutiveFailures is the number of consecutive failed exports after which a backend is ejected.
+	ConsecutiveFailures int `mapstructure:"consecutive_failures"`
+	// Duration is the time a backend stays ejected before receiving data again.
+	Duration time.Duration `mapstructure:"duration"`
+	// MaxEjectedPercent is the maximum percentage of backends that can be ejected at the same time.
+	MaxEjectedPercent int `mapstructure:"max_ejected_percent"`
+	// prevent unkeyed literal initialization
+	_ struct{}
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -103,3 +133,29 @@ type AWSCloudMapResolver struct {
 	Timeout       time.Duration            `mapstructure:"timeout"`
 	Port          *uint16                  `mapstructure:"port"`
 }
+
+var _ xconfmap.Validator = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.BoundedLoad != nil && cfg.BoundedLoad.LoadFactor != 0 && cfg.BoundedLoad.LoadFactor <= 1 {
+		return errors.New("bounded_load: load_factor must be greater than 1")
+	}
+	if cfg.Ejection != nil {
+		if cfg.Ejection.ConsecutiveFailures < 0 {
+			return errors.New("ejection: consecutive_failures must not be negative")
+		}
+		if cfg.Ejection.Duration < 0 {
+			return errors.New("ejection: duration must not be negative")
+		}
+		if cfg.Ejection.MaxEjectedPercent < 0 || cfg.Ejection.MaxEjectedPercent > 100 {
+			return errors.New("ejection: max_ejected_percent must be between 0 and 100")
+		}
+	}
+	// The load and the failures of a backend are measured around the export to its OTLP exporter,
+	// which returns as soon as the data is queued when the OTLP exporter has a sending queue.
+	if (cfg.BoundedLoad != nil || cfg.Ejection != nil) && cfg.Protocol.OTLP.QueueConfig.Enabled {
+		return errors.New("bounded_load and ejection require protocol::otlp::sending_queue to be disabled")
+	}
+	return nil
+}
@@ -6,10 +6,15 @@ package loadbalancingexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
 )
@@ -25,3 +30,85 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, sub.Unmarshal(cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestLoadBoundedLoadAndEjectionConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "6").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	oCfg := cfg.(*Config)
+	require.NotNil(t, oCfg.BoundedLoad)
+	assert.Equal(t, 1.5, oCfg.BoundedLoad.LoadFactor)
+	require.NotNil(t, oCfg.Ejection)
+	assert.Equal(t, 3, oCfg.Ejection.ConsecutiveFailures)
+	assert.Equal(t, time.Minute, oCfg.Ejection.Duration)
+	assert.Equal(t, 30, oCfg.Ejection.MaxEjectedPercent)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name: "defaults",
+			cfg: &Config{
+				BoundedLoad: &BoundedLoadSettings{},
+				Ejection:    &EjectionSettings{},
+			},
+		},
+		{
+			name:        "load factor too low",
+			cfg:         &Config{BoundedLoad: &BoundedLoadSettings{LoadFactor: 1}},
+			expectedErr: "bounded_load: load_factor must be greater than 1",
+		},
+		{
+			name:        "negative consecutive failures",
+			cfg:         &Config{Ejection: &EjectionSettings{ConsecutiveFailures: -1}},
+			expectedErr: "ejection: consecutive_failures must not be negative",
+		},
+		{
+			name:        "negative duration",
+			cfg:         &Config{Ejection: &EjectionSettings{Duration: -time.Second}},
+			expectedErr: "ejection: duration must not be negative",
+		},
+		{
+			name:        "max ejected percent too high",
+			cfg:         &Config{Ejection: &EjectionSettings{MaxEjectedPercent: 101}},
+			expectedErr: "ejection: max_ejected_percent must be between 0 and 100",
+		},
+		{
+			name: "bounded load with otlp sending queue",
+			cfg: &Config{
+				Protocol:    Protocol{OTLP: otlpexporter.Config{QueueConfig: exporterhelper.QueueBatchConfig{Enabled: true}}},
+				BoundedLoad: &BoundedLoadSettings{},
+			},
+			expectedErr: "bounded_load and ejection require protocol::otlp::sending_queue to be disabled",
+		},
+		{
+			name: "ejection with otlp sending queue",
+			cfg: &Config{
+				Protocol: Protocol{OTLP: otlpexporter.Config{QueueConfig: exporterhelper.QueueBatchConfig{Enabled: true}}},
+				Ejection: &EjectionSettings{},
+			},
+			expectedErr: "bounded_load and ejection require protocol::otlp::sending_queue to be disabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"hash/crc32"
+	"slices"
 	"sort"
 )
 
@@ -43,12 +44,37 @@ func (h *hashRing) endpointFor(identifier []byte) string {
 		// perhaps the ring itself couldn't get initialized yet?
 		return ""
 	}
+	return h.findEndpoint(positionForIdentifier(identifier))
+}
+
+// positionForIdentifier calculates the position in the ring for the given identifier
+func positionForIdentifier(identifier []byte) position {
 	hasher := crc32.NewIEEE()
 	hasher.Write(identifier)
 	hash := hasher.Sum32()
-	pos := hash % maxPositions
+	return position(hash % maxPositions)
+}
 
-	return h.findEndpoint(position(pos))
+// walk calls visit for each distinct endpoint of the ring, starting from the one responsible for the given
+// position and following the ring clockwise, until visit returns false or all the endpoints were visited
+func (h *hashRing) walk(pos position, visit func(endpoint string) bool) {
+	if h == nil || len(h.items) == 0 {
+		return
+	}
+	start := sort.Search(len(h.items), func(i int) bool {
+		return h.items[i].pos >= pos
+	})
+	var visited []string
+	for i := range h.items {
+		endpoint := h.items[(start+i)%len(h.items)].endpoint
+		if slices.Contains(visited, endpoint) {
+			continue
+		}
+		visited = append(visited, endpoint)
+		if !visit(endpoint) {
+			return
+		}
+	}
 }
 
 // findEndpoint returns the "next" endpoint starting from the given position, or an empty string in case no endpoints are available
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHashRing(t *testing.T) {
@@ -46,6 +47,40 @@ func TestEndpointFor(t *testing.T) {
 	}
 }
 
+func TestWalk(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	ring := newHashRing(endpoints)
+	id := []byte("ad-service-7")
+
+	// test
+	var visited []string
+	ring.walk(positionForIdentifier(id), func(endpoint string) bool {
+		visited = append(visited, endpoint)
+		return true
+	})
+
+	// verify
+	require.Len(t, visited, len(endpoints))
+	assert.ElementsMatch(t, endpoints, visited)
+	assert.Equal(t, ring.endpointFor(id), visited[0])
+}
+
+func TestWalkStops(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+
+	// test
+	calls := 0
+	ring.walk(positionForIdentifier([]byte("ad-service-7")), func(string) bool {
+		calls++
+		return false
+	})
+
+	// verify
+	assert.Equal(t, 1, calls)
+}
+
 func TestPositionsFor(t *testing.T) {
 	// prepare
 	endpoint := "host1"
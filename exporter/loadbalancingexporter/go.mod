@@ -16,6 +16,7 @@ require (
 	go.opentelemetry.io/collector/component/componenttest v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/config/configretry v1.34.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/confmap v1.34.1-0.20250610090210-188191247685
+	go.opentelemetry.io/collector/confmap/xconfmap v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/consumer v1.34.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/consumer/consumertest v0.128.1-0.20250610090210-188191247685
 	go.opentelemetry.io/collector/exporter v0.128.1-0.20250610090210-188191247685
@@ -123,7 +124,6 @@ require (
 	go.opentelemetry.io/collector/confmap/provider/fileprovider v1.34.1-0.20250610090210-188191247685 // indirect
 	go.opentelemetry.io/collector/confmap/provider/httpprovider v1.34.1-0.20250610090210-188191247685 // indirect
 	go.opentelemetry.io/collector/confmap/provider/yamlprovider v1.34.1-0.20250610090210-188191247685 // indirect
-	go.opentelemetry.io/collector/confmap/xconfmap v0.128.1-0.20250610090210-188191247685 // indirect
 	go.opentelemetry.io/collector/connector v0.128.1-0.20250610090210-188191247685 // indirect
 	go.opentelemetry.io/collector/connector/connectortest v0.128.1-0.20250610090210-188191247685 // indirect
 	go.opentelemetry.io/collector/connector/xconnector v0.128.1-0.20250610090210-188191247685 // indirect
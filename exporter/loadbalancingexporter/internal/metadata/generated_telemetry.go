@@ -3,10 +3,12 @@
 package metadata
 
 import (
+	"context"
 	"errors"
 	"sync"
 
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
@@ -23,14 +25,17 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                         metric.Meter
-	mu                            sync.Mutex
-	registrations                 []metric.Registration
-	LoadbalancerBackendLatency    metric.Int64Histogram
-	LoadbalancerBackendOutcome    metric.Int64Counter
-	LoadbalancerNumBackendUpdates metric.Int64Counter
-	LoadbalancerNumBackends       metric.Int64Gauge
-	LoadbalancerNumResolutions    metric.Int64Counter
+	meter                          metric.Meter
+	mu                             sync.Mutex
+	registrations                  []metric.Registration
+	LoadbalancerBackendEjections   metric.Int64Counter
+	LoadbalancerBackendLatency     metric.Int64Histogram
+	LoadbalancerBackendOutcome     metric.Int64Counter
+	LoadbalancerBoundedLoadSpills  metric.Int64Counter
+	LoadbalancerNumBackendUpdates  metric.Int64Counter
+	LoadbalancerNumBackends        metric.Int64Gauge
+	LoadbalancerNumEjectedBackends metric.Int64ObservableGauge
+	LoadbalancerNumResolutions     metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -44,6 +49,31 @@ func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
 	tbof(mb)
 }
 
+// RegisterLoadbalancerNumEjectedBackendsCallback sets callback for observable LoadbalancerNumEjectedBackends metric.
+func (builder *TelemetryBuilder) RegisterLoadbalancerNumEjectedBackendsCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.LoadbalancerNumEjectedBackends, obs: o})
+		return nil
+	}, builder.LoadbalancerNumEjectedBackends)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
+type observerInt64 struct {
+	embedded.Int64Observer
+	inst metric.Int64Observable
+	obs  metric.Observer
+}
+
+func (oi *observerInt64) Observe(value int64, opts ...metric.ObserveOption) {
+	oi.obs.ObserveInt64(oi.inst, value, opts...)
+}
+
 // Shutdown unregister all registered callbacks for async instruments.
 func (builder *TelemetryBuilder) Shutdown() {
 	builder.mu.Lock()
@@ -62,6 +92,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.LoadbalancerBackendEjections, err = builder.meter.Int64Counter(
+		"otelcol_loadbalancer_backend_ejections",
+		metric.WithDescription("Number of times a backend was ejected from the ring after consecutive export failures."),
+		metric.WithUnit("{ejections}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.LoadbalancerBackendLatency, err = builder.meter.Int64Histogram(
 		"otelcol_loadbalancer_backend_latency",
 		metric.WithDescription("Response latency in ms for the backends."),
@@ -75,6 +111,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{outcomes}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.LoadbalancerBoundedLoadSpills, err = builder.meter.Int64Counter(
+		"otelcol_loadbalancer_bounded_load_spills",
+		metric.WithDescription("Number of routing keys sent to another backend because their backend was above its load bound."),
+		metric.WithUnit("{keys}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.LoadbalancerNumBackendUpdates, err = builder.meter.Int64Counter(
 		"otelcol_loadbalancer_num_backend_updates",
 		metric.WithDescription("Number of times the list of backends was updated."),
@@ -87,6 +129,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{backends}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.LoadbalancerNumEjectedBackends, err = builder.meter.Int64ObservableGauge(
+		"otelcol_loadbalancer_num_ejected_backends",
+		metric.WithDescription("Current number of backends ejected from the ring."),
+		metric.WithUnit("{backends}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.LoadbalancerNumResolutions, err = builder.meter.Int64Counter(
 		"otelcol_loadbalancer_num_resolutions",
 		metric.WithDescription("Number of times the resolver has triggered new resolutions."),
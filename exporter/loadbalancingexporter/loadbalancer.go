@@ -7,10 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
@@ -18,6 +22,11 @@ import (
 
 const (
 	defaultPort = "4317"
+
+	defaultLoadFactor          = 1.25
+	defaultConsecutiveFailures = 5
+	defaultEjectionDuration    = 30 * time.Second
+	defaultMaxEjectedPercent   = 50
 )
 
 var (
@@ -36,6 +45,15 @@ type loadBalancer struct {
 
 	componentFactory componentFactory
 	exporters        map[string]*wrappedExporter
+	telemetry        *metadata.TelemetryBuilder
+
+	// boundedLoad and ejection are nil when the respective feature is disabled
+	boundedLoad *BoundedLoadSettings
+	ejection    *EjectionSettings
+	// totalLoad is the number of routing keys being exported to all the backends, used for bounded loads
+	totalLoad atomic.Int64
+	// ejectLock serializes the ejections, so that the maximum number of ejected backends is respected
+	ejectLock sync.Mutex
 
 	stopped    bool
 	updateLock sync.RWMutex
@@ -132,12 +150,46 @@ func newLoadBalancer(logger *zap.Logger, cfg component.Config, factory component
 		return nil, errNoResolver
 	}
 
-	return &loadBalancer{
+	lb := &loadBalancer{
 		logger:           logger,
 		res:              res,
 		componentFactory: factory,
 		exporters:        map[string]*wrappedExporter{},
-	}, nil
+		telemetry:        telemetry,
+	}
+
+	if oCfg.BoundedLoad != nil {
+		boundedLoad := *oCfg.BoundedLoad
+		if boundedLoad.LoadFactor == 0 {
+			boundedLoad.LoadFactor = defaultLoadFactor
+		}
+		lb.boundedLoad = &boundedLoad
+	}
+	if oCfg.Ejection != nil {
+		ejection := *oCfg.Ejection
+		if ejection.ConsecutiveFailures == 0 {
+			ejection.ConsecutiveFailures = defaultConsecutiveFailures
+		}
+		if ejection.Duration == 0 {
+			ejection.Duration = defaultEjectionDuration
+		}
+		if ejection.MaxEjectedPercent == 0 {
+			ejection.MaxEjectedPercent = defaultMaxEjectedPercent
+		}
+		lb.ejection = &ejection
+
+		err := telemetry.RegisterLoadbalancerNumEjectedBackendsCallback(func(_ context.Context, observer metric.Int64Observer) error {
+			lb.updateLock.RLock()
+			defer lb.updateLock.RUnlock()
+			observer.Observe(int64(lb.numEjected(time.Now())))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lb, nil
 }
 
 func (lb *loadBalancer) Start(ctx context.Context, host component.Host) error {
@@ -225,16 +277,24 @@ func (lb *loadBalancer) Shutdown(ctx context.Context) error {
 	for _, e := range lb.exporters {
 		err = errors.Join(err, e.Shutdown(ctx))
 	}
+	if lb.ejection != nil {
+		lb.telemetry.Shutdown()
+	}
 	return err
 }
 
 // exporterAndEndpoint returns the exporter and the endpoint for the given identifier.
-func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter, string, error) {
+// When bounded loads are enabled, the identifier counts towards the load of the returned exporter
+// until it is released with exportDone.
+func (lb *loadBalancer) exporterAndEndpoint(ctx context.Context, identifier []byte) (*wrappedExporter, string, error) {
 	// NOTE: make rolling updates of next tier of collectors work. currently, this may cause
 	// data loss because the latest batches sent to outdated backend will never find their way out.
 	// for details: https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/1690
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
+	if lb.boundedLoad != nil || lb.ejection != nil {
+		return lb.selectExporter(ctx, identifier)
+	}
 	endpoint := lb.ring.endpointFor(identifier)
 	exp, found := lb.exporters[endpointWithPort(endpoint)]
 	if !found {
@@ -244,3 +304,116 @@ func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter
 
 	return exp, endpoint, nil
 }
+
+// selectExporter walks the ring from the position of the given identifier, returning the first backend that
+// is neither ejected nor above its load bound. If there is no such backend, the owner of the identifier is returned.
+// The caller must hold the updateLock.
+func (lb *loadBalancer) selectExporter(ctx context.Context, identifier []byte) (*wrappedExporter, string, error) {
+	now := time.Now()
+	var capacity int64 = math.MaxInt64
+	if lb.boundedLoad != nil {
+		available := len(lb.exporters) - lb.numEjected(now)
+		if available > 0 {
+			// each backend may take up to load_factor times the average load, including the new key
+			capacity = int64(math.Ceil(lb.boundedLoad.LoadFactor * float64(lb.totalLoad.Load()+1) / float64(available)))
+		}
+	}
+
+	var owner, selected *wrappedExporter
+	var ownerEndpoint, selectedEndpoint string
+	spilled := false
+	lb.ring.walk(positionForIdentifier(identifier), func(endpoint string) bool {
+		exp, found := lb.exporters[endpointWithPort(endpoint)]
+		if !found {
+			return true
+		}
+		if owner == nil {
+			owner, ownerEndpoint = exp, endpoint
+		}
+		if exp.isEjected(now) {
+			return true
+		}
+		if exp.load.Load() >= capacity {
+			spilled = spilled || exp == owner
+			return true
+		}
+		selected, selectedEndpoint = exp, endpoint
+		return false
+	})
+
+	if selected == nil {
+		if owner == nil {
+			return nil, "", fmt.Errorf("couldn't find the exporter for the identifier %q", identifier)
+		}
+		// every backend is either ejected or above its load bound: stick to the consistent hashing
+		selected, selectedEndpoint = owner, ownerEndpoint
+	} else if spilled {
+		lb.telemetry.LoadbalancerBoundedLoadSpills.Add(ctx, 1, metric.WithAttributeSet(owner.endpointAttr))
+	}
+
+	if lb.boundedLoad != nil {
+		selected.load.Add(1)
+		lb.totalLoad.Add(1)
+	}
+	return selected, selectedEndpoint, nil
+}
+
+// exportDone releases the given number of routing keys from the load of the exporter, and records the
+// outcome of the export, ejecting the exporter after too many consecutive failures.
+func (lb *loadBalancer) exportDone(ctx context.Context, exp *wrappedExporter, keys int, err error) {
+	lb.release(exp, keys)
+	if lb.ejection == nil {
+		return
+	}
+	if err == nil {
+		exp.consecutiveFailures.Store(0)
+		return
+	}
+	if exp.consecutiveFailures.Add(1) >= int64(lb.ejection.ConsecutiveFailures) {
+		lb.eject(ctx, exp)
+	}
+}
+
+// release removes the given number of routing keys from the load of the exporter.
+func (lb *loadBalancer) release(exp *wrappedExporter, keys int) {
+	if lb.boundedLoad != nil {
+		exp.load.Add(-int64(keys))
+		lb.totalLoad.Add(-int64(keys))
+	}
+}
+
+// eject removes the exporter from the ring for the configured duration, unless the maximum
+// number of ejected backends has been reached.
+func (lb *loadBalancer) eject(ctx context.Context, exp *wrappedExporter) {
+	lb.updateLock.RLock()
+	defer lb.updateLock.RUnlock()
+	lb.ejectLock.Lock()
+	defer lb.ejectLock.Unlock()
+
+	now := time.Now()
+	if exp.isEjected(now) {
+		return
+	}
+	if (lb.numEjected(now)+1)*100 > lb.ejection.MaxEjectedPercent*len(lb.exporters) {
+		lb.logger.Debug("not ejecting failing backend, too many backends ejected already", zap.String("endpoint", exp.endpoint))
+		return
+	}
+
+	exp.ejectedUntil.Store(now.Add(lb.ejection.Duration).UnixNano())
+	exp.consecutiveFailures.Store(0)
+	lb.telemetry.LoadbalancerBackendEjections.Add(ctx, 1, metric.WithAttributeSet(exp.endpointAttr))
+	lb.logger.Warn("ejecting backend after consecutive export failures",
+		zap.String("endpoint", exp.endpoint), zap.Duration("duration", lb.ejection.Duration))
+}
+
+// numEjected returns the number of exporters ejected at the given time.
+// The caller must hold the updateLock.
+func (lb *loadBalancer) numEjected(now time.Time) int {
+	ejected := 0
+	for _, exp := range lb.exporters {
+		if exp.isEjected(now) {
+			ejected++
+		}
+	}
+	return ejected
+}
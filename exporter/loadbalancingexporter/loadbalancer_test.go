@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,9 +16,14 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadatatest"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -149,7 +155,7 @@ func TestWithDNSResolverNoEndpoints(t *testing.T) {
 	defer func() { assert.NoError(t, p.Shutdown(context.Background())) }()
 
 	// test
-	_, e, _ := p.exporterAndEndpoint([]byte{128, 128, 0, 0})
+	_, e, _ := p.exporterAndEndpoint(context.Background(), []byte{128, 128, 0, 0})
 
 	// verify
 	assert.Empty(t, e)
@@ -399,19 +405,174 @@ func TestFailedExporterInRing(t *testing.T) {
 
 	// test
 	// this trace ID will reach the endpoint-2 -- see the consistent hashing tests for more info
-	_, _, err = p.exporterAndEndpoint([]byte{128, 128, 0, 0})
+	_, _, err = p.exporterAndEndpoint(context.Background(), []byte{128, 128, 0, 0})
 
 	// verify
 	assert.Error(t, err)
 
 	// test
 	// this service name will reach the endpoint-2 -- see the consistent hashing tests for more info
-	_, _, err = p.exporterAndEndpoint([]byte("get-recommendations-1"))
+	_, _, err = p.exporterAndEndpoint(context.Background(), []byte("get-recommendations-1"))
 
 	// verify
 	assert.Error(t, err)
 }
 
+func TestBoundedLoadSpillsToNextBackend(t *testing.T) {
+	// prepare
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tt.NewTelemetrySettings())
+	require.NoError(t, err)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2", "endpoint-3"}},
+		},
+		BoundedLoad: &BoundedLoadSettings{},
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(zap.NewNop(), cfg, componentFactory, tb)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, defaultLoadFactor, p.boundedLoad.LoadFactor)
+	id := []byte("ad-service-7")
+	owner := p.ring.endpointFor(id)
+
+	// test
+	exp1, endpoint1, err := p.exporterAndEndpoint(context.Background(), id)
+	require.NoError(t, err)
+	exp2, endpoint2, err := p.exporterAndEndpoint(context.Background(), id)
+	require.NoError(t, err)
+
+	// verify
+	// the owner is at its bound after the first key, the second one spills to the next backend
+	assert.Equal(t, owner, endpoint1)
+	assert.NotEqual(t, owner, endpoint2)
+	assert.EqualValues(t, 1, exp1.load.Load())
+	assert.EqualValues(t, 1, exp2.load.Load())
+	assert.EqualValues(t, 2, p.totalLoad.Load())
+	metadatatest.AssertEqualLoadbalancerBoundedLoadSpills(t, tt, []metricdata.DataPoint[int64]{
+		{
+			Value:      1,
+			Attributes: attribute.NewSet(attribute.String("endpoint", endpointWithPort(owner))),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+
+	// test
+	p.exportDone(context.Background(), exp1, 1, nil)
+	p.exportDone(context.Background(), exp2, 1, nil)
+	_, endpoint3, err := p.exporterAndEndpoint(context.Background(), id)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, owner, endpoint3)
+	assert.EqualValues(t, 1, p.totalLoad.Load())
+}
+
+func TestEjectFailingBackend(t *testing.T) {
+	// prepare
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tt.NewTelemetrySettings())
+	require.NoError(t, err)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2"}},
+		},
+		Ejection: &EjectionSettings{
+			ConsecutiveFailures: 2,
+			Duration:            time.Hour,
+		},
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(zap.NewNop(), cfg, componentFactory, tb)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	id := []byte("ad-service-7")
+	owner, ownerEndpoint, err := p.exporterAndEndpoint(context.Background(), id)
+	require.NoError(t, err)
+
+	// test
+	p.exportDone(context.Background(), owner, 1, errors.New("failed"))
+	p.exportDone(context.Background(), owner, 1, nil)
+	p.exportDone(context.Background(), owner, 1, errors.New("failed"))
+
+	// verify
+	// a success resets the consecutive failures
+	assert.False(t, owner.isEjected(time.Now()))
+
+	// test
+	p.exportDone(context.Background(), owner, 1, errors.New("failed"))
+	exp, endpoint, err := p.exporterAndEndpoint(context.Background(), id)
+	require.NoError(t, err)
+
+	// verify
+	assert.True(t, owner.isEjected(time.Now()))
+	assert.NotEqual(t, ownerEndpoint, endpoint)
+	metadatatest.AssertEqualLoadbalancerBackendEjections(t, tt, []metricdata.DataPoint[int64]{
+		{
+			Value:      1,
+			Attributes: attribute.NewSet(attribute.String("endpoint", endpointWithPort(ownerEndpoint))),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualLoadbalancerNumEjectedBackends(t, tt, []metricdata.DataPoint[int64]{
+		{Value: 1},
+	}, metricdatatest.IgnoreTimestamp())
+
+	// test
+	// the remaining backend can't be ejected, as it would exceed the default max_ejected_percent
+	p.exportDone(context.Background(), exp, 1, errors.New("failed"))
+	p.exportDone(context.Background(), exp, 1, errors.New("failed"))
+
+	// verify
+	assert.False(t, exp.isEjected(time.Now()))
+
+	// test
+	// the ejection expires
+	owner.ejectedUntil.Store(time.Now().Add(-time.Second).UnixNano())
+	_, endpoint, err = p.exporterAndEndpoint(context.Background(), id)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, ownerEndpoint, endpoint)
+}
+
+func TestAllBackendsEjected(t *testing.T) {
+	// prepare
+	ts, tb := getTelemetryAssets(t)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2"}},
+		},
+		Ejection: &EjectionSettings{MaxEjectedPercent: 100},
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	for _, exp := range p.exporters {
+		for i := 0; i < defaultConsecutiveFailures; i++ {
+			p.exportDone(context.Background(), exp, 0, errors.New("failed"))
+		}
+		require.True(t, exp.isEjected(time.Now()))
+	}
+	id := []byte("ad-service-7")
+
+	// test
+	_, endpoint, err := p.exporterAndEndpoint(context.Background(), id)
+
+	// verify
+	// with all the backends ejected, the keys are routed by consistent hashing alone
+	require.NoError(t, err)
+	assert.Equal(t, p.ring.endpointFor(id), endpoint)
+}
+
 func TestNewLoadBalancerInvalidNamespaceAwsResolver(t *testing.T) {
 	// prepare
 	ts, tb := getTelemetryAssets(t)
@@ -114,12 +114,17 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 	// Now assign each batch to an exporter, and merge as we go
 	metricsByExporter := map[*wrappedExporter]pmetric.Metrics{}
 	exporterEndpoints := map[*wrappedExporter]string{}
+	keys := map[*wrappedExporter]int{}
 
 	for routingID, mds := range batches {
-		exp, endpoint, err := e.loadBalancer.exporterAndEndpoint([]byte(routingID))
+		exp, endpoint, err := e.loadBalancer.exporterAndEndpoint(ctx, []byte(routingID))
 		if err != nil {
+			for exp, n := range keys {
+				e.loadBalancer.release(exp, n)
+			}
 			return err
 		}
+		keys[exp]++
 
 		expMetrics, ok := metricsByExporter[exp]
 		if !ok {
@@ -139,6 +144,7 @@ func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 		duration := time.Since(start)
 
 		exp.consumeWG.Done()
+		e.loadBalancer.exportDone(ctx, exp, keys[exp], err)
 		errs = multierr.Append(errs, err)
 		e.telemetry.LoadbalancerBackendLatency.Record(ctx, duration.Milliseconds(), metric.WithAttributeSet(exp.endpointAttr))
 		if err == nil {
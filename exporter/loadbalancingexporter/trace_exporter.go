@@ -104,17 +104,21 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 
 	exporterSegregatedTraces := make(exporterTraces)
 	endpoints := make(map[*wrappedExporter]string)
+	keys := make(map[*wrappedExporter]int)
 	for _, batch := range batches {
 		routingID, err := routingIdentifiersFromTraces(batch, e.routingKey, e.routingAttrs)
 		if err != nil {
+			e.releaseKeys(keys)
 			return err
 		}
 
 		for rid := range routingID {
-			exp, endpoint, err := e.loadBalancer.exporterAndEndpoint([]byte(rid))
+			exp, endpoint, err := e.loadBalancer.exporterAndEndpoint(ctx, []byte(rid))
 			if err != nil {
+				e.releaseKeys(keys)
 				return err
 			}
+			keys[exp]++
 
 			_, ok := exporterSegregatedTraces[exp]
 			if !ok {
@@ -133,6 +137,7 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 		start := time.Now()
 		err := exp.ConsumeTraces(ctx, td)
 		exp.consumeWG.Done()
+		e.loadBalancer.exportDone(ctx, exp, keys[exp], err)
 		errs = multierr.Append(errs, err)
 		duration := time.Since(start)
 		e.telemetry.LoadbalancerBackendLatency.Record(ctx, duration.Milliseconds(), metric.WithAttributeSet(exp.endpointAttr))
@@ -147,6 +152,14 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 	return errs
 }
 
+// releaseKeys releases the load of the routing keys assigned to exporters when the traces
+// could not be routed.
+func (e *traceExporterImp) releaseKeys(keys map[*wrappedExporter]int) {
+	for exp, n := range keys {
+		e.loadBalancer.release(exp, n)
+	}
+}
+
 // routingIdentifiersFromTraces reads the traces and determines an identifier that can be used to define a position on the
 // ring hash. It takes the routingKey, defining what type of routing should be used, and a series of attributes
 // (optionally) used if the routingKey is attrRouting.
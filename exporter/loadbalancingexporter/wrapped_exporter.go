@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
@@ -21,6 +23,14 @@ import (
 type wrappedExporter struct {
 	component.Component
 	consumeWG sync.WaitGroup
+	endpoint  string
+
+	// load is the number of routing keys being exported, used for bounded loads
+	load atomic.Int64
+	// consecutiveFailures is the number of exports that failed since the last success, used for ejection
+	consecutiveFailures atomic.Int64
+	// ejectedUntil is the time, in Unix nanoseconds, until which the exporter is ejected from the ring
+	ejectedUntil atomic.Int64
 
 	// we store the attributes here for both cases, to avoid new allocations on the hot path
 	endpointAttr attribute.Set
@@ -32,12 +42,17 @@ func newWrappedExporter(exp component.Component, identifier string) *wrappedExpo
 	ea := attribute.String("endpoint", identifier)
 	return &wrappedExporter{
 		Component:    exp,
+		endpoint:     identifier,
 		endpointAttr: attribute.NewSet(ea),
 		successAttr:  attribute.NewSet(ea, attribute.Bool("success", true)),
 		failureAttr:  attribute.NewSet(ea, attribute.Bool("success", false)),
 	}
 }
 
+func (we *wrappedExporter) isEjected(now time.Time) bool {
+	return now.UnixNano() < we.ejectedUntil.Load()
+}
+
 func (we *wrappedExporter) Shutdown(ctx context.Context) error {
 	we.consumeWG.Wait()
 	return we.Component.Shutdown(ctx)
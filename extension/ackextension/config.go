@@ -3,18 +3,45 @@
 
 package ackextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 )
 
 // Config defines configuration for ack extension
 type Config struct {
-	// StorageID defines the storage type of the extension. In-memory type is set by default (if not provided). Future consideration is disk type.
+	// StorageID defines the storage extension used to persist the partitions and their ack IDs.
+	// In-memory type is set by default (if not provided).
 	StorageID *component.ID `mapstructure:"storage"`
 	// MaxNumPartition Specifies the maximum number of partitions that clients can acquire for this extension instance.
 	// Implementation defines how limit exceeding should be handled.
 	MaxNumPartition uint64 `mapstructure:"max_number_of_partition"`
 	// MaxNumPendingAcksPerPartition Specifies the maximum number of ackIDs and their corresponding status information that are waiting to be queried in each partition.
 	MaxNumPendingAcksPerPartition uint64 `mapstructure:"max_number_of_pending_acks_per_partition"`
+	// TTL is how long an ackID is kept waiting to be queried before it expires. Zero disables expiry.
+	// Only applies when a storage extension is configured.
+	TTL time.Duration `mapstructure:"ttl"`
+	// FlushInterval is how often the changed partitions are written to the storage extension.
+	// Only applies when a storage extension is configured.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
+
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.MaxNumPartition == 0 {
+		errs = append(errs, errors.New("max_number_of_partition must be positive"))
+	}
+	if cfg.MaxNumPendingAcksPerPartition == 0 {
+		errs = append(errs, errors.New("max_number_of_pending_acks_per_partition must be positive"))
+	}
+	if cfg.TTL < 0 {
+		errs = append(errs, errors.New("ttl must not be negative"))
+	}
+	if cfg.StorageID != nil && cfg.FlushInterval <= 0 {
+		errs = append(errs, errors.New("flush_interval must be positive when storage is set"))
+	}
+	return errors.Join(errs...)
+}
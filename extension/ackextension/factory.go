@@ -5,6 +5,7 @@ package ackextension // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -17,6 +18,8 @@ var defaultStorageType = (*component.ID)(nil)
 const (
 	defaultMaxNumPartition               uint64 = 1_000_000
 	defaultMaxNumPendingAcksPerPartition uint64 = 1_000_000
+	defaultTTL                                  = time.Hour
+	defaultFlushInterval                        = time.Second
 )
 
 // NewFactory creates a factory for ack extension.
@@ -34,13 +37,15 @@ func createDefaultConfig() component.Config {
 		StorageID:                     defaultStorageType,
 		MaxNumPartition:               defaultMaxNumPartition,
 		MaxNumPendingAcksPerPartition: defaultMaxNumPendingAcksPerPartition,
+		TTL:                           defaultTTL,
+		FlushInterval:                 defaultFlushInterval,
 	}
 }
 
-func createExtension(_ context.Context, _ extension.Settings, cfg component.Config) (extension.Extension, error) {
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
 	if cfg.(*Config).StorageID == nil {
 		return newInMemoryAckExtension(cfg.(*Config)), nil
 	}
 
-	return nil, nil
+	return newPersistentAckExtension(cfg.(*Config), set)
 }
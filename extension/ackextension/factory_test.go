@@ -4,10 +4,12 @@
 package ackextension
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/extensiontest"
 )
 
 func TestFactory(t *testing.T) {
@@ -19,4 +21,20 @@ func TestFactory(t *testing.T) {
 	require.Equal(t, defaultStorageType, cfg.StorageID)
 	require.Equal(t, defaultMaxNumPendingAcksPerPartition, cfg.MaxNumPendingAcksPerPartition)
 	require.Equal(t, defaultMaxNumPartition, cfg.MaxNumPartition)
+	require.Equal(t, defaultTTL, cfg.TTL)
+	require.Equal(t, defaultFlushInterval, cfg.FlushInterval)
+}
+
+func TestFactoryCreateExtension(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	ext, err := f.Create(context.Background(), extensiontest.NewNopSettings(f.Type()), cfg)
+	require.NoError(t, err)
+	require.IsType(t, &inMemoryAckExtension{}, ext)
+
+	storageID := component.MustNewID("file_storage")
+	cfg.StorageID = &storageID
+	ext, err = f.Create(context.Background(), extensiontest.NewNopSettings(f.Type()), cfg)
+	require.NoError(t, err)
+	require.IsType(t, &persistentAckExtension{}, ext)
 }
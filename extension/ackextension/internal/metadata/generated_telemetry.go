@@ -0,0 +1,82 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                   metric.Meter
+	mu                      sync.Mutex
+	registrations           []metric.Registration
+	AckExtensionAckedAcks   metric.Int64UpDownCounter
+	AckExtensionExpiredAcks metric.Int64Counter
+	AckExtensionPendingAcks metric.Int64UpDownCounter
+}
+
+// TelemetryBuilderOption applies changes to default builder.
+type TelemetryBuilderOption interface {
+	apply(*TelemetryBuilder)
+}
+
+type telemetryBuilderOptionFunc func(mb *TelemetryBuilder)
+
+func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
+	tbof(mb)
+}
+
+// Shutdown unregister all registered callbacks for async instruments.
+func (builder *TelemetryBuilder) Shutdown() {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	for _, reg := range builder.registrations {
+		reg.Unregister()
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...TelemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{}
+	for _, op := range options {
+		op.apply(&builder)
+	}
+	builder.meter = Meter(settings)
+	var err, errs error
+	builder.AckExtensionAckedAcks, err = builder.meter.Int64UpDownCounter(
+		"otelcol_ack_extension_acked_acks",
+		metric.WithDescription("Number of acknowledged ack IDs waiting to be queried in the persistent ack store."),
+		metric.WithUnit("{acks}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.AckExtensionExpiredAcks, err = builder.meter.Int64Counter(
+		"otelcol_ack_extension_expired_acks",
+		metric.WithDescription("Number of ack IDs removed from the persistent ack store because their TTL expired."),
+		metric.WithUnit("{acks}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.AckExtensionPendingAcks, err = builder.meter.Int64UpDownCounter(
+		"otelcol_ack_extension_pending_acks",
+		metric.WithDescription("Number of ack IDs waiting to be acknowledged in the persistent ack store."),
+		metric.WithUnit("{acks}"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
@@ -0,0 +1,459 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension/internal/metadata"
+)
+
+const (
+	partitionsKey      = "partitions"
+	partitionKeyPrefix = "partition_"
+
+	snapshotVersion byte = 1
+)
+
+// persistentAckExtension is the implementation of the AckExtension backed by a storage extension.
+// The partitions are kept in memory and the changed ones are written to the storage extension
+// every FlushInterval and on shutdown, so that pending acks survive a restart of the collector.
+// Eviction follows the in-memory implementation: when MaxNumPartition is reached, the least
+// recently used partition is evicted, and when MaxNumPendingAcksPerPartition is reached, the
+// least recently used ack is evicted. Acks that are not queried within TTL expire.
+type persistentAckExtension struct {
+	cfg       *Config
+	id        component.ID
+	logger    *zap.Logger
+	telemetry *metadata.TelemetryBuilder
+	client    storage.Client
+	now       func() time.Time
+
+	mu         sync.Mutex
+	partitions *simplelru.LRU[string, *persistentPartition]
+	// evicted holds the partitions to delete from the storage on the next flush.
+	evicted map[string]struct{}
+	// indexDirty is set when the list of partitions changed since the last flush.
+	indexDirty bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type persistentPartition struct {
+	nextID uint64
+	acks   *simplelru.LRU[uint64, ackEntry]
+	dirty  bool
+}
+
+type ackEntry struct {
+	acked bool
+	// created is the time the ack ID was generated, in seconds since the Unix epoch.
+	created int64
+}
+
+func newPersistentAckExtension(cfg *Config, set extension.Settings) (*persistentAckExtension, error) {
+	telemetry, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	e := &persistentAckExtension{
+		cfg:       cfg,
+		id:        set.ID,
+		logger:    set.Logger,
+		telemetry: telemetry,
+		now:       time.Now,
+		evicted:   map[string]struct{}{},
+	}
+	e.partitions, err = simplelru.NewLRU[string, *persistentPartition](int(cfg.MaxNumPartition), e.onPartitionEvicted)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Start gets a client from the storage extension and restores the persisted partitions.
+func (p *persistentAckExtension) Start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[*p.cfg.StorageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", p.cfg.StorageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", p.cfg.StorageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindExtension, p.id, "")
+	if err != nil {
+		return err
+	}
+	p.client = client
+
+	if err := p.restore(ctx); err != nil {
+		return errors.Join(fmt.Errorf("failed to restore acks: %w", err), client.Close(ctx))
+	}
+
+	flushCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.cfg.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-flushCtx.Done():
+				return
+			case <-ticker.C:
+				p.expire()
+				if err := p.flush(flushCtx); err != nil {
+					p.logger.Warn("Failed to persist acks", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown writes the pending changes to the storage extension and closes its client.
+func (p *persistentAckExtension) Shutdown(ctx context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	defer p.telemetry.Shutdown()
+	if p.client == nil {
+		return nil
+	}
+	err := p.flush(ctx)
+	return errors.Join(err, p.client.Close(ctx))
+}
+
+// ProcessEvent marks the beginning of processing an event. It generates an ack ID for the associated partition ID.
+func (p *persistentAckExtension) ProcessEvent(partitionID string) (ackID uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	partition, ok := p.partitions.Get(partitionID)
+	if !ok {
+		var err error
+		if partition, err = p.newPartition(); err != nil {
+			p.logger.Error("Failed to create partition", zap.String("partition", partitionID), zap.Error(err))
+			return 0
+		}
+		p.partitions.Add(partitionID, partition)
+		delete(p.evicted, partitionID)
+		p.indexDirty = true
+	}
+	partition.nextID++
+	partition.acks.Add(partition.nextID, ackEntry{created: p.now().Unix()})
+	partition.dirty = true
+	p.telemetry.AckExtensionPendingAcks.Add(context.Background(), 1)
+	return partition.nextID
+}
+
+// Ack acknowledges an event has been processed.
+func (p *persistentAckExtension) Ack(partitionID string, ackID uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	partition, ok := p.partitions.Get(partitionID)
+	if !ok {
+		return
+	}
+	entry, ok := partition.acks.Get(ackID)
+	if !ok || entry.acked {
+		return
+	}
+	entry.acked = true
+	partition.acks.Add(ackID, entry)
+	partition.dirty = true
+	p.telemetry.AckExtensionPendingAcks.Add(context.Background(), -1)
+	p.telemetry.AckExtensionAckedAcks.Add(context.Background(), 1)
+}
+
+// QueryAcks checks the statuses of given ackIDs for a partition.
+// ackIDs that are not generated from ProcessEvent or have been removed as a result of previous calls to QueryAcks will return false.
+func (p *persistentAckExtension) QueryAcks(partitionID string, ackIDs []uint64) map[uint64]bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make(map[uint64]bool, len(ackIDs))
+	partition, ok := p.partitions.Get(partitionID)
+	for _, ackID := range ackIDs {
+		result[ackID] = false
+		if !ok {
+			continue
+		}
+		if entry, found := partition.acks.Peek(ackID); found && entry.acked {
+			result[ackID] = true
+			partition.acks.Remove(ackID)
+			partition.dirty = true
+		}
+	}
+	return result
+}
+
+func (p *persistentAckExtension) newPartition() (*persistentPartition, error) {
+	partition := &persistentPartition{}
+	// The eviction callback is also called when an ack is removed by QueryAcks or expire.
+	acks, err := simplelru.NewLRU[uint64, ackEntry](int(p.cfg.MaxNumPendingAcksPerPartition), func(_ uint64, entry ackEntry) {
+		p.count(entry, -1)
+		partition.dirty = true
+	})
+	if err != nil {
+		return nil, err
+	}
+	partition.acks = acks
+	return partition, nil
+}
+
+func (p *persistentAckExtension) onPartitionEvicted(partitionID string, partition *persistentPartition) {
+	for _, entry := range partition.acks.Values() {
+		p.count(entry, -1)
+	}
+	p.evicted[partitionID] = struct{}{}
+	p.indexDirty = true
+}
+
+// count adds delta to the metric matching the state of entry.
+func (p *persistentAckExtension) count(entry ackEntry, delta int64) {
+	if entry.acked {
+		p.telemetry.AckExtensionAckedAcks.Add(context.Background(), delta)
+	} else {
+		p.telemetry.AckExtensionPendingAcks.Add(context.Background(), delta)
+	}
+}
+
+// expire removes the acks older than TTL.
+func (p *persistentAckExtension) expire() {
+	if p.cfg.TTL <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	deadline := p.now().Add(-p.cfg.TTL).Unix()
+	var expired int64
+	for _, partition := range p.partitions.Values() {
+		for _, ackID := range partition.acks.Keys() {
+			if entry, ok := partition.acks.Peek(ackID); ok && entry.created < deadline {
+				partition.acks.Remove(ackID)
+				expired++
+			}
+		}
+	}
+	if expired > 0 {
+		p.telemetry.AckExtensionExpiredAcks.Add(context.Background(), expired)
+	}
+}
+
+// flush writes the changed partitions and the list of partitions to the storage.
+// The changes are written again by the next flush if the storage fails.
+func (p *persistentAckExtension) flush(ctx context.Context) error {
+	p.mu.Lock()
+	var ops []*storage.Operation
+	var flushed []*persistentPartition
+	for _, partitionID := range p.partitions.Keys() {
+		partition, _ := p.partitions.Peek(partitionID)
+		if partition.dirty {
+			ops = append(ops, storage.SetOperation(partitionKeyPrefix+partitionID, encodePartition(partition)))
+			partition.dirty = false
+			flushed = append(flushed, partition)
+		}
+	}
+	evicted := p.evicted
+	for partitionID := range evicted {
+		ops = append(ops, storage.DeleteOperation(partitionKeyPrefix+partitionID))
+	}
+	p.evicted = map[string]struct{}{}
+	indexDirty := p.indexDirty
+	if indexDirty {
+		ops = append(ops, storage.SetOperation(partitionsKey, encodePartitionIDs(p.partitions.Keys())))
+		p.indexDirty = false
+	}
+	p.mu.Unlock()
+
+	if len(ops) == 0 {
+		return nil
+	}
+	err := p.client.Batch(ctx, ops...)
+	if err != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, partition := range flushed {
+			partition.dirty = true
+		}
+		for partitionID := range evicted {
+			// The partition may have been created again since.
+			if !p.partitions.Contains(partitionID) {
+				p.evicted[partitionID] = struct{}{}
+			}
+		}
+		p.indexDirty = p.indexDirty || indexDirty
+	}
+	return err
+}
+
+// restore loads the partitions persisted by a previous run.
+func (p *persistentAckExtension) restore(ctx context.Context) error {
+	data, err := p.client.Get(ctx, partitionsKey)
+	if err != nil || data == nil {
+		return err
+	}
+	partitionIDs, err := decodePartitionIDs(data)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, partitionID := range partitionIDs {
+		data, err := p.client.Get(ctx, partitionKeyPrefix+partitionID)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			continue
+		}
+		partition, err := p.newPartition()
+		if err != nil {
+			return err
+		}
+		if err := decodePartition(data, partition, func(entry ackEntry) { p.count(entry, 1) }); err != nil {
+			return fmt.Errorf("partition %q: %w", partitionID, err)
+		}
+		p.partitions.Add(partitionID, partition)
+	}
+	return nil
+}
+
+// encodePartition encodes the next ack ID of the partition followed by its acks,
+// from the least to the most recently used.
+func encodePartition(partition *persistentPartition) []byte {
+	buf := make([]byte, 0, 1+binary.MaxVarintLen64*2+partition.acks.Len()*(2*binary.MaxVarintLen64+1))
+	buf = append(buf, snapshotVersion)
+	buf = binary.AppendUvarint(buf, partition.nextID)
+	buf = binary.AppendUvarint(buf, uint64(partition.acks.Len()))
+	for _, ackID := range partition.acks.Keys() {
+		entry, _ := partition.acks.Peek(ackID)
+		buf = binary.AppendUvarint(buf, ackID)
+		if entry.acked {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		buf = binary.AppendVarint(buf, entry.created)
+	}
+	return buf
+}
+
+func decodePartition(data []byte, partition *persistentPartition, track func(ackEntry)) error {
+	d := decoder{data: data}
+	if version := d.byte(); version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", version)
+	}
+	partition.nextID = d.uvarint()
+	n := d.uvarint()
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		ackID := d.uvarint()
+		entry := ackEntry{acked: d.byte() == 1, created: d.varint()}
+		if d.err == nil {
+			partition.acks.Add(ackID, entry)
+			track(entry)
+		}
+	}
+	return d.err
+}
+
+func encodePartitionIDs(partitionIDs []string) []byte {
+	buf := binary.AppendUvarint(nil, uint64(len(partitionIDs)))
+	for _, partitionID := range partitionIDs {
+		buf = binary.AppendUvarint(buf, uint64(len(partitionID)))
+		buf = append(buf, partitionID...)
+	}
+	return buf
+}
+
+func decodePartitionIDs(data []byte) ([]string, error) {
+	d := decoder{data: data}
+	n := d.uvarint()
+	var partitionIDs []string
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		if id := d.bytes(d.uvarint()); d.err == nil {
+			partitionIDs = append(partitionIDs, string(id))
+		}
+	}
+	return partitionIDs, d.err
+}
+
+var errCorrupted = errors.New("corrupted data")
+
+// decoder reads the values written by the encode functions, keeping the first error.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = errCorrupted
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.err = errCorrupted
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.data) == 0 {
+		d.err = errCorrupted
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *decoder) bytes(n uint64) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if uint64(len(d.data)) < n {
+		d.err = errCorrupted
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ackextension
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ackextension/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestPersistentConfig() *Config {
+	storageID := storagetest.NewStorageID("test")
+	return &Config{
+		StorageID:                     &storageID,
+		MaxNumPartition:               defaultMaxNumPartition,
+		MaxNumPendingAcksPerPartition: defaultMaxNumPendingAcksPerPartition,
+		TTL:                           defaultTTL,
+		FlushInterval:                 time.Hour,
+	}
+}
+
+func startPersistentExtension(t *testing.T, cfg *Config, host component.Host, tt *componenttest.Telemetry) *persistentAckExtension {
+	ext, err := newPersistentAckExtension(cfg, metadatatest.NewSettings(tt))
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), host))
+	return ext
+}
+
+func TestPersistentAckExtensionAck(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	ext := startPersistentExtension(t, newTestPersistentConfig(), host, tt)
+
+	for i := uint64(1); i <= 3; i++ {
+		require.Equal(t, i, ext.ProcessEvent("p1"))
+	}
+	require.Equal(t, uint64(1), ext.ProcessEvent("p2"))
+	ext.Ack("p1", 1)
+	ext.Ack("p1", 3)
+	ext.Ack("p1", 3)
+	ext.Ack("p3", 1)
+
+	metadatatest.AssertEqualAckExtensionPendingAcks(t, tt, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualAckExtensionAckedAcks(t, tt, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+
+	require.Equal(t, map[uint64]bool{1: true, 2: false, 3: true, 4: false}, ext.QueryAcks("p1", []uint64{1, 2, 3, 4}))
+	// Acks are removed once queried.
+	require.Equal(t, map[uint64]bool{1: false, 3: false}, ext.QueryAcks("p1", []uint64{1, 3}))
+	require.Equal(t, map[uint64]bool{1: false}, ext.QueryAcks("p3", []uint64{1}))
+
+	metadatatest.AssertEqualAckExtensionPendingAcks(t, tt, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualAckExtensionAckedAcks(t, tt, []metricdata.DataPoint[int64]{{Value: 0}}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestPersistentAckExtensionRestore(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newTestPersistentConfig()
+
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	ext := startPersistentExtension(t, cfg, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir), tt)
+	for i := 0; i < 3; i++ {
+		ext.ProcessEvent("p1")
+	}
+	ext.ProcessEvent("p2")
+	ext.Ack("p1", 2)
+	ext.Ack("p2", 1)
+	require.Equal(t, map[uint64]bool{1: true}, ext.QueryAcks("p2", []uint64{1}))
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	restoredTel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, restoredTel.Shutdown(context.Background())) })
+	restored := startPersistentExtension(t, cfg, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir), restoredTel)
+	metadatatest.AssertEqualAckExtensionPendingAcks(t, restoredTel, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualAckExtensionAckedAcks(t, restoredTel, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+
+	// Ack IDs keep increasing after a restart so that they are not reused.
+	require.Equal(t, uint64(4), restored.ProcessEvent("p1"))
+	require.Equal(t, uint64(2), restored.ProcessEvent("p2"))
+	restored.Ack("p1", 1)
+	require.Equal(t, map[uint64]bool{1: true, 2: true, 3: false}, restored.QueryAcks("p1", []uint64{1, 2, 3}))
+	require.NoError(t, restored.Shutdown(context.Background()))
+}
+
+func TestPersistentAckExtensionTTL(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	cfg := newTestPersistentConfig()
+	cfg.TTL = time.Minute
+	ext := startPersistentExtension(t, cfg, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"), tt)
+
+	now := time.Unix(1_700_000_000, 0)
+	ext.now = func() time.Time { return now }
+	ext.ProcessEvent("p1")
+	ext.ProcessEvent("p1")
+	ext.Ack("p1", 1)
+	now = now.Add(30 * time.Second)
+	ext.ProcessEvent("p1")
+
+	now = now.Add(45 * time.Second)
+	ext.expire()
+	require.Equal(t, map[uint64]bool{1: false, 2: false}, ext.QueryAcks("p1", []uint64{1, 2}))
+	ext.Ack("p1", 3)
+	require.Equal(t, map[uint64]bool{3: true}, ext.QueryAcks("p1", []uint64{3}))
+
+	metadatatest.AssertEqualAckExtensionExpiredAcks(t, tt, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualAckExtensionPendingAcks(t, tt, []metricdata.DataPoint[int64]{{Value: 0}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualAckExtensionAckedAcks(t, tt, []metricdata.DataPoint[int64]{{Value: 0}}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestPersistentAckExtensionEviction(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newTestPersistentConfig()
+	cfg.MaxNumPartition = 2
+	cfg.MaxNumPendingAcksPerPartition = 2
+
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	ext := startPersistentExtension(t, cfg, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir), tt)
+	for i := 0; i < 3; i++ {
+		ext.ProcessEvent("p1")
+	}
+	ext.Ack("p1", 1)
+	require.Equal(t, map[uint64]bool{1: false}, ext.QueryAcks("p1", []uint64{1}))
+
+	ext.ProcessEvent("p2")
+	require.NoError(t, ext.flush(context.Background()))
+	ext.ProcessEvent("p3")
+	metadatatest.AssertEqualAckExtensionPendingAcks(t, tt, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	restored := startPersistentExtension(t, cfg, storagetest.NewStorageHost().WithFileBackedStorageExtension("test", dir), componenttest.NewTelemetry())
+	require.Equal(t, []string{"p2", "p3"}, restored.partitions.Keys())
+	require.NoError(t, restored.Shutdown(context.Background()))
+
+	client := storagetest.NewFileBackedClient(component.KindExtension, component.MustNewID("ack"), "", dir)
+	data, err := client.Get(context.Background(), partitionKeyPrefix+"p1")
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+// failingBatchClient is a storage client whose batches fail.
+type failingBatchClient struct {
+	storage.Client
+}
+
+func (failingBatchClient) Batch(context.Context, ...*storage.Operation) error {
+	return errors.New("storage unavailable")
+}
+
+func TestPersistentAckExtensionFlushError(t *testing.T) {
+	cfg := newTestPersistentConfig()
+	cfg.MaxNumPartition = 1
+	ext := startPersistentExtension(t, cfg, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"), componenttest.NewTelemetry())
+	client := ext.client
+
+	ext.ProcessEvent("p1")
+	require.NoError(t, ext.flush(context.Background()))
+
+	// The changes are kept when the storage fails, and written by the next flush.
+	ext.ProcessEvent("p2")
+	ext.client = failingBatchClient{Client: client}
+	require.EqualError(t, ext.flush(context.Background()), "storage unavailable")
+	ext.client = client
+	require.NoError(t, ext.flush(context.Background()))
+
+	data, err := client.Get(context.Background(), partitionKeyPrefix+"p1")
+	require.NoError(t, err)
+	require.Nil(t, data)
+	data, err = client.Get(context.Background(), partitionKeyPrefix+"p2")
+	require.NoError(t, err)
+	require.NotNil(t, data)
+	data, err = client.Get(context.Background(), partitionsKey)
+	require.NoError(t, err)
+	partitionIDs, err := decodePartitionIDs(data)
+	require.NoError(t, err)
+	require.Equal(t, []string{"p2"}, partitionIDs)
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestPersistentAckExtensionStartErrors(t *testing.T) {
+	ext, err := newPersistentAckExtension(newTestPersistentConfig(), metadatatest.NewSettings(componenttest.NewTelemetry()))
+	require.NoError(t, err)
+	require.ErrorContains(t, ext.Start(context.Background(), storagetest.NewStorageHost()), "storage extension 'test_storage/test' not found")
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	storageID := storagetest.NewNonStorageID("test")
+	cfg := newTestPersistentConfig()
+	cfg.StorageID = &storageID
+	ext, err = newPersistentAckExtension(cfg, metadatatest.NewSettings(componenttest.NewTelemetry()))
+	require.NoError(t, err)
+	require.ErrorContains(t, ext.Start(context.Background(), storagetest.NewStorageHost().WithNonStorageExtension("test")), "non-storage extension 'non_storage/test' found")
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestPartitionEncoding(t *testing.T) {
+	ext, err := newPersistentAckExtension(newTestPersistentConfig(), metadatatest.NewSettings(componenttest.NewTelemetry()))
+	require.NoError(t, err)
+	newPartition := func() *persistentPartition {
+		partition, err := ext.newPartition()
+		require.NoError(t, err)
+		return partition
+	}
+	partition := newPartition()
+	partition.nextID = 2
+	partition.acks.Add(1, ackEntry{created: 10})
+	partition.acks.Add(2, ackEntry{acked: true, created: 20})
+	data := encodePartition(partition)
+
+	decoded := newPartition()
+	var tracked []ackEntry
+	require.NoError(t, decodePartition(data, decoded, func(entry ackEntry) { tracked = append(tracked, entry) }))
+	require.Equal(t, uint64(2), decoded.nextID)
+	require.Equal(t, []ackEntry{{created: 10}, {acked: true, created: 20}}, tracked)
+
+	require.ErrorIs(t, decodePartition(data[:len(data)-1], newPartition(), func(ackEntry) {}), errCorrupted)
+	require.ErrorContains(t, decodePartition([]byte{2}, newPartition(), func(ackEntry) {}), "unsupported snapshot version 2")
+	_, err = decodePartitionIDs([]byte{1, 5, 'a'})
+	require.ErrorIs(t, err, errCorrupted)
+}
+
+func TestNewPartitionError(t *testing.T) {
+	cfg := newTestPersistentConfig()
+	cfg.MaxNumPendingAcksPerPartition = 0
+	ext, err := newPersistentAckExtension(cfg, metadatatest.NewSettings(componenttest.NewTelemetry()))
+	require.NoError(t, err)
+	_, err = ext.newPartition()
+	require.Error(t, err)
+	require.Zero(t, ext.ProcessEvent("p1"))
+}
@@ -84,6 +84,7 @@ func (rc redisClient) Delete(ctx context.Context, key string) error {
 
 func (rc redisClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
 	p := rc.client.Pipeline()
+	queued := make([]*storage.Operation, 0, len(ops))
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Delete:
@@ -92,10 +93,35 @@ func (rc redisClient) Batch(ctx context.Context, ops ...*storage.Operation) erro
 			p.Get(ctx, rc.prefix+op.Key)
 		case storage.Set:
 			p.Set(ctx, rc.prefix+op.Key, op.Value, rc.expiration)
+		default:
+			continue
 		}
+		queued = append(queued, op)
 	}
-	_, err := p.Exec(ctx)
-	return err
+	// Exec only returns the error of the first failed command, so the error of each command is checked.
+	cmds, err := p.Exec(ctx)
+	if err != nil && len(cmds) != len(queued) {
+		return err
+	}
+	for i, cmd := range cmds {
+		op := queued[i]
+		if err := cmd.Err(); err != nil {
+			// A missing key is not an error, like in Get.
+			if op.Type == storage.Get && errors.Is(err, redis.Nil) {
+				op.Value = nil
+				continue
+			}
+			return err
+		}
+		if op.Type == storage.Get {
+			b, err := cmd.(*redis.StringCmd).Bytes()
+			if err != nil {
+				return err
+			}
+			op.Value = b
+		}
+	}
+	return nil
 }
 
 func (rc redisClient) Close(_ context.Context) error {
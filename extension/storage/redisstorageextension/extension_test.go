@@ -5,10 +5,12 @@ package redisstorageextension
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 
 	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -206,9 +208,64 @@ func TestRedisKey(t *testing.T) {
 
 		err := client.Batch(ctx, ops...)
 		require.NoError(t, err)
+		require.Equal(t, []byte("val1"), ops[1].Value)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("batch get missing key", func(t *testing.T) {
+		mockedClient, mock := redismock.NewClientMock()
+		ctx := context.Background()
+		client := redisClient{
+			client: mockedClient,
+			prefix: "test_",
+		}
+
+		ops := []*storage.Operation{
+			storage.GetOperation("key1"),
+			storage.GetOperation("key2"),
+		}
+
+		mock.ExpectGet(client.prefix + "key1").SetVal("val1")
+		mock.ExpectGet(client.prefix + "key2").RedisNil()
+
+		err := client.Batch(ctx, ops...)
+		require.NoError(t, err)
+		require.Equal(t, []byte("val1"), ops[0].Value)
+		require.Nil(t, ops[1].Value)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("batch write failure after missing key", func(t *testing.T) {
+		// Unlike redismock, Redis runs all the commands of a pipeline when one of them fails.
+		hookedClient := redis.NewClient(&redis.Options{})
+		t.Cleanup(func() { require.NoError(t, hookedClient.Close()) })
+		hookedClient.AddHook(pipelineErrorsHook{redis.Nil, errors.New("OOM command not allowed")})
+		client := redisClient{
+			client: hookedClient,
+			prefix: "test_",
+		}
+
+		err := client.Batch(context.Background(),
+			storage.GetOperation("key1"),
+			storage.SetOperation("key2", []byte("val2")),
+		)
+		require.EqualError(t, err, "OOM command not allowed")
+	})
+
+	t.Run("batch missing key on write", func(t *testing.T) {
+		mockedClient, mock := redismock.NewClientMock()
+		ctx := context.Background()
+		client := redisClient{
+			client: mockedClient,
+			prefix: "test_",
+		}
+
+		mock.ExpectDel(client.prefix + "key1").RedisNil()
+
+		err := client.Batch(ctx, storage.DeleteOperation("key1"))
+		require.ErrorIs(t, err, redis.Nil)
+	})
+
 	t.Run("single operations", func(t *testing.T) {
 		mockedClient, mock := redismock.NewClientMock()
 		ctx := context.Background()
@@ -318,3 +375,30 @@ func newTestExtension(t *testing.T) storage.Extension {
 func newTestEntity(name string) component.ID {
 	return component.MustNewIDWithName("nop", name)
 }
+
+// pipelineErrorsHook fails the commands of a pipeline with the error at their index,
+// without sending them to Redis.
+type pipelineErrorsHook []error
+
+func (pipelineErrorsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (pipelineErrorsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return next
+}
+
+func (h pipelineErrorsHook) ProcessPipelineHook(redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(_ context.Context, cmds []redis.Cmder) error {
+		var firstErr error
+		for i, cmd := range cmds {
+			if i < len(h) && h[i] != nil {
+				cmd.SetErr(h[i])
+				if firstErr == nil {
+					firstErr = h[i]
+				}
+			}
+		}
+		return firstErr
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
@@ -45,6 +46,10 @@ func StandardDataPointFuncs() map[string]ottl.Factory[ottldatapoint.TransformCon
 	return ottlfuncs.StandardConverters[ottldatapoint.TransformContext]()
 }
 
+func StandardExemplarFuncs() map[string]ottl.Factory[ottlexemplar.TransformContext] {
+	return ottlfuncs.StandardConverters[ottlexemplar.TransformContext]()
+}
+
 func StandardScopeFuncs() map[string]ottl.Factory[ottlscope.TransformContext] {
 	return ottlfuncs.StandardConverters[ottlscope.TransformContext]()
 }
@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package conventions // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+package conventions // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/convention"
 
 // TODO: replace for semconv once https://github.com/open-telemetry/semantic-conventions/issues/1033 is closed.
 const (
@@ -37,4 +37,10 @@ const (
 
 	// AttributeGeoLocationLon represents the attribute name for the longitude.
 	AttributeGeoLocationLon = "geo.location.lon"
+
+	// AttributeASNumber represents the attribute name for the autonomous system number.
+	AttributeASNumber = "as.number"
+
+	// AttributeASOrganizationName represents the attribute name for the autonomous system organization name.
+	AttributeASOrganizationName = "as.organization.name"
 )
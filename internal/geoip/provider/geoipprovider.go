@@ -1,15 +1,15 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package provider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+package provider // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
 
 import (
 	"context"
 	"errors"
 	"net"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
-	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -35,6 +35,6 @@ type GeoIPProviderFactory interface {
 	// CreateDefaultConfig creates the default configuration for the GeoIPProvider.
 	CreateDefaultConfig() Config
 
-	// CreateGeoIPProvider creates a provider based on this config. Processor's settings are provided as an argument to initialize the logger if needed.
-	CreateGeoIPProvider(ctx context.Context, settings processor.Settings, cfg Config) (GeoIPProvider, error)
+	// CreateGeoIPProvider creates a provider based on this config. The telemetry settings of the component are provided as an argument to initialize the logger if needed.
+	CreateGeoIPProvider(ctx context.Context, settings component.TelemetrySettings, cfg Config) (GeoIPProvider, error)
 }
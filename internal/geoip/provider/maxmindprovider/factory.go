@@ -1,14 +1,14 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package maxmind // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+package maxmind // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider/maxmindprovider"
 
 import (
 	"context"
 
-	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/component"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
 )
 
 const (
@@ -27,7 +27,7 @@ func (f *Factory) CreateDefaultConfig() provider.Config {
 }
 
 // CreateGeoIPProvider creates a provider based on this config.
-func (f *Factory) CreateGeoIPProvider(_ context.Context, _ processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+func (f *Factory) CreateGeoIPProvider(_ context.Context, _ component.TelemetrySettings, cfg provider.Config) (provider.GeoIPProvider, error) {
 	maxMindConfig := cfg.(*Config)
 	return newMaxMindProvider(maxMindConfig)
 }
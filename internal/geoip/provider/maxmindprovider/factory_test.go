@@ -8,9 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"go.opentelemetry.io/collector/processor/processortest"
-
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
+	"go.opentelemetry.io/collector/component/componenttest"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -25,7 +23,7 @@ func TestCreateProvider(t *testing.T) {
 		DatabasePath: "",
 	}
 
-	provider, err := factory.CreateGeoIPProvider(context.Background(), processortest.NewNopSettings(metadata.Type), cfg)
+	provider, err := factory.CreateGeoIPProvider(context.Background(), componenttest.NewNopTelemetrySettings(), cfg)
 
 	assert.ErrorContains(t, err, "could not open geoip database")
 	assert.Nil(t, provider)
@@ -1,7 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-package maxmind // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+package maxmind // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider/maxmindprovider"
 
 import (
 	"context"
@@ -12,8 +12,8 @@ import (
 	"github.com/oschwald/geoip2-golang"
 	"go.opentelemetry.io/otel/attribute"
 
-	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
 )
 
 var (
@@ -21,6 +21,7 @@ var (
 	defaultLanguageCode = "en"
 	geoIP2CityDBType    = "GeoIP2-City"
 	geoLite2CityDBType  = "GeoLite2-City"
+	geoLite2ASNDBType   = "GeoLite2-ASN"
 
 	errUnsupportedDB = errors.New("unsupported geo IP database type")
 )
@@ -42,20 +43,24 @@ func newMaxMindProvider(cfg *Config) (*maxMindProvider, error) {
 	return &maxMindProvider{geoReader: geoReader, langCode: defaultLanguageCode}, nil
 }
 
-// Location implements provider.GeoIPProvider for MaxMind. If a non City database type is used or no metadata is found in the database, an error will be returned.
+// Location implements provider.GeoIPProvider for MaxMind. If a non City or ASN database type is used or no metadata is found in the database, an error will be returned.
 func (g *maxMindProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	var attrs *[]attribute.KeyValue
+	var err error
 	switch g.geoReader.Metadata().DatabaseType {
 	case geoIP2CityDBType, geoLite2CityDBType:
-		attrs, err := g.cityAttributes(ipAddress)
-		if err != nil {
-			return attribute.Set{}, err
-		} else if len(*attrs) == 0 {
-			return attribute.Set{}, provider.ErrNoMetadataFound
-		}
-		return attribute.NewSet(*attrs...), nil
+		attrs, err = g.cityAttributes(ipAddress)
+	case geoLite2ASNDBType:
+		attrs, err = g.asnAttributes(ipAddress)
 	default:
 		return attribute.Set{}, fmt.Errorf("%w type: %s", errUnsupportedDB, g.geoReader.Metadata().DatabaseType)
 	}
+	if err != nil {
+		return attribute.Set{}, err
+	} else if len(*attrs) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attribute.NewSet(*attrs...), nil
 }
 
 // Close unmaps the geo database file from virtual memory and returns the
@@ -109,3 +114,22 @@ func (g *maxMindProvider) cityAttributes(ipAddress net.IP) (*[]attribute.KeyValu
 
 	return &attributes, err
 }
+
+// asnAttributes returns a list of key-values containing the autonomous system metadata associated to the provided IP. If an invalid or nil IP is provided, an error is returned.
+func (g *maxMindProvider) asnAttributes(ipAddress net.IP) (*[]attribute.KeyValue, error) {
+	attributes := make([]attribute.KeyValue, 0, 2)
+
+	asn, err := g.geoReader.ASN(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if asn.AutonomousSystemNumber != 0 {
+		attributes = append(attributes, attribute.Int64(conventions.AttributeASNumber, int64(asn.AutonomousSystemNumber)))
+	}
+	if asn.AutonomousSystemOrganization != "" {
+		attributes = append(attributes, attribute.String(conventions.AttributeASOrganizationName, asn.AutonomousSystemOrganization))
+	}
+
+	return &attributes, nil
+}
@@ -14,8 +14,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 
-	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider/maxmindprovider/testdata"
 )
 
 func TestInvalidNewProvider(t *testing.T) {
@@ -93,6 +93,21 @@ func TestProviderLocation(t *testing.T) {
 				attribute.Float64(conventions.AttributeGeoLocationLon, 1),
 			}...),
 		},
+		{
+			name:         "autonomous system attributes using GeoLite2-ASN database",
+			sourceIP:     net.IPv4(1, 128, 0, 1),
+			testDatabase: "GeoLite2-ASN-Test.mmdb",
+			expectedAttributes: attribute.NewSet([]attribute.KeyValue{
+				attribute.Int64(conventions.AttributeASNumber, 1221),
+				attribute.String(conventions.AttributeASOrganizationName, "Telstra Pty Ltd"),
+			}...),
+		},
+		{
+			name:           "no IP metadata in GeoLite2-ASN database",
+			sourceIP:       net.IPv4(1, 2, 3, 4),
+			testDatabase:   "GeoLite2-ASN-Test.mmdb",
+			expectedErrMsg: "no geo IP metadata found",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package provider // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// getFactory retrieves the GeoIPProviderFactory for the given key, which is either a provider type or
+// a provider type and a name separated by a "/", e.g. "maxmind/asn".
+// It returns the factory and a boolean indicating whether the factory was found.
+func getFactory(key string, factories map[string]GeoIPProviderFactory) (GeoIPProviderFactory, bool) {
+	providerType, _, _ := strings.Cut(key, "/")
+	factory, ok := factories[providerType]
+	return factory, ok
+}
+
+// UnmarshalConfigs loads the configuration of the providers defined in the given section. The configuration of each
+// provider is created by the factory of the provider type, which is the part of the key before the optional "/".
+func UnmarshalConfigs(providersSection *confmap.Conf, factories map[string]GeoIPProviderFactory) (map[string]Config, error) {
+	providers := map[string]Config{}
+
+	// loop through all defined providers and load their configuration
+	for key := range providersSection.ToStringMap() {
+		factory, ok := getFactory(key, factories)
+		if !ok {
+			return nil, fmt.Errorf("invalid provider key: %s", key)
+		}
+
+		providerCfg := factory.CreateDefaultConfig()
+		providerSection, err := providersSection.Sub(key)
+		if err != nil {
+			return nil, err
+		}
+		err = providerSection.Unmarshal(providerCfg)
+		if err != nil {
+			return nil, fmt.Errorf("error reading settings for provider type %q: %w", key, err)
+		}
+
+		providers[key] = providerCfg
+	}
+
+	return providers, nil
+}
+
+// ValidateConfigs validates the configuration of all the providers.
+func ValidateConfigs(providers map[string]Config) error {
+	for providerID, providerConfig := range providers {
+		if err := providerConfig.Validate(); err != nil {
+			return fmt.Errorf("error validating provider %s: %w", providerID, err)
+		}
+	}
+	return nil
+}
+
+// CreateGeoIPProviders creates a list of GeoIPProvider instances based on the provided configuration and providers factories.
+// The providers must be closed by the caller.
+func CreateGeoIPProviders(
+	ctx context.Context,
+	set component.TelemetrySettings,
+	providers map[string]Config,
+	factories map[string]GeoIPProviderFactory,
+) ([]GeoIPProvider, error) {
+	geoIPProviders := make([]GeoIPProvider, 0, len(providers))
+
+	for key, cfg := range providers {
+		factory, ok := getFactory(key, factories)
+		if !ok {
+			return nil, fmt.Errorf("geoIP provider factory not found for key: %q", key)
+		}
+
+		provider, err := factory.CreateGeoIPProvider(ctx, set, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create provider for key %q: %w", key, err)
+		}
+
+		geoIPProviders = append(geoIPProviders, provider)
+	}
+
+	return geoIPProviders, nil
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type configMock struct {
+	DatabasePath string `mapstructure:"database_path"`
+}
+
+func (cfg *configMock) Validate() error {
+	if cfg.DatabasePath == "" {
+		return errors.New("a database path must be provided")
+	}
+	return nil
+}
+
+type factoryMock struct{}
+
+func (*factoryMock) CreateDefaultConfig() Config {
+	return &configMock{}
+}
+
+func (*factoryMock) CreateGeoIPProvider(_ context.Context, _ component.TelemetrySettings, cfg Config) (GeoIPProvider, error) {
+	if cfg.(*configMock).DatabasePath == "invalid" {
+		return nil, errors.New("could not open database")
+	}
+	return &providerMock{}, nil
+}
+
+type providerMock struct{}
+
+func (*providerMock) Location(context.Context, net.IP) (attribute.Set, error) {
+	return attribute.Set{}, ErrNoMetadataFound
+}
+
+func (*providerMock) Close(context.Context) error {
+	return nil
+}
+
+var testFactories = map[string]GeoIPProviderFactory{"mock": &factoryMock{}}
+
+func TestUnmarshalConfigs(t *testing.T) {
+	providers, err := UnmarshalConfigs(confmap.NewFromStringMap(map[string]any{
+		"mock/city": map[string]any{"database_path": "/tmp/city.mmdb"},
+		"mock/asn":  map[string]any{"database_path": "/tmp/asn.mmdb"},
+	}), testFactories)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Config{
+		"mock/city": &configMock{DatabasePath: "/tmp/city.mmdb"},
+		"mock/asn":  &configMock{DatabasePath: "/tmp/asn.mmdb"},
+	}, providers)
+	assert.NoError(t, ValidateConfigs(providers))
+
+	assert.ErrorContains(t, ValidateConfigs(map[string]Config{"mock": &configMock{}}), "error validating provider mock")
+
+	_, err = UnmarshalConfigs(confmap.NewFromStringMap(map[string]any{"unknown": map[string]any{}}), testFactories)
+	assert.EqualError(t, err, "invalid provider key: unknown")
+
+	_, err = UnmarshalConfigs(confmap.NewFromStringMap(map[string]any{"mock": map[string]any{"unknown": "value"}}), testFactories)
+	assert.ErrorContains(t, err, `error reading settings for provider type "mock"`)
+}
+
+func TestCreateGeoIPProviders(t *testing.T) {
+	providers, err := CreateGeoIPProviders(context.Background(), componenttest.NewNopTelemetrySettings(), map[string]Config{
+		"mock/city": &configMock{DatabasePath: "/tmp/city.mmdb"},
+		"mock/asn":  &configMock{DatabasePath: "/tmp/asn.mmdb"},
+	}, testFactories)
+	require.NoError(t, err)
+	require.Len(t, providers, 2)
+	_, err = providers[0].Location(context.Background(), net.IPv4(1, 2, 3, 4))
+	assert.ErrorIs(t, err, ErrNoMetadataFound)
+
+	_, err = CreateGeoIPProviders(context.Background(), componenttest.NewNopTelemetrySettings(), map[string]Config{
+		"unknown": &configMock{},
+	}, testFactories)
+	assert.EqualError(t, err, `geoIP provider factory not found for key: "unknown"`)
+
+	_, err = CreateGeoIPProviders(context.Background(), componenttest.NewNopTelemetrySettings(), map[string]Config{
+		"mock": &configMock{DatabasePath: "invalid"},
+	}, testFactories)
+	assert.EqualError(t, err, `failed to create provider for key "mock": could not open database`)
+}
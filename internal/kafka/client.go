@@ -102,6 +102,29 @@ func NewSaramaSyncProducer(
 	return sarama.NewSyncProducer(clientConfig.Brokers, saramaConfig)
 }
 
+// NewSaramaTransactionalProducer returns a new synchronous transactional Kafka producer with
+// the given configuration and transactional ID. The producer is always idempotent, regardless
+// of the producer configuration.
+//
+// Creating the producer fences the previous producers with the same transactional ID, and
+// completes their pending transactions.
+func NewSaramaTransactionalProducer(
+	ctx context.Context,
+	clientConfig configkafka.ClientConfig,
+	producerConfig configkafka.ProducerConfig,
+	producerTimeout time.Duration,
+	transactionalID string,
+) (sarama.SyncProducer, error) {
+	saramaConfig, err := newSaramaClientConfig(ctx, clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	producerConfig.Idempotent = true
+	setSaramaProducerConfig(saramaConfig, producerConfig, producerTimeout)
+	saramaConfig.Producer.Transaction.ID = transactionalID
+	return sarama.NewSyncProducer(clientConfig.Brokers, saramaConfig)
+}
+
 func setSaramaProducerConfig(
 	out *sarama.Config,
 	producerConfig configkafka.ProducerConfig,
@@ -115,6 +138,11 @@ func setSaramaProducerConfig(
 	out.Producer.Timeout = producerTimeout
 	out.Producer.Compression = saramaCompressionCodecs[producerConfig.Compression]
 	out.Producer.CompressionLevel = convertToSaramaCompressionLevel(producerConfig.CompressionParams.Level)
+	if producerConfig.Idempotent {
+		out.Producer.Idempotent = true
+		// Sarama only supports a single in-flight request per broker for idempotent producers.
+		out.Net.MaxOpenRequests = 1
+	}
 }
 
 // newSaramaClientConfig returns a Sarama client config, based on the given config.
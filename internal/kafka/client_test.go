@@ -544,6 +544,18 @@ func TestSetSaramaProducerConfig_Compression(t *testing.T) {
 	}
 }
 
+func TestSetSaramaProducerConfig_Idempotent(t *testing.T) {
+	config := configkafka.NewDefaultProducerConfig()
+	config.RequiredAcks = configkafka.WaitForAll
+	config.Idempotent = true
+
+	saramaConfig := sarama.NewConfig()
+	setSaramaProducerConfig(saramaConfig, config, time.Millisecond)
+	assert.True(t, saramaConfig.Producer.Idempotent)
+	assert.Equal(t, 1, saramaConfig.Net.MaxOpenRequests)
+	assert.NoError(t, saramaConfig.Validate())
+}
+
 func TestNewSaramaClientConfigWithAWSMSKIAM(t *testing.T) {
 	// Test case for AWS_MSK_IAM_OAUTHBEARER mechanism
 	clientConfig := configkafka.ClientConfig{
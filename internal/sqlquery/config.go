@@ -42,6 +42,7 @@ type Query struct {
 	SQL                string      `mapstructure:"sql"`
 	Metrics            []MetricCfg `mapstructure:"metrics"`
 	Logs               []LogsCfg   `mapstructure:"logs"`
+	Traces             []TracesCfg `mapstructure:"traces"`
 	TrackingColumn     string      `mapstructure:"tracking_column"`
 	TrackingStartValue string      `mapstructure:"tracking_start_value"`
 }
@@ -51,14 +52,19 @@ func (q Query) Validate() error {
 	if q.SQL == "" {
 		errs = append(errs, errors.New("'query.sql' cannot be empty"))
 	}
-	if len(q.Logs) == 0 && len(q.Metrics) == 0 {
-		errs = append(errs, errors.New("at least one of 'query.logs' and 'query.metrics' must not be empty"))
+	if len(q.Logs) == 0 && len(q.Metrics) == 0 && len(q.Traces) == 0 {
+		errs = append(errs, errors.New("at least one of 'query.logs', 'query.metrics' and 'query.traces' must not be empty"))
 	}
 	for _, logs := range q.Logs {
 		if err := logs.Validate(); err != nil {
 			errs = append(errs, err)
 		}
 	}
+	for _, traces := range q.Traces {
+		if err := traces.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	for _, metric := range q.Metrics {
 		if err := metric.Validate(); err != nil {
 			errs = append(errs, err)
@@ -80,6 +86,61 @@ func (config LogsCfg) Validate() error {
 	return errors.Join(errs...)
 }
 
+type TracesCfg struct {
+	TraceIDColumn       string   `mapstructure:"trace_id_column"`
+	SpanIDColumn        string   `mapstructure:"span_id_column"`
+	ParentSpanIDColumn  string   `mapstructure:"parent_span_id_column"`
+	NameColumn          string   `mapstructure:"name_column"`
+	StartTsColumn       string   `mapstructure:"start_ts_column"`
+	EndTsColumn         string   `mapstructure:"end_ts_column"`
+	StatusCodeColumn    string   `mapstructure:"status_code_column"`
+	StatusMessageColumn string   `mapstructure:"status_message_column"`
+	Kind                SpanKind `mapstructure:"kind"`
+	AttributeColumns    []string `mapstructure:"attribute_columns"`
+}
+
+func (config TracesCfg) Validate() error {
+	var errs []error
+	if config.TraceIDColumn == "" {
+		errs = append(errs, errors.New("'trace_id_column' must not be empty"))
+	}
+	if config.SpanIDColumn == "" {
+		errs = append(errs, errors.New("'span_id_column' must not be empty"))
+	}
+	if config.NameColumn == "" {
+		errs = append(errs, errors.New("'name_column' must not be empty"))
+	}
+	if config.StartTsColumn == "" {
+		errs = append(errs, errors.New("'start_ts_column' must not be empty"))
+	}
+	if config.EndTsColumn == "" {
+		errs = append(errs, errors.New("'end_ts_column' must not be empty"))
+	}
+	if err := config.Kind.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+type SpanKind string
+
+const (
+	SpanKindUnspecified SpanKind = ""
+	SpanKindInternal    SpanKind = "internal"
+	SpanKindServer      SpanKind = "server"
+	SpanKindClient      SpanKind = "client"
+	SpanKindProducer    SpanKind = "producer"
+	SpanKindConsumer    SpanKind = "consumer"
+)
+
+func (k SpanKind) Validate() error {
+	switch k {
+	case SpanKindUnspecified, SpanKindInternal, SpanKindServer, SpanKindClient, SpanKindProducer, SpanKindConsumer:
+		return nil
+	}
+	return fmt.Errorf("traces config has unsupported kind: '%s'", k)
+}
+
 type MetricCfg struct {
 	MetricName       string            `mapstructure:"metric_name"`
 	ValueColumn      string            `mapstructure:"value_column"`
@@ -192,6 +192,11 @@ type ProducerConfig struct {
 	// broker request. Defaults to 0 for unlimited. Similar to
 	// `queue.buffering.max.messages` in the JVM producer.
 	FlushMaxMessages int `mapstructure:"flush_max_messages"`
+
+	// Idempotent enables the idempotent producer, which ensures that retried produce
+	// requests do not write duplicate messages. It requires required_acks to be
+	// 'all' (-1). Similar to `enable.idempotence` in the JVM producer. (default false)
+	Idempotent bool `mapstructure:"idempotent"`
 }
 
 func NewDefaultProducerConfig() ProducerConfig {
@@ -204,6 +209,9 @@ func NewDefaultProducerConfig() ProducerConfig {
 }
 
 func (c ProducerConfig) Validate() error {
+	if c.Idempotent && c.RequiredAcks != WaitForAll {
+		return fmt.Errorf("idempotent producer requires required_acks to be 'all' (-1). configured value is %d", c.RequiredAcks)
+	}
 	switch c.Compression {
 	case "none", "gzip", "snappy", "lz4", "zstd":
 		ct := configcompression.Type(c.Compression)
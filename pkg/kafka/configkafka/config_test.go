@@ -201,6 +201,14 @@ func TestProducerConfig(t *testing.T) {
 				return cfg
 			}(),
 		},
+		"idempotent": {
+			expected: func() ProducerConfig {
+				cfg := NewDefaultProducerConfig()
+				cfg.RequiredAcks = WaitForAll
+				cfg.Idempotent = true
+				return cfg
+			}(),
+		},
 
 		// Invalid configurations
 		"invalid_compression": {
@@ -209,6 +217,9 @@ func TestProducerConfig(t *testing.T) {
 		"invalid_required_acks": {
 			expectedErr: "required_acks: expected 'all' (-1), 0, or 1; configured value is 3",
 		},
+		"invalid_idempotent": {
+			expectedErr: "idempotent producer requires required_acks to be 'all' (-1). configured value is 1",
+		},
 	})
 }
 
@@ -285,9 +285,53 @@ func (p *ottlValueComparator) comparePSlice(a pcommon.Slice, b any, op compareOp
 	}
 }
 
+// compareIn reports whether a is equal to one of the items of b, which must be a list.
+// If b is not a list, a is never considered a member of it.
+func (p *ottlValueComparator) compareIn(a any, b any) bool {
+	switch v := b.(type) {
+	case []any:
+		for _, item := range v {
+			if p.compare(a, item, eq) {
+				return true
+			}
+		}
+	case pcommon.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if p.compare(a, v.At(i).AsRaw(), eq) {
+				return true
+			}
+		}
+	case []string:
+		return containsItem(p, a, v)
+	case []int64:
+		return containsItem(p, a, v)
+	case []float64:
+		return containsItem(p, a, v)
+	case []bool:
+		return containsItem(p, a, v)
+	}
+	return false
+}
+
+func containsItem[T any](p *ottlValueComparator, a any, items []T) bool {
+	for _, item := range items {
+		if p.compare(a, item, eq) {
+			return true
+		}
+	}
+	return false
+}
+
 // a and b are the return values from a Getter; we try to compare them
 // according to the given operator.
 func (p *ottlValueComparator) compare(a any, b any, op compareOp) bool {
+	// Membership operators don't compare b itself but its items.
+	switch op {
+	case in:
+		return p.compareIn(a, b)
+	case notIn:
+		return !p.compareIn(a, b)
+	}
 	// nils are equal to each other and never equal to anything else,
 	// so if they're both nil, report equality.
 	if a == nil && b == nil {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
@@ -185,6 +186,42 @@ func Test_comparison(t *testing.T) {
 	}
 }
 
+func Test_comparisonIn(t *testing.T) {
+	psl := pcommon.NewSlice()
+	psl.AppendEmpty().SetStr("1")
+	psl.AppendEmpty().SetInt(2)
+
+	tests := []struct {
+		name string
+		a    any
+		b    any
+		want bool
+	}{
+		{"string in slice", sa, []any{sb, sa}, true},
+		{"string not in slice", sa, []any{sb}, false},
+		{"int64 in slice of float64", i64a, []any{f64a}, true},
+		{"string in pslice", sa, psl, true},
+		{"int64 in pslice", i64b, psl, true},
+		{"float64 not in pslice", f64a, psl, false},
+		{"string in string slice", sa, []string{sa, sb}, true},
+		{"int64 in int64 slice", i64a, []int64{i64b}, false},
+		{"float64 in float64 slice", f64b, []float64{f64a, f64b}, true},
+		{"bool in bool slice", ta, []bool{ta}, true},
+		{"nil in slice", nil, []any{sa, nil}, true},
+		{"map in slice", m1, []any{m2, m1}, true},
+		{"empty slice", sa, []any{}, false},
+		{"not a slice", sa, sa, false},
+		{"nil list", sa, nil, false},
+	}
+	comp := NewValueComparator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, comp.compare(tt.a, tt.b, in))
+			assert.Equal(t, !tt.want, comp.compare(tt.a, tt.b, notIn))
+		})
+	}
+}
+
 // Benchmarks -- these benchmarks compare the performance of comparisons of a variety of data types.
 // It's not attempting to be exhaustive, but again, it hits most of the major types and combinations.
 // The summary is that they're pretty fast; all the calls to compare are 12 ns/op or less on a 2019 intel
@@ -292,6 +292,7 @@ func Test_NewPriorityContextInferrer_InvalidCondition(t *testing.T) {
 func Test_NewPriorityContextInferrer_DefaultPriorityList(t *testing.T) {
 	expectedPriority := []string{
 		"log",
+		"exemplar",
 		"datapoint",
 		"metric",
 		"spanevent",
@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxexemplar_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxexemplar"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	traceID  = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	traceID2 = [16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	spanID   = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	spanID2  = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func TestPathGetSetter(t *testing.T) {
+	refExemplar := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	tests := []struct {
+		name              string
+		path              ottl.Path[*testContext]
+		orig              any
+		newVal            any
+		expectSetterError bool
+		modified          func(exemplar pmetric.Exemplar)
+	}{
+		{
+			name: "time_unix_nano",
+			path: &pathtest.Path[*testContext]{
+				N: "time_unix_nano",
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "time",
+			path: &pathtest.Path[*testContext]{
+				N: "time",
+			},
+			orig:   time.Date(1970, 1, 1, 0, 0, 0, 100000000, time.UTC),
+			newVal: time.Date(1970, 1, 1, 0, 0, 0, 200000000, time.UTC),
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "value_double",
+			path: &pathtest.Path[*testContext]{
+				N: "value_double",
+			},
+			orig:   1.1,
+			newVal: 2.2,
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.SetDoubleValue(2.2)
+			},
+		},
+		{
+			name: "value_int",
+			path: &pathtest.Path[*testContext]{
+				N: "value_int",
+			},
+			orig:   int64(0),
+			newVal: int64(3),
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.SetIntValue(3)
+			},
+		},
+		{
+			name: "filtered_attributes",
+			path: &pathtest.Path[*testContext]{
+				N: "filtered_attributes",
+			},
+			orig:   refExemplar.FilteredAttributes(),
+			newVal: newAttrs,
+			modified: func(exemplar pmetric.Exemplar) {
+				newAttrs.CopyTo(exemplar.FilteredAttributes())
+			},
+		},
+		{
+			name: "filtered_attributes string",
+			path: &pathtest.Path[*testContext]{
+				N: "filtered_attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("user.email"),
+					},
+				},
+			},
+			orig:   "user@example.com",
+			newVal: "redacted",
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.FilteredAttributes().PutStr("user.email", "redacted")
+			},
+		},
+		{
+			name: "filtered_attributes nested",
+			path: &pathtest.Path[*testContext]{
+				N: "filtered_attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("slice"),
+					},
+					&pathtest.Key[*testContext]{
+						I: ottltest.Intp(0),
+					},
+				},
+			},
+			orig:   "one",
+			newVal: "two",
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.FilteredAttributes().PutEmptySlice("slice").AppendEmpty().SetStr("two")
+			},
+		},
+		{
+			name: "trace_id",
+			path: &pathtest.Path[*testContext]{
+				N: "trace_id",
+			},
+			orig:   pcommon.TraceID(traceID),
+			newVal: pcommon.TraceID(traceID2),
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "trace_id string",
+			path: &pathtest.Path[*testContext]{
+				N: "trace_id",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "string",
+				},
+			},
+			orig:   "0102030405060708090a0b0c0d0e0f10",
+			newVal: "100f0e0d0c0b0a090807060504030201",
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "span_id",
+			path: &pathtest.Path[*testContext]{
+				N: "span_id",
+			},
+			orig:   pcommon.SpanID(spanID),
+			newVal: pcommon.SpanID(spanID2),
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "span_id string",
+			path: &pathtest.Path[*testContext]{
+				N: "span_id",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "string",
+				},
+			},
+			orig:   "0102030405060708",
+			newVal: "0807060504030201",
+			modified: func(exemplar pmetric.Exemplar) {
+				exemplar.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "span_id string invalid",
+			path: &pathtest.Path[*testContext]{
+				N: "span_id",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "string",
+				},
+			},
+			orig:              "0102030405060708",
+			newVal:            "invalid",
+			expectSetterError: true,
+		},
+	}
+	// Copy all tests cases and sets the path.Context value to the generated ones.
+	// It ensures all exiting field access also work when the path context is set.
+	for _, tt := range slices.Clone(tests) {
+		testWithContext := tt
+		testWithContext.name = "with_path_context:" + tt.name
+		pathWithContext := *tt.path.(*pathtest.Path[*testContext])
+		pathWithContext.C = ctxexemplar.Name
+		testWithContext.path = ottl.Path[*testContext](&pathWithContext)
+		tests = append(tests, testWithContext)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ctxexemplar.PathGetSetter(tt.path)
+			assert.NoError(t, err)
+
+			exemplar := createTelemetry()
+
+			tCtx := newTestContext(exemplar)
+
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			if tt.expectSetterError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			exExemplar := createTelemetry()
+			tt.modified(exExemplar)
+			assert.Equal(t, exExemplar, exemplar)
+		})
+	}
+}
+
+func TestPathGetSetter_InvalidPath(t *testing.T) {
+	_, err := ctxexemplar.PathGetSetter[*testContext](&pathtest.Path[*testContext]{
+		N: "trace_id",
+		NextPath: &pathtest.Path[*testContext]{
+			N: "bytes",
+		},
+	})
+	assert.ErrorContains(t, err, `segment "bytes" from path "bytes" is not a valid path`)
+
+	_, err = ctxexemplar.PathGetSetter[*testContext](&pathtest.Path[*testContext]{N: "attributes"})
+	assert.Error(t, err)
+}
+
+func createTelemetry() pmetric.Exemplar {
+	exemplar := pmetric.NewExemplar()
+	exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	exemplar.SetDoubleValue(1.1)
+	exemplar.SetTraceID(traceID)
+	exemplar.SetSpanID(spanID)
+	exemplar.FilteredAttributes().PutStr("user.email", "user@example.com")
+	exemplar.FilteredAttributes().PutEmptySlice("slice").AppendEmpty().SetStr("one")
+	return exemplar
+}
+
+type testContext struct {
+	exemplar pmetric.Exemplar
+}
+
+func (l *testContext) GetExemplar() pmetric.Exemplar {
+	return l.exemplar
+}
+
+func newTestContext(exemplar pmetric.Exemplar) *testContext {
+	return &testContext{exemplar: exemplar}
+}
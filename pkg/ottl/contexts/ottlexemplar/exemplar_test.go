@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlexemplar
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxexemplar"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_newPathGetSetter_Cache(t *testing.T) {
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		orig     any
+		newVal   any
+		modified func(cache pcommon.Map)
+	}{
+		{
+			name: "cache",
+			path: &pathtest.Path[TransformContext]{
+				N: "cache",
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "cache access",
+			path: &pathtest.Path[TransformContext]{
+				N: "cache",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("temp"),
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "new value",
+			modified: func(cache pcommon.Map) {
+				cache.PutStr("temp", "new value")
+			},
+		},
+	}
+	// Copy all tests cases and sets the path.Context value to the generated ones.
+	// It ensures all exiting field access also work when the path context is set.
+	for _, tt := range slices.Clone(tests) {
+		testWithContext := tt
+		testWithContext.name = "with_path_context:" + tt.name
+		pathWithContext := *tt.path.(*pathtest.Path[TransformContext])
+		pathWithContext.C = ctxexemplar.Name
+		testWithContext.path = ottl.Path[TransformContext](&pathWithContext)
+		tests = append(tests, testWithContext)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCache := pcommon.NewMap()
+			cacheGetter := func(_ TransformContext) pcommon.Map {
+				return testCache
+			}
+			accessor, err := pathExpressionParser(cacheGetter)(tt.path)
+			assert.NoError(t, err)
+
+			dataPoint := pmetric.NewNumberDataPoint()
+			ctx := NewTransformContext(dataPoint.Exemplars().AppendEmpty(), dataPoint, pmetric.NewMetric(), pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics())
+
+			got, err := accessor.Get(context.Background(), ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), ctx, tt.newVal)
+			assert.NoError(t, err)
+
+			exCache := pcommon.NewMap()
+			tt.modified(exCache)
+
+			assert.Equal(t, exCache, testCache)
+		})
+	}
+}
+
+func Test_newPathGetSetter_Exemplar(t *testing.T) {
+	dataPoint := pmetric.NewHistogramDataPoint()
+	exemplar := dataPoint.Exemplars().AppendEmpty()
+	exemplar.SetDoubleValue(1.5)
+	exemplar.FilteredAttributes().PutStr("user.id", "42")
+	ctx := NewTransformContext(exemplar, dataPoint, pmetric.NewMetric(), pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics())
+
+	accessor, err := pathExpressionParser(getCache)(&pathtest.Path[TransformContext]{C: "exemplar", N: "filtered_attributes", KeySlice: []ottl.Key[TransformContext]{
+		&pathtest.Key[TransformContext]{
+			S: ottltest.Strp("user.id"),
+		},
+	}})
+	require.NoError(t, err)
+	got, err := accessor.Get(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "42", got)
+
+	accessor, err = pathExpressionParser(getCache)(&pathtest.Path[TransformContext]{N: "value_double"})
+	require.NoError(t, err)
+	got, err = accessor.Get(context.Background(), ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, got)
+}
+
+func Test_ParseEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		want ottl.Enum
+	}{
+		{
+			name: "AGGREGATION_TEMPORALITY_DELTA",
+			want: ottl.Enum(pmetric.AggregationTemporalityDelta),
+		},
+		{
+			name: "FLAG_NO_RECORDED_VALUE",
+			want: 1,
+		},
+		{
+			name: "METRIC_DATA_TYPE_HISTOGRAM",
+			want: ottl.Enum(pmetric.MetricTypeHistogram),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp(tt.name)))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, *actual)
+		})
+	}
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	tests := []struct {
+		name       string
+		enumSymbol *ottl.EnumSymbol
+	}{
+		{
+			name:       "unknown enum symbol",
+			enumSymbol: (*ottl.EnumSymbol)(ottltest.Strp("not an enum")),
+		},
+		{
+			name:       "nil enum symbol",
+			enumSymbol: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum(tt.enumSymbol)
+			assert.Error(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func Test_newPathGetSetter_higherContextPath(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("foo", "bar")
+
+	metric := pmetric.NewMetric()
+	metric.SetName("metric")
+
+	instrumentationScope := pcommon.NewInstrumentationScope()
+	instrumentationScope.SetName("instrumentation_scope")
+
+	dataPoint := pmetric.NewNumberDataPoint()
+	dataPoint.Attributes().PutStr("dp", "value")
+
+	ctx := NewTransformContext(
+		dataPoint.Exemplars().AppendEmpty(),
+		dataPoint,
+		metric,
+		pmetric.NewMetricSlice(),
+		instrumentationScope,
+		resource,
+		pmetric.NewScopeMetrics(),
+		pmetric.NewResourceMetrics())
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		expected any
+	}{
+		{
+			name: "resource",
+			path: &pathtest.Path[TransformContext]{C: "", N: "resource", NextPath: &pathtest.Path[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("foo"),
+					},
+				},
+			}},
+			expected: "bar",
+		},
+		{
+			name: "resource with context",
+			path: &pathtest.Path[TransformContext]{C: "resource", N: "attributes", KeySlice: []ottl.Key[TransformContext]{
+				&pathtest.Key[TransformContext]{
+					S: ottltest.Strp("foo"),
+				},
+			}},
+			expected: "bar",
+		},
+		{
+			name:     "metric",
+			path:     &pathtest.Path[TransformContext]{N: "metric", NextPath: &pathtest.Path[TransformContext]{N: "name"}},
+			expected: metric.Name(),
+		},
+		{
+			name:     "metric with context",
+			path:     &pathtest.Path[TransformContext]{C: "metric", N: "name"},
+			expected: metric.Name(),
+		},
+		{
+			name: "datapoint",
+			path: &pathtest.Path[TransformContext]{N: "datapoint", NextPath: &pathtest.Path[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("dp"),
+					},
+				},
+			}},
+			expected: "value",
+		},
+		{
+			name: "datapoint with context",
+			path: &pathtest.Path[TransformContext]{C: "datapoint", N: "attributes", KeySlice: []ottl.Key[TransformContext]{
+				&pathtest.Key[TransformContext]{
+					S: ottltest.Strp("dp"),
+				},
+			}},
+			expected: "value",
+		},
+		{
+			name:     "instrumentation_scope",
+			path:     &pathtest.Path[TransformContext]{N: "instrumentation_scope", NextPath: &pathtest.Path[TransformContext]{N: "name"}},
+			expected: instrumentationScope.Name(),
+		},
+		{
+			name:     "instrumentation_scope with context",
+			path:     &pathtest.Path[TransformContext]{C: "instrumentation_scope", N: "name"},
+			expected: instrumentationScope.Name(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := pathExpressionParser(getCache)(tt.path)
+			require.NoError(t, err)
+
+			got, err := accessor.Get(context.Background(), ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_NewParser_EnablePathContextNames(t *testing.T) {
+	parser, err := NewParser(nil, componenttest.NewNopTelemetrySettings(), EnablePathContextNames())
+	require.NoError(t, err)
+
+	_, err = parser.ParseCondition(`exemplar.trace_id.string == "" and datapoint.attributes["a"] == nil and metric.name == "m"`)
+	assert.NoError(t, err)
+
+	_, err = parser.ParseCondition(`trace_id.string == ""`)
+	assert.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -715,6 +716,16 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], "pass") where IsInCIDR("10.1.2.3", ["10.0.0.0/8", "192.168.0.0/16"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsInCIDR("172.16.1.1", ["10.0.0.0/8"])`,
+			want:      func(_ ottllog.TransformContext) {},
+		},
 		{
 			statement: `set(attributes["test"], "pass") where IsMatch("aa123bb", "\\d{3}")`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -962,6 +973,105 @@ func Test_e2e_converters(t *testing.T) {
 				s.AppendEmpty().SetInt(3)
 			},
 		},
+		{
+			statement: `set(attributes["test"], ForEach(Split(attributes["flags"], "|"), ToLowerCase($item)))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetStr("b")
+				s.AppendEmpty().SetStr("c")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ForEach([1, 2, 3], $item * 2 + $index))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetInt(2)
+				s.AppendEmpty().SetInt(5)
+				s.AppendEmpty().SetInt(8)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ForEach(attributes["things"], $item["name"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("foo")
+				s.AppendEmpty().SetStr("bar")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ForEach([[1, 2], [3]], Reduce($item, 0, $acc + $item)))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetInt(3)
+				s.AppendEmpty().SetInt(3)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ForEach(attributes["flags"], $item))`,
+			want:      func(_ ottllog.TransformContext) {},
+			errMsg:    "ForEach with unsupported type: 'string'. Target is not a list",
+		},
+		{
+			statement: `set(attributes["test"], ForEach([1, 2, 3], $item, 2))`,
+			want:      func(_ ottllog.TransformContext) {},
+			errMsg:    "ForEach target has 3 items, more than the limit of 2",
+		},
+		{
+			statement: `set(attributes["test"], ForEach([1, 2, 3], $undefined))`,
+			want:      func(_ ottllog.TransformContext) {},
+			errMsg:    "variable $undefined is not defined",
+		},
+		{
+			statement: `set(attributes["test"], Filter(Split(attributes["flags"], "|"), IsMatch($item, "[AC]")))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("A")
+				s.AppendEmpty().SetStr("C")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(attributes["foo"], IsString($value)))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("bar", "pass")
+				m.PutStr("flags", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(Split(attributes["flags"], "|"), $item != "B"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("A")
+				s.AppendEmpty().SetStr("C")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(attributes["foo"], IsString($value) and $key != "bar"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("flags", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(attributes["things"], $item["name"]))`,
+			want:      func(_ ottllog.TransformContext) {},
+			errMsg:    "expected bool but got string",
+		},
+		{
+			statement: `set(attributes["test"], MapValues({"a": "x", "b": "y"}, Concat([$key, $value], "=")))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("a", "a=x")
+				m.PutStr("b", "b=y")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Reduce(attributes["things"], 0, $acc + $item["value"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 7)
+			},
+		},
 		{
 			statement: `set(attributes["test"], Sort([Double(1.5), Double(10.2), Double(2.3), Double(0.5)]))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -1298,6 +1408,46 @@ func Test_e2e_ottl_features(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			name:      "where clause with in operator",
+			statement: `set(attributes["test"], "pass") where attributes["http.method"] in ["get", "post"]`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			name:      "where clause with not in operator",
+			statement: `set(attributes["test"], "pass") where attributes["http.method"] not in ["get", "post"]`,
+			want:      func(_ ottllog.TransformContext) {},
+		},
+		{
+			name:      "where clause with negated in operator",
+			statement: `set(attributes["test"], "pass") where not attributes["http.method"] in ["put", "delete"]`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			name:      "where clause with in operator and numbers",
+			statement: `set(attributes["test"], "pass") where attributes["int_value"] in [0, 1.5]`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			name:      "where clause with in operator and slice path",
+			statement: `set(attributes["test"], "pass") where "looong" in attributes["array"]`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			name:      "where clause with in operator and converter",
+			statement: `set(attributes["test"], "pass") where "C" in Split(attributes["flags"], "|")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
 		{
 			name:      "composing functions",
 			statement: `merge_maps(attributes, ParseJSON("{\"json_test\":\"pass\"}"), "insert") where body == "operationA"`,
@@ -1437,6 +1587,43 @@ func Test_e2e_ottl_statement_sequence(t *testing.T) {
 	}
 }
 
+func Test_e2e_ottl_statement_sequence_variables(t *testing.T) {
+	settings := componenttest.NewNopTelemetrySettings()
+	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings)
+	require.NoError(t, err)
+
+	statements, err := parser.ParseStatements([]string{
+		`set($parsed, ParseJSON("{\"a\":\"x\",\"b\":{\"c\":1}}"))`,
+		`set(attributes["a"], $parsed["a"]) where $parsed["b"] != nil`,
+		`set(attributes["c"], $parsed["b"]["c"] + 1)`,
+		`set($parsed, "overwritten")`,
+		`set(attributes["overwritten"], $parsed)`,
+	})
+	require.NoError(t, err)
+	sequence := ottl.NewStatementSequence(statements, settings)
+
+	tCtx := constructLogTransformContext()
+	require.NoError(t, sequence.Execute(context.Background(), tCtx))
+
+	exTCtx := constructLogTransformContext()
+	exTCtx.GetLogRecord().Attributes().PutStr("a", "x")
+	exTCtx.GetLogRecord().Attributes().PutDouble("c", 2)
+	exTCtx.GetLogRecord().Attributes().PutStr("overwritten", "overwritten")
+	assert.NoError(t, plogtest.CompareResourceLogs(newResourceLogs(exTCtx), newResourceLogs(tCtx)))
+
+	// The variables are not visible outside of the execution that set them.
+	statements, err = parser.ParseStatements([]string{`set(attributes["leaked"], $parsed)`})
+	require.NoError(t, err)
+	other := ottl.NewStatementSequence(statements, settings)
+	assert.EqualError(t, other.Execute(context.Background(), constructLogTransformContext()), "failed to execute statement: set(attributes[\"leaked\"], $parsed), variable $parsed is not defined")
+
+	// Setting a variable outside of a statement sequence fails.
+	statement, err := parser.ParseStatement(`set($parsed, body)`)
+	require.NoError(t, err)
+	_, _, err = statement.Execute(context.Background(), constructLogTransformContext())
+	assert.EqualError(t, err, "variable $parsed can only be set by the statements of a statement sequence")
+}
+
 func Test_e2e_ottl_value_expressions(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -88,8 +88,13 @@ func (g exprGetter[K]) Get(ctx context.Context, tCtx K) (any, error) {
 	if g.keys == nil {
 		return result, nil
 	}
+	return indexValue(result, g.keys)
+}
 
-	for _, k := range g.keys {
+// indexValue applies the static keys to the value returned by a converter or a variable.
+func indexValue(result any, keys []key) (any, error) {
+	var err error
+	for _, k := range keys {
 		switch {
 		case k.String != nil:
 			switch r := result.(type) {
@@ -829,6 +834,9 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		if eL.Converter != nil {
 			return p.newGetterFromConverter(*eL.Converter)
 		}
+		if eL.Variable != nil {
+			return newVariableGetSetter[K](eL.Variable), nil
+		}
 	}
 
 	if val.List != nil {
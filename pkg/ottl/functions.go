@@ -420,6 +420,8 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 				return fmt.Errorf("undefined function %s", name)
 			}
 			val = StandardFunctionGetter[K]{FCtx: FunctionContext{Set: p.telemetrySettings}, Fact: f}
+		case arg.Condition != nil:
+			val, err = p.buildConditionArg(arg.Condition, fieldType)
 		case fieldType.Kind() == reflect.Slice:
 			val, err = p.buildSliceArg(arg.Value, fieldType)
 		default:
@@ -551,7 +553,10 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 		if argVal.Literal != nil && argVal.Literal.Path != nil {
 			return p.buildGetSetterFromPath(argVal.Literal.Path)
 		}
-		return nil, errors.New("must be a path")
+		if argVal.Literal != nil && argVal.Literal.Variable != nil {
+			return newVariableGetSetter[K](argVal.Literal.Variable), nil
+		}
+		return nil, errors.New("must be a path or a variable")
 	case strings.HasPrefix(name, "Getter"):
 		arg, err := p.newGetter(argVal)
 		if err != nil {
@@ -595,12 +600,18 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 		}
 		return StandardIntLikeGetter[K]{Getter: arg.Get}, nil
 	case strings.HasPrefix(name, "PMapGetSetter"):
-		if argVal.Literal == nil || argVal.Literal.Path == nil {
-			return nil, errors.New("must be a path")
+		if argVal.Literal == nil || (argVal.Literal.Path == nil && argVal.Literal.Variable == nil) {
+			return nil, errors.New("must be a path or a variable")
 		}
-		pathGetSetter, err := p.buildGetSetterFromPath(argVal.Literal.Path)
-		if err != nil {
-			return nil, err
+		var pathGetSetter GetSetter[K]
+		if argVal.Literal.Variable != nil {
+			pathGetSetter = newVariableGetSetter[K](argVal.Literal.Variable)
+		} else {
+			var err error
+			pathGetSetter, err = p.buildGetSetterFromPath(argVal.Literal.Path)
+			if err != nil {
+				return nil, err
+			}
 		}
 		stdMapGetter := StandardPMapGetter[K]{Getter: pathGetSetter.Get}
 		return StandardPMapGetSetter[K]{Getter: stdMapGetter.Get, Setter: pathGetSetter.Set}, nil
@@ -671,6 +682,25 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 	}
 }
 
+// buildConditionArg builds a boolean argument from a boolean expression, which is evaluated each time the argument is read.
+func (p *Parser[K]) buildConditionArg(condition *booleanExpression, argType reflect.Type) (any, error) {
+	name := argType.Name()
+	if !strings.HasPrefix(name, "BoolGetter") && !strings.HasPrefix(name, "BoolLikeGetter") {
+		return nil, fmt.Errorf("a condition is not allowed for an argument of type %s", name)
+	}
+	expr, err := p.newBoolExpr(condition)
+	if err != nil {
+		return nil, err
+	}
+	getter := func(ctx context.Context, tCtx K) (any, error) {
+		return expr.Eval(ctx, tCtx)
+	}
+	if strings.HasPrefix(name, "BoolLikeGetter") {
+		return StandardBoolLikeGetter[K]{Getter: getter}, nil
+	}
+	return StandardBoolGetter[K]{Getter: getter}, nil
+}
+
 type buildArgFunc func(value, reflect.Type) (any, error)
 
 func buildSlice[T any](argVal value, argType reflect.Type, buildArg buildArgFunc, name string) (any, error) {
@@ -104,6 +104,25 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 				Arguments: []argument{},
 			},
 		},
+		{
+			name: "condition for a non boolean argument",
+			inv: editor{
+				Function: "testing_getter",
+				Arguments: []argument{
+					{
+						Condition: &booleanExpression{
+							Left: &term{
+								Left: &booleanValue{
+									ConstExpr: &constExpr{
+										Boolean: (*boolean)(ottltest.Boolp(true)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Invalid Function Name",
 			inv: editor{
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
 )
 
@@ -22,7 +23,7 @@ type parsedStatement struct {
 func (p *parsedStatement) checkForCustomError() error {
 	validator := &grammarCustomErrorsVisitor{}
 	if p.Converter != nil {
-		validator.add(fmt.Errorf("editor names must start with a lowercase letter but got '%v'", p.Converter.Function))
+		validator.add(participle.Errorf(p.Converter.Pos, "editor names must start with a lowercase letter but got '%v'", p.Converter.Function))
 	}
 
 	p.Editor.accept(validator)
@@ -136,23 +137,29 @@ const (
 	lte
 	gte
 	gt
+	in
+	notIn
 )
 
 // a fast way to get from a string to a compareOp
 var compareOpTable = map[string]compareOp{
-	"==": eq,
-	"!=": ne,
-	"<":  lt,
-	"<=": lte,
-	">":  gt,
-	">=": gte,
+	"==":     eq,
+	"!=":     ne,
+	"<":      lt,
+	"<=":     lte,
+	">":      gt,
+	">=":     gte,
+	"in":     in,
+	"not in": notIn,
 }
 
 // Capture is how the parser converts an operator string to a compareOp.
+// The "not in" operator is captured as two separate tokens.
 func (c *compareOp) Capture(values []string) error {
-	op, ok := compareOpTable[values[0]]
+	opStr := strings.Join(values, " ")
+	op, ok := compareOpTable[opStr]
 	if !ok {
-		return fmt.Errorf("'%s' is not a valid operator", values[0])
+		return fmt.Errorf("'%s' is not a valid operator", opStr)
 	}
 	*c = op
 	return nil
@@ -173,6 +180,10 @@ func (c *compareOp) String() string {
 		return "gte"
 	case gt:
 		return "gt"
+	case in:
+		return "in"
+	case notIn:
+		return "not in"
 	default:
 		return "UNKNOWN OP!"
 	}
@@ -181,7 +192,7 @@ func (c *compareOp) String() string {
 // comparison represents an optional boolean condition.
 type comparison struct {
 	Left  value     `parser:"@@"`
-	Op    compareOp `parser:"@OpComparison"`
+	Op    compareOp `parser:"( @OpComparison | @( OpNot? OpIn ) )"`
 	Right value     `parser:"@@"`
 }
 
@@ -192,6 +203,7 @@ func (c *comparison) accept(v grammarVisitor) {
 
 // editor represents the function call of a statement.
 type editor struct {
+	Pos       lexer.Position
 	Function  string     `parser:"@(Lowercase(Uppercase | Lowercase)*)"`
 	Arguments []argument `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
 	// If keys are matched return an error
@@ -207,6 +219,7 @@ func (i *editor) accept(v grammarVisitor) {
 
 // converter represents a converter function call.
 type converter struct {
+	Pos       lexer.Position
 	Function  string     `parser:"@(Uppercase(Uppercase | Lowercase)*)"`
 	Arguments []argument `parser:"'(' ( @@ ( ',' @@ )* )? ')'"`
 	Keys      []key      `parser:"( @@ )*"`
@@ -221,13 +234,20 @@ func (c *converter) accept(v grammarVisitor) {
 	}
 }
 
+// argument represents an argument of a function call. A value followed by a comparison or boolean operator
+// is parsed as a boolean expression instead, e.g. `$item != "x"` or `IsString($item) and $item != ""`.
 type argument struct {
-	Name         string  `parser:"(@(Lowercase(Uppercase | Lowercase)*) Equal)?"`
-	Value        value   `parser:"( @@"`
-	FunctionName *string `parser:"| @(Uppercase(Uppercase | Lowercase)*) )"`
+	Name         string             `parser:"(@(Lowercase(Uppercase | Lowercase)*) Equal)?"`
+	Value        value              `parser:"( @@ (?! OpComparison | OpIn | OpNot | OpAnd | OpOr)"`
+	Condition    *booleanExpression `parser:"| @@"`
+	FunctionName *string            `parser:"| @(Uppercase(Uppercase | Lowercase)*) )"`
 }
 
 func (a *argument) accept(v grammarVisitor) {
+	if a.Condition != nil {
+		a.Condition.accept(v)
+		return
+	}
 	a.Value.accept(v)
 }
 
@@ -295,6 +315,27 @@ func (f *field) accept(v grammarVisitor) {
 	}
 }
 
+// variable represents a reference to a variable, such as the current item bound by a function
+// that evaluates an expression for each item of a list.
+type variable struct {
+	Name variableName `parser:"@Variable"`
+	Keys []key        `parser:"( @@ )*"`
+}
+
+func (v *variable) accept(vis grammarVisitor) {
+	for _, key := range v.Keys {
+		key.accept(vis)
+	}
+}
+
+// variableName is the name of a variable, without its leading '$'.
+type variableName string
+
+func (n *variableName) Capture(values []string) error {
+	*n = variableName(strings.TrimPrefix(values[0], "$"))
+	return nil
+}
+
 type key struct {
 	String         *string          `parser:"'[' (@String "`
 	Int            *int64           `parser:"| @Int"`
@@ -367,6 +408,7 @@ type mathExprLiteral struct {
 	Converter *converter `parser:"| @@"`
 	Float     *float64   `parser:"| @Float"`
 	Int       *int64     `parser:"| @Int"`
+	Variable  *variable  `parser:"| @@"`
 	Path      *path      `parser:"| @@ )"`
 }
 
@@ -375,6 +417,9 @@ func (m *mathExprLiteral) accept(v grammarVisitor) {
 	if m.Path != nil {
 		m.Path.accept(v)
 	}
+	if m.Variable != nil {
+		m.Variable.accept(v)
+	}
 	if m.Editor != nil {
 		m.Editor.accept(v)
 	}
@@ -507,6 +552,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
+		{Name: `OpIn`, Pattern: `\b(in)\b`},
 		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
 		{Name: `OpAddSub`, Pattern: `\+|\-`},
 		{Name: `OpMultDiv`, Pattern: `\/|\*`},
@@ -517,6 +563,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `LBrace`, Pattern: `\{`},
 		{Name: `RBrace`, Pattern: `\}`},
 		{Name: `Colon`, Pattern: `\:`},
+		{Name: `Variable`, Pattern: `\$[a-zA-Z_][a-zA-Z0-9_]*`},
 		{Name: `Punct`, Pattern: `[,.\[\]]`},
 		{Name: `Uppercase`, Pattern: `[A-Z][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z][a-z0-9_]*`},
@@ -562,6 +609,7 @@ type grammarVisitor interface {
 }
 
 // grammarCustomErrorsVisitor is used to execute custom validations on the grammar AST.
+// The reported errors are participle.Error values holding the position of the invalid node.
 type grammarCustomErrorsVisitor struct {
 	errs []error
 }
@@ -585,12 +633,12 @@ func (g *grammarCustomErrorsVisitor) visitConverter(_ *converter) {}
 
 func (g *grammarCustomErrorsVisitor) visitEditor(v *editor) {
 	if v.Keys != nil {
-		g.add(fmt.Errorf("only paths and converters may be indexed, not editors, but got %s%s", v.Function, buildOriginalKeysText(v.Keys)))
+		g.add(participle.Errorf(v.Pos, "only paths and converters may be indexed, not editors, but got %s%s", v.Function, buildOriginalKeysText(v.Keys)))
 	}
 }
 
 func (g *grammarCustomErrorsVisitor) visitMathExprLiteral(v *mathExprLiteral) {
 	if v.Editor != nil {
-		g.add(fmt.Errorf("converter names must start with an uppercase letter but got '%v'", v.Editor.Function))
+		g.add(participle.Errorf(v.Editor.Pos, "converter names must start with an uppercase letter but got '%v'", v.Editor.Function))
 	}
 }
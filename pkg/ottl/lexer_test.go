@@ -78,6 +78,27 @@ func Test_lexer(t *testing.T) {
 			{"OpNot", "not"},
 			{"Boolean", "false"},
 		}},
+		{"name_containing_in", "index join", false, []result{
+			{"Lowercase", "index"},
+			{"Lowercase", "join"}, // should not parse "in" as an operator
+		}},
+		{"in", "name in list", false, []result{
+			{"Lowercase", "name"},
+			{"OpIn", "in"},
+			{"Lowercase", "list"},
+		}},
+		{"not_in", "name not in list", false, []result{
+			{"Lowercase", "name"},
+			{"OpNot", "not"},
+			{"OpIn", "in"},
+			{"Lowercase", "list"},
+		}},
+		{"variable", `$item["name"]`, false, []result{
+			{"Variable", "$item"},
+			{"Punct", "["},
+			{"String", `"name"`},
+			{"Punct", "]"},
+		}},
 		{"nothing_recognizable", "|", true, []result{
 			{"", ""},
 		}},
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type FilterArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Condition ottl.BoolGetter[K]
+	Limit     ottl.Optional[int64]
+}
+
+func NewFilterFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Filter", &FilterArguments[K]{}, createFilterFunction[K])
+}
+
+func createFilterFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FilterArguments[K])
+
+	if !ok {
+		return nil, errors.New("FilterFactory args must be of type *FilterArguments[K]")
+	}
+
+	limit, err := getIterationLimit("Filter", args.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter(args.Target, args.Condition, limit), nil
+}
+
+func filter[K any](target ottl.Getter[K], condition ottl.BoolGetter[K], limit int64) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := val.(pcommon.Value); ok {
+			val = ottlcommon.GetValue(v)
+		}
+
+		itemCtx, scope := ottl.NewVariableScope(ctx)
+		switch val.(type) {
+		case pcommon.Map, map[string]any:
+			result := pcommon.NewMap()
+			err = iterateMap("Filter", val, limit, func(key string, value any) error {
+				scope.Set(keyVariable, key)
+				scope.Set(valueVariable, value)
+				keep, err := condition.Get(itemCtx, tCtx)
+				if err != nil || !keep {
+					return err
+				}
+				return setIterationResult(result.PutEmpty(key), value)
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		default:
+			result := []any{}
+			err = iterateList("Filter", val, limit, func(i int, item any) error {
+				scope.Set(itemVariable, item)
+				scope.Set(indexVariable, int64(i))
+				keep, err := condition.Get(itemCtx, tCtx)
+				if err != nil || !keep {
+					return err
+				}
+				result = append(result, item)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_filter(t *testing.T) {
+	isString := func(name string) ottl.BoolGetter[any] {
+		return ottl.StandardBoolGetter[any]{
+			Getter: func(ctx context.Context, tCtx any) (any, error) {
+				val, err := getVariable(name).Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				_, ok := val.(string)
+				return ok, nil
+			},
+		}
+	}
+
+	pmap := pcommon.NewMap()
+	pmap.PutStr("a", "b")
+	pmap.PutInt("c", 1)
+	pmap.PutEmptyMap("d").PutStr("e", "f")
+
+	expectedMap := pcommon.NewMap()
+	expectedMap.PutStr("a", "b")
+
+	tests := []struct {
+		name      string
+		target    any
+		condition ottl.BoolGetter[any]
+		expected  any
+	}{
+		{
+			name:      "list",
+			target:    []any{"a", int64(1), "b"},
+			condition: isString("item"),
+			expected:  []any{"a", "b"},
+		},
+		{
+			name:      "typed list",
+			target:    []int64{1, 2},
+			condition: isString("item"),
+			expected:  []any{},
+		},
+		{
+			name:      "pcommon.Map",
+			target:    pmap,
+			condition: isString("value"),
+			expected:  expectedMap,
+		},
+		{
+			name:      "pcommon.Value",
+			target:    pcommon.NewValueMap(),
+			condition: isString("value"),
+			expected:  pcommon.NewMap(),
+		},
+		{
+			name:      "map[string]any",
+			target:    map[string]any{"a": "b", "c": int64(1)},
+			condition: isString("value"),
+			expected:  expectedMap,
+		},
+		{
+			name:      "map keys",
+			target:    map[string]any{"a": "b", "c": int64(1)},
+			condition: isString("key"),
+			expected: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutStr("a", "b")
+				m.PutInt("c", 1)
+				return m
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := filter[any](getLiteral(tt.target), tt.condition, 0)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_filter_error(t *testing.T) {
+	notBool := ottl.StandardBoolGetter[any]{Getter: getVariable("item").Get}
+
+	exprFunc := filter[any](getLiteral([]any{"a"}), notBool, 0)
+	_, err := exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "expected bool but got string")
+
+	exprFunc = filter[any](getLiteral(int64(1)), notBool, 0)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "Filter with unsupported type: 'int64'. Target is not a list")
+
+	exprFunc = filter[any](getLiteral(map[string]any{"a": "b", "c": "d"}), notBool, 1)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "Filter target has 2 items, more than the limit of 1")
+}
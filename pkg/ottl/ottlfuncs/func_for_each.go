@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+// Names of the variables bound by the functions evaluating an expression for each item of a list or map.
+const (
+	itemVariable  = "item"
+	indexVariable = "index"
+	keyVariable   = "key"
+	valueVariable = "value"
+)
+
+type ForEachArguments[K any] struct {
+	Target     ottl.Getter[K]
+	Expression ottl.Getter[K]
+	Limit      ottl.Optional[int64]
+}
+
+func NewForEachFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ForEach", &ForEachArguments[K]{}, createForEachFunction[K])
+}
+
+func createForEachFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ForEachArguments[K])
+
+	if !ok {
+		return nil, errors.New("ForEachFactory args must be of type *ForEachArguments[K]")
+	}
+
+	limit, err := getIterationLimit("ForEach", args.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return forEach(args.Target, args.Expression, limit), nil
+}
+
+func forEach[K any](target ottl.Getter[K], expression ottl.Getter[K], limit int64) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		itemCtx, scope := ottl.NewVariableScope(ctx)
+		result := []any{}
+		err = iterateList("ForEach", val, limit, func(i int, item any) error {
+			scope.Set(itemVariable, item)
+			scope.Set(indexVariable, int64(i))
+			res, err := expression.Get(itemCtx, tCtx)
+			if err != nil {
+				return err
+			}
+			result = append(result, res)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
+
+// getIterationLimit returns the maximum number of items a function evaluating an expression
+// for each item accepts, or 0 if there is no limit.
+func getIterationLimit(function string, limit ottl.Optional[int64]) (int64, error) {
+	if limit.IsEmpty() {
+		return 0, nil
+	}
+	if limit.Get() <= 0 {
+		return 0, fmt.Errorf("invalid limit for %s function, %d cannot be negative or zero", function, limit.Get())
+	}
+	return limit.Get(), nil
+}
+
+func checkIterationLimit(function string, length int, limit int64) error {
+	if limit > 0 && int64(length) > limit {
+		return fmt.Errorf("%s target has %d items, more than the limit of %d", function, length, limit)
+	}
+	return nil
+}
+
+// iterateList calls fn for each item of val, which must be a list.
+func iterateList(function string, val any, limit int64, fn func(i int, item any) error) error {
+	if v, ok := val.(pcommon.Value); ok {
+		if v.Type() != pcommon.ValueTypeSlice {
+			return fmt.Errorf("%s with unsupported type: '%s'. Target is not a list", function, v.Type().String())
+		}
+		val = v.Slice()
+	}
+
+	switch v := val.(type) {
+	case pcommon.Slice:
+		if err := checkIterationLimit(function, v.Len(), limit); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := fn(i, ottlcommon.GetValue(v.At(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		return iterateTypedList(function, v, limit, fn)
+	case []string:
+		return iterateTypedList(function, v, limit, fn)
+	case []int64:
+		return iterateTypedList(function, v, limit, fn)
+	case []float64:
+		return iterateTypedList(function, v, limit, fn)
+	case []bool:
+		return iterateTypedList(function, v, limit, fn)
+	default:
+		return fmt.Errorf("%s with unsupported type: '%T'. Target is not a list", function, val)
+	}
+}
+
+func iterateTypedList[T any](function string, items []T, limit int64, fn func(i int, item any) error) error {
+	if err := checkIterationLimit(function, len(items), limit); err != nil {
+		return err
+	}
+	for i, item := range items {
+		if err := fn(i, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterateMap calls fn for each entry of val, which must be a map.
+func iterateMap(function string, val any, limit int64, fn func(key string, value any) error) error {
+	if v, ok := val.(pcommon.Value); ok {
+		if v.Type() != pcommon.ValueTypeMap {
+			return fmt.Errorf("%s with unsupported type: '%s'. Target is not a map", function, v.Type().String())
+		}
+		val = v.Map()
+	}
+
+	switch v := val.(type) {
+	case pcommon.Map:
+		if err := checkIterationLimit(function, v.Len(), limit); err != nil {
+			return err
+		}
+		var err error
+		v.Range(func(key string, value pcommon.Value) bool {
+			err = fn(key, ottlcommon.GetValue(value))
+			return err == nil
+		})
+		return err
+	case map[string]any:
+		if err := checkIterationLimit(function, len(v), limit); err != nil {
+			return err
+		}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if err := fn(key, v[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s with unsupported type: '%T'. Target is not a map", function, val)
+	}
+}
+
+// setIterationResult sets a value returned by an expression to dest.
+func setIterationResult(dest pcommon.Value, val any) error {
+	switch v := val.(type) {
+	case nil:
+	case string:
+		dest.SetStr(v)
+	case bool:
+		dest.SetBool(v)
+	case int64:
+		dest.SetInt(v)
+	case float64:
+		dest.SetDouble(v)
+	case []byte:
+		dest.SetEmptyBytes().FromRaw(v)
+	case pcommon.Value:
+		v.CopyTo(dest)
+	case pcommon.Map:
+		v.CopyTo(dest.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(dest.SetEmptySlice())
+	case map[string]any:
+		m := dest.SetEmptyMap()
+		for key, item := range v {
+			if err := setIterationResult(m.PutEmpty(key), item); err != nil {
+				return err
+			}
+		}
+	case []any:
+		return setIterationResults(dest, v)
+	case []string:
+		return setIterationResults(dest, v)
+	case []int64:
+		return setIterationResults(dest, v)
+	case []float64:
+		return setIterationResults(dest, v)
+	case []bool:
+		return setIterationResults(dest, v)
+	default:
+		return fmt.Errorf("unsupported result type: %T", val)
+	}
+	return nil
+}
+
+func setIterationResults[T any](dest pcommon.Value, items []T) error {
+	slice := dest.SetEmptySlice()
+	slice.EnsureCapacity(len(items))
+	for _, item := range items {
+		if err := setIterationResult(slice.AppendEmpty(), item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// getVariable returns a getter resolving the variable with the given name in the scope of the context.
+func getVariable(name string) ottl.Getter[any] {
+	return ottl.StandardGetSetter[any]{
+		Getter: func(ctx context.Context, _ any) (any, error) {
+			_, scope := ottl.NewVariableScope(ctx)
+			val, ok := scope.Get(name)
+			if !ok {
+				return nil, errors.New("variable not defined")
+			}
+			return val, nil
+		},
+	}
+}
+
+func getLiteral(val any) ottl.Getter[any] {
+	return ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return val, nil
+		},
+	}
+}
+
+func Test_forEach(t *testing.T) {
+	pslice := pcommon.NewSlice()
+	pslice.AppendEmpty().SetStr("a")
+	pslice.AppendEmpty().SetEmptyMap().PutStr("b", "c")
+
+	pvalue := pcommon.NewValueSlice()
+	pvalue.Slice().AppendEmpty().SetInt(1)
+
+	tests := []struct {
+		name       string
+		target     any
+		expression ottl.Getter[any]
+		expected   []any
+	}{
+		{
+			name:       "pcommon.Slice",
+			target:     pslice,
+			expression: getVariable("item"),
+			expected:   []any{"a", pslice.At(1).Map()},
+		},
+		{
+			name:       "pcommon.Value",
+			target:     pvalue,
+			expression: getVariable("item"),
+			expected:   []any{int64(1)},
+		},
+		{
+			name:       "[]any",
+			target:     []any{"a", int64(1)},
+			expression: getVariable("index"),
+			expected:   []any{int64(0), int64(1)},
+		},
+		{
+			name:       "[]string",
+			target:     []string{"a", "b"},
+			expression: getVariable("item"),
+			expected:   []any{"a", "b"},
+		},
+		{
+			name:       "[]int64",
+			target:     []int64{1, 2},
+			expression: getLiteral(true),
+			expected:   []any{true, true},
+		},
+		{
+			name:       "[]float64",
+			target:     []float64{1.5},
+			expression: getVariable("item"),
+			expected:   []any{1.5},
+		},
+		{
+			name:       "[]bool",
+			target:     []bool{true},
+			expression: getVariable("item"),
+			expected:   []any{true},
+		},
+		{
+			name:       "empty list",
+			target:     []any{},
+			expression: getVariable("item"),
+			expected:   []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := forEach[any](getLiteral(tt.target), tt.expression, 0)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_forEach_error(t *testing.T) {
+	exprFunc := forEach[any](getLiteral("a"), getVariable("item"), 0)
+	_, err := exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "ForEach with unsupported type: 'string'. Target is not a list")
+
+	exprFunc = forEach[any](getLiteral(pcommon.NewValueMap()), getVariable("item"), 0)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "ForEach with unsupported type: 'Map'. Target is not a list")
+
+	exprFunc = forEach[any](getLiteral([]any{1, 2, 3}), getVariable("item"), 2)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "ForEach target has 3 items, more than the limit of 2")
+
+	exprFunc = forEach[any](getLiteral([]any{1}), getVariable("key"), 0)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "variable not defined")
+}
+
+func Test_forEach_nested(t *testing.T) {
+	// The inner $item hides the outer one, whose $index is still available.
+	inner := forEach[any](getVariable("item"), ottl.StandardGetSetter[any]{
+		Getter: func(ctx context.Context, _ any) (any, error) {
+			_, scope := ottl.NewVariableScope(ctx)
+			item, _ := scope.Get("item")
+			index, _ := scope.Get("index")
+			return []any{item, index}, nil
+		},
+	}, 0)
+	exprFunc := forEach[any](getLiteral([]any{[]any{"a", "b"}}), ottl.StandardGetSetter[any]{Getter: inner}, 0)
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{[]any{[]any{"a", int64(0)}, []any{"b", int64(1)}}}, result)
+}
+
+func Test_createForEachFunction_invalidLimit(t *testing.T) {
+	_, err := createForEachFunction[any](ottl.FunctionContext{}, &ForEachArguments[any]{
+		Target:     getLiteral([]any{}),
+		Expression: getVariable("item"),
+		Limit:      ottl.NewTestingOptional[int64](0),
+	})
+	assert.EqualError(t, err, "invalid limit for ForEach function, 0 cannot be negative or zero")
+}
+
+func Test_setIterationResult(t *testing.T) {
+	pmap := pcommon.NewMap()
+	pmap.PutStr("a", "b")
+
+	dest := pcommon.NewValueEmpty()
+	require.NoError(t, setIterationResult(dest, []any{"a", int64(1), 1.5, true, []byte{1}, pmap, []string{"c"}, map[string]any{"d": nil}, nil}))
+
+	expected := pcommon.NewValueSlice()
+	require.NoError(t, expected.Slice().FromRaw([]any{"a", int64(1), 1.5, true, []byte{1}, map[string]any{"a": "b"}, []any{"c"}, map[string]any{"d": nil}, nil}))
+	assert.Equal(t, expected.AsRaw(), dest.AsRaw())
+
+	assert.EqualError(t, setIterationResult(dest, struct{}{}), "unsupported result type: struct {}")
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_isInCIDR(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		networks []string
+		expected bool
+	}{
+		{
+			name:     "ipv4 in network",
+			target:   "10.1.2.3",
+			networks: []string{"10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "ipv4 not in network",
+			target:   "11.1.2.3",
+			networks: []string{"10.0.0.0/8"},
+			expected: false,
+		},
+		{
+			name:     "ipv4 in one of several networks",
+			target:   "192.168.1.10",
+			networks: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+			expected: true,
+		},
+		{
+			name:     "network with host bits",
+			target:   "192.168.1.10",
+			networks: []string{"192.168.1.1/24"},
+			expected: true,
+		},
+		{
+			name:     "single address",
+			target:   "192.168.1.10",
+			networks: []string{"192.168.1.10/32"},
+			expected: true,
+		},
+		{
+			name:     "ipv6 in network",
+			target:   "2001:db8::1",
+			networks: []string{"2001:db8::/32"},
+			expected: true,
+		},
+		{
+			name:     "ipv6 not in ipv4 network",
+			target:   "2001:db8::1",
+			networks: []string{"0.0.0.0/0"},
+			expected: false,
+		},
+		{
+			name:     "ipv4-mapped ipv6 in ipv4 network",
+			target:   "::ffff:10.1.2.3",
+			networks: []string{"10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "not an ip address",
+			target:   "localhost",
+			networks: []string{"127.0.0.0/8"},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := isInCIDR(target, tt.networks)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_isInCIDR_validation(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return "10.1.2.3", nil
+		},
+	}
+	_, err := isInCIDR(target, []string{"10.0.0.0/8", "10.0.0.0"})
+	assert.ErrorContains(t, err, "the network supplied to IsInCIDR is not a valid CIDR")
+
+	_, err = isInCIDR(target, nil)
+	assert.EqualError(t, err, "the networks supplied to IsInCIDR must not be empty")
+}
+
+func Test_isInCIDR_error(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return int64(1), nil
+		},
+	}
+	exprFunc, err := isInCIDR(target, []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
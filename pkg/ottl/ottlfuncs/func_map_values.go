@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type MapValuesArguments[K any] struct {
+	Target     ottl.Getter[K]
+	Expression ottl.Getter[K]
+	Limit      ottl.Optional[int64]
+}
+
+func NewMapValuesFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("MapValues", &MapValuesArguments[K]{}, createMapValuesFunction[K])
+}
+
+func createMapValuesFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MapValuesArguments[K])
+
+	if !ok {
+		return nil, errors.New("MapValuesFactory args must be of type *MapValuesArguments[K]")
+	}
+
+	limit, err := getIterationLimit("MapValues", args.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapValues(args.Target, args.Expression, limit), nil
+}
+
+func mapValues[K any](target ottl.Getter[K], expression ottl.Getter[K], limit int64) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		itemCtx, scope := ottl.NewVariableScope(ctx)
+		result := pcommon.NewMap()
+		err = iterateMap("MapValues", val, limit, func(key string, value any) error {
+			scope.Set(keyVariable, key)
+			scope.Set(valueVariable, value)
+			res, err := expression.Get(itemCtx, tCtx)
+			if err != nil {
+				return err
+			}
+			return setIterationResult(result.PutEmpty(key), res)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_mapValues(t *testing.T) {
+	keyAndValue := ottl.StandardGetSetter[any]{
+		Getter: func(ctx context.Context, tCtx any) (any, error) {
+			key, err := getVariable("key").Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			value, err := getVariable("value").Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			return fmt.Sprintf("%v=%v", key, value), nil
+		},
+	}
+
+	pmap := pcommon.NewMap()
+	pmap.PutStr("a", "b")
+	pmap.PutInt("c", 1)
+
+	expected := pcommon.NewMap()
+	expected.PutStr("a", "a=b")
+	expected.PutStr("c", "c=1")
+
+	tests := []struct {
+		name       string
+		target     any
+		expression ottl.Getter[any]
+		expected   pcommon.Map
+	}{
+		{
+			name:       "pcommon.Map",
+			target:     pmap,
+			expression: keyAndValue,
+			expected:   expected,
+		},
+		{
+			name:       "map[string]any",
+			target:     map[string]any{"a": "b", "c": int64(1)},
+			expression: keyAndValue,
+			expected:   expected,
+		},
+		{
+			name:       "list values",
+			target:     map[string]any{"a": "b"},
+			expression: getLiteral([]string{"c", "d"}),
+			expected: func() pcommon.Map {
+				m := pcommon.NewMap()
+				s := m.PutEmptySlice("a")
+				s.AppendEmpty().SetStr("c")
+				s.AppendEmpty().SetStr("d")
+				return m
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := mapValues[any](getLiteral(tt.target), tt.expression, 0)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_mapValues_error(t *testing.T) {
+	exprFunc := mapValues[any](getLiteral([]any{"a"}), getVariable("value"), 0)
+	_, err := exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "MapValues with unsupported type: '[]interface {}'. Target is not a map")
+
+	exprFunc = mapValues[any](getLiteral(pcommon.NewValueStr("a")), getVariable("value"), 0)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "MapValues with unsupported type: 'Str'. Target is not a map")
+
+	exprFunc = mapValues[any](getLiteral(map[string]any{"a": "b"}), getLiteral(struct{}{}), 0)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "unsupported result type: struct {}")
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// accumulatorVariable is the name of the variable holding the value accumulated by Reduce.
+const accumulatorVariable = "acc"
+
+type ReduceArguments[K any] struct {
+	Target     ottl.Getter[K]
+	Initial    ottl.Getter[K]
+	Expression ottl.Getter[K]
+	Limit      ottl.Optional[int64]
+}
+
+func NewReduceFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Reduce", &ReduceArguments[K]{}, createReduceFunction[K])
+}
+
+func createReduceFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ReduceArguments[K])
+
+	if !ok {
+		return nil, errors.New("ReduceFactory args must be of type *ReduceArguments[K]")
+	}
+
+	limit, err := getIterationLimit("Reduce", args.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return reduce(args.Target, args.Initial, args.Expression, limit), nil
+}
+
+func reduce[K any](target ottl.Getter[K], initial ottl.Getter[K], expression ottl.Getter[K], limit int64) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := initial.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		itemCtx, scope := ottl.NewVariableScope(ctx)
+		err = iterateList("Reduce", val, limit, func(i int, item any) error {
+			scope.Set(accumulatorVariable, acc)
+			scope.Set(itemVariable, item)
+			scope.Set(indexVariable, int64(i))
+			acc, err = expression.Get(itemCtx, tCtx)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return acc, nil
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_reduce(t *testing.T) {
+	sum := ottl.StandardGetSetter[any]{
+		Getter: func(ctx context.Context, tCtx any) (any, error) {
+			acc, err := getVariable("acc").Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			item, err := getVariable("item").Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			return acc.(int64) + item.(int64), nil
+		},
+	}
+
+	exprFunc := reduce[any](getLiteral([]int64{1, 2, 3}), getLiteral(int64(10)), sum, 0)
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(16), result)
+
+	// The initial value is returned for an empty list.
+	exprFunc = reduce[any](getLiteral([]any{}), getLiteral("initial"), sum, 0)
+	result, err = exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "initial", result)
+}
+
+func Test_reduce_error(t *testing.T) {
+	exprFunc := reduce[any](getLiteral(map[string]any{}), getLiteral(int64(0)), getVariable("acc"), 0)
+	_, err := exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "Reduce with unsupported type: 'map[string]interface {}'. Target is not a list")
+
+	exprFunc = reduce[any](getLiteral([]any{1, 2}), getLiteral(int64(0)), getVariable("acc"), 1)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "Reduce target has 2 items, more than the limit of 1")
+
+	exprFunc = reduce[any](getLiteral([]any{1}), getLiteral(int64(0)), getVariable("key"), 0)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "variable not defined")
+}
@@ -23,6 +23,7 @@ type Statement[K any] struct {
 	function          Expr[K]
 	condition         BoolExpr[K]
 	origText          string
+	usesVariables     bool
 	telemetrySettings component.TelemetrySettings
 }
 
@@ -164,6 +165,7 @@ func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
 		function:          function,
 		condition:         expression,
 		origText:          statement,
+		usesVariables:     parsedStatementUsesVariables(parsed),
 		telemetrySettings: p.telemetrySettings,
 	}, nil
 }
@@ -349,6 +351,7 @@ func newParser[G any]() *participle.Parser[G] {
 type StatementSequence[K any] struct {
 	statements        []*Statement[K]
 	errorMode         ErrorMode
+	usesVariables     bool
 	telemetrySettings component.TelemetrySettings
 }
 
@@ -374,6 +377,9 @@ func NewStatementSequence[K any](statements []*Statement[K], telemetrySettings c
 	for _, op := range options {
 		op(&s)
 	}
+	for _, statement := range statements {
+		s.usesVariables = s.usesVariables || statement.usesVariables
+	}
 	return s
 }
 
@@ -385,6 +391,11 @@ func (s *StatementSequence[K]) Execute(ctx context.Context, tCtx K) error {
 	if s.telemetrySettings.Logger.Core().Enabled(zap.DebugLevel) {
 		s.telemetrySettings.Logger.Debug("initial TransformContext before executing StatementSequence", zap.Any("TransformContext", tCtx))
 	}
+	// The variables set by the statements are only visible to the statements of this sequence, for this TransformContext.
+	// The scope is only created when the statements reference variables, to avoid its allocation otherwise.
+	if s.usesVariables {
+		ctx, _ = NewVariableScope(ctx)
+	}
 	for _, statement := range s.statements {
 		_, _, err := statement.Execute(ctx, tCtx)
 		if err != nil {
@@ -4,6 +4,7 @@
 package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
@@ -159,21 +160,21 @@ type (
 // createConditionsParserWithConverter is a method to create the necessary parser wrapper and shadowing the K type.
 func createConditionsParserWithConverter[K any, R any](converter ParsedConditionsConverter[K, R], parser *Parser[K]) parserCollectionContextParserFunc[R, ConditionsGetter] {
 	return func(pc *ParserCollection[R], context string, conditions ConditionsGetter, prependPathsContext bool) (R, error) {
-		var err error
 		var parsingConditions []string
 		if prependPathsContext {
 			originalConditions := conditions.GetConditions()
 			parsingConditions = make([]string, 0, len(originalConditions))
+			var prependErrs []error
 			for _, cond := range originalConditions {
 				prependedCondition, prependErr := parser.prependContextToConditionPaths(context, cond)
 				if prependErr != nil {
-					err = prependErr
-					break
+					prependErrs = append(prependErrs, fmt.Errorf("unable to parse OTTL condition %q: %w", cond, prependErr))
+					continue
 				}
 				parsingConditions = append(parsingConditions, prependedCondition)
 			}
-			if err != nil {
-				return *new(R), err
+			if len(prependErrs) > 0 {
+				return *new(R), errors.Join(prependErrs...)
 			}
 			if pc.modifiedLogging {
 				pc.logModifications(originalConditions, parsingConditions)
@@ -196,21 +197,21 @@ func createConditionsParserWithConverter[K any, R any](converter ParsedCondition
 // createStatementsParserWithConverter is a method to create the necessary parser wrapper and shadowing the K type.
 func createStatementsParserWithConverter[K any, R any](converter ParsedStatementsConverter[K, R], parser *Parser[K]) parserCollectionContextParserFunc[R, StatementsGetter] {
 	return func(pc *ParserCollection[R], context string, statements StatementsGetter, prependPathsContext bool) (R, error) {
-		var err error
 		var parsingStatements []string
 		if prependPathsContext {
 			originalStatements := statements.GetStatements()
 			parsingStatements = make([]string, 0, len(originalStatements))
-			for _, cond := range originalStatements {
-				prependedStatement, prependErr := parser.prependContextToStatementPaths(context, cond)
+			var prependErrs []error
+			for _, statement := range originalStatements {
+				prependedStatement, prependErr := parser.prependContextToStatementPaths(context, statement)
 				if prependErr != nil {
-					err = prependErr
-					break
+					prependErrs = append(prependErrs, fmt.Errorf("unable to parse OTTL statement %q: %w", statement, prependErr))
+					continue
 				}
 				parsingStatements = append(parsingStatements, prependedStatement)
 			}
-			if err != nil {
-				return *new(R), err
+			if len(prependErrs) > 0 {
+				return *new(R), errors.Join(prependErrs...)
 			}
 			if pc.modifiedLogging {
 				pc.logModifications(originalStatements, parsingStatements)
@@ -472,6 +472,28 @@ func Test_ParseStatementsWithContext_PrependPathContext(t *testing.T) {
 	assert.Equal(t, `set(dummy.attributes["bar"], "bar")`, parsedStatements[1].origText)
 }
 
+func Test_ParseStatementsWithContext_PrependPathContextErrors(t *testing.T) {
+	ps := mockParser(t, WithPathContextNames[any]([]string{"dummy"}))
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("dummy", ps, WithStatementConverter(newNopParsedStatementsConverter[any]())),
+	)
+	require.NoError(t, err)
+
+	_, err = pc.ParseStatementsWithContext(
+		"dummy",
+		mockGetter{[]string{
+			`Set(attributes["foo"], "foo")`,
+			`set(attributes["bar"], "bar")`,
+			`set(attributes["baz"], int())`,
+		}},
+		true,
+	)
+
+	assert.EqualError(t, err, `unable to parse OTTL statement "Set(attributes[\"foo\"], \"foo\")": 1:1: editor names must start with a lowercase letter but got 'Set'`+"\n"+
+		`unable to parse OTTL statement "set(attributes[\"baz\"], int())": 1:24: converter names must start with an uppercase letter but got 'int'`)
+}
+
 func Test_NewStatementsGetter(t *testing.T) {
 	statements := []string{`set(foo, "bar")`, `set(bar, "foo")`}
 	statementsGetter := NewStatementsGetter(statements)
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alecthomas/participle/v2"
 	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,6 +38,11 @@ func Test_parse(t *testing.T) {
 			statement: `set("foo")`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -54,6 +60,11 @@ func Test_parse(t *testing.T) {
 			statement: `met(1.2)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "met",
 					Arguments: []argument{
 						{
@@ -73,6 +84,11 @@ func Test_parse(t *testing.T) {
 			statement: `fff(12)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "fff",
 					Arguments: []argument{
 						{
@@ -92,6 +108,11 @@ func Test_parse(t *testing.T) {
 			statement: `fff({"stringAttr": "value", "intAttr": 3, "floatAttr": 2.5, "boolAttr": true})`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "fff",
 					Arguments: []argument{
 						{
@@ -136,6 +157,11 @@ func Test_parse(t *testing.T) {
 			statement: `fff({})`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "fff",
 					Arguments: []argument{
 						{
@@ -155,12 +181,22 @@ func Test_parse(t *testing.T) {
 			statement: `fff(GetSomething({"foo":"bar"}))`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "fff",
 					Arguments: []argument{
 						{
 							Value: value{
 								Literal: &mathExprLiteral{
 									Converter: &converter{
+										Pos: lexer.Position{
+											Offset: 4,
+											Line:   1,
+											Column: 5,
+										},
 										Function: "GetSomething",
 										Arguments: []argument{
 											{
@@ -190,6 +226,11 @@ func Test_parse(t *testing.T) {
 			statement: `fff({"mapAttr": {"foo": "bar", "get": bear.honey, "arrayAttr":["foo", "bar"]}})`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "fff",
 					Arguments: []argument{
 						{
@@ -258,6 +299,11 @@ func Test_parse(t *testing.T) {
 			statement: `set("foo", GetSomething(bear.honey))`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -269,6 +315,11 @@ func Test_parse(t *testing.T) {
 							Value: value{
 								Literal: &mathExprLiteral{
 									Converter: &converter{
+										Pos: lexer.Position{
+											Offset: 11,
+											Line:   1,
+											Column: 12,
+										},
 										Function: "GetSomething",
 										Arguments: []argument{
 											{
@@ -305,6 +356,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(foo.attributes["bar"].cat, "dog")`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -349,6 +405,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["bar"], "dog")`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -390,6 +451,11 @@ func Test_parse(t *testing.T) {
 			statement: `replace_pattern(attributes["message"], "device=*", attributes["device_name"], SHA256)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "replace_pattern",
 					Arguments: []argument{
 						{
@@ -458,6 +524,11 @@ func Test_parse(t *testing.T) {
 			statement: `replace_pattern(attributes["message"], Sha256)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "replace_pattern",
 					Arguments: []argument{
 						{
@@ -496,6 +567,11 @@ func Test_parse(t *testing.T) {
 			statement: `replace_pattern(attributes["message"], S)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "replace_pattern",
 					Arguments: []argument{
 						{
@@ -536,6 +612,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(foo.bar["x"]["y"].z, Test()[0]["pass"])`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -572,6 +653,11 @@ func Test_parse(t *testing.T) {
 							Value: value{
 								Literal: &mathExprLiteral{
 									Converter: &converter{
+										Pos: lexer.Position{
+											Offset: 25,
+											Line:   1,
+											Column: 26,
+										},
 										Function: "Test",
 										Keys: []key{
 											{
@@ -595,6 +681,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(foo.attributes["bar"].cat, "dog") where name == "fido"`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -666,6 +757,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(foo.attributes["bar"].cat, "dog") where name != "fido"`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -737,6 +833,11 @@ func Test_parse(t *testing.T) {
 			statement: `set  ( foo.attributes[ "bar"].cat,   "dog")   where name=="fido"`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -808,6 +909,11 @@ func Test_parse(t *testing.T) {
 			statement: `set("fo\"o")`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -825,6 +931,11 @@ func Test_parse(t *testing.T) {
 			statement: `convert_gauge_to_sum("cumulative", false)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "convert_gauge_to_sum",
 					Arguments: []argument{
 						{
@@ -847,6 +958,11 @@ func Test_parse(t *testing.T) {
 			statement: `convert_gauge_to_sum("cumulative", true)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "convert_gauge_to_sum",
 					Arguments: []argument{
 						{
@@ -869,6 +985,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["bytes"], 0x0102030405060708)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -909,6 +1030,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["test"], nil)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -949,6 +1075,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["test"], TEST_ENUM)`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -989,6 +1120,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["test"], [])`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -1031,6 +1167,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["test"], ["value0"])`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -1077,6 +1218,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["test"], ["value1", "value2"])`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -1126,6 +1272,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["test"], [Concat(["a", "b"], "+"), ["1", 2, 3.0], nil, attributes["test"]])`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -1158,6 +1309,11 @@ func Test_parse(t *testing.T) {
 										{
 											Literal: &mathExprLiteral{
 												Converter: &converter{
+													Pos: lexer.Position{
+														Offset: 25,
+														Line:   1,
+														Column: 26,
+													},
 													Function: "Concat",
 													Arguments: []argument{
 														{
@@ -1240,6 +1396,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(attributes["test"], 1000 - 600) where 1 + 1 * 2 == three / One()`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -1355,6 +1516,11 @@ func Test_parse(t *testing.T) {
 													Value: &mathValue{
 														Literal: &mathExprLiteral{
 															Converter: &converter{
+																Pos: lexer.Position{
+																	Offset: 63,
+																	Line:   1,
+																	Column: 64,
+																},
 																Function: "One",
 															},
 														},
@@ -1375,6 +1541,11 @@ func Test_parse(t *testing.T) {
 			statement: `set(name="foo")`,
 			expected: &parsedStatement{
 				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
 					Function: "set",
 					Arguments: []argument{
 						{
@@ -1388,6 +1559,176 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:      "editor with comparison arg",
+			statement: `fff($item != "x")`,
+			expected: &parsedStatement{
+				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					Function: "fff",
+					Arguments: []argument{
+						{
+							Condition: &booleanExpression{
+								Left: &term{
+									Left: &booleanValue{
+										Comparison: &comparison{
+											Left: value{
+												Literal: &mathExprLiteral{
+													Variable: &variable{Name: "item"},
+												},
+											},
+											Op: ne,
+											Right: value{
+												String: ottltest.Strp("x"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "editor with boolean expression arg",
+			statement: `fff(not IsString($item) or $item in ["a", "b"], 1)`,
+			expected: &parsedStatement{
+				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					Function: "fff",
+					Arguments: []argument{
+						{
+							Condition: &booleanExpression{
+								Left: &term{
+									Left: &booleanValue{
+										Negation: ottltest.Strp("not"),
+										ConstExpr: &constExpr{
+											Converter: &converter{
+												Pos: lexer.Position{
+													Offset: 8,
+													Line:   1,
+													Column: 9,
+												},
+												Function: "IsString",
+												Arguments: []argument{
+													{
+														Value: value{
+															Literal: &mathExprLiteral{
+																Variable: &variable{Name: "item"},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								Right: []*opOrTerm{
+									{
+										Operator: "or",
+										Term: &term{
+											Left: &booleanValue{
+												Comparison: &comparison{
+													Left: value{
+														Literal: &mathExprLiteral{
+															Variable: &variable{Name: "item"},
+														},
+													},
+													Op: in,
+													Right: value{
+														List: &list{
+															Values: []value{
+																{String: ottltest.Strp("a")},
+																{String: ottltest.Strp("b")},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Value: value{
+								Literal: &mathExprLiteral{
+									Int: ottltest.Intp(1),
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "editor with named comparison arg",
+			statement: `fff(condition=1 + 1 == 2)`,
+			expected: &parsedStatement{
+				Editor: editor{
+					Pos: lexer.Position{
+						Offset: 0,
+						Line:   1,
+						Column: 1,
+					},
+					Function: "fff",
+					Arguments: []argument{
+						{
+							Name: "condition",
+							Condition: &booleanExpression{
+								Left: &term{
+									Left: &booleanValue{
+										Comparison: &comparison{
+											Left: value{
+												MathExpression: &mathExpression{
+													Left: &addSubTerm{
+														Left: &mathValue{
+															Literal: &mathExprLiteral{
+																Int: ottltest.Intp(1),
+															},
+														},
+													},
+													Right: []*opAddSubTerm{
+														{
+															Operator: add,
+															Term: &addSubTerm{
+																Left: &mathValue{
+																	Literal: &mathExprLiteral{
+																		Int: ottltest.Intp(1),
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+											Op: eq,
+											Right: value{
+												Literal: &mathExprLiteral{
+													Int: ottltest.Intp(2),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1535,6 +1876,11 @@ func Test_parseCondition_full(t *testing.T) {
 												Value: &mathValue{
 													Literal: &mathExprLiteral{
 														Converter: &converter{
+															Pos: lexer.Position{
+																Offset: 21,
+																Line:   1,
+																Column: 22,
+															},
 															Function: "One",
 														},
 													},
@@ -1614,6 +1960,11 @@ func testParsePath[K any](p Path[K]) (GetSetter[any], error) {
 func setNameTest(b *booleanExpression) *parsedStatement {
 	return &parsedStatement{
 		Editor: editor{
+			Pos: lexer.Position{
+				Offset: 0,
+				Line:   1,
+				Column: 1,
+			},
 			Function: "set",
 			Arguments: []argument{
 				{
@@ -1912,6 +2263,42 @@ func Test_parseWhere(t *testing.T) {
 				},
 			}),
 		},
+		{
+			statement: `name not in ["foo", "bar"]`,
+			expected: setNameTest(&booleanExpression{
+				Left: &term{
+					Left: &booleanValue{
+						Comparison: &comparison{
+							Left: value{
+								Literal: &mathExprLiteral{
+									Path: &path{
+										Pos: lexer.Position{
+											Offset: 24,
+											Line:   1,
+											Column: 25,
+										},
+										Fields: []field{
+											{
+												Name: "name",
+											},
+										},
+									},
+								},
+							},
+							Op: notIn,
+							Right: value{
+								List: &list{
+									Values: []value{
+										{String: ottltest.Strp("foo")},
+										{String: ottltest.Strp("bar")},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+		},
 		{
 			statement: `name == "foo" or name == "bar"`,
 			expected: setNameTest(&booleanExpression{
@@ -2067,6 +2454,11 @@ func Test_parseWhere(t *testing.T) {
 					Left: &booleanValue{
 						ConstExpr: &constExpr{
 							Converter: &converter{
+								Pos: lexer.Position{
+									Offset: 24,
+									Line:   1,
+									Column: 25,
+								},
 								Function: "True",
 							},
 						},
@@ -2081,6 +2473,11 @@ func Test_parseWhere(t *testing.T) {
 					Left: &booleanValue{
 						ConstExpr: &constExpr{
 							Converter: &converter{
+								Pos: lexer.Position{
+									Offset: 24,
+									Line:   1,
+									Column: 25,
+								},
 								Function: "True",
 							},
 						},
@@ -2091,6 +2488,11 @@ func Test_parseWhere(t *testing.T) {
 							Value: &booleanValue{
 								ConstExpr: &constExpr{
 									Converter: &converter{
+										Pos: lexer.Position{
+											Offset: 35,
+											Line:   1,
+											Column: 36,
+										},
 										Function: "False",
 									},
 								},
@@ -2359,6 +2761,11 @@ func Test_parseStatement(t *testing.T) {
 		{statement: `set() where true and 1 == int() `, wantErrContaining: converterNameErrorPrefix},
 		{statement: `set() where false or 1 == int() `, wantErrContaining: converterNameErrorPrefix},
 		{statement: `set(foo.attributes["bar"].cat)["key"]`, wantErrContaining: editorWithIndexErrorPrefix},
+		{statement: `set(attributes["test"], Filter(attributes["list"], $item != "x"))`},
+		{statement: `set(attributes["test"], Filter(attributes["list"], $item == "x" or not IsMatch($item, "y")))`},
+		{statement: `set(attributes["test"], Filter(attributes["list"], $item !=))`, wantErr: true},
+		{statement: `set(attributes["test"], Filter(attributes["list"], $item and))`, wantErr: true},
+		{statement: `set(attributes["test"], Filter(attributes["list"], int() == 1))`, wantErrContaining: converterNameErrorPrefix},
 		{statement: `set(foo.attributes["bar"].cat, "dog")`},
 		{statement: `set(set = foo.attributes["animal"], val = "dog") where animal == "cat"`},
 		{statement: `test() where service == "pinger" or foo.attributes["endpoint"] == "/x/alive"`},
@@ -2399,6 +2806,39 @@ func Test_parseStatement(t *testing.T) {
 	}
 }
 
+func Test_parseStatement_customErrorPosition(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  string
+	}{
+		{
+			statement: `Set()`,
+			expected:  "1:1: editor names must start with a lowercase letter but got 'Set'",
+		},
+		{
+			statement: `set(1 + int())`,
+			expected:  "1:9: converter names must start with an uppercase letter but got 'int'",
+		},
+		{
+			statement: `set(name) where 1 == int()`,
+			expected:  "1:22: converter names must start with an uppercase letter but got 'int'",
+		},
+		{
+			statement: "set(name)\n  where test(foo)[\"key\"] == \"bar\"",
+			expected:  "2:9: converter names must start with an uppercase letter but got 'test'; 2:9: only paths and converters may be indexed, not editors, but got test[key]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			_, err := parseStatement(tt.statement)
+			assert.EqualError(t, err, tt.expected)
+
+			var perr participle.Error
+			require.ErrorAs(t, err, &perr)
+		})
+	}
+}
+
 // This test doesn't validate parser results, simply checks whether the parse succeeds or not.
 // It's a fast way to check a large range of possible syntaxes.
 func Test_parseCondition(t *testing.T) {
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"fmt"
+)
+
+type variableScopeContextKey struct{}
+
+// VariableScope holds the values of the variables referenced with the `$name` syntax,
+// such as the current item bound by a function that evaluates an expression for each item of a list.
+// A scope also gives access to the variables of the scopes it is nested in.
+type VariableScope struct {
+	parent *VariableScope
+	values map[string]any
+}
+
+// NewVariableScope returns a new scope nested in the scope of the given context, if any,
+// and a child context in which the variables of the new scope are defined.
+func NewVariableScope(ctx context.Context) (context.Context, *VariableScope) {
+	parent, _ := ctx.Value(variableScopeContextKey{}).(*VariableScope)
+	scope := &VariableScope{parent: parent}
+	return context.WithValue(ctx, variableScopeContextKey{}, scope), scope
+}
+
+// Set defines the value of the variable with the given name, without its leading '$',
+// hiding any variable with the same name in the parent scopes.
+func (s *VariableScope) Set(name string, value any) {
+	// The values are allocated lazily, since most scopes of statement sequences never hold any variable.
+	if s.values == nil {
+		s.values = make(map[string]any)
+	}
+	s.values[name] = value
+}
+
+// Get returns the value of the variable with the given name, without its leading '$',
+// looking it up in the parent scopes if it is not defined in this scope.
+func (s *VariableScope) Get(name string) (any, bool) {
+	for scope := s; scope != nil; scope = scope.parent {
+		if val, ok := scope.values[name]; ok {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+// grammarVariableVisitor is used to find whether a parsedStatement references any variable.
+type grammarVariableVisitor struct {
+	found bool
+}
+
+func (v *grammarVariableVisitor) visitPath(_ *path)           {}
+func (v *grammarVariableVisitor) visitEditor(_ *editor)       {}
+func (v *grammarVariableVisitor) visitConverter(_ *converter) {}
+func (v *grammarVariableVisitor) visitValue(_ *value)         {}
+
+func (v *grammarVariableVisitor) visitMathExprLiteral(m *mathExprLiteral) {
+	if m.Variable != nil {
+		v.found = true
+	}
+}
+
+func parsedStatementUsesVariables(ps *parsedStatement) bool {
+	visitor := &grammarVariableVisitor{}
+	ps.Editor.accept(visitor)
+	if ps.WhereClause != nil {
+		ps.WhereClause.accept(visitor)
+	}
+	return visitor.found
+}
+
+// variableGetSetter gets the value of a variable, and sets it in the innermost scope,
+// which is the scope of the StatementSequence for variables set by statements.
+type variableGetSetter[K any] struct {
+	name string
+	keys []key
+}
+
+func newVariableGetSetter[K any](v *variable) *variableGetSetter[K] {
+	return &variableGetSetter[K]{name: string(v.Name), keys: v.Keys}
+}
+
+func (g *variableGetSetter[K]) Get(ctx context.Context, _ K) (any, error) {
+	// A nil scope has no variables defined.
+	scope, _ := ctx.Value(variableScopeContextKey{}).(*VariableScope)
+	val, ok := scope.Get(g.name)
+	if !ok {
+		return nil, fmt.Errorf("variable $%s is not defined", g.name)
+	}
+	if g.keys == nil {
+		return val, nil
+	}
+	return indexValue(val, g.keys)
+}
+
+func (g *variableGetSetter[K]) Set(ctx context.Context, _ K, val any) error {
+	if g.keys != nil {
+		return fmt.Errorf("variable $%s cannot be set with keys, only the whole variable can be set", g.name)
+	}
+	scope, _ := ctx.Value(variableScopeContextKey{}).(*VariableScope)
+	if scope == nil {
+		return fmt.Errorf("variable $%s can only be set by the statements of a statement sequence", g.name)
+	}
+	scope.Set(g.name, val)
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_VariableScope(t *testing.T) {
+	ctx, outer := NewVariableScope(context.Background())
+	outer.Set("a", "outer")
+	outer.Set("b", int64(1))
+
+	_, inner := NewVariableScope(ctx)
+	inner.Set("a", "inner")
+
+	val, ok := inner.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "inner", val)
+
+	val, ok = inner.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), val)
+
+	val, ok = outer.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "outer", val)
+
+	_, ok = outer.Get("c")
+	assert.False(t, ok)
+}
+
+func Test_variableGetSetter_Get(t *testing.T) {
+	pm := pcommon.NewMap()
+	pm.PutEmptySlice("list").AppendEmpty().SetStr("item")
+
+	ctx, scope := NewVariableScope(context.Background())
+	scope.Set("map", pm)
+	scope.Set("value", int64(1))
+
+	tests := []struct {
+		name     string
+		variable *variable
+		expected any
+	}{
+		{
+			name:     "variable",
+			variable: &variable{Name: "value"},
+			expected: int64(1),
+		},
+		{
+			name: "variable with keys",
+			variable: &variable{
+				Name: "map",
+				Keys: []key{
+					{String: ottltest.Strp("list")},
+					{Int: ottltest.Intp(0)},
+				},
+			},
+			expected: "item",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := newVariableGetSetter[any](tt.variable)
+			val, err := getter.Get(ctx, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, val)
+		})
+	}
+
+	getter := &variableGetSetter[any]{name: "undefined"}
+	_, err := getter.Get(ctx, nil)
+	assert.EqualError(t, err, "variable $undefined is not defined")
+
+	getter = &variableGetSetter[any]{name: "value"}
+	_, err = getter.Get(context.Background(), nil)
+	assert.EqualError(t, err, "variable $value is not defined")
+}
+
+func Test_variableGetSetter_Set(t *testing.T) {
+	ctx, outer := NewVariableScope(context.Background())
+	outer.Set("value", int64(1))
+	ctx, inner := NewVariableScope(ctx)
+
+	setter := &variableGetSetter[any]{name: "value"}
+	require.NoError(t, setter.Set(ctx, nil, int64(2)))
+
+	val, ok := inner.Get("value")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), val)
+	val, ok = outer.Get("value")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), val)
+
+	setter = &variableGetSetter[any]{name: "value", keys: []key{{String: ottltest.Strp("a")}}}
+	assert.EqualError(t, setter.Set(ctx, nil, int64(2)), "variable $value cannot be set with keys, only the whole variable can be set")
+
+	setter = &variableGetSetter[any]{name: "value"}
+	assert.EqualError(t, setter.Set(context.Background(), nil, int64(2)), "variable $value can only be set by the statements of a statement sequence")
+}
+
+func Test_parseVariable(t *testing.T) {
+	p, err := NewParser[any](
+		defaultFunctionsForTests(),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+	)
+	require.NoError(t, err)
+
+	expr, err := p.ParseValueExpression(`$value * 2 + $map["a"]`)
+	require.NoError(t, err)
+
+	ctx, scope := NewVariableScope(context.Background())
+	scope.Set("value", int64(3))
+	scope.Set("map", map[string]any{"a": int64(1)})
+
+	val, err := expr.Eval(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), val)
+}
+
+func Test_StatementSequence_usesVariables(t *testing.T) {
+	p, err := NewParser[any](
+		defaultFunctionsForTests(),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		statements    []string
+		usesVariables bool
+	}{
+		{
+			name:       "no variables",
+			statements: []string{`testing_getsetter(name) where name == "a"`},
+		},
+		{
+			name:          "variable",
+			statements:    []string{`testing_getsetter(name)`, `testing_getsetter($value)`},
+			usesVariables: true,
+		},
+		{
+			name:          "variable in condition",
+			statements:    []string{`testing_getsetter(name) where $value["a"] == "b"`},
+			usesVariables: true,
+		},
+		{
+			name:          "variable in argument",
+			statements:    []string{`testing_getter([$value, "a"])`},
+			usesVariables: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := p.ParseStatements(tt.statements)
+			require.NoError(t, err)
+			sequence := NewStatementSequence(statements, componenttest.NewNopTelemetrySettings())
+			assert.Equal(t, tt.usesVariables, sequence.usesVariables)
+		})
+	}
+}
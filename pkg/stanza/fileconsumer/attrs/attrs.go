@@ -6,6 +6,7 @@ package attrs // import "github.com/open-telemetry/opentelemetry-collector-contr
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 )
@@ -71,3 +72,25 @@ func (r *Resolver) Resolve(file *os.File) (attributes map[string]any, err error)
 	}
 	return attributes, nil
 }
+
+// ResolveMember resolves the attributes of a member of an archive file. The name of the member
+// is its base name, and its path is the path of the archive joined with the path of the member.
+func (r *Resolver) ResolveMember(file *os.File, member string) (attributes map[string]any, err error) {
+	attributes, err = r.Resolve(file)
+	if err != nil {
+		return nil, err
+	}
+	if r.IncludeFileName {
+		attributes[LogFileName] = path.Base(member)
+	}
+	if r.IncludeFilePath {
+		attributes[LogFilePath] = filepath.Join(file.Name(), filepath.FromSlash(member))
+	}
+	if r.IncludeFileNameResolved {
+		attributes[LogFileNameResolved] = path.Base(member)
+	}
+	if r.IncludeFilePathResolved {
+		attributes[LogFilePathResolved] = filepath.Join(attributes[LogFilePathResolved].(string), filepath.FromSlash(member))
+	}
+	return attributes, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,3 +90,23 @@ func TestResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveMember(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	temp := filetest.OpenTemp(t, tempDir)
+
+	r := Resolver{
+		IncludeFileName:         true,
+		IncludeFilePath:         true,
+		IncludeFileNameResolved: true,
+		IncludeFilePathResolved: true,
+	}
+	attributes, err := r.ResolveMember(temp, "var/log/app.log")
+	assert.NoError(t, err)
+	assert.Equal(t, "app.log", attributes[LogFileName])
+	assert.Equal(t, filepath.Join(temp.Name(), "var", "log", "app.log"), attributes[LogFilePath])
+	assert.Equal(t, "app.log", attributes[LogFileNameResolved])
+	assert.True(t, strings.HasSuffix(attributes[LogFilePathResolved].(string), filepath.Join(filepath.Base(temp.Name()), "var", "log", "app.log")))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/decompress"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/metadata"
@@ -36,6 +37,7 @@ const (
 	defaultMaxConcurrentFiles = 1024
 	defaultEncoding           = "utf-8"
 	defaultPollInterval       = 200 * time.Millisecond
+	compressionAuto           = "auto"
 )
 
 var allowFileDeletion = featuregate.GlobalRegistry().MustRegister(
@@ -190,6 +192,7 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		maxBatches:       c.MaxBatches,
 		telemetryBuilder: telemetryBuilder,
 		noTracking:       o.noTracking,
+		readArchives:     c.Compression == compressionAuto,
 	}, nil
 }
 
@@ -219,6 +222,10 @@ func (c Config) validate() error {
 		return err
 	}
 
+	if c.Compression != "" && c.Compression != compressionAuto && !decompress.IsFormat(c.Compression) {
+		return fmt.Errorf("invalid compression '%s'", c.Compression)
+	}
+
 	if c.DeleteAfterRead {
 		if !allowFileDeletion.IsEnabled() {
 			return fmt.Errorf("'delete_after_read' requires feature gate '%s'", allowFileDeletion.ID())
@@ -524,6 +524,35 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"ValidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "zstd"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "zstd", m.readerFactory.Compression)
+				require.False(t, m.readArchives)
+			},
+		},
+		{
+			"AutoCompressionReadsArchives",
+			func(cfg *Config) {
+				cfg.Compression = "auto"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.True(t, m.readArchives)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"LineStartAndEnd",
 			func(cfg *Config) {
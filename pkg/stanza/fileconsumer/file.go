@@ -15,6 +15,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/checkpoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/members"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/tracker"
@@ -32,6 +33,12 @@ type Manager struct {
 	tracker       tracker.Tracker
 	noTracking    bool
 
+	// readArchives enables reading each member of tar and zip archives as a logical file.
+	readArchives   bool
+	archives       map[string]*archiveIndex
+	polledArchives map[string]*archiveIndex
+	pendingMembers []pendingMembers
+
 	pollInterval   time.Duration
 	persister      operator.Persister
 	maxBatches     int
@@ -117,6 +124,12 @@ func (m *Manager) poll(ctx context.Context) {
 	// Used to keep track of the number of batches processed in this poll cycle
 	batchesProcessed := 0
 
+	// Only keep the index of the archives matched by this poll cycle.
+	m.polledArchives = make(map[string]*archiveIndex, len(m.archives))
+	defer func() {
+		m.archives = m.polledArchives
+	}()
+
 	// Get the list of paths on disk
 	matches, err := m.fileMatcher.MatchFiles()
 	if err != nil {
@@ -158,7 +171,14 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 	m.makeReaders(ctx, paths)
 
 	m.readLostFiles(ctx)
+	m.readCurrentPollFiles(ctx)
 
+	// The members of archives that did not fit in this batch are read in batches of their own.
+	m.consumePendingMembers(ctx)
+}
+
+// readCurrentPollFiles reads the readers of the current batch to end.
+func (m *Manager) readCurrentPollFiles(ctx context.Context) {
 	// read new readers to end
 	var wg sync.WaitGroup
 	for _, r := range m.tracker.CurrentPollFiles() {
@@ -204,7 +224,15 @@ func (m *Manager) makeFingerprint(path string) (*fingerprint.Fingerprint, *os.Fi
 // discarding any that have a duplicate fingerprint to other files that have already
 // been read this polling interval
 func (m *Manager) makeReaders(ctx context.Context, paths []string) {
+	// Archives are opened after the files, so that their members only fill the rest of the batch.
+	var archives []string
+
 	for _, path := range paths {
+		if m.readArchives && members.IsArchive(path) {
+			archives = append(archives, path)
+			continue
+		}
+
 		fp, file := m.makeFingerprint(path)
 		if fp == nil {
 			continue
@@ -230,6 +258,10 @@ func (m *Manager) makeReaders(ctx context.Context, paths []string) {
 
 		m.tracker.Add(r)
 	}
+
+	for _, path := range archives {
+		m.makeMemberReaders(ctx, path)
+	}
 }
 
 func (m *Manager) newReader(ctx context.Context, file *os.File, fp *fingerprint.Fingerprint) (*reader.Reader, error) {
@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/featuregate"
@@ -1570,6 +1571,41 @@ func TestReadGzipCompressedLogsFromBeginning(t *testing.T) {
 	sink.ExpectToken(t, []byte("testlog2"))
 }
 
+// TestReadCompressedLogsAutoDetected tests that, with auto compression, files are decompressed
+// based on their extension
+func TestReadCompressedLogsAutoDetected(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "auto"
+	operator, sink := testManager(t, cfg)
+
+	zstdFile := filetest.OpenTempWithPattern(t, tempDir, "*.log.zst")
+	zstdWriter, err := zstd.NewWriter(zstdFile)
+	require.NoError(t, err)
+	_, err = zstdWriter.Write([]byte("zstdlog1\nzstdlog2\n"))
+	require.NoError(t, err)
+	require.NoError(t, zstdWriter.Close())
+
+	plainFile := filetest.OpenTempWithPattern(t, tempDir, "*.log")
+	filetest.WriteString(t, plainFile, "plainlog1\n")
+
+	operator.poll(context.TODO())
+	sink.ExpectTokens(t, []byte("zstdlog1"), []byte("zstdlog2"), []byte("plainlog1"))
+
+	// appended streams are read on the next poll
+	zstdWriter, err = zstd.NewWriter(zstdFile)
+	require.NoError(t, err)
+	_, err = zstdWriter.Write([]byte("zstdlog3\n"))
+	require.NoError(t, err)
+	require.NoError(t, zstdWriter.Close())
+
+	operator.poll(context.TODO())
+	sink.ExpectToken(t, []byte("zstdlog3"))
+}
+
 // TestReadGzipCompressedLogsFromEnd tests that, when starting at the end of a gzip compressed file, we
 // read all the lines that are added afterward
 func TestReadGzipCompressedLogsFromEnd(t *testing.T) {
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package decompress decompresses gzip, zstd, bzip2 and xz streams.
+package decompress // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/decompress"
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	Gzip  = "gzip"
+	Zstd  = "zstd"
+	Bzip2 = "bzip2"
+	Xz    = "xz"
+)
+
+// extensions maps the file extensions to the compression formats.
+var extensions = map[string]string{
+	".gz":   Gzip,
+	".tgz":  Gzip,
+	".zst":  Zstd,
+	".zstd": Zstd,
+	".tzst": Zstd,
+	".bz2":  Bzip2,
+	".tbz2": Bzip2,
+	".xz":   Xz,
+	".txz":  Xz,
+}
+
+// IsFormat returns true if format is a supported compression format.
+func IsFormat(format string) bool {
+	switch format {
+	case Gzip, Zstd, Bzip2, Xz:
+		return true
+	}
+	return false
+}
+
+// FromExtension returns the compression format of a file based on its extension,
+// or an empty string if the file is not compressed.
+func FromExtension(path string) string {
+	return extensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// NewReader returns a reader decompressing r with the given format.
+// Concatenated streams are read as a single stream.
+func NewReader(format string, r io.Reader) (io.ReadCloser, error) {
+	switch format {
+	case Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case Bzip2:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case Xz:
+		xr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xr), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression format %q", format)
+	}
+}
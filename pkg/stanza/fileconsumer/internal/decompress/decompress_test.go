@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decompress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+)
+
+func TestFromExtension(t *testing.T) {
+	tests := map[string]string{
+		"app.log":          "",
+		"app.log.gz":       Gzip,
+		"bundle.tgz":       Gzip,
+		"app.log.zst":      Zstd,
+		"app.log.ZSTD":     Zstd,
+		"app.log.bz2":      Bzip2,
+		"app.log.xz":       Xz,
+		"bundle.txz":       Xz,
+		"/var/log/app.zip": "",
+	}
+	for path, format := range tests {
+		assert.Equal(t, format, FromExtension(path), path)
+	}
+}
+
+func TestIsFormat(t *testing.T) {
+	for _, format := range []string{Gzip, Zstd, Bzip2, Xz} {
+		assert.True(t, IsFormat(format))
+	}
+	assert.False(t, IsFormat(""))
+	assert.False(t, IsFormat("auto"))
+	assert.False(t, IsFormat("lz4"))
+}
+
+func TestNewReader(t *testing.T) {
+	content := []byte("testlog1\ntestlog2\n")
+	tests := []struct {
+		format   string
+		compress func(t *testing.T, w io.Writer) io.WriteCloser
+	}{
+		{
+			format: Gzip,
+			compress: func(_ *testing.T, w io.Writer) io.WriteCloser {
+				return gzip.NewWriter(w)
+			},
+		},
+		{
+			format: Zstd,
+			compress: func(t *testing.T, w io.Writer) io.WriteCloser {
+				zw, err := zstd.NewWriter(w)
+				require.NoError(t, err)
+				return zw
+			},
+		},
+		{
+			format: Xz,
+			compress: func(t *testing.T, w io.Writer) io.WriteCloser {
+				xw, err := xz.NewWriter(w)
+				require.NoError(t, err)
+				return xw
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			// concatenated streams are read as a single stream
+			var buf bytes.Buffer
+			for i := 0; i < 2; i++ {
+				w := tt.compress(t, &buf)
+				_, err := w.Write(content)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+			}
+
+			r, err := NewReader(tt.format, &buf)
+			require.NoError(t, err)
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, append(append([]byte{}, content...), content...), got)
+		})
+	}
+}
+
+func TestNewReaderBzip2(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "sample.log.bz2"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := NewReader(Bzip2, f)
+	require.NoError(t, err)
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "testlog1\ntestlog2\n", string(got))
+}
+
+func TestNewReaderUnsupported(t *testing.T) {
+	_, err := NewReader("lz4", bytes.NewReader(nil))
+	assert.EqualError(t, err, `unsupported compression format "lz4"`)
+}
@@ -5,15 +5,16 @@ package fingerprint // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"bytes"
-	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
-	"path/filepath"
 
 	"go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/decompress"
 )
 
 const DefaultSize = 1000 // bytes
@@ -44,16 +45,16 @@ func NewFromFile(file *os.File, size int, decompressData bool) (*Fingerprint, er
 	buf := make([]byte, size)
 	if DecompressedFingerprintFeatureGate.IsEnabled() {
 		if decompressData {
-			if hasGzipExtension(file.Name()) {
+			if format := decompress.FromExtension(file.Name()); format != "" {
 				// If the file is of compressed type, uncompress the data before creating its fingerprint
-				uncompressedData, err := gzip.NewReader(file)
+				uncompressedData, err := decompress.NewReader(format, io.NewSectionReader(file, 0, math.MaxInt64))
 				if err != nil {
-					return nil, fmt.Errorf("error uncompressing gzip file: %w", err)
+					return nil, fmt.Errorf("error uncompressing %s file: %w", format, err)
 				}
 				defer uncompressedData.Close()
 
-				n, err := uncompressedData.Read(buf)
-				if err != nil && !errors.Is(err, io.EOF) {
+				n, err := io.ReadFull(uncompressedData, buf)
+				if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
 					return nil, fmt.Errorf("error reading fingerprint bytes: %w", err)
 				}
 				return New(buf[:n]), nil
@@ -68,10 +69,6 @@ func NewFromFile(file *os.File, size int, decompressData bool) (*Fingerprint, er
 	return New(buf[:n]), nil
 }
 
-func hasGzipExtension(filename string) bool {
-	return filepath.Ext(filename) == ".gz"
-}
-
 // Copy creates a new copy of the fingerprint
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.firstBytes), cap(f.firstBytes))
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package members lists and opens the members of tar and zip archives,
+// so that each of them can be read as a logical file.
+package members // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/members"
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/decompress"
+)
+
+// Member is a regular file in an archive.
+type Member struct {
+	// Name is the path of the member in the archive.
+	Name string
+	// Size is the uncompressed size of the member.
+	Size int64
+	// FirstBytes holds the first bytes of the member, used to compute its fingerprint.
+	FirstBytes []byte
+}
+
+// IsArchive returns true if the file is a tar archive, optionally compressed, or a zip archive,
+// based on its extension.
+func IsArchive(path string) bool {
+	return isZip(path) || isTar(path)
+}
+
+func isZip(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".zip")
+}
+
+func isTar(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".tar", ".tgz", ".tzst", ".tbz2", ".txz":
+		return true
+	}
+	return decompress.FromExtension(path) != "" && strings.EqualFold(filepath.Ext(strings.TrimSuffix(path, filepath.Ext(path))), ".tar")
+}
+
+// List returns the non-empty regular files of the archive, with up to firstBytes bytes of their content.
+func List(file io.ReaderAt, size int64, path string, firstBytes int) ([]Member, error) {
+	var members []Member
+	err := walk(file, size, path, func(name string, memberSize int64, r io.Reader) (bool, error) {
+		buf := make([]byte, min(int64(firstBytes), memberSize))
+		n, err := io.ReadFull(r, buf)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, fmt.Errorf("read member %q: %w", name, err)
+		}
+		members = append(members, Member{Name: name, Size: memberSize, FirstBytes: buf[:n]})
+		return true, nil
+	})
+	return members, err
+}
+
+// Open returns the content of the member of the archive with the given name.
+func Open(file io.ReaderAt, size int64, path, name string) (io.ReadCloser, error) {
+	if isZip(path) {
+		zr, err := zip.NewReader(file, size)
+		if err != nil {
+			return nil, fmt.Errorf("open zip archive: %w", err)
+		}
+		for _, f := range zr.File {
+			if f.Name == name && f.Mode().IsRegular() {
+				return f.Open()
+			}
+		}
+		return nil, fmt.Errorf("member %q not found", name)
+	}
+
+	stream, err := openTar(file, size, path)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(stream)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			stream.Close()
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("member %q not found", name)
+			}
+			return nil, fmt.Errorf("read tar archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && hdr.Name == name {
+			return readCloser{Reader: tr, Closer: stream}, nil
+		}
+	}
+}
+
+// walk calls fn for each non-empty regular file of the archive, until fn returns false.
+func walk(file io.ReaderAt, size int64, path string, fn func(name string, size int64, r io.Reader) (bool, error)) error {
+	if isZip(path) {
+		zr, err := zip.NewReader(file, size)
+		if err != nil {
+			return fmt.Errorf("open zip archive: %w", err)
+		}
+		for _, f := range zr.File {
+			if !f.Mode().IsRegular() || f.UncompressedSize64 == 0 {
+				continue
+			}
+			ok, err := walkZipFile(f, fn)
+			if err != nil || !ok {
+				return err
+			}
+		}
+		return nil
+	}
+
+	stream, err := openTar(file, size, path)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	tr := tar.NewReader(stream)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || hdr.Size == 0 {
+			continue
+		}
+		ok, err := fn(hdr.Name, hdr.Size, tr)
+		if err != nil || !ok {
+			return err
+		}
+	}
+}
+
+func walkZipFile(f *zip.File, fn func(name string, size int64, r io.Reader) (bool, error)) (bool, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return false, fmt.Errorf("open member %q: %w", f.Name, err)
+	}
+	defer rc.Close()
+	return fn(f.Name, int64(f.UncompressedSize64), rc)
+}
+
+// openTar returns the tar stream of the archive, decompressed based on the extension of the archive.
+func openTar(file io.ReaderAt, size int64, path string) (io.ReadCloser, error) {
+	stream := io.NewSectionReader(file, 0, size)
+	format := decompress.FromExtension(path)
+	if format == "" {
+		return io.NopCloser(stream), nil
+	}
+	dr, err := decompress.NewReader(format, stream)
+	if err != nil {
+		return nil, fmt.Errorf("open compressed archive: %w", err)
+	}
+	return dr, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package members
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+)
+
+type file struct {
+	name    string
+	content string
+}
+
+var files = []file{
+	{name: "logs/app.log", content: "app1\napp2\n"},
+	{name: "logs/empty.log"},
+	{name: "system.log", content: "system1\n"},
+}
+
+func newTar(t *testing.T, w io.Writer) {
+	tw := tar.NewWriter(w)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "logs/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(f.content))}))
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+}
+
+func newArchive(t *testing.T, path string) []byte {
+	var buf bytes.Buffer
+	switch path {
+	case "bundle.tar":
+		newTar(t, &buf)
+	case "bundle.tar.gz", "bundle.tgz":
+		gw := gzip.NewWriter(&buf)
+		newTar(t, gw)
+		require.NoError(t, gw.Close())
+	case "bundle.tar.zst":
+		zw, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		newTar(t, zw)
+		require.NoError(t, zw.Close())
+	case "bundle.tar.xz":
+		xw, err := xz.NewWriter(&buf)
+		require.NoError(t, err)
+		newTar(t, xw)
+		require.NoError(t, xw.Close())
+	case "bundle.zip":
+		zw := zip.NewWriter(&buf)
+		_, err := zw.Create("logs/")
+		require.NoError(t, err)
+		for _, f := range files {
+			w, err := zw.Create(f.name)
+			require.NoError(t, err)
+			_, err = w.Write([]byte(f.content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+	default:
+		t.Fatalf("unexpected archive %q", path)
+	}
+	return buf.Bytes()
+}
+
+func TestIsArchive(t *testing.T) {
+	tests := map[string]bool{
+		"bundle.tar":     true,
+		"bundle.tar.gz":  true,
+		"bundle.TGZ":     true,
+		"bundle.tar.zst": true,
+		"bundle.tar.bz2": true,
+		"bundle.tar.xz":  true,
+		"bundle.zip":     true,
+		"app.log":        false,
+		"app.log.gz":     false,
+		"app.tar.log":    false,
+	}
+	for path, expected := range tests {
+		assert.Equal(t, expected, IsArchive(path), path)
+	}
+}
+
+func TestListAndOpen(t *testing.T) {
+	for _, path := range []string{"bundle.tar", "bundle.tar.gz", "bundle.tgz", "bundle.tar.zst", "bundle.tar.xz", "bundle.zip"} {
+		t.Run(path, func(t *testing.T) {
+			archive := newArchive(t, path)
+			r := bytes.NewReader(archive)
+
+			list, err := List(r, int64(len(archive)), path, 4)
+			require.NoError(t, err)
+			assert.Equal(t, []Member{
+				{Name: "logs/app.log", Size: 10, FirstBytes: []byte("app1")},
+				{Name: "system.log", Size: 8, FirstBytes: []byte("syst")},
+			}, list)
+
+			for _, f := range []file{files[0], files[2]} {
+				rc, err := Open(r, int64(len(archive)), path, f.name)
+				require.NoError(t, err)
+				content, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				require.NoError(t, rc.Close())
+				assert.Equal(t, f.content, string(content))
+			}
+
+			_, err = Open(r, int64(len(archive)), path, "missing.log")
+			assert.EqualError(t, err, `member "missing.log" not found`)
+		})
+	}
+}
+
+func TestListShortMember(t *testing.T) {
+	archive := newArchive(t, "bundle.tar")
+	list, err := List(bytes.NewReader(archive), int64(len(archive)), "bundle.tar", 1000)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, []byte("app1\napp2\n"), list[0].FirstBytes)
+}
+
+func TestListInvalidArchive(t *testing.T) {
+	content := []byte("not an archive")
+	_, err := List(bytes.NewReader(content), int64(len(content)), "bundle.zip", 10)
+	assert.ErrorContains(t, err, "open zip archive")
+	_, err = List(bytes.NewReader(content), int64(len(content)), "bundle.tar.gz", 10)
+	assert.ErrorContains(t, err, "open compressed archive")
+}
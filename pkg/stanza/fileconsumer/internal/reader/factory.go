@@ -18,8 +18,10 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/decompress"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/members"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/flush"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/tokenlen"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/trim"
@@ -61,8 +63,8 @@ func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader
 		return nil, err
 	}
 	var filetype string
-	if filepath.Ext(file.Name()) == gzipExtension {
-		filetype = gzipExtension
+	if decompress.FromExtension(file.Name()) != "" {
+		filetype = filepath.Ext(file.Name())
 	}
 
 	m := &Metadata{
@@ -78,23 +80,7 @@ func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader
 }
 
 func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader, err error) {
-	r = &Reader{
-		Metadata:          m,
-		set:               f.TelemetrySettings,
-		file:              file,
-		fileName:          file.Name(),
-		fingerprintSize:   f.FingerprintSize,
-		bufPool:           &f.BufPool,
-		initialBufferSize: f.InitialBufferSize,
-		maxLogSize:        f.MaxLogSize,
-		decoder:           f.Encoding.NewDecoder(),
-		deleteAtEOF:       f.DeleteAtEOF,
-		compression:       f.Compression,
-		acquireFSLock:     f.AcquireFSLock,
-		maxBatchSize:      DefaultMaxBatchSize,
-		emitFunc:          f.EmitFunc,
-	}
-	r.set.Logger = r.set.Logger.With(zap.String("path", r.fileName))
+	r = f.newReader(file, m)
 
 	if r.Fingerprint.Len() > r.fingerprintSize {
 		// User has reconfigured fingerprint_size
@@ -116,6 +102,75 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 		r.Offset = info.Size()
 	}
 
+	attributes, err := f.Attributes.Resolve(file)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.initReader(r, attributes); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewMemberReader creates a reader for a member of the archive file. The metadata is nil
+// if the member was not read before.
+func (f *Factory) NewMemberReader(file *os.File, member members.Member, fp *fingerprint.Fingerprint, m *Metadata) (*Reader, error) {
+	if m == nil {
+		m = &Metadata{
+			FileAttributes: map[string]any{},
+			TokenLenState:  tokenlen.State{},
+			FlushState: flush.State{
+				LastDataChange: time.Now(),
+			},
+		}
+	}
+	// The fingerprint of the member is always computed from its beginning,
+	// so it replaces the fingerprint of the metadata if fingerprint_size was reconfigured.
+	m.Fingerprint = fp
+
+	r := f.newReader(file, m)
+	r.member = &member
+	r.set.Logger = r.set.Logger.With(zap.String("member", member.Name))
+	// The archive holds other members, so it is never deleted.
+	r.deleteAtEOF = false
+	if !f.FromBeginning {
+		r.Offset = member.Size
+	}
+
+	attributes, err := f.Attributes.ResolveMember(file, member.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.initReader(r, attributes); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (f *Factory) newReader(file *os.File, m *Metadata) *Reader {
+	r := &Reader{
+		Metadata:          m,
+		set:               f.TelemetrySettings,
+		file:              file,
+		fileName:          file.Name(),
+		fingerprintSize:   f.FingerprintSize,
+		bufPool:           &f.BufPool,
+		initialBufferSize: f.InitialBufferSize,
+		maxLogSize:        f.MaxLogSize,
+		decoder:           f.Encoding.NewDecoder(),
+		deleteAtEOF:       f.DeleteAtEOF,
+		compression:       f.Compression,
+		acquireFSLock:     f.AcquireFSLock,
+		maxBatchSize:      DefaultMaxBatchSize,
+		emitFunc:          f.EmitFunc,
+	}
+	r.set.Logger = r.set.Logger.With(zap.String("path", r.fileName))
+	return r
+}
+
+// initReader sets up the split functions and the header reader, and adds the resolved attributes of the file.
+func (f *Factory) initReader(r *Reader, attributes map[string]any) (err error) {
+	m := r.Metadata
 	tokenLenFunc := m.TokenLenState.Func(f.SplitFunc)
 	flushFunc := m.FlushState.Func(tokenLenFunc, f.FlushTimeout)
 	r.contentSplitFunc = trim.WithFunc(trim.ToLength(flushFunc, f.MaxLogSize), f.TrimFunc)
@@ -124,18 +179,13 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 		r.headerSplitFunc = f.HeaderConfig.SplitFunc
 		r.headerReader, err = header.NewReader(f.TelemetrySettings, *f.HeaderConfig)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	attributes, err := f.Attributes.Resolve(file)
-	if err != nil {
-		return nil, err
-	}
 	// Copy attributes into existing map to avoid overwriting header attributes
 	for k, v := range attributes {
 		r.FileAttributes[k] = v
 	}
-
-	return r, nil
+	return nil
 }
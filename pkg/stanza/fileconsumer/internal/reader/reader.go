@@ -5,7 +5,6 @@ package reader // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"bufio"
-	"compress/gzip"
 	"context"
 	"errors"
 	"io"
@@ -18,15 +17,15 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/decompress"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/members"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/flush"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/tokenlen"
 )
 
-const gzipExtension = ".gz"
-
 type Metadata struct {
 	Fingerprint     *fingerprint.Fingerprint
 	Offset          int64
@@ -57,6 +56,7 @@ type Reader struct {
 	deleteAtEOF            bool
 	needsUpdateFingerprint bool
 	compression            string
+	member                 *members.Member
 	acquireFSLock          bool
 	maxBatchSize           int
 }
@@ -70,21 +70,24 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		defer r.unlockFile()
 	}
 
-	switch r.compression {
-	case "gzip":
-		currentEOF, err := r.createGzipReader()
-		if err != nil {
+	if r.member != nil {
+		// Archives are not expected to change, so a member that was read entirely is done.
+		if r.Offset >= r.member.Size {
+			return
+		}
+		if err := r.openMember(); err != nil {
+			r.set.Logger.Error("failed to open archive member", zap.String("member", r.member.Name), zap.Error(err))
 			return
 		}
-		// Offset tracking in an uncompressed file is based on the length of emitted tokens, but in this case
-		// we need to set the offset to the end of the file.
-		defer func() {
-			r.Offset = currentEOF
-		}()
-	case "auto":
-		// Identifying a filename by its extension may not always be correct. We could have a compressed file without the .gz extension
-		if r.FileType == gzipExtension {
-			currentEOF, err := r.createGzipReader()
+		defer r.closeMember()
+	} else {
+		format := r.compression
+		if format == "auto" {
+			// Identifying a filename by its extension may not always be correct. We could have a compressed file without a known extension
+			format = decompress.FromExtension(r.FileType)
+		}
+		if decompress.IsFormat(format) {
+			currentEOF, err := r.createDecompressReader(format)
 			if err != nil {
 				return
 			}
@@ -96,13 +99,11 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		} else {
 			r.reader = r.file
 		}
-	default:
-		r.reader = r.file
-	}
 
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
-		r.set.Logger.Error("failed to seek", zap.Error(err))
-		return
+		if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			r.set.Logger.Error("failed to seek", zap.Error(err))
+			return
+		}
 	}
 
 	defer func() {
@@ -120,9 +121,9 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	r.readContents(ctx)
 }
 
-// createGzipReader creates gzip reader and returns the file offset
-func (r *Reader) createGzipReader() (int64, error) {
-	// We need to create a gzip reader each time ReadToEnd is called because the underlying
+// createDecompressReader creates a reader decompressing the file and returns the file offset
+func (r *Reader) createDecompressReader(format string) (int64, error) {
+	// We need to create a decompressing reader each time ReadToEnd is called because the underlying
 	// SectionReader can only read a fixed window (from previous offset to EOF).
 	info, err := r.file.Stat()
 	if err != nil {
@@ -130,19 +131,50 @@ func (r *Reader) createGzipReader() (int64, error) {
 		return 0, err
 	}
 	currentEOF := info.Size()
-	// use a gzip Reader with an underlying SectionReader to pick up at the last
-	// offset of a gzip compressed file
-	gzipReader, err := gzip.NewReader(io.NewSectionReader(r.file, r.Offset, currentEOF))
+	// use a decompressing Reader with an underlying SectionReader to pick up at the last
+	// offset of a compressed file
+	decompressReader, err := decompress.NewReader(format, io.NewSectionReader(r.file, r.Offset, currentEOF))
 	if err != nil {
 		if !errors.Is(err, io.EOF) {
-			r.set.Logger.Error("failed to create gzip reader", zap.Error(err))
+			r.set.Logger.Error("failed to create decompressing reader", zap.String("compression", format), zap.Error(err))
 		}
 		return 0, err
 	}
-	r.reader = gzipReader
+	r.reader = decompressReader
 	return currentEOF, nil
 }
 
+// openMember opens the archive member read by r, positioned at the current offset.
+// A compressed member cannot be seeked, so it is decompressed from its beginning and the bytes
+// before the offset are discarded. This costs a read of the member up to the offset each time
+// the member is opened. A member is usually read entirely in a single poll, and is only opened
+// again while its last token waits to be flushed, or after a restart.
+func (r *Reader) openMember() error {
+	info, err := r.file.Stat()
+	if err != nil {
+		return err
+	}
+	rc, err := members.Open(r.file, info.Size(), r.fileName, r.member.Name)
+	if err != nil {
+		return err
+	}
+	if _, err = io.CopyN(io.Discard, rc, r.Offset); err != nil {
+		rc.Close()
+		return err
+	}
+	r.reader = rc
+	return nil
+}
+
+func (r *Reader) closeMember() {
+	if rc, ok := r.reader.(io.Closer); ok {
+		if err := rc.Close(); err != nil {
+			r.set.Logger.Debug("Problem closing archive member", zap.Error(err))
+		}
+	}
+	r.reader = nil
+}
+
 func (r *Reader) readHeader(ctx context.Context) (doneReadingFile bool) {
 	bufPtr := r.getBufPtrFromPool()
 	defer r.bufPool.Put(bufPtr)
@@ -197,7 +229,13 @@ func (r *Reader) readHeader(ctx context.Context) (doneReadingFile bool) {
 	r.HeaderFinalized = true
 
 	// Reset position in file to r.Offest after the header scanner might have moved it past a content token.
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
+	if r.member != nil {
+		r.closeMember()
+		if err := r.openMember(); err != nil {
+			r.set.Logger.Error("failed to reopen archive member post-header", zap.Error(err))
+			return true
+		}
+	} else if _, err := r.file.Seek(r.Offset, 0); err != nil {
 		r.set.Logger.Error("failed to seek post-header", zap.Error(err))
 		return true
 	}
@@ -312,7 +350,15 @@ func (r *Reader) Read(dst []byte) (n int, err error) {
 }
 
 func (r *Reader) NameEquals(other *Reader) bool {
-	return r.fileName == other.fileName
+	return r.fileName == other.fileName && r.memberName() == other.memberName()
+}
+
+// memberName returns the name of the archive member read by r, or an empty string for a file.
+func (r *Reader) memberName() string {
+	if r.member == nil {
+		return ""
+	}
+	return r.member.Name
 }
 
 // Validate returns true if the reader still has a valid file handle, false otherwise.
@@ -320,6 +366,10 @@ func (r *Reader) Validate() bool {
 	if r.file == nil {
 		return false
 	}
+	if r.member != nil {
+		// Archives are not expected to change.
+		return true
+	}
 	refreshedFingerprint, err := fingerprint.NewFromFile(r.file, r.fingerprintSize, r.compression != "")
 	if err != nil {
 		return false
@@ -340,7 +390,7 @@ func (m Metadata) GetFingerprint() *fingerprint.Fingerprint {
 
 func (r *Reader) updateFingerprint() {
 	r.needsUpdateFingerprint = false
-	if r.file == nil {
+	if r.file == nil || r.member != nil {
 		return
 	}
 	refreshedFingerprint, err := fingerprint.NewFromFile(r.file, r.fingerprintSize, r.compression != "")
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/members"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/tracker"
+)
+
+// archiveIndex caches the members of an archive, so that an archive is only
+// decompressed again to list its members when it changes.
+type archiveIndex struct {
+	size    int64
+	modTime time.Time
+	members []members.Member
+}
+
+// pendingMembers holds the members of an archive that did not fit in the batch
+// of files being consumed, and are read in a batch of their own.
+type pendingMembers struct {
+	path    string
+	members []members.Member
+}
+
+// listMembers returns the members of the archive, from the index of the archive if it did not change.
+func (m *Manager) listMembers(file *os.File) ([]members.Member, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	path := file.Name()
+	index, ok := m.archives[path]
+	if !ok || index.size != info.Size() || !index.modTime.Equal(info.ModTime()) {
+		list, err := members.List(file, info.Size(), path, m.readerFactory.FingerprintSize)
+		if err != nil {
+			return nil, err
+		}
+		index = &archiveIndex{size: info.Size(), modTime: info.ModTime(), members: list}
+	}
+	if m.polledArchives != nil {
+		m.polledArchives[path] = index
+	}
+	return index.members, nil
+}
+
+// makeMemberReaders creates a reader for each member of an archive, discarding the members
+// that have a duplicate fingerprint to other files that have already been read this polling interval.
+func (m *Manager) makeMemberReaders(ctx context.Context, path string) {
+	file, err := os.Open(path) // #nosec - operator must read in files defined by user
+	if err != nil {
+		m.set.Logger.Error("Failed to open archive", zap.Error(err))
+		return
+	}
+	list, err := m.listMembers(file)
+	if closeErr := file.Close(); closeErr != nil {
+		m.set.Logger.Debug("problem closing archive", zap.Error(closeErr))
+	}
+	if err != nil {
+		m.set.Logger.Error("Failed to list archive members", zap.String("path", path), zap.Error(err))
+		return
+	}
+	m.addMemberReaders(ctx, path, list)
+}
+
+// addMemberReaders creates a reader for each of the given members of an archive. Once the batch
+// holds maxBatchFiles readers, the remaining members are kept in pendingMembers.
+func (m *Manager) addMemberReaders(ctx context.Context, path string, list []members.Member) {
+	for i, member := range list {
+		if len(m.tracker.CurrentPollFiles()) >= m.maxBatchFiles {
+			m.pendingMembers = append(m.pendingMembers, pendingMembers{path: path, members: list[i:]})
+			return
+		}
+
+		fp := fingerprint.New(member.FirstBytes)
+		if fp.Len() == 0 {
+			continue
+		}
+
+		if r := m.tracker.GetCurrentFile(fp); r != nil {
+			m.set.Logger.Debug("Skipping duplicate archive member", zap.String("path", path), zap.String("member", member.Name))
+			// re-add the reader as Match() removes duplicates
+			m.tracker.Add(r)
+			continue
+		}
+
+		// Each member holds its own handle of the archive, which is closed with the reader.
+		file, err := os.Open(path) // #nosec - operator must read in files defined by user
+		if err != nil {
+			m.set.Logger.Error("Failed to open archive", zap.Error(err))
+			return
+		}
+		r, err := m.newMemberReader(ctx, file, member, fp)
+		if err != nil {
+			m.set.Logger.Error("Failed to create archive member reader", zap.String("member", member.Name), zap.Error(err))
+			if closeErr := file.Close(); closeErr != nil {
+				m.set.Logger.Debug("problem closing archive", zap.Error(closeErr))
+			}
+			continue
+		}
+		m.tracker.Add(r)
+	}
+}
+
+// consumePendingMembers reads the pending archive members in batches of at most maxBatchFiles readers.
+func (m *Manager) consumePendingMembers(ctx context.Context) {
+	for len(m.pendingMembers) > 0 {
+		pending := m.pendingMembers
+		m.pendingMembers = nil
+		for _, p := range pending {
+			m.addMemberReaders(ctx, p.path, p.members)
+		}
+		m.readCurrentPollFiles(ctx)
+	}
+}
+
+func (m *Manager) newMemberReader(ctx context.Context, file *os.File, member members.Member, fp *fingerprint.Fingerprint) (*reader.Reader, error) {
+	// Check previous poll cycle for match
+	if oldReader := m.tracker.GetOpenFile(fp); oldReader != nil {
+		return m.readerFactory.NewMemberReader(file, member, fp, oldReader.Close())
+	}
+
+	// Check for closed files for match
+	if oldMetadata := m.tracker.GetClosedFile(fp); oldMetadata != nil {
+		r, err := m.readerFactory.NewMemberReader(file, member, fp, oldMetadata)
+		if err != nil {
+			return nil, err
+		}
+		m.telemetryBuilder.FileconsumerOpenFiles.Add(ctx, 1)
+		return r, nil
+	}
+
+	if m.tracker.Name() != tracker.NoStateTracker {
+		m.set.Logger.Info("Started watching archive member", zap.String("path", file.Name()), zap.String("member", member.Name))
+	}
+
+	r, err := m.readerFactory.NewMemberReader(file, member, fp, nil)
+	if err != nil {
+		return nil, err
+	}
+	m.telemetryBuilder.FileconsumerOpenFiles.Add(ctx, 1)
+	return r, nil
+}
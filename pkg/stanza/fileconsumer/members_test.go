@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+)
+
+func writeTarGz(t *testing.T, path string, members map[string]string) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for name, content := range members {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+}
+
+func writeZip(t *testing.T, path string, members map[string]string) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, content := range members {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func memberToken(body, archive, member string) emit.Token {
+	return emit.NewToken([]byte(body), map[string]any{
+		attrs.LogFileName: filepath.Base(member),
+		attrs.LogFilePath: filepath.Join(archive, member),
+	})
+}
+
+// TestReadArchiveMembers tests that each member of an archive is read as a logical file,
+// and that the members are only read once.
+func TestReadArchiveMembers(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "auto"
+	cfg.IncludeFilePath = true
+	operator, sink := testManager(t, cfg)
+
+	tarPath := filepath.Join(tempDir, "bundle.tar.gz")
+	writeTarGz(t, tarPath, map[string]string{
+		"var/log/app.log":    "app1\napp2\n",
+		"var/log/system.log": "system1\n",
+	})
+	zipPath := filepath.Join(tempDir, "bundle.zip")
+	writeZip(t, zipPath, map[string]string{
+		"logs/db.log": "db1\n",
+	})
+
+	operator.poll(context.Background())
+	sink.ExpectCalls(t,
+		memberToken("app1", tarPath, "var/log/app.log"),
+		memberToken("app2", tarPath, "var/log/app.log"),
+		memberToken("system1", tarPath, "var/log/system.log"),
+		memberToken("db1", zipPath, "logs/db.log"),
+	)
+
+	operator.poll(context.Background())
+	operator.poll(context.Background())
+	operator.poll(context.Background())
+	operator.poll(context.Background())
+	sink.ExpectNoCalls(t)
+	require.Len(t, operator.archives, 2)
+
+	// A member of a new archive with the same content as a known member is not read again.
+	writeTarGz(t, filepath.Join(tempDir, "copy.tar.gz"), map[string]string{
+		"app.log": "app1\napp2\n",
+		"new.log": "new1\n",
+	})
+	operator.poll(context.Background())
+	sink.ExpectCalls(t, memberToken("new1", filepath.Join(tempDir, "copy.tar.gz"), "new.log"))
+	sink.ExpectNoCalls(t)
+}
+
+// TestReadArchiveMembersFromEnd tests that, when starting at the end, the members of the archives
+// that already exist are not read.
+func TestReadArchiveMembersFromEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = "auto"
+	operator, sink := testManager(t, cfg)
+
+	writeZip(t, filepath.Join(tempDir, "old.zip"), map[string]string{"old.log": "old1\n"})
+	operator.poll(context.Background())
+	sink.ExpectNoCalls(t)
+
+	writeZip(t, filepath.Join(tempDir, "new.zip"), map[string]string{"new.log": "new1\n"})
+	operator.poll(context.Background())
+	sink.ExpectCall(t, []byte("new1"), map[string]any{attrs.LogFileName: "new.log"})
+	sink.ExpectNoCalls(t)
+}
+
+// TestArchivesAreFilesWithoutAutoCompression tests that archives are only read member by member
+// when the compression is detected automatically.
+func TestArchivesAreFilesWithoutAutoCompression(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, _ := testManager(t, cfg)
+
+	writeZip(t, filepath.Join(tempDir, "bundle.zip"), map[string]string{"app.log": "app1\n"})
+	operator.poll(context.Background())
+	require.Empty(t, operator.archives)
+}
+
+// TestReadArchiveMembersMaxConcurrentFiles tests that the members of an archive are opened
+// in batches of at most max_concurrent_files / 2 readers.
+func TestReadArchiveMembersMaxConcurrentFiles(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "auto"
+	cfg.MaxConcurrentFiles = 4
+	operator, sink := testManager(t, cfg)
+
+	zipPath := filepath.Join(tempDir, "bundle.zip")
+	writeZip(t, zipPath, map[string]string{
+		"a.log": "a1\n",
+		"b.log": "b1\n",
+		"c.log": "c1\n",
+		"d.log": "d1\n",
+		"e.log": "e1\n",
+	})
+	logPath := filepath.Join(tempDir, "app.log")
+	require.NoError(t, os.WriteFile(logPath, []byte("app1\n"), 0o600))
+
+	// The batch of two readers holds the file and the first member, and the other members are pending.
+	operator.polledArchives = make(map[string]*archiveIndex)
+	operator.makeReaders(context.Background(), []string{zipPath, logPath})
+	require.Len(t, operator.tracker.CurrentPollFiles(), 2)
+	require.Len(t, operator.pendingMembers, 1)
+	require.Len(t, operator.pendingMembers[0].members, 4)
+	operator.consumePendingMembers(context.Background())
+	require.Empty(t, operator.pendingMembers)
+
+	require.ElementsMatch(t, [][]byte{
+		[]byte("app1"), []byte("a1"), []byte("b1"), []byte("c1"), []byte("d1"), []byte("e1"),
+	}, sink.NextTokens(t, 6))
+	sink.ExpectNoCalls(t)
+
+	operator.poll(context.Background())
+	sink.ExpectNoCalls(t)
+}
@@ -3,9 +3,158 @@
 
 package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor"
 
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type ContextID string
+
+const (
+	resource ContextID = "resource"
+	record   ContextID = "record"
+)
+
+func (c *ContextID) UnmarshalText(text []byte) error {
+	str := ContextID(strings.ToLower(string(text)))
+	switch str {
+	case resource, record:
+		*c = str
+		return nil
+	default:
+		return fmt.Errorf("unknown context %s, available values: %s, %s", str, resource, record)
+	}
+}
+
 // Config holds the configuration for the DnsLookup processor.
-type Config struct{}
+type Config struct {
+	// Resolve contains the configuration for forward DNS lookups (hostname to IP address).
+	Resolve LookupConfig `mapstructure:"resolve"`
+
+	// Reverse contains the configuration for reverse DNS lookups (IP address to hostname).
+	Reverse LookupConfig `mapstructure:"reverse"`
+
+	// HitCacheSize is the maximum number of successful lookups kept in the cache. Set to 0 to disable it.
+	HitCacheSize int `mapstructure:"hit_cache_size"`
+
+	// HitCacheTTL is the time successful lookups are kept in the cache.
+	HitCacheTTL time.Duration `mapstructure:"hit_cache_ttl"`
+
+	// MissCacheSize is the maximum number of failed lookups kept in the cache. Set to 0 to disable it.
+	MissCacheSize int `mapstructure:"miss_cache_size"`
+
+	// MissCacheTTL is the time failed lookups are kept in the cache.
+	MissCacheTTL time.Duration `mapstructure:"miss_cache_ttl"`
+
+	// MaxRetries is the maximum number of retries for lookups that time out or fail temporarily.
+	MaxRetries int `mapstructure:"max_retries"`
+
+	// Timeout is the maximum time to wait for a single DNS query.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Hostfiles is a list of files in the hosts(5) format, checked before any DNS server.
+	Hostfiles []string `mapstructure:"hostfiles"`
+
+	// Nameservers is a list of DNS servers ("host" or "host:port") used for lookups, queried in a round-robin fashion.
+	Nameservers []string `mapstructure:"nameservers"`
+
+	// EnableSystemResolver enables the DNS resolver of the operating system as a fallback
+	// after the host files and the nameservers.
+	EnableSystemResolver bool `mapstructure:"enable_system_resolver"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// LookupConfig defines the attributes used by a lookup.
+type LookupConfig struct {
+	// Enabled turns the lookup on or off.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Context specifies where to look for the attributes. Available options: resource or record.
+	Context ContextID `mapstructure:"context"`
+
+	// SourceAttributes is the list of attributes holding the value to look up. The first attribute found is used.
+	SourceAttributes []string `mapstructure:"source_attributes"`
+
+	// TargetAttribute is the attribute the lookup result is written to.
+	TargetAttribute string `mapstructure:"target_attribute"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
 
 func (cfg *Config) Validate() error {
-	return nil
+	var errs error
+	if !cfg.Resolve.Enabled && !cfg.Reverse.Enabled {
+		errs = errors.Join(errs, errors.New("at least one of resolve or reverse must be enabled"))
+	}
+	if cfg.Resolve.Enabled {
+		errs = errors.Join(errs, cfg.Resolve.validate("resolve"))
+	}
+	if cfg.Reverse.Enabled {
+		errs = errors.Join(errs, cfg.Reverse.validate("reverse"))
+	}
+
+	if cfg.HitCacheSize < 0 {
+		errs = errors.Join(errs, errors.New("hit_cache_size must not be negative"))
+	}
+	if cfg.HitCacheSize > 0 && cfg.HitCacheTTL <= 0 {
+		errs = errors.Join(errs, errors.New("hit_cache_ttl must be positive"))
+	}
+	if cfg.MissCacheSize < 0 {
+		errs = errors.Join(errs, errors.New("miss_cache_size must not be negative"))
+	}
+	if cfg.MissCacheSize > 0 && cfg.MissCacheTTL <= 0 {
+		errs = errors.Join(errs, errors.New("miss_cache_ttl must be positive"))
+	}
+	if cfg.MaxRetries < 0 {
+		errs = errors.Join(errs, errors.New("max_retries must not be negative"))
+	}
+	if cfg.Timeout <= 0 {
+		errs = errors.Join(errs, errors.New("timeout must be positive"))
+	}
+
+	for _, ns := range cfg.Nameservers {
+		host := ns
+		if h, _, err := net.SplitHostPort(ns); err == nil {
+			host = h
+		}
+		if strings.Trim(host, "[]") == "" {
+			errs = errors.Join(errs, fmt.Errorf("invalid nameserver %q", ns))
+		}
+	}
+
+	if len(cfg.Hostfiles) == 0 && len(cfg.Nameservers) == 0 && !cfg.EnableSystemResolver {
+		errs = errors.Join(errs, errors.New("at least one of hostfiles, nameservers or enable_system_resolver must be set"))
+	}
+
+	return errs
+}
+
+func (lc *LookupConfig) validate(name string) error {
+	var errs error
+	if lc.Context == "" {
+		errs = errors.Join(errs, fmt.Errorf("%s: context must be specified", name))
+	}
+	if len(lc.SourceAttributes) == 0 {
+		errs = errors.Join(errs, fmt.Errorf("%s: source_attributes must not be empty", name))
+	}
+	for _, attr := range lc.SourceAttributes {
+		if attr == "" {
+			errs = errors.Join(errs, fmt.Errorf("%s: source_attributes must not contain empty attribute names", name))
+			break
+		}
+	}
+	if lc.TargetAttribute == "" {
+		errs = errors.Join(errs, fmt.Errorf("%s: target_attribute must be specified", name))
+	}
+	return errs
 }
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id                    component.ID
+		expected              component.Config
+		validateErrorMessage  string
+		unmarshalErrorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Resolve: LookupConfig{
+					Enabled:          true,
+					Context:          record,
+					SourceAttributes: []string{"custom.address", "source.address"},
+					TargetAttribute:  "custom.ip",
+				},
+				Reverse: LookupConfig{
+					Enabled:          true,
+					Context:          resource,
+					SourceAttributes: []string{"custom.ip"},
+					TargetAttribute:  "custom.hostname",
+				},
+				HitCacheSize:         100,
+				HitCacheTTL:          30 * time.Second,
+				MissCacheSize:        10,
+				MissCacheTTL:         time.Second,
+				MaxRetries:           1,
+				Timeout:              time.Second,
+				Hostfiles:            []string{"testdata/hosts"},
+				Nameservers:          []string{"127.0.0.1", "[::1]:5353"},
+				EnableSystemResolver: false,
+			},
+		},
+		{
+			id:                    component.NewIDWithName(metadata.Type, "invalid_context"),
+			unmarshalErrorMessage: "unknown context span, available values: resource, record",
+		},
+		{
+			id:                   component.NewIDWithName(metadata.Type, "no_lookup"),
+			validateErrorMessage: "at least one of resolve or reverse must be enabled",
+		},
+		{
+			id:                   component.NewIDWithName(metadata.Type, "no_resolver"),
+			validateErrorMessage: "at least one of hostfiles, nameservers or enable_system_resolver must be set",
+		},
+		{
+			id:                   component.NewIDWithName(metadata.Type, "invalid_resolve"),
+			validateErrorMessage: "resolve: source_attributes must not be empty\nresolve: target_attribute must be specified\ntimeout must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			if tt.unmarshalErrorMessage != "" {
+				assert.ErrorContains(t, sub.Unmarshal(cfg), tt.unmarshalErrorMessage)
+				return
+			}
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.validateErrorMessage != "" {
+				assert.EqualError(t, xconfmap.Validate(cfg), tt.validateErrorMessage)
+				return
+			}
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
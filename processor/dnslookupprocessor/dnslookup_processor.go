@@ -5,26 +5,181 @@ package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"errors"
 
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
 )
 
-type dnsLookupProcessor struct{}
+// lookupFunc resolves a single attribute value, returning the value to write into the target attribute.
+type lookupFunc func(ctx context.Context, value string) (string, error)
+
+type dnsLookupProcessor struct {
+	cfg      *Config
+	resolver resolver.Resolver
+	logger   *zap.Logger
+}
+
+func newDNSLookupProcessor(cfg *Config, set processor.Settings) (*dnsLookupProcessor, error) {
+	res, err := createResolver(cfg, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return &dnsLookupProcessor{
+		cfg:      cfg,
+		resolver: res,
+		logger:   set.Logger,
+	}, nil
+}
+
+// createResolver chains the configured resolvers: host files first, then the nameservers and
+// finally the system resolver.
+func createResolver(cfg *Config, logger *zap.Logger) (resolver.Resolver, error) {
+	var resolvers []resolver.Resolver
+	if len(cfg.Hostfiles) > 0 {
+		hostFileResolver, err := resolver.NewHostFileResolver(cfg.Hostfiles)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, hostFileResolver)
+	}
+	if len(cfg.Nameservers) > 0 {
+		resolvers = append(resolvers, resolver.NewNameserverResolver(cfg.Nameservers, cfg.Timeout, cfg.MaxRetries, logger))
+	}
+	if cfg.EnableSystemResolver {
+		resolvers = append(resolvers, resolver.NewSystemResolver(cfg.Timeout, cfg.MaxRetries, logger))
+	}
 
-func newDNSLookupProcessor() *dnsLookupProcessor {
-	return &dnsLookupProcessor{}
+	return resolver.NewChainResolver(resolvers, resolver.CacheConfig{
+		HitSize:  cfg.HitCacheSize,
+		HitTTL:   cfg.HitCacheTTL,
+		MissSize: cfg.MissCacheSize,
+		MissTTL:  cfg.MissCacheTTL,
+	}, logger), nil
 }
 
-func (g *dnsLookupProcessor) processMetrics(_ context.Context, ms pmetric.Metrics) (pmetric.Metrics, error) {
+func (dp *dnsLookupProcessor) processMetrics(ctx context.Context, ms pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		dp.processContext(ctx, resource, rm.Resource().Attributes())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			metrics := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				dp.processMetricAttributes(ctx, metrics.At(k))
+			}
+		}
+	}
 	return ms, nil
 }
 
-func (g *dnsLookupProcessor) processTraces(_ context.Context, ts ptrace.Traces) (ptrace.Traces, error) {
+func (dp *dnsLookupProcessor) processMetricAttributes(ctx context.Context, m pmetric.Metric) {
+	// This is a lot of repeated code, but since there is no single parent superclass
+	// between metric data types, we can't use polymorphism.
+	//exhaustive:enforce
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp.processContext(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp.processContext(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp.processContext(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp.processContext(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp.processContext(ctx, record, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
+
+func (dp *dnsLookupProcessor) processTraces(ctx context.Context, ts ptrace.Traces) (ptrace.Traces, error) {
+	rss := ts.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		dp.processContext(ctx, resource, rs.Resource().Attributes())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				dp.processContext(ctx, record, spans.At(k).Attributes())
+			}
+		}
+	}
 	return ts, nil
 }
 
-func (g *dnsLookupProcessor) processLogs(_ context.Context, ls plog.Logs) (plog.Logs, error) {
+func (dp *dnsLookupProcessor) processLogs(ctx context.Context, ls plog.Logs) (plog.Logs, error) {
+	rls := ls.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		dp.processContext(ctx, resource, rl.Resource().Attributes())
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			logs := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				dp.processContext(ctx, record, logs.At(k).Attributes())
+			}
+		}
+	}
 	return ls, nil
 }
+
+// processContext runs the lookups configured for the given context on the attributes.
+func (dp *dnsLookupProcessor) processContext(ctx context.Context, contextID ContextID, attributes pcommon.Map) {
+	if dp.cfg.Resolve.Enabled && dp.cfg.Resolve.Context == contextID {
+		dp.processAttributes(ctx, dp.cfg.Resolve, attributes, dp.resolver.Resolve)
+	}
+	if dp.cfg.Reverse.Enabled && dp.cfg.Reverse.Context == contextID {
+		dp.processAttributes(ctx, dp.cfg.Reverse, attributes, dp.resolver.Reverse)
+	}
+}
+
+// processAttributes looks up the value of the first source attribute that can be resolved and writes
+// the result into the target attribute. Lookup failures never drop telemetry, they are only logged.
+func (dp *dnsLookupProcessor) processAttributes(ctx context.Context, lookupCfg LookupConfig, attributes pcommon.Map, lookup lookupFunc) {
+	for _, attr := range lookupCfg.SourceAttributes {
+		value, found := attributes.Get(attr)
+		if !found || value.Type() != pcommon.ValueTypeStr || value.Str() == "" {
+			continue
+		}
+
+		result, err := lookup(ctx, value.Str())
+		switch {
+		case err == nil:
+			attributes.PutStr(lookupCfg.TargetAttribute, result)
+			return
+		case errors.Is(err, resolver.ErrInvalidHostname), errors.Is(err, resolver.ErrInvalidIP):
+			// The attribute doesn't hold a value this lookup can handle, try the next one.
+			continue
+		case errors.Is(err, resolver.ErrNoResolution):
+			dp.logger.Debug("No DNS resolution found", zap.String("attribute", attr), zap.String("value", value.Str()))
+			return
+		default:
+			dp.logger.Debug("DNS lookup failed", zap.String("attribute", attr), zap.String("value", value.Str()), zap.Error(err))
+			return
+		}
+	}
+}
+
+func (dp *dnsLookupProcessor) shutdown(_ context.Context) error {
+	return dp.resolver.Close()
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dnslookupprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/metadata"
+)
+
+func hostFileConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Hostfiles = []string{"testdata/hosts"}
+	cfg.EnableSystemResolver = false
+	return cfg
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) *dnsLookupProcessor {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	dp, err := newDNSLookupProcessor(cfg, processortest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, dp.shutdown(context.Background()))
+	})
+	return dp
+}
+
+func TestProcessAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      func() *Config
+		attrs    map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "resolve hostname",
+			cfg:      hostFileConfig,
+			attrs:    map[string]any{"source.address": "service-a.example.com"},
+			expected: map[string]any{"source.address": "service-a.example.com", "source.ip": "192.168.1.10"},
+		},
+		{
+			name:     "resolve alias",
+			cfg:      hostFileConfig,
+			attrs:    map[string]any{"source.address": "SERVICE-A"},
+			expected: map[string]any{"source.address": "SERVICE-A", "source.ip": "192.168.1.10"},
+		},
+		{
+			name:     "resolve unknown hostname",
+			cfg:      hostFileConfig,
+			attrs:    map[string]any{"source.address": "unknown.example.com"},
+			expected: map[string]any{"source.address": "unknown.example.com"},
+		},
+		{
+			name:     "source attribute holds an IP address",
+			cfg:      hostFileConfig,
+			attrs:    map[string]any{"source.address": "192.168.1.10"},
+			expected: map[string]any{"source.address": "192.168.1.10"},
+		},
+		{
+			name:     "source attribute is not a string",
+			cfg:      hostFileConfig,
+			attrs:    map[string]any{"source.address": 42},
+			expected: map[string]any{"source.address": int64(42)},
+		},
+		{
+			name: "first resolvable source attribute wins",
+			cfg: func() *Config {
+				cfg := hostFileConfig()
+				cfg.Resolve.SourceAttributes = []string{"host.name", "peer.name", "source.address"}
+				return cfg
+			},
+			attrs:    map[string]any{"host.name": "not a hostname", "peer.name": "service-b.example.com", "source.address": "service-a.example.com"},
+			expected: map[string]any{"host.name": "not a hostname", "peer.name": "service-b.example.com", "source.address": "service-a.example.com", "source.ip": "192.168.1.11"},
+		},
+		{
+			name: "reverse IP address",
+			cfg: func() *Config {
+				cfg := hostFileConfig()
+				cfg.Resolve.Enabled = false
+				cfg.Reverse.Enabled = true
+				return cfg
+			},
+			attrs:    map[string]any{"source.ip": "2001:db8:0::1"},
+			expected: map[string]any{"source.ip": "2001:db8:0::1", "source.address": "ipv6.example.com"},
+		},
+		{
+			name: "resolve and reverse",
+			cfg: func() *Config {
+				cfg := hostFileConfig()
+				cfg.Reverse.Enabled = true
+				cfg.Reverse.SourceAttributes = []string{"client.ip"}
+				cfg.Reverse.TargetAttribute = "client.address"
+				return cfg
+			},
+			attrs: map[string]any{"source.address": "service-b.example.com", "client.ip": "192.168.1.10"},
+			expected: map[string]any{
+				"source.address": "service-b.example.com",
+				"source.ip":      "192.168.1.11",
+				"client.ip":      "192.168.1.10",
+				"client.address": "service-a.example.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := newTestProcessor(t, tt.cfg())
+			attrs := pcommon.NewMap()
+			require.NoError(t, attrs.FromRaw(tt.attrs))
+
+			dp.processContext(context.Background(), resource, attrs)
+			assert.Equal(t, tt.expected, attrs.AsRaw())
+		})
+	}
+}
+
+func TestProcessSignals(t *testing.T) {
+	cfg := hostFileConfig()
+	cfg.Reverse.Enabled = true
+	cfg.Reverse.Context = record
+	dp := newTestProcessor(t, cfg)
+
+	t.Run("logs", func(t *testing.T) {
+		logs := plog.NewLogs()
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("source.address", "service-a.example.com")
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.Attributes().PutStr("source.ip", "192.168.1.11")
+
+		logs, err := dp.processLogs(context.Background(), logs)
+		require.NoError(t, err)
+		assertStr(t, logs.ResourceLogs().At(0).Resource().Attributes(), "source.ip", "192.168.1.10")
+		assertStr(t, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes(), "source.address", "service-b.example.com")
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		traces := ptrace.NewTraces()
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("source.address", "service-a.example.com")
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.Attributes().PutStr("source.ip", "192.168.1.11")
+
+		traces, err := dp.processTraces(context.Background(), traces)
+		require.NoError(t, err)
+		assertStr(t, traces.ResourceSpans().At(0).Resource().Attributes(), "source.ip", "192.168.1.10")
+		assertStr(t, traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes(), "source.address", "service-b.example.com")
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		metrics := pmetric.NewMetrics()
+		rm := metrics.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("source.address", "service-a.example.com")
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("source.ip", "192.168.1.11")
+
+		metrics, err := dp.processMetrics(context.Background(), metrics)
+		require.NoError(t, err)
+		assertStr(t, metrics.ResourceMetrics().At(0).Resource().Attributes(), "source.ip", "192.168.1.10")
+		assertStr(t, metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes(), "source.address", "service-b.example.com")
+	})
+}
+
+func TestInvalidHostfile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Hostfiles = []string{"testdata/does-not-exist"}
+	_, err := newDNSLookupProcessor(cfg, processortest.NewNopSettings(metadata.Type))
+	assert.ErrorContains(t, err, "failed to open host file")
+}
+
+func assertStr(t *testing.T, attrs pcommon.Map, key, expected string) {
+	t.Helper()
+	v, ok := attrs.Get(key)
+	require.True(t, ok, "attribute %q not found", key)
+	assert.Equal(t, expected, v.Str())
+}
@@ -5,11 +5,13 @@ package dnslookupprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/metadata"
 )
@@ -24,17 +26,49 @@ func NewFactory() processor.Factory {
 
 // createDefaultConfig returns a default configuration for the processor.
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		Resolve: LookupConfig{
+			Enabled:          true,
+			Context:          resource,
+			SourceAttributes: []string{string(semconv.SourceAddressKey)},
+			TargetAttribute:  "source.ip",
+		},
+		Reverse: LookupConfig{
+			Enabled:          false,
+			Context:          resource,
+			SourceAttributes: []string{"source.ip"},
+			TargetAttribute:  string(semconv.SourceAddressKey),
+		},
+		HitCacheSize:         1000,
+		HitCacheTTL:          60 * time.Second,
+		MissCacheSize:        1000,
+		MissCacheTTL:         5 * time.Second,
+		MaxRetries:           2,
+		Timeout:              500 * time.Millisecond,
+		EnableSystemResolver: true,
+	}
 }
 
 func createMetricsProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Metrics) (processor.Metrics, error) {
-	return processorhelper.NewMetrics(ctx, set, cfg, nextConsumer, newDNSLookupProcessor().processMetrics, processorhelper.WithCapabilities(processorCapabilities))
+	dp, err := newDNSLookupProcessor(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewMetrics(ctx, set, cfg, nextConsumer, dp.processMetrics, processorhelper.WithShutdown(dp.shutdown), processorhelper.WithCapabilities(processorCapabilities))
 }
 
 func createTracesProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Traces) (processor.Traces, error) {
-	return processorhelper.NewTraces(ctx, set, cfg, nextConsumer, newDNSLookupProcessor().processTraces, processorhelper.WithCapabilities(processorCapabilities))
+	dp, err := newDNSLookupProcessor(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTraces(ctx, set, cfg, nextConsumer, dp.processTraces, processorhelper.WithShutdown(dp.shutdown), processorhelper.WithCapabilities(processorCapabilities))
 }
 
 func createLogsProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Logs) (processor.Logs, error) {
-	return processorhelper.NewLogs(ctx, set, cfg, nextConsumer, newDNSLookupProcessor().processLogs, processorhelper.WithCapabilities(processorCapabilities))
+	dp, err := newDNSLookupProcessor(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewLogs(ctx, set, cfg, nextConsumer, dp.processLogs, processorhelper.WithShutdown(dp.shutdown), processorhelper.WithCapabilities(processorCapabilities))
 }
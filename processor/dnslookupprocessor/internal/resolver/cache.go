@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// ttlCache is a size bounded LRU cache whose entries expire after a fixed TTL.
+// Expired entries are removed lazily when they are read, so no background goroutine is needed.
+type ttlCache struct {
+	entries *lru.Cache[string, cacheEntry]
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func newTTLCache(size int, ttl time.Duration) *ttlCache {
+	// lru.New only fails when the size is not positive, which is checked by the callers.
+	entries, _ := lru.New[string, cacheEntry](size)
+	return &ttlCache{
+		entries: entries,
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+func (c *ttlCache) get(key string) (string, bool) {
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		return "", false
+	}
+	if c.now().After(entry.expiresAt) {
+		c.entries.Remove(key)
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache) add(key, value string) {
+	c.entries.Add(key, cacheEntry{value: value, expiresAt: c.now().Add(c.ttl)})
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CacheConfig configures the positive (hit) and negative (miss) caches of a ChainResolver.
+// A cache with a size lower or equal to zero is disabled.
+type CacheConfig struct {
+	HitSize  int
+	HitTTL   time.Duration
+	MissSize int
+	MissTTL  time.Duration
+}
+
+// ChainResolver tries each of its resolvers in order until one of them succeeds.
+// Successful and failed lookups are cached to avoid querying the resolvers for every telemetry item.
+type ChainResolver struct {
+	resolvers []Resolver
+	hitCache  *ttlCache
+	missCache *ttlCache
+	logger    *zap.Logger
+}
+
+var _ Resolver = (*ChainResolver)(nil)
+
+// NewChainResolver creates a ChainResolver querying the resolvers in the given order.
+func NewChainResolver(resolvers []Resolver, cacheCfg CacheConfig, logger *zap.Logger) *ChainResolver {
+	r := &ChainResolver{
+		resolvers: resolvers,
+		logger:    logger,
+	}
+	if cacheCfg.HitSize > 0 {
+		r.hitCache = newTTLCache(cacheCfg.HitSize, cacheCfg.HitTTL)
+	}
+	if cacheCfg.MissSize > 0 {
+		r.missCache = newTTLCache(cacheCfg.MissSize, cacheCfg.MissTTL)
+	}
+	return r
+}
+
+func (r *ChainResolver) Resolve(ctx context.Context, hostname string) (string, error) {
+	normalized, err := NormalizeHostname(hostname)
+	if err != nil {
+		return "", err
+	}
+	return r.lookup(ctx, "resolve:"+normalized, func(res Resolver) (string, error) {
+		return res.Resolve(ctx, normalized)
+	})
+}
+
+func (r *ChainResolver) Reverse(ctx context.Context, ip string) (string, error) {
+	parsed, err := ParseIP(ip)
+	if err != nil {
+		return "", err
+	}
+	return r.lookup(ctx, "reverse:"+parsed.String(), func(res Resolver) (string, error) {
+		return res.Reverse(ctx, parsed.String())
+	})
+}
+
+func (r *ChainResolver) lookup(ctx context.Context, key string, lookup func(Resolver) (string, error)) (string, error) {
+	if r.hitCache != nil {
+		if result, ok := r.hitCache.get(key); ok {
+			return result, nil
+		}
+	}
+	if r.missCache != nil {
+		if _, ok := r.missCache.get(key); ok {
+			return "", ErrNoResolution
+		}
+	}
+
+	var errs error
+	for _, res := range r.resolvers {
+		result, err := lookup(res)
+		if err == nil {
+			if r.hitCache != nil {
+				r.hitCache.add(key, result)
+			}
+			return result, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if !errors.Is(err, ErrNoResolution) {
+			r.logger.Debug("DNS resolver failed", zap.String("resolver", res.Name()), zap.String("lookup", key), zap.Error(err))
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	// Only cache the lookups that all resolvers answered without finding a record, so
+	// transient failures such as timeouts are retried on the next lookup.
+	if errs != nil {
+		return "", errs
+	}
+	if r.missCache != nil {
+		r.missCache.add(key, "")
+	}
+	return "", ErrNoResolution
+}
+
+func (r *ChainResolver) Name() string {
+	return "chain"
+}
+
+func (r *ChainResolver) Close() error {
+	var errs error
+	for _, res := range r.resolvers {
+		errs = errors.Join(errs, res.Close())
+	}
+	return errs
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockResolver struct {
+	hosts    map[string]string
+	err      error
+	resolves int
+	reverses int
+}
+
+func (m *mockResolver) Resolve(_ context.Context, hostname string) (string, error) {
+	m.resolves++
+	if m.err != nil {
+		return "", m.err
+	}
+	if ip, ok := m.hosts[hostname]; ok {
+		return ip, nil
+	}
+	return "", ErrNoResolution
+}
+
+func (m *mockResolver) Reverse(_ context.Context, ip string) (string, error) {
+	m.reverses++
+	if m.err != nil {
+		return "", m.err
+	}
+	for host, hostIP := range m.hosts {
+		if hostIP == ip {
+			return host, nil
+		}
+	}
+	return "", ErrNoResolution
+}
+
+func (m *mockResolver) Name() string { return "mock" }
+
+func (m *mockResolver) Close() error { return nil }
+
+func TestChainResolverOrder(t *testing.T) {
+	first := &mockResolver{hosts: map[string]string{"a.example.com": "10.0.0.1"}}
+	second := &mockResolver{hosts: map[string]string{"a.example.com": "10.0.0.2", "b.example.com": "10.0.0.3"}}
+	r := NewChainResolver([]Resolver{first, second}, CacheConfig{}, zap.NewNop())
+	ctx := context.Background()
+
+	ip, err := r.Resolve(ctx, "a.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip)
+	assert.Equal(t, 0, second.resolves)
+
+	ip, err = r.Resolve(ctx, "b.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.3", ip)
+
+	host, err := r.Reverse(ctx, "10.0.0.3")
+	require.NoError(t, err)
+	assert.Equal(t, "b.example.com", host)
+
+	_, err = r.Resolve(ctx, "c.example.com")
+	assert.ErrorIs(t, err, ErrNoResolution)
+}
+
+func TestChainResolverCache(t *testing.T) {
+	mock := &mockResolver{hosts: map[string]string{"a.example.com": "10.0.0.1"}}
+	r := NewChainResolver([]Resolver{mock}, CacheConfig{HitSize: 10, HitTTL: time.Minute, MissSize: 10, MissTTL: time.Minute}, zap.NewNop())
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		ip, err := r.Resolve(ctx, "a.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.1", ip)
+
+		_, err = r.Resolve(ctx, "missing.example.com")
+		assert.ErrorIs(t, err, ErrNoResolution)
+	}
+	assert.Equal(t, 2, mock.resolves, "hits and misses must be served from the cache")
+
+	// Expire all the cache entries.
+	now := time.Now().Add(2 * time.Minute)
+	r.hitCache.now = func() time.Time { return now }
+	r.missCache.now = func() time.Time { return now }
+
+	_, err := r.Resolve(ctx, "a.example.com")
+	require.NoError(t, err)
+	_, err = r.Resolve(ctx, "missing.example.com")
+	assert.ErrorIs(t, err, ErrNoResolution)
+	assert.Equal(t, 4, mock.resolves)
+}
+
+func TestChainResolverFailuresAreNotCached(t *testing.T) {
+	mock := &mockResolver{err: errors.New("timeout")}
+	r := NewChainResolver([]Resolver{mock}, CacheConfig{HitSize: 10, HitTTL: time.Minute, MissSize: 10, MissTTL: time.Minute}, zap.NewNop())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := r.Reverse(ctx, "10.0.0.1")
+		assert.ErrorContains(t, err, "timeout")
+	}
+	assert.Equal(t, 2, mock.reverses)
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HostFileResolver resolves hostnames and IP addresses using files in the hosts(5) format.
+// It never performs network requests, which makes it suitable for offline environments.
+type HostFileResolver struct {
+	hostToIP map[string]string
+	ipToHost map[string]string
+}
+
+var _ Resolver = (*HostFileResolver)(nil)
+
+// NewHostFileResolver loads the given host files. When a hostname or IP address is defined several
+// times, the first definition wins, following the order of the files and of the lines in each file.
+func NewHostFileResolver(paths []string) (*HostFileResolver, error) {
+	r := &HostFileResolver{
+		hostToIP: make(map[string]string),
+		ipToHost: make(map[string]string),
+	}
+	for _, path := range paths {
+		if err := r.load(path); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *HostFileResolver) load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open host file %q: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return fmt.Errorf("invalid line %d in host file %q: expected an IP address followed by at least one hostname", lineNumber, path)
+		}
+
+		ip, err := ParseIP(fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid line %d in host file %q: %w: %s", lineNumber, path, err, fields[0])
+		}
+
+		for i, field := range fields[1:] {
+			hostname, err := NormalizeHostname(field)
+			if err != nil {
+				return fmt.Errorf("invalid line %d in host file %q: %w: %s", lineNumber, path, err, field)
+			}
+			if _, ok := r.hostToIP[hostname]; !ok {
+				r.hostToIP[hostname] = ip.String()
+			}
+			// Only the canonical hostname, the first one in the line, is used for reverse lookups.
+			if _, ok := r.ipToHost[ip.String()]; !ok && i == 0 {
+				r.ipToHost[ip.String()] = hostname
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read host file %q: %w", path, err)
+	}
+	return nil
+}
+
+func (r *HostFileResolver) Resolve(_ context.Context, hostname string) (string, error) {
+	normalized, err := NormalizeHostname(hostname)
+	if err != nil {
+		return "", err
+	}
+	if ip, ok := r.hostToIP[normalized]; ok {
+		return ip, nil
+	}
+	return "", ErrNoResolution
+}
+
+func (r *HostFileResolver) Reverse(_ context.Context, ip string) (string, error) {
+	parsed, err := ParseIP(ip)
+	if err != nil {
+		return "", err
+	}
+	if hostname, ok := r.ipToHost[parsed.String()]; ok {
+		return hostname, nil
+	}
+	return "", ErrNoResolution
+}
+
+func (r *HostFileResolver) Name() string {
+	return "hostfiles"
+}
+
+func (r *HostFileResolver) Close() error {
+	return nil
+}
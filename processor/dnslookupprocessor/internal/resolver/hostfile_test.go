@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeHostFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hosts")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestHostFileResolver(t *testing.T) {
+	first := writeHostFile(t, `
+# comment
+10.0.0.1 first.example.com alias
+10.0.0.2 second.example.com
+`)
+	second := writeHostFile(t, `
+10.0.0.3 first.example.com
+10.0.0.2 other.example.com
+::1      localhost6
+`)
+
+	r, err := NewHostFileResolver([]string{first, second})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	ip, err := r.Resolve(ctx, "first.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip, "first definition wins")
+
+	ip, err = r.Resolve(ctx, "Alias.")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip)
+
+	ip, err = r.Resolve(ctx, "localhost6")
+	require.NoError(t, err)
+	assert.Equal(t, "::1", ip)
+
+	_, err = r.Resolve(ctx, "unknown.example.com")
+	assert.ErrorIs(t, err, ErrNoResolution)
+
+	_, err = r.Resolve(ctx, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidHostname)
+
+	host, err := r.Reverse(ctx, "10.0.0.2")
+	require.NoError(t, err)
+	assert.Equal(t, "second.example.com", host)
+
+	host, err = r.Reverse(ctx, "0:0:0:0:0:0:0:1")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost6", host)
+
+	_, err = r.Reverse(ctx, "10.0.0.9")
+	assert.ErrorIs(t, err, ErrNoResolution)
+
+	_, err = r.Reverse(ctx, "first.example.com")
+	assert.ErrorIs(t, err, ErrInvalidIP)
+}
+
+func TestHostFileResolverInvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "missing hostname",
+			content: "10.0.0.1\n",
+			errMsg:  "invalid line 1",
+		},
+		{
+			name:    "invalid IP address",
+			content: "\n10.0.0.300 host\n",
+			errMsg:  "invalid line 2",
+		},
+		{
+			name:    "invalid hostname",
+			content: "10.0.0.1 -host\n",
+			errMsg:  "invalid hostname: -host",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHostFileResolver([]string{writeHostFile(t, tt.content)})
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestNormalizeHostname(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{input: "Example.COM.", expected: "example.com", valid: true},
+		{input: "my_service.local", expected: "my_service.local", valid: true},
+		{input: "", valid: false},
+		{input: "10.0.0.1", valid: false},
+		{input: "::1", valid: false},
+		{input: "a..b", valid: false},
+		{input: "with space", valid: false},
+		{input: "trailing-.example.com", valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			hostname, err := NormalizeHostname(tt.input)
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidHostname)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, hostname)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const defaultDNSPort = "53"
+
+// lookuper is the subset of net.Resolver used by NameserverResolver, so it can be replaced in tests.
+type lookuper interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+}
+
+// NameserverResolver resolves hostnames and IP addresses by querying DNS servers. When no nameservers
+// are given it uses the resolver of the operating system.
+type NameserverResolver struct {
+	name       string
+	resolver   lookuper
+	timeout    time.Duration
+	maxRetries int
+	logger     *zap.Logger
+}
+
+var _ Resolver = (*NameserverResolver)(nil)
+
+// NewNameserverResolver creates a resolver querying the given nameservers in a round-robin fashion.
+// Nameservers without a port use the standard DNS port.
+func NewNameserverResolver(nameservers []string, timeout time.Duration, maxRetries int, logger *zap.Logger) *NameserverResolver {
+	addresses := make([]string, 0, len(nameservers))
+	for _, ns := range nameservers {
+		addresses = append(addresses, nameserverAddress(ns))
+	}
+
+	var next atomic.Uint32
+	return &NameserverResolver{
+		name: "nameservers",
+		resolver: &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				address := addresses[int(next.Add(1)-1)%len(addresses)]
+				d := net.Dialer{Timeout: timeout}
+				return d.DialContext(ctx, network, address)
+			},
+		},
+		timeout:    timeout,
+		maxRetries: maxRetries,
+		logger:     logger,
+	}
+}
+
+// NewSystemResolver creates a resolver using the DNS configuration of the operating system.
+func NewSystemResolver(timeout time.Duration, maxRetries int, logger *zap.Logger) *NameserverResolver {
+	return &NameserverResolver{
+		name:       "system",
+		resolver:   net.DefaultResolver,
+		timeout:    timeout,
+		maxRetries: maxRetries,
+		logger:     logger,
+	}
+}
+
+func nameserverAddress(nameserver string) string {
+	if _, _, err := net.SplitHostPort(nameserver); err == nil {
+		return nameserver
+	}
+	return net.JoinHostPort(strings.Trim(nameserver, "[]"), defaultDNSPort)
+}
+
+func (r *NameserverResolver) Resolve(ctx context.Context, hostname string) (string, error) {
+	normalized, err := NormalizeHostname(hostname)
+	if err != nil {
+		return "", err
+	}
+
+	var addrs []net.IPAddr
+	err = r.withRetries(ctx, func(ctx context.Context) (lookupErr error) {
+		addrs, lookupErr = r.resolver.LookupIPAddr(ctx, normalized)
+		return lookupErr
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(addrs) == 0 {
+		return "", ErrNoResolution
+	}
+	return addrs[0].IP.String(), nil
+}
+
+func (r *NameserverResolver) Reverse(ctx context.Context, ip string) (string, error) {
+	parsed, err := ParseIP(ip)
+	if err != nil {
+		return "", err
+	}
+
+	var names []string
+	err = r.withRetries(ctx, func(ctx context.Context) (lookupErr error) {
+		names, lookupErr = r.resolver.LookupAddr(ctx, parsed.String())
+		return lookupErr
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", ErrNoResolution
+	}
+	return strings.TrimSuffix(names[0], "."), nil
+}
+
+// withRetries runs the lookup, retrying it on timeouts and temporary errors. Lookups that
+// reach the DNS server but don't find any record return ErrNoResolution without retrying.
+func (r *NameserverResolver) withRetries(ctx context.Context, lookup func(context.Context) error) error {
+	var err error
+	for attempt := 0; attempt <= r.maxRetries; attempt++ {
+		lookupCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		err = lookup(lookupCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return ErrNoResolution
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.logger.Debug("DNS lookup failed", zap.String("resolver", r.name), zap.Int("attempt", attempt+1), zap.Error(err))
+	}
+	return err
+}
+
+func (r *NameserverResolver) Name() string {
+	return r.name
+}
+
+func (r *NameserverResolver) Close() error {
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeLookuper struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeLookuper) next() error {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func (f *fakeLookuper) LookupIPAddr(_ context.Context, _ string) ([]net.IPAddr, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return []net.IPAddr{{IP: net.ParseIP("10.0.0.1")}}, nil
+}
+
+func (f *fakeLookuper) LookupAddr(_ context.Context, _ string) ([]string, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	return []string{"host.example.com."}, nil
+}
+
+func newTestNameserverResolver(lookuper lookuper, maxRetries int) *NameserverResolver {
+	return &NameserverResolver{
+		name:       "test",
+		resolver:   lookuper,
+		timeout:    time.Second,
+		maxRetries: maxRetries,
+		logger:     zap.NewNop(),
+	}
+}
+
+func TestNameserverResolver(t *testing.T) {
+	fake := &fakeLookuper{}
+	r := newTestNameserverResolver(fake, 0)
+
+	ip, err := r.Resolve(context.Background(), "host.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip)
+
+	host, err := r.Reverse(context.Background(), "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "host.example.com", host)
+}
+
+func TestNameserverResolverRetries(t *testing.T) {
+	timeout := &net.DNSError{Err: "i/o timeout", IsTimeout: true}
+	fake := &fakeLookuper{errs: []error{timeout, timeout}}
+	r := newTestNameserverResolver(fake, 2)
+
+	ip, err := r.Resolve(context.Background(), "host.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip)
+	assert.Equal(t, 3, fake.calls)
+
+	fake = &fakeLookuper{errs: []error{timeout, timeout}}
+	r = newTestNameserverResolver(fake, 1)
+	_, err = r.Resolve(context.Background(), "host.example.com")
+	assert.ErrorContains(t, err, "i/o timeout")
+	assert.Equal(t, 2, fake.calls)
+}
+
+func TestNameserverResolverNotFound(t *testing.T) {
+	fake := &fakeLookuper{errs: []error{&net.DNSError{Err: "no such host", IsNotFound: true}}}
+	r := newTestNameserverResolver(fake, 2)
+
+	_, err := r.Reverse(context.Background(), "10.0.0.1")
+	assert.ErrorIs(t, err, ErrNoResolution)
+	assert.Equal(t, 1, fake.calls, "not found errors must not be retried")
+
+	_, err = r.Reverse(context.Background(), "not-an-ip")
+	assert.True(t, errors.Is(err, ErrInvalidIP))
+}
+
+func TestNameserverAddress(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:53", nameserverAddress("127.0.0.1"))
+	assert.Equal(t, "127.0.0.1:5353", nameserverAddress("127.0.0.1:5353"))
+	assert.Equal(t, "[::1]:53", nameserverAddress("::1"))
+	assert.Equal(t, "[::1]:53", nameserverAddress("[::1]"))
+}
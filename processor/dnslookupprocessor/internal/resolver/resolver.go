@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resolver // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/dnslookupprocessor/internal/resolver"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+var (
+	// ErrNoResolution is returned when a resolver can't resolve the given hostname or IP address.
+	ErrNoResolution = errors.New("no resolution found")
+	// ErrInvalidHostname is returned when the value to resolve is not a valid hostname.
+	ErrInvalidHostname = errors.New("invalid hostname")
+	// ErrInvalidIP is returned when the value to reverse resolve is not a valid IP address.
+	ErrInvalidIP = errors.New("invalid IP address")
+)
+
+// Resolver performs forward and reverse DNS lookups.
+type Resolver interface {
+	// Resolve returns the IP address of the given hostname.
+	Resolve(ctx context.Context, hostname string) (string, error)
+	// Reverse returns the hostname of the given IP address.
+	Reverse(ctx context.Context, ip string) (string, error)
+	// Name returns the name of the resolver, used for logging purposes.
+	Name() string
+	// Close releases the resources held by the resolver.
+	Close() error
+}
+
+// ParseIP parses the given string as an IP address, returning ErrInvalidIP if it isn't one.
+func ParseIP(value string) (net.IP, error) {
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil {
+		return nil, ErrInvalidIP
+	}
+	return ip, nil
+}
+
+// NormalizeHostname validates the given hostname following RFC 1123 and returns it lowercased
+// and without the trailing dot. IP addresses are not considered valid hostnames.
+func NormalizeHostname(value string) (string, error) {
+	hostname := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(value), "."))
+	if hostname == "" || len(hostname) > 253 || net.ParseIP(hostname) != nil {
+		return "", ErrInvalidHostname
+	}
+
+	for _, label := range strings.Split(hostname, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return "", ErrInvalidHostname
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+				return "", ErrInvalidHostname
+			}
+		}
+	}
+	return hostname, nil
+}
@@ -62,6 +62,11 @@ type MetricFilters struct {
 	// If any condition resolves to true, the datapoint will be dropped.
 	// Supports `and`, `or`, and `()`
 	DataPointConditions []string `mapstructure:"datapoint"`
+
+	// ExemplarConditions is a list of OTTL conditions for an ottlexemplar context.
+	// If any condition resolves to true, the exemplar will be dropped from its datapoint.
+	// Supports `and`, `or`, and `()`
+	ExemplarConditions []string `mapstructure:"exemplar"`
 }
 
 // TraceFilters filters by OTTL conditions
@@ -269,7 +274,7 @@ func (cfg *Config) Validate() error {
 	if (cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil) && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
 		return errors.New("cannot use ottl conditions and include/exclude for spans at the same time")
 	}
-	if (cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil) && (cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil) {
+	if (cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil || cfg.Metrics.ExemplarConditions != nil) && (cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil) {
 		return errors.New("cannot use ottl conditions and include/exclude for metrics at the same time")
 	}
 	if cfg.Logs.LogConditions != nil && (cfg.Logs.Include != nil || cfg.Logs.Exclude != nil) {
@@ -298,6 +303,11 @@ func (cfg *Config) Validate() error {
 		errors = multierr.Append(errors, err)
 	}
 
+	if cfg.Metrics.ExemplarConditions != nil {
+		_, err := filterottl.NewBoolExprForExemplar(cfg.Metrics.ExemplarConditions, filterottl.StandardExemplarFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		errors = multierr.Append(errors, err)
+	}
+
 	if cfg.Logs.LogConditions != nil {
 		_, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
@@ -856,6 +856,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 					DataPointConditions: []string{
 						`attributes["test"] == "pass"`,
 					},
+					ExemplarConditions: []string{
+						`filtered_attributes["test"] == "pass"`,
+					},
 				},
 				Logs: LogFilters{
 					LogConditions: []string{
@@ -900,6 +903,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_datapoint"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_exemplar"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_log"),
 		},
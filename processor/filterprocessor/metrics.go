@@ -22,6 +22,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 )
@@ -30,6 +31,7 @@ type filterMetricProcessor struct {
 	skipResourceExpr  expr.BoolExpr[ottlresource.TransformContext]
 	skipMetricExpr    expr.BoolExpr[ottlmetric.TransformContext]
 	skipDataPointExpr expr.BoolExpr[ottldatapoint.TransformContext]
+	skipExemplarExpr  expr.BoolExpr[ottlexemplar.TransformContext]
 	telemetry         *filterTelemetry
 	logger            *zap.Logger
 }
@@ -46,7 +48,7 @@ func newFilterMetricProcessor(set processor.Settings, cfg *Config) (*filterMetri
 	}
 	fsp.telemetry = fpt
 
-	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
+	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil || cfg.Metrics.ExemplarConditions != nil {
 		if cfg.Metrics.MetricConditions != nil {
 			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
@@ -61,6 +63,13 @@ func newFilterMetricProcessor(set processor.Settings, cfg *Config) (*filterMetri
 			}
 		}
 
+		if cfg.Metrics.ExemplarConditions != nil {
+			fsp.skipExemplarExpr, err = filterottl.NewBoolExprForExemplar(cfg.Metrics.ExemplarConditions, filterottl.StandardExemplarFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		return fsp, nil
 	}
 
@@ -113,7 +122,7 @@ func newFilterMetricProcessor(set processor.Settings, cfg *Config) (*filterMetri
 
 // processMetrics filters the given metrics based off the filterMetricProcessor's filters.
 func (fmp *filterMetricProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
-	if fmp.skipResourceExpr == nil && fmp.skipMetricExpr == nil && fmp.skipDataPointExpr == nil {
+	if fmp.skipResourceExpr == nil && fmp.skipMetricExpr == nil && fmp.skipDataPointExpr == nil && fmp.skipExemplarExpr == nil {
 		return md, nil
 	}
 
@@ -145,27 +154,15 @@ func (fmp *filterMetricProcessor) processMetrics(ctx context.Context, md pmetric
 					}
 				}
 				if fmp.skipDataPointExpr != nil {
-					//exhaustive:enforce
-					switch metric.Type() {
-					case pmetric.MetricTypeSum:
-						errors = multierr.Append(errors, fmp.handleNumberDataPoints(ctx, metric.Sum().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.Sum().DataPoints().Len() == 0
-					case pmetric.MetricTypeGauge:
-						errors = multierr.Append(errors, fmp.handleNumberDataPoints(ctx, metric.Gauge().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.Gauge().DataPoints().Len() == 0
-					case pmetric.MetricTypeHistogram:
-						errors = multierr.Append(errors, fmp.handleHistogramDataPoints(ctx, metric.Histogram().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.Histogram().DataPoints().Len() == 0
-					case pmetric.MetricTypeExponentialHistogram:
-						errors = multierr.Append(errors, fmp.handleExponentialHistogramDataPoints(ctx, metric.ExponentialHistogram().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.ExponentialHistogram().DataPoints().Len() == 0
-					case pmetric.MetricTypeSummary:
-						errors = multierr.Append(errors, fmp.handleSummaryDataPoints(ctx, metric.Summary().DataPoints(), metric, smetrics.Metrics(), scope, resource))
-						return metric.Summary().DataPoints().Len() == 0
-					default:
-						return false
+					dropped, err := fmp.handleDataPoints(ctx, metric, smetrics.Metrics(), scope, resource)
+					errors = multierr.Append(errors, err)
+					if dropped {
+						return true
 					}
 				}
+				if fmp.skipExemplarExpr != nil {
+					errors = multierr.Append(errors, fmp.handleExemplars(ctx, metric, smetrics.Metrics(), scope, resource))
+				}
 				return false
 			})
 			return smetrics.Metrics().Len() == 0
@@ -257,6 +254,31 @@ func newResExpr(mp *filterconfig.MetricMatchProperties) (expr.BoolExpr[ottlresou
 	return resExpr(attributeMatcher), nil
 }
 
+// handleDataPoints removes the datapoints of the metric matching the datapoint conditions,
+// and returns whether all of them were removed.
+func (fmp *filterMetricProcessor) handleDataPoints(ctx context.Context, metric pmetric.Metric, metrics pmetric.MetricSlice, is pcommon.InstrumentationScope, resource pcommon.Resource) (bool, error) {
+	//exhaustive:enforce
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		err := fmp.handleNumberDataPoints(ctx, metric.Sum().DataPoints(), metric, metrics, is, resource)
+		return metric.Sum().DataPoints().Len() == 0, err
+	case pmetric.MetricTypeGauge:
+		err := fmp.handleNumberDataPoints(ctx, metric.Gauge().DataPoints(), metric, metrics, is, resource)
+		return metric.Gauge().DataPoints().Len() == 0, err
+	case pmetric.MetricTypeHistogram:
+		err := fmp.handleHistogramDataPoints(ctx, metric.Histogram().DataPoints(), metric, metrics, is, resource)
+		return metric.Histogram().DataPoints().Len() == 0, err
+	case pmetric.MetricTypeExponentialHistogram:
+		err := fmp.handleExponentialHistogramDataPoints(ctx, metric.ExponentialHistogram().DataPoints(), metric, metrics, is, resource)
+		return metric.ExponentialHistogram().DataPoints().Len() == 0, err
+	case pmetric.MetricTypeSummary:
+		err := fmp.handleSummaryDataPoints(ctx, metric.Summary().DataPoints(), metric, metrics, is, resource)
+		return metric.Summary().DataPoints().Len() == 0, err
+	default:
+		return false, nil
+	}
+}
+
 func (fmp *filterMetricProcessor) handleNumberDataPoints(ctx context.Context, dps pmetric.NumberDataPointSlice, metric pmetric.Metric, metrics pmetric.MetricSlice, is pcommon.InstrumentationScope, resource pcommon.Resource) error {
 	var errors error
 	dps.RemoveIf(func(datapoint pmetric.NumberDataPoint) bool {
@@ -308,3 +330,39 @@ func (fmp *filterMetricProcessor) handleSummaryDataPoints(ctx context.Context, d
 	})
 	return errors
 }
+
+func (fmp *filterMetricProcessor) handleExemplars(ctx context.Context, metric pmetric.Metric, metrics pmetric.MetricSlice, is pcommon.InstrumentationScope, resource pcommon.Resource) error {
+	var errors error
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		for _, dp := range metric.Sum().DataPoints().All() {
+			errors = multierr.Append(errors, fmp.removeExemplars(ctx, dp.Exemplars(), dp, metric, metrics, is, resource))
+		}
+	case pmetric.MetricTypeGauge:
+		for _, dp := range metric.Gauge().DataPoints().All() {
+			errors = multierr.Append(errors, fmp.removeExemplars(ctx, dp.Exemplars(), dp, metric, metrics, is, resource))
+		}
+	case pmetric.MetricTypeHistogram:
+		for _, dp := range metric.Histogram().DataPoints().All() {
+			errors = multierr.Append(errors, fmp.removeExemplars(ctx, dp.Exemplars(), dp, metric, metrics, is, resource))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for _, dp := range metric.ExponentialHistogram().DataPoints().All() {
+			errors = multierr.Append(errors, fmp.removeExemplars(ctx, dp.Exemplars(), dp, metric, metrics, is, resource))
+		}
+	}
+	return errors
+}
+
+func (fmp *filterMetricProcessor) removeExemplars(ctx context.Context, exemplars pmetric.ExemplarSlice, dataPoint any, metric pmetric.Metric, metrics pmetric.MetricSlice, is pcommon.InstrumentationScope, resource pcommon.Resource) error {
+	var errors error
+	exemplars.RemoveIf(func(exemplar pmetric.Exemplar) bool {
+		skip, err := fmp.skipExemplarExpr.Eval(ctx, ottlexemplar.NewTransformContext(exemplar, dataPoint, metric, metrics, is, resource, pmetric.NewScopeMetrics(), pmetric.NewResourceMetrics()))
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			return false
+		}
+		return skip
+	})
+	return errors
+}
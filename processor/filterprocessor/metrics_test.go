@@ -907,6 +907,96 @@ func fillMetricFive(m pmetric.Metric) {
 	dataPoint1.Attributes().PutStr("attr3", "test3")
 }
 
+func TestFilterMetricProcessorExemplarsWithOTTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions MetricFilters
+		want       func(md pmetric.Metrics)
+	}{
+		{
+			name: "drop exemplars of unsampled traces",
+			conditions: MetricFilters{
+				ExemplarConditions: []string{
+					`trace_id.string == "00000000000000000000000000000000"`,
+				},
+			},
+			want: func(md pmetric.Metrics) {
+				metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+				metrics.At(0).Sum().DataPoints().At(0).Exemplars().RemoveIf(func(exemplar pmetric.Exemplar) bool {
+					return exemplar.TraceID().IsEmpty()
+				})
+				metrics.At(1).Histogram().DataPoints().At(0).Exemplars().RemoveIf(func(exemplar pmetric.Exemplar) bool {
+					return exemplar.TraceID().IsEmpty()
+				})
+			},
+		},
+		{
+			name: "drop exemplars using higher contexts",
+			conditions: MetricFilters{
+				ExemplarConditions: []string{
+					`metric.name == "operationA" and datapoint.attributes["env"] == "prod" and filtered_attributes["user.id"] != nil`,
+				},
+			},
+			want: func(md pmetric.Metrics) {
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Exemplars().RemoveIf(func(exemplar pmetric.Exemplar) bool {
+					_, ok := exemplar.FilteredAttributes().Get("user.id")
+					return ok
+				})
+			},
+		},
+		{
+			name: "dropping all exemplars keeps the datapoints",
+			conditions: MetricFilters{
+				ExemplarConditions: []string{
+					`true`,
+				},
+			},
+			want: func(md pmetric.Metrics) {
+				metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+				metrics.At(0).Sum().DataPoints().At(0).Exemplars().RemoveIf(func(pmetric.Exemplar) bool { return true })
+				metrics.At(1).Histogram().DataPoints().At(0).Exemplars().RemoveIf(func(pmetric.Exemplar) bool { return true })
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := newFilterMetricProcessor(processortest.NewNopSettings(metadata.Type), &Config{Metrics: tt.conditions, ErrorMode: ottl.PropagateError})
+			require.NoError(t, err)
+
+			got, err := processor.processMetrics(context.Background(), constructMetricsWithExemplars())
+			require.NoError(t, err)
+
+			exTd := constructMetricsWithExemplars()
+			tt.want(exTd)
+			assert.Equal(t, exTd, got)
+		})
+	}
+}
+
+func constructMetricsWithExemplars() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("operationA")
+	dataPoint := sum.SetEmptySum().DataPoints().AppendEmpty()
+	dataPoint.Attributes().PutStr("env", "prod")
+	exemplar := dataPoint.Exemplars().AppendEmpty()
+	exemplar.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	exemplar.FilteredAttributes().PutStr("user.id", "42")
+	dataPoint.Exemplars().AppendEmpty().SetDoubleValue(1)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("operationB")
+	dataPoints := histogram.SetEmptyHistogram().DataPoints()
+	dataPoints.AppendEmpty().Exemplars().AppendEmpty().SetIntValue(1)
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("operationC")
+	summary.SetEmptySummary().DataPoints().AppendEmpty()
+	return md
+}
+
 func Test_ResourceSkipExpr_With_Bridge(t *testing.T) {
 	tests := []struct {
 		name      string
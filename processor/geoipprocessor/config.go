@@ -12,7 +12,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/otel/attribute"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
 )
 
 const (
@@ -59,11 +59,8 @@ func (cfg *Config) Validate() error {
 		return errors.New("must specify at least one geo IP data provider when using the geoip processor")
 	}
 
-	// validate all provider's configuration
-	for providerID, providerConfig := range cfg.Providers {
-		if err := providerConfig.Validate(); err != nil {
-			return fmt.Errorf("error validating provider %s: %w", providerID, err)
-		}
+	if err := provider.ValidateConfigs(cfg.Providers); err != nil {
+		return err
 	}
 
 	if cfg.Attributes != nil && len(cfg.Attributes) == 0 {
@@ -85,34 +82,13 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 		return err
 	}
 
-	// dynamically load the individual providers configs based on the key name
-	cfg.Providers = map[string]provider.Config{}
-
 	// retrieve `providers` configuration section
 	providersSection, err := componentParser.Sub(providersKey)
 	if err != nil {
 		return err
 	}
 
-	// loop through all defined providers and load their configuration
-	for key := range providersSection.ToStringMap() {
-		factory, ok := getProviderFactory(key)
-		if !ok {
-			return fmt.Errorf("invalid provider key: %s", key)
-		}
-
-		providerCfg := factory.CreateDefaultConfig()
-		providerSection, err := providersSection.Sub(key)
-		if err != nil {
-			return err
-		}
-		err = providerSection.Unmarshal(providerCfg)
-		if err != nil {
-			return fmt.Errorf("error reading settings for provider type %q: %w", key, err)
-		}
-
-		cfg.Providers[key] = providerCfg
-	}
-
-	return nil
+	// dynamically load the individual providers configs based on the key name
+	cfg.Providers, err = provider.UnmarshalConfigs(providersSection, providerFactories)
+	return err
 }
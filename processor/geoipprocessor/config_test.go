@@ -16,9 +16,9 @@ import (
 	"go.opentelemetry.io/collector/otelcol/otelcoltest"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
+	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider/maxmindprovider"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
-	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -54,6 +54,17 @@ func TestLoadConfig(t *testing.T) {
 				Attributes: defaultAttributes,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "maxmind_named_providers"),
+			expected: &Config{
+				Context: resource,
+				Providers: map[string]provider.Config{
+					"maxmind/city": &maxmind.Config{DatabasePath: "/tmp/city.mmdb"},
+					"maxmind/asn":  &maxmind.Config{DatabasePath: "/tmp/asn.mmdb"},
+				},
+				Attributes: defaultAttributes,
+			},
+		},
 		{
 			id:                    component.NewIDWithName(metadata.Type, "invalid_providers_config"),
 			unmarshalErrorMessage: "unexpected sub-config value kind for key:providers value:this should be a map kind:string",
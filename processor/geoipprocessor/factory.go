@@ -5,7 +5,6 @@ package geoipprocessor // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"context"
-	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -14,9 +13,9 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
+	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider/maxmindprovider"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
-	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
 var (
@@ -42,16 +41,6 @@ func NewFactory() processor.Factory {
 	return processor.NewFactory(metadata.Type, createDefaultConfig, processor.WithMetrics(createMetricsProcessor, metadata.MetricsStability), processor.WithLogs(createLogsProcessor, metadata.LogsStability), processor.WithTraces(createTracesProcessor, metadata.TracesStability))
 }
 
-// getProviderFactory retrieves the GeoIPProviderFactory for the given key.
-// It returns the factory and a boolean indicating whether the factory was found.
-func getProviderFactory(key string) (provider.GeoIPProviderFactory, bool) {
-	if factory, ok := providerFactories[key]; ok {
-		return factory, true
-	}
-
-	return nil, false
-}
-
 // createDefaultConfig returns a default configuration for the processor.
 func createDefaultConfig() component.Config {
 	return &Config{
@@ -67,23 +56,7 @@ func createGeoIPProviders(
 	config *Config,
 	factories map[string]provider.GeoIPProviderFactory,
 ) ([]provider.GeoIPProvider, error) {
-	providers := make([]provider.GeoIPProvider, 0, len(config.Providers))
-
-	for key, cfg := range config.Providers {
-		factory := factories[key]
-		if factory == nil {
-			return nil, fmt.Errorf("geoIP provider factory not found for key: %q", key)
-		}
-
-		provider, err := factory.CreateGeoIPProvider(ctx, set, cfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create provider for key %q: %w", key, err)
-		}
-
-		providers = append(providers, provider)
-	}
-
-	return providers, nil
+	return provider.CreateGeoIPProviders(ctx, set.TelemetrySettings, config.Providers, factories)
 }
 
 func createMetricsProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Metrics) (processor.Metrics, error) {
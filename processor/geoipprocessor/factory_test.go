@@ -10,13 +10,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
-	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -74,7 +74,7 @@ func TestCreateProcessor_ProcessorKeyConfigError(t *testing.T) {
 }
 
 func TestCreateProcessor_FailedProvider(t *testing.T) {
-	baseMockFactory.CreateGeoIPProviderF = func(context.Context, processor.Settings, provider.Config) (provider.GeoIPProvider, error) {
+	baseMockFactory.CreateGeoIPProviderF = func(context.Context, component.TelemetrySettings, provider.Config) (provider.GeoIPProvider, error) {
 		return nil, errors.New("error creating provider")
 	}
 
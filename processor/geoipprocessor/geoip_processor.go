@@ -15,7 +15,7 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
 )
 
 var (
@@ -107,6 +107,8 @@ func (g *geoIPProcessor) processAttributes(ctx context.Context, metadata pcommon
 
 	for _, geoAttr := range attributes.ToSlice() {
 		switch geoAttr.Value.Type() {
+		case attribute.INT64:
+			metadata.PutInt(string(geoAttr.Key), geoAttr.Value.AsInt64())
 		case attribute.FLOAT64:
 			metadata.PutDouble(string(geoAttr.Key), geoAttr.Value.AsFloat64())
 		case attribute.STRING:
@@ -14,17 +14,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumertest"
-	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/otel/attribute"
 
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/ptracetest"
-	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
 )
 
 type providerConfigMock struct {
@@ -33,7 +32,7 @@ type providerConfigMock struct {
 
 type providerFactoryMock struct {
 	CreateDefaultConfigF func() provider.Config
-	CreateGeoIPProviderF func(context.Context, processor.Settings, provider.Config) (provider.GeoIPProvider, error)
+	CreateGeoIPProviderF func(context.Context, component.TelemetrySettings, provider.Config) (provider.GeoIPProvider, error)
 }
 
 type providerMock struct {
@@ -54,7 +53,7 @@ func (fm *providerFactoryMock) CreateDefaultConfig() provider.Config {
 	return fm.CreateDefaultConfigF()
 }
 
-func (fm *providerFactoryMock) CreateGeoIPProvider(ctx context.Context, settings processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+func (fm *providerFactoryMock) CreateGeoIPProvider(ctx context.Context, settings component.TelemetrySettings, cfg provider.Config) (provider.GeoIPProvider, error) {
 	return fm.CreateGeoIPProviderF(ctx, settings, cfg)
 }
 
@@ -79,7 +78,7 @@ var baseMockFactory = providerFactoryMock{
 	CreateDefaultConfigF: func() provider.Config {
 		return &providerConfigMock{ValidateF: func() error { return nil }}
 	},
-	CreateGeoIPProviderF: func(context.Context, processor.Settings, provider.Config) (provider.GeoIPProvider, error) {
+	CreateGeoIPProviderF: func(context.Context, component.TelemetrySettings, provider.Config) (provider.GeoIPProvider, error) {
 		return &baseMockProvider, nil
 	},
 }
@@ -212,7 +211,7 @@ func compareAllSignals(cfg component.Config, goldenDir string) func(t *testing.T
 func TestProcessor(t *testing.T) {
 	t.Parallel()
 
-	baseMockFactory.CreateGeoIPProviderF = func(context.Context, processor.Settings, provider.Config) (provider.GeoIPProvider, error) {
+	baseMockFactory.CreateGeoIPProviderF = func(context.Context, component.TelemetrySettings, provider.Config) (provider.GeoIPProvider, error) {
 		return &baseProviderMock, nil
 	}
 
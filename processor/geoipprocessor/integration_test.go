@@ -9,13 +9,13 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
-	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
+	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider/maxmindprovider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider/maxmindprovider/testdata"
 )
 
 func TestProcessorWithMaxMind(t *testing.T) {
-	tmpDBfiles := testdata.GenerateLocalDB(t, "./internal/provider/maxmindprovider/testdata/")
+	tmpDBfiles := testdata.GenerateLocalDB(t, "../../internal/geoip/provider/maxmindprovider/testdata/")
 	defer os.RemoveAll(tmpDBfiles)
 
 	maxmindConfig := maxmind.Config{
@@ -401,6 +401,64 @@ func TestLogsAreCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
 	})
 }
 
+func TestLogsAreCorrectlyRoutedWithOTTLMembershipConditions(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	firstExp := &mockLogsExporter{}
+	secondExp := &mockLogsExporter{}
+
+	host := newMockHost(map[pipeline.Signal]map[component.ID]component.Component{
+		pipeline.SignalLogs: {
+			component.MustNewID("otlp"):              defaultExp,
+			component.MustNewIDWithName("otlp", "1"): firstExp,
+			component.MustNewIDWithName("otlp", "2"): secondExp,
+		},
+	})
+
+	exp, err := newLogProcessor(noopTelemetrySettings, &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where resource.attributes["X-Tenant"] in ["acme", "globex"]`,
+				Exporters: []string{"otlp/1"},
+			},
+			{
+				Statement: `route() where IsInCIDR(resource.attributes["host.ip"], ["10.0.0.0/8"])`,
+				Exporters: []string{"otlp/2"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "globex")
+	rl.Resource().Attributes().PutStr("host.ip", "192.168.1.1")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	rl = l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "initech")
+	rl.Resource().Attributes().PutStr("host.ip", "10.1.2.3")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	rl = l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "initech")
+	rl.Resource().Attributes().PutStr("host.ip", "192.168.1.2")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	assert.Len(t, defaultExp.AllLogs(), 1)
+	assert.Len(t, firstExp.AllLogs(), 1)
+	assert.Len(t, secondExp.AllLogs(), 1)
+
+	tenant, _ := firstExp.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("X-Tenant")
+	assert.Equal(t, "globex", tenant.AsString())
+	hostIP, _ := secondExp.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("host.ip")
+	assert.Equal(t, "10.1.2.3", hostIP.AsString())
+}
+
 // see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/26462
 func TestLogsAttributeWithOTTLDoesNotCauseCrash(t *testing.T) {
 	// prepare
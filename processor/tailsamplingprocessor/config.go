@@ -6,6 +6,8 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -45,6 +47,9 @@ const (
 	// OTTLCondition sample traces which match user provided OpenTelemetry Transformation Language
 	// conditions.
 	OTTLCondition PolicyType = "ottl_condition"
+	// AdaptiveThroughput samples traces grouped by key, adjusting the sampling probability of each key
+	// to sample a target number of traces per second.
+	AdaptiveThroughput PolicyType = "adaptive_throughput"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -74,6 +79,8 @@ type sharedPolicyCfg struct {
 	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
 	// Configs for OTTL condition filter sampling policy evaluator
 	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
+	// Configs for adaptive throughput sampling policy evaluator.
+	AdaptiveThroughputCfg AdaptiveThroughputCfg `mapstructure:"adaptive_throughput"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -233,6 +240,26 @@ type OTTLConditionCfg struct {
 	SpanEventConditions []string       `mapstructure:"spanevent"`
 }
 
+// AdaptiveThroughputCfg holds the configurable settings to create an adaptive throughput
+// sampling policy evaluator.
+type AdaptiveThroughputCfg struct {
+	// Keys are the attributes whose values group the traces, looked up in the attributes of the root span
+	// of the trace, then of its resource. `span.name` and `span.status.code` refer to the name and status
+	// code of the root span.
+	Keys []string `mapstructure:"keys"`
+	// TracesPerSecond is the target number of traces sampled per second, across all keys.
+	TracesPerSecond float64 `mapstructure:"traces_per_second"`
+	// MinTracesPerSecondPerKey is the number of traces sampled per second for every key, even when the
+	// target number of traces per second is exceeded, so that traces of rare keys are kept.
+	MinTracesPerSecondPerKey float64 `mapstructure:"min_traces_per_second_per_key"`
+	// AdjustmentInterval is how often the sampling probability of every key is adjusted, based on the
+	// number of traces of the key since the last adjustment. Defaults to 10s.
+	AdjustmentInterval time.Duration `mapstructure:"adjustment_interval"`
+	// MaxKeys is the maximum number of keys tracked during an adjustment interval, after which the traces
+	// of the new keys are grouped together. Defaults to 10000.
+	MaxKeys int `mapstructure:"max_keys"`
+}
+
 type DecisionCacheConfig struct {
 	// SampledCacheSize specifies the size of the cache that holds the sampled trace IDs.
 	// This value will be the maximum amount of trace IDs that the cache can hold before overwriting previous IDs.
@@ -244,6 +271,12 @@ type DecisionCacheConfig struct {
 	// For effective use, this value should be at least an order of magnitude greater than Config.NumTraces.
 	// If left as default 0, a no-op DecisionCache will be used.
 	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// StorageID is the ID of a storage extension used to share the sampling decisions with other
+	// collectors using the same storage, so that spans arriving after a decision was made for their
+	// trace get the same decision, regardless of the collector receiving them.
+	// The decisions are kept until the storage extension expires them.
+	// If left unset, the decisions are only kept in memory.
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 // Config holds the configuration for tail-based sampling.
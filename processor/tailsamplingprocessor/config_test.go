@@ -117,6 +117,18 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-12",
+						Type: AdaptiveThroughput,
+						AdaptiveThroughputCfg: AdaptiveThroughputCfg{
+							Keys:                     []string{"service.name", "http.route", "span.status.code"},
+							TracesPerSecond:          100,
+							MinTracesPerSecondPerKey: 1,
+							AdjustmentInterval:       30 * time.Second,
+						},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -186,3 +198,37 @@ func TestLoadConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestLoadConfigDecisionStore(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "tail_sampling_config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "decision_store").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	storageID := component.MustNewID("redis_storage")
+	assert.Equal(t,
+		&Config{
+			DecisionWait: 30 * time.Second,
+			NumTraces:    50000,
+			DecisionCache: DecisionCacheConfig{
+				SampledCacheSize:    1_000,
+				NonSampledCacheSize: 10_000,
+				StorageID:           &storageID,
+			},
+			PolicyCfgs: []PolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-1",
+						Type: AlwaysSample,
+					},
+				},
+			},
+		}, cfg)
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	decisionKeyPrefix = "decision_"
+
+	storedSampled    byte = 1
+	storedNotSampled byte = 2
+)
+
+// decisionStore shares the sampling decisions with the other collectors using the same storage extension.
+// Errors from the storage are logged and otherwise ignored: the processor falls back to deciding on its own.
+type decisionStore struct {
+	client storage.Client
+	logger *zap.Logger
+}
+
+func newDecisionStore(ctx context.Context, host component.Host, storageID, id component.ID, logger *zap.Logger) (*decisionStore, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, id, "")
+	if err != nil {
+		return nil, err
+	}
+	return &decisionStore{client: client, logger: logger}, nil
+}
+
+// get returns the stored decisions for the given trace IDs. Trace IDs without a stored decision are not in the result.
+func (s *decisionStore) get(ctx context.Context, ids []pcommon.TraceID) map[pcommon.TraceID]sampling.Decision {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ops := make([]*storage.Operation, len(ids))
+	for i, id := range ids {
+		ops[i] = storage.GetOperation(decisionKey(id))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		s.logger.Warn("Failed to get sampling decisions from the storage", zap.Error(err))
+		return nil
+	}
+
+	decisions := make(map[pcommon.TraceID]sampling.Decision)
+	for i, op := range ops {
+		if len(op.Value) != 1 {
+			continue
+		}
+		switch op.Value[0] {
+		case storedSampled:
+			decisions[ids[i]] = sampling.Sampled
+		case storedNotSampled:
+			decisions[ids[i]] = sampling.NotSampled
+		}
+	}
+	return decisions
+}
+
+// put stores the given final decisions.
+func (s *decisionStore) put(ctx context.Context, decisions map[pcommon.TraceID]sampling.Decision) {
+	if len(decisions) == 0 {
+		return
+	}
+
+	ops := make([]*storage.Operation, 0, len(decisions))
+	for id, decision := range decisions {
+		value := storedNotSampled
+		if decision == sampling.Sampled {
+			value = storedSampled
+		}
+		ops = append(ops, storage.SetOperation(decisionKey(id), []byte{value}))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		s.logger.Warn("Failed to store sampling decisions", zap.Error(err))
+	}
+}
+
+func (s *decisionStore) close(ctx context.Context) error {
+	return s.client.Close(ctx)
+}
+
+func decisionKey(id pcommon.TraceID) string {
+	return decisionKeyPrefix + id.String()
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func newDecisionStoreTestProcessor(t *testing.T, storageID component.ID, mpe *mockPolicyEvaluator, nextConsumer *consumertest.TracesSink) processor.Traces {
+	cfg := Config{
+		DecisionWait:  defaultTestDecisionWait,
+		NumTraces:     defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{StorageID: &storageID},
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+			withPolicies([]*policy{
+				{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+			}),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+	return p
+}
+
+func TestDecisionStoreStartErrors(t *testing.T) {
+	host := storagetest.NewStorageHost().WithNonStorageExtension("non_storage")
+
+	p := newDecisionStoreTestProcessor(t, storagetest.NewStorageID("missing"), &mockPolicyEvaluator{}, new(consumertest.TracesSink))
+	assert.ErrorContains(t, p.Start(context.Background(), host), "storage extension 'test_storage/missing' not found")
+
+	p = newDecisionStoreTestProcessor(t, storagetest.NewNonStorageID("non_storage"), &mockPolicyEvaluator{}, new(consumertest.TracesSink))
+	assert.ErrorContains(t, p.Start(context.Background(), host), "non-storage extension 'non_storage/non_storage' found")
+}
+
+func TestLateSpanUsesStoredDecision(t *testing.T) {
+	storageID := storagetest.NewStorageID("decisions")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("decisions", t.TempDir())
+
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+
+	// The first processor decides on both traces, then is shut down.
+	mpe := &mockPolicyEvaluator{}
+	nextConsumer := new(consumertest.TracesSink)
+	p := newDecisionStoreTestProcessor(t, storageID, mpe, nextConsumer)
+	require.NoError(t, p.Start(context.Background(), host))
+	tsp := p.(*tailSamplingSpanProcessor)
+
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	require.Equal(t, 2, mpe.EvaluationCount)
+	require.Equal(t, 1, nextConsumer.SpanCount())
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// The second processor gets the late spans, and follows the decisions of the first one
+	// without evaluating its own policies, which would have made the opposite decisions.
+	mpe = &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	nextConsumer = new(consumertest.TracesSink)
+	p = newDecisionStoreTestProcessor(t, storageID, mpe, nextConsumer)
+	require.NoError(t, p.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+	tsp = p.(*tailSamplingSpanProcessor)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.Equal(t, 1, nextConsumer.SpanCount())
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	require.Equal(t, 1, nextConsumer.SpanCount())
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 0, mpe.EvaluationCount)
+	assert.Equal(t, uint64(0), tsp.numTracesOnMap.Load())
+}
+
+func TestStoredDecisionIsUsedOnTick(t *testing.T) {
+	storageID := storagetest.NewStorageID("decisions")
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("decisions")
+
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	nextConsumer := new(consumertest.TracesSink)
+	p := newDecisionStoreTestProcessor(t, storageID, mpe, nextConsumer)
+	require.NoError(t, p.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+	tsp := p.(*tailSamplingSpanProcessor)
+
+	traceID := uInt64ToTraceID(1)
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+
+	// Another collector decides on the trace before this one does.
+	tsp.decisionStore.put(context.Background(), map[pcommon.TraceID]sampling.Decision{traceID: sampling.NotSampled})
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 0, mpe.EvaluationCount)
+	require.Equal(t, 0, nextConsumer.SpanCount())
+
+	// The decisions made by this collector are stored as well.
+	otherID := uInt64ToTraceID(2)
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(otherID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 1, mpe.EvaluationCount)
+	require.Equal(t, 1, nextConsumer.SpanCount())
+
+	decisions := tsp.decisionStore.get(context.Background(), []pcommon.TraceID{traceID, otherID, uInt64ToTraceID(3)})
+	assert.Equal(t, map[pcommon.TraceID]sampling.Decision{
+		traceID: sampling.NotSampled,
+		otherID: sampling.Sampled,
+	}, decisions)
+}
@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+const (
+	defaultAdjustmentInterval = 10 * time.Second
+	defaultMaxKeys            = 10000
+
+	// spanNameKey and spanStatusCodeKey refer to the name and the status code of the span instead of attributes.
+	spanNameKey       = "span.name"
+	spanStatusCodeKey = "span.status.code"
+
+	// overflowKey groups the traces of the keys seen after the maximum number of keys was reached.
+	overflowKey = "\x00overflow"
+)
+
+type adaptiveThroughput struct {
+	logger                   *zap.Logger
+	keys                     []string
+	tracesPerSecond          float64
+	minTracesPerSecondPerKey float64
+	adjustmentInterval       time.Duration
+	maxKeys                  int
+	now                      func() time.Time
+
+	lastAdjustment time.Time
+	// counts holds the number of traces evaluated for each key since the last adjustment.
+	counts map[string]int64
+	// thresholds holds the sampling threshold of each key, computed at the last adjustment.
+	// Keys that were not seen during the previous interval are always sampled.
+	thresholds map[string]otelsampling.Threshold
+}
+
+var _ PolicyEvaluator = (*adaptiveThroughput)(nil)
+
+// NewAdaptiveThroughput creates a policy evaluator that groups the traces by the values of the given keys,
+// and adjusts the sampling probability of each group every adjustment interval so that the sampled traces
+// add up to tracesPerSecond, sampling at least minTracesPerSecondPerKey traces of each group.
+// The sampling threshold is kept in the trace data, to be recorded in the OpenTelemetry tracestate of the spans.
+func NewAdaptiveThroughput(
+	settings component.TelemetrySettings,
+	keys []string,
+	tracesPerSecond float64,
+	minTracesPerSecondPerKey float64,
+	adjustmentInterval time.Duration,
+	maxKeys int,
+) (PolicyEvaluator, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key must be specified")
+	}
+	if tracesPerSecond <= 0 {
+		return nil, errors.New("traces_per_second must be positive")
+	}
+	if minTracesPerSecondPerKey < 0 {
+		return nil, errors.New("min_traces_per_second_per_key must not be negative")
+	}
+	if adjustmentInterval < 0 {
+		return nil, errors.New("adjustment_interval must not be negative")
+	}
+	if maxKeys < 0 {
+		return nil, errors.New("max_keys must not be negative")
+	}
+	if adjustmentInterval == 0 {
+		adjustmentInterval = defaultAdjustmentInterval
+	}
+	if maxKeys == 0 {
+		maxKeys = defaultMaxKeys
+	}
+
+	return &adaptiveThroughput{
+		logger:                   settings.Logger,
+		keys:                     keys,
+		tracesPerSecond:          tracesPerSecond,
+		minTracesPerSecondPerKey: minTracesPerSecondPerKey,
+		adjustmentInterval:       adjustmentInterval,
+		maxKeys:                  maxKeys,
+		now:                      time.Now,
+		counts:                   make(map[string]int64),
+		thresholds:               make(map[string]otelsampling.Threshold),
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (a *adaptiveThroughput) Evaluate(_ context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	a.logger.Debug("Evaluating spans in adaptive throughput filter")
+
+	now := a.now()
+	if a.lastAdjustment.IsZero() {
+		a.lastAdjustment = now
+	} else if elapsed := now.Sub(a.lastAdjustment); elapsed >= a.adjustmentInterval {
+		a.adjust(elapsed)
+		a.lastAdjustment = now
+	}
+
+	trace.Lock()
+	defer trace.Unlock()
+
+	key, rnd := a.keyAndRandomness(traceID, trace.ReceivedBatches)
+	if _, ok := a.counts[key]; !ok && len(a.counts) >= a.maxKeys {
+		key = overflowKey
+	}
+	a.counts[key]++
+
+	threshold, ok := a.thresholds[key]
+	if !ok {
+		return Sampled, nil
+	}
+	if !threshold.ShouldSample(rnd) {
+		return NotSampled, nil
+	}
+	trace.Threshold = threshold
+	return Sampled, nil
+}
+
+// adjust computes the sampling threshold of every key seen since the last adjustment.
+func (a *adaptiveThroughput) adjust(elapsed time.Duration) {
+	rates := make(map[string]float64, len(a.counts))
+	for key, count := range a.counts {
+		rates[key] = float64(count) / elapsed.Seconds()
+	}
+	a.counts = make(map[string]int64, len(rates))
+	a.thresholds = make(map[string]otelsampling.Threshold, len(rates))
+
+	level := fairShare(rates, a.tracesPerSecond)
+	for key, rate := range rates {
+		probability := max(level, a.minTracesPerSecondPerKey) / rate
+		if probability >= 1 {
+			a.thresholds[key] = otelsampling.AlwaysSampleThreshold
+			continue
+		}
+		threshold, err := otelsampling.ProbabilityToThreshold(max(probability, otelsampling.MinSamplingProbability))
+		if err != nil {
+			a.logger.Debug("Invalid sampling probability", zap.Float64("probability", probability), zap.Error(err))
+			threshold = otelsampling.AlwaysSampleThreshold
+		}
+		a.thresholds[key] = threshold
+	}
+
+	a.logger.Debug("Adjusted adaptive throughput sampling probabilities",
+		zap.Int("keys", len(rates)),
+		zap.Float64("level", level))
+}
+
+// fairShare returns the rate of traces to sample for each key so that the sampled traces add up to the target,
+// where the keys with a rate below that level are entirely sampled. It returns +Inf if all the traces fit the target.
+func fairShare(rates map[string]float64, target float64) float64 {
+	sorted := make([]float64, 0, len(rates))
+	for _, rate := range rates {
+		sorted = append(sorted, rate)
+	}
+	slices.Sort(sorted)
+
+	remaining := target
+	for i, rate := range sorted {
+		share := remaining / float64(len(sorted)-i)
+		if rate > share {
+			return share
+		}
+		remaining -= rate
+	}
+	return math.Inf(1)
+}
+
+// keyAndRandomness returns the key of the trace, computed from its root span, and the randomness used for
+// consistent sampling, which is the r-value of the tracestate if any, or the trace ID otherwise.
+func (a *adaptiveThroughput) keyAndRandomness(traceID pcommon.TraceID, td ptrace.Traces) (string, otelsampling.Randomness) {
+	span, resource, found := rootSpan(td)
+	rnd := otelsampling.TraceIDToRandomness(traceID)
+	if !found {
+		return "", rnd
+	}
+	if ts, err := otelsampling.NewW3CTraceState(span.TraceState().AsRaw()); err == nil {
+		if rv, ok := ts.OTelValue().RValueRandomness(); ok {
+			rnd = rv
+		}
+	}
+
+	values := make([]string, len(a.keys))
+	for i, key := range a.keys {
+		switch key {
+		case spanNameKey:
+			values[i] = span.Name()
+		case spanStatusCodeKey:
+			values[i] = span.Status().Code().String()
+		default:
+			if v, ok := span.Attributes().Get(key); ok {
+				values[i] = v.AsString()
+			} else if v, ok := resource.Attributes().Get(key); ok {
+				values[i] = v.AsString()
+			}
+		}
+	}
+	return strings.Join(values, ","), rnd
+}
+
+// rootSpan returns the root span of the trace and its resource, or its first span if the root span was not received.
+func rootSpan(td ptrace.Traces) (ptrace.Span, pcommon.Resource, bool) {
+	var (
+		found    bool
+		first    ptrace.Span
+		resource pcommon.Resource
+	)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if spans.At(k).ParentSpanID().IsEmpty() {
+					return spans.At(k), rss.At(i).Resource(), true
+				}
+				if !found {
+					found = true
+					first = spans.At(k)
+					resource = rss.At(i).Resource()
+				}
+			}
+		}
+	}
+	return first, resource, found
+}
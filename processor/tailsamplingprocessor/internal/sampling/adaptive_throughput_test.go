@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+func TestNewAdaptiveThroughputErrors(t *testing.T) {
+	cases := []struct {
+		Desc                     string
+		keys                     []string
+		tracesPerSecond          float64
+		minTracesPerSecondPerKey float64
+		adjustmentInterval       time.Duration
+		maxKeys                  int
+		expectedErr              string
+	}{
+		{
+			Desc:            "no keys",
+			tracesPerSecond: 10,
+			expectedErr:     "at least one key must be specified",
+		},
+		{
+			Desc:        "no traces per second",
+			keys:        []string{"http.route"},
+			expectedErr: "traces_per_second must be positive",
+		},
+		{
+			Desc:                     "negative min traces per second per key",
+			keys:                     []string{"http.route"},
+			tracesPerSecond:          10,
+			minTracesPerSecondPerKey: -1,
+			expectedErr:              "min_traces_per_second_per_key must not be negative",
+		},
+		{
+			Desc:               "negative adjustment interval",
+			keys:               []string{"http.route"},
+			tracesPerSecond:    10,
+			adjustmentInterval: -time.Second,
+			expectedErr:        "adjustment_interval must not be negative",
+		},
+		{
+			Desc:            "negative max keys",
+			keys:            []string{"http.route"},
+			tracesPerSecond: 10,
+			maxKeys:         -1,
+			expectedErr:     "max_keys must not be negative",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			_, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), c.keys, c.tracesPerSecond, c.minTracesPerSecondPerKey, c.adjustmentInterval, c.maxKeys)
+			assert.EqualError(t, err, c.expectedErr)
+		})
+	}
+}
+
+func TestFairShare(t *testing.T) {
+	cases := []struct {
+		Desc     string
+		rates    map[string]float64
+		target   float64
+		expected float64
+	}{
+		{
+			Desc:     "all traces fit",
+			rates:    map[string]float64{"a": 10, "b": 10},
+			target:   100,
+			expected: math.Inf(1),
+		},
+		{
+			Desc:     "even rates",
+			rates:    map[string]float64{"a": 50, "b": 50},
+			target:   10,
+			expected: 5,
+		},
+		{
+			Desc:     "rare key is entirely sampled",
+			rates:    map[string]float64{"a": 1, "b": 100},
+			target:   11,
+			expected: 10,
+		},
+		{
+			Desc:     "several rare keys",
+			rates:    map[string]float64{"a": 1, "b": 2, "c": 100, "d": 200},
+			target:   21,
+			expected: 9,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			assert.Equal(t, c.expected, fairShare(c.rates, c.target))
+		})
+	}
+}
+
+func TestAdaptiveThroughput(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), []string{"service.name", "http.route"}, 10, 0, 10*time.Second, 0)
+	require.NoError(t, err)
+	at := evaluator.(*adaptiveThroughput)
+	now := time.Unix(1000, 0)
+	at.now = func() time.Time { return now }
+
+	// Until the first adjustment, every trace is sampled.
+	var id uint64
+	evaluate := func(route string) Decision {
+		id++
+		traceID := adaptiveTestTraceID(id)
+		decision, err := at.Evaluate(context.Background(), traceID, newTraceWithRoute(traceID, route, ""))
+		require.NoError(t, err)
+		return decision
+	}
+	for range 1000 {
+		assert.Equal(t, Sampled, evaluate("/frequent"))
+	}
+	for range 10 {
+		assert.Equal(t, Sampled, evaluate("/rare"))
+	}
+
+	// The rates are 100 and 1 traces per second: the rare key is entirely sampled,
+	// and the frequent key gets the remaining 9 traces per second.
+	now = now.Add(10 * time.Second)
+	sampled := 0
+	for range 10000 {
+		if evaluate("/frequent") == Sampled {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 900, sampled, 100)
+	for range 10 {
+		assert.Equal(t, Sampled, evaluate("/rare"))
+	}
+	assert.Equal(t, otelsampling.AlwaysSampleThreshold, at.thresholds["svc,/rare"])
+
+	// Keys that were not seen during the previous interval are entirely sampled.
+	assert.Equal(t, Sampled, evaluate("/new"))
+}
+
+func TestAdaptiveThroughputMinTracesPerSecondPerKey(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), []string{"http.route"}, 10, 20, time.Second, 0)
+	require.NoError(t, err)
+	at := evaluator.(*adaptiveThroughput)
+	at.counts = map[string]int64{"/a": 100, "/b": 100}
+
+	at.adjust(time.Second)
+	for _, key := range []string{"/a", "/b"} {
+		assert.InDelta(t, 0.2, at.thresholds[key].Probability(), 0.001)
+	}
+}
+
+func TestAdaptiveThroughputMaxKeys(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), []string{"http.route"}, 10, 0, time.Second, 2)
+	require.NoError(t, err)
+	at := evaluator.(*adaptiveThroughput)
+
+	for i, route := range []string{"/a", "/b", "/c", "/d"} {
+		traceID := adaptiveTestTraceID(uint64(i))
+		_, err := at.Evaluate(context.Background(), traceID, newTraceWithRoute(traceID, route, ""))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]int64{"/a": 1, "/b": 1, overflowKey: 2}, at.counts)
+}
+
+func TestAdaptiveThroughputTraceState(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), []string{"http.route"}, 10, 0, time.Second, 0)
+	require.NoError(t, err)
+	at := evaluator.(*adaptiveThroughput)
+	threshold, err := otelsampling.ProbabilityToThreshold(0.25)
+	require.NoError(t, err)
+	at.lastAdjustment = time.Now()
+	at.thresholds["/a"] = threshold
+
+	// The r-value of the tracestate takes precedence over the trace ID.
+	traceID := adaptiveTestTraceID(math.MaxUint64)
+	trace := newTraceWithRoute(traceID, "/a", "ot=rv:00000000000000")
+	decision, err := at.Evaluate(context.Background(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+
+	traceID = adaptiveTestTraceID(0)
+	trace = newTraceWithRoute(traceID, "/a", "ot=rv:ffffffffffffff,vendor=value")
+	decision, err = at.Evaluate(context.Background(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	// The threshold of the sampled traces is kept to be recorded in the tracestate after the final decision.
+	assert.Equal(t, threshold, trace.Threshold)
+	span := trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "ot=rv:ffffffffffffff,vendor=value", span.TraceState().AsRaw())
+
+	SetThresholdOnSpans(trace.ReceivedBatches, trace.Threshold, zap.NewNop())
+	ts, err := otelsampling.NewW3CTraceState(span.TraceState().AsRaw())
+	require.NoError(t, err)
+	assert.Equal(t, "c", ts.OTelValue().TValue())
+	assert.Equal(t, "ffffffffffffff", ts.OTelValue().RValue())
+	assert.InDelta(t, 4, ts.OTelValue().AdjustedCount(), 0.001)
+	assert.Equal(t, "value", ts.ExtraValues()[0].Value)
+}
+
+func TestAdaptiveThroughputKey(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), []string{"service.name", "span.name", "span.status.code", "missing"}, 10, 0, 0, 0)
+	require.NoError(t, err)
+	at := evaluator.(*adaptiveThroughput)
+
+	// The root span is found even if it is not the first span of the trace.
+	traceID := adaptiveTestTraceID(1)
+	trace := newTraceWithRoute(traceID, "/a", "")
+	spans := trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	spans.At(0).SetName("child")
+	spans.At(0).SetParentSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1})
+	root := spans.AppendEmpty()
+	root.SetName("root")
+	root.Status().SetCode(ptrace.StatusCodeError)
+
+	key, _ := at.keyAndRandomness(traceID, trace.ReceivedBatches)
+	assert.Equal(t, "svc,root,Error,", key)
+}
+
+func adaptiveTestTraceID(id uint64) pcommon.TraceID {
+	var traceID pcommon.TraceID
+	// Spread the identifiers over the randomness bits of the trace ID.
+	binary.BigEndian.PutUint64(traceID[8:], id*0x9E3779B97F4A7C15)
+	return traceID
+}
+
+func newTraceWithRoute(traceID pcommon.TraceID, route, traceState string) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "svc")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.Attributes().PutStr("http.route", route)
+	span.TraceState().FromRaw(traceState)
+	return &TraceData{
+		ReceivedBatches: traces,
+	}
+}
@@ -11,6 +11,8 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 // TraceData stores the sampling related trace data.
@@ -26,6 +28,9 @@ type TraceData struct {
 	ReceivedBatches ptrace.Traces
 	// FinalDecision.
 	FinalDecision Decision
+	// Threshold is the sampling threshold of the adaptive throughput policy that sampled the trace,
+	// or AlwaysSampleThreshold if the trace was not sampled with a lower probability.
+	Threshold otelsampling.Threshold
 }
 
 // Decision gives the status of sampling decision.
@@ -4,8 +4,13 @@
 package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 
 import (
+	"strings"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 // hasResourceOrSpanWithCondition iterates through all the resources and instrumentation library spans until any
@@ -104,3 +109,32 @@ func SetAttrOnScopeSpans(data *TraceData, attrName string, attrKey string) {
 		}
 	}
 }
+
+// SetThresholdOnSpans records the sampling threshold in the tracestate of the spans, so that their adjusted count
+// reflects the sampling probability. Spans that were already sampled with a lower probability are left as is.
+func SetThresholdOnSpans(td ptrace.Traces, threshold otelsampling.Threshold, logger *zap.Logger) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				ts, err := otelsampling.NewW3CTraceState(span.TraceState().AsRaw())
+				if err != nil {
+					logger.Debug("Invalid tracestate", zap.Error(err))
+					continue
+				}
+				if err = ts.OTelValue().UpdateTValueWithSampling(threshold); err != nil {
+					continue
+				}
+				var w strings.Builder
+				if err = ts.Serialize(&w); err != nil {
+					logger.Debug("Failed to serialize tracestate", zap.Error(err))
+					continue
+				}
+				span.TraceState().FromRaw(w.String())
+			}
+		}
+	}
+}
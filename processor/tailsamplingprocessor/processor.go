@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
@@ -60,6 +61,9 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher    idbatcher.Batcher
 	sampledIDCache     cache.Cache[bool]
 	nonSampledIDCache  cache.Cache[bool]
+	thresholdCache     cache.Cache[otelsampling.Threshold]
+	decisionStorageID  *component.ID
+	decisionStore      *decisionStore
 	deleteChan         chan pcommon.TraceID
 	numTracesOnMap     *atomic.Uint64
 	recordPolicy       bool
@@ -113,6 +117,13 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 			return nil, err
 		}
 	}
+	thresholds := cache.NewNopDecisionCache[otelsampling.Threshold]()
+	if cfg.DecisionCache.SampledCacheSize > 0 {
+		thresholds, err = cache.NewLRUDecisionCache[otelsampling.Threshold](cfg.DecisionCache.SampledCacheSize)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	tsp := &tailSamplingSpanProcessor{
 		ctx:                ctx,
@@ -122,6 +133,8 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		maxNumTraces:       cfg.NumTraces,
 		sampledIDCache:     sampledDecisions,
 		nonSampledIDCache:  nonSampledDecisions,
+		thresholdCache:     thresholds,
+		decisionStorageID:  cfg.DecisionCache.StorageID,
 		logger:             telemetrySettings.Logger,
 		numTracesOnMap:     &atomic.Uint64{},
 		deleteChan:         make(chan pcommon.TraceID, cfg.NumTraces),
@@ -250,6 +263,9 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 	case OTTLCondition:
 		ottlfCfg := cfg.OTTLConditionCfg
 		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.ErrorMode)
+	case AdaptiveThroughput:
+		atCfg := cfg.AdaptiveThroughputCfg
+		return sampling.NewAdaptiveThroughput(settings, atCfg.Keys, atCfg.TracesPerSecond, atCfg.MinTracesPerSecondPerKey, atCfg.AdjustmentInterval, atCfg.MaxKeys)
 
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
@@ -354,6 +370,13 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	batch, _ := tsp.decisionBatcher.CloseCurrentAndTakeFirstBatch()
 	batchLen := len(batch)
 
+	// Traces may have been decided by another collector sharing the decision store.
+	var storedDecisions, newDecisions map[pcommon.TraceID]sampling.Decision
+	if tsp.decisionStore != nil {
+		storedDecisions = tsp.decisionStore.get(ctx, batch)
+		newDecisions = make(map[pcommon.TraceID]sampling.Decision, batchLen)
+	}
+
 	for _, id := range batch {
 		d, ok := tsp.idToTrace.Load(id)
 		if !ok {
@@ -363,25 +386,36 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
 
-		decision := tsp.makeDecision(id, trace, &metrics)
+		decision, stored := storedDecisions[id]
+		if !stored {
+			decision = tsp.makeDecision(id, trace, &metrics)
+			if newDecisions != nil {
+				newDecisions[id] = decision
+			}
+		}
 
 		tsp.telemetry.ProcessorTailSamplingGlobalCountTracesSampled.Add(tsp.ctx, 1, decisionToAttribute[decision])
 
 		// Sampled or not, remove the batches
 		trace.Lock()
 		allSpans := trace.ReceivedBatches
+		threshold := trace.Threshold
 		trace.FinalDecision = decision
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
 		switch decision {
 		case sampling.Sampled:
-			tsp.releaseSampledTrace(ctx, id, allSpans)
+			tsp.releaseSampledTrace(ctx, id, allSpans, threshold)
 		case sampling.NotSampled:
 			tsp.releaseNotSampledTrace(id)
 		}
 	}
 
+	if tsp.decisionStore != nil {
+		tsp.decisionStore.put(ctx, newDecisions)
+	}
+
 	tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, int64(time.Since(startTime)/time.Millisecond))
 	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(tsp.numTracesOnMap.Load()))
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
@@ -407,6 +441,9 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		sampling.Dropped:          nil,
 	}
 
+	// The number of policies that sampled the trace, to know whether its sampling threshold applies.
+	sampledPolicies := 0
+
 	ctx := context.Background()
 	startTime := time.Now()
 
@@ -431,6 +468,10 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 			tsp.telemetry.ProcessorTailSamplingCountSpansSampled.Add(ctx, trace.SpanCount.Load(), p.attribute, decisionToAttribute[decision])
 		}
 
+		if decision == sampling.Sampled || decision == sampling.InvertSampled {
+			sampledPolicies++
+		}
+
 		// We associate the first policy with the sampling decision to understand what policy sampled a span
 		if samplingDecisions[decision] == nil {
 			samplingDecisions[decision] = p
@@ -461,6 +502,14 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		sampledPolicy = samplingDecisions[sampling.InvertSampled]
 	}
 
+	// The sampling threshold of the adaptive throughput policy only reflects the probability of the trace
+	// to be sampled if no other policy sampled it.
+	if finalDecision != sampling.Sampled || sampledPolicies > 1 {
+		trace.Lock()
+		trace.Threshold = otelsampling.AlwaysSampleThreshold
+		trace.Unlock()
+	}
+
 	if tsp.recordPolicy && sampledPolicy != nil {
 		sampling.SetAttrOnScopeSpans(trace, "tailsampling.policy", sampledPolicy.name)
 	}
@@ -509,6 +558,10 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 	// Group spans per their traceId to minimize contention on idToTrace
 	idToSpansAndScope := tsp.groupSpansByTraceKey(resourceSpans)
+	var storedDecisions map[pcommon.TraceID]sampling.Decision
+	if tsp.decisionStore != nil {
+		storedDecisions = tsp.getStoredDecisions(idToSpansAndScope)
+	}
 	var newTraceIDs int64
 	for id, spans := range idToSpansAndScope {
 		// If the trace ID is in the sampled cache, short circuit the decision
@@ -516,7 +569,8 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			tsp.logger.Debug("Trace ID is in the sampled cache", zap.Stringer("id", id))
 			traceTd := ptrace.NewTraces()
 			appendToTraces(traceTd, resourceSpans, spans)
-			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
+			threshold, _ := tsp.thresholdCache.Get(id)
+			tsp.releaseSampledTrace(tsp.ctx, id, traceTd, threshold)
 			metric.WithAttributeSet(attribute.NewSet())
 			tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
 				Add(tsp.ctx, int64(len(spans)), attrSampledTrue)
@@ -529,6 +583,22 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 				Add(tsp.ctx, int64(len(spans)), attrSampledFalse)
 			continue
 		}
+		// If another collector already decided on the trace, follow its decision
+		if decision, ok := storedDecisions[id]; ok {
+			tsp.logger.Debug("Trace ID is in the decision store", zap.Stringer("id", id))
+			if decision == sampling.Sampled {
+				traceTd := ptrace.NewTraces()
+				appendToTraces(traceTd, resourceSpans, spans)
+				tsp.releaseSampledTrace(tsp.ctx, id, traceTd, otelsampling.AlwaysSampleThreshold)
+				tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
+					Add(tsp.ctx, int64(len(spans)), attrSampledTrue)
+			} else {
+				tsp.releaseNotSampledTrace(id)
+				tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
+					Add(tsp.ctx, int64(len(spans)), attrSampledFalse)
+			}
+			continue
+		}
 
 		lenSpans := int64(len(spans))
 
@@ -567,6 +637,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 		actualData.Lock()
 		finalDecision := actualData.FinalDecision
+		threshold := actualData.Threshold
 
 		if finalDecision == sampling.Unspecified {
 			// If the final decision hasn't been made, add the new spans under the lock.
@@ -581,7 +652,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 		case sampling.Sampled:
 			traceTd := ptrace.NewTraces()
 			appendToTraces(traceTd, resourceSpans, spans)
-			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
+			tsp.releaseSampledTrace(tsp.ctx, id, traceTd, threshold)
 		case sampling.NotSampled:
 			tsp.releaseNotSampledTrace(id)
 		default:
@@ -596,20 +667,49 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	tsp.telemetry.ProcessorTailSamplingNewTraceIDReceived.Add(tsp.ctx, newTraceIDs)
 }
 
+// getStoredDecisions looks up the decision store for the traces that are neither in the decision caches
+// nor in memory, as those are the traces that may have been decided by another collector.
+func (tsp *tailSamplingSpanProcessor) getStoredDecisions(idToSpans map[pcommon.TraceID][]spanAndScope) map[pcommon.TraceID]sampling.Decision {
+	ids := make([]pcommon.TraceID, 0, len(idToSpans))
+	for id := range idToSpans {
+		if _, ok := tsp.sampledIDCache.Get(id); ok {
+			continue
+		}
+		if _, ok := tsp.nonSampledIDCache.Get(id); ok {
+			continue
+		}
+		if _, ok := tsp.idToTrace.Load(id); ok {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return tsp.decisionStore.get(tsp.ctx, ids)
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.decisionStorageID != nil {
+		store, err := newDecisionStore(ctx, host, *tsp.decisionStorageID, tsp.set.ID, tsp.logger)
+		if err != nil {
+			return fmt.Errorf("failed to get the decision store: %w", err)
+		}
+		tsp.decisionStore = store
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	if tsp.decisionStore != nil {
+		return tsp.decisionStore.close(ctx)
+	}
 	return nil
 }
 
@@ -629,11 +729,16 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceRemovalAge.Record(tsp.ctx, int64(deletionTime.Sub(trace.ArrivalTime)/time.Second))
 }
 
-// releaseSampledTrace sends the trace data to the next consumer. It
-// additionally adds the trace ID to the cache of sampled trace IDs. If the
-// trace ID is cached, it deletes the spans from the internal map.
-func (tsp *tailSamplingSpanProcessor) releaseSampledTrace(ctx context.Context, id pcommon.TraceID, td ptrace.Traces) {
+// releaseSampledTrace sends the trace data to the next consumer, recording the
+// sampling threshold in the tracestate of its spans. It additionally adds the
+// trace ID to the cache of sampled trace IDs. If the trace ID is cached, it
+// deletes the spans from the internal map.
+func (tsp *tailSamplingSpanProcessor) releaseSampledTrace(ctx context.Context, id pcommon.TraceID, td ptrace.Traces, threshold otelsampling.Threshold) {
 	tsp.sampledIDCache.Put(id, true)
+	if threshold != otelsampling.AlwaysSampleThreshold {
+		tsp.thresholdCache.Put(id, threshold)
+		sampling.SetThresholdOnSpans(td, threshold, tsp.logger)
+	}
 	if err := tsp.nextConsumer.ConsumeTraces(ctx, td); err != nil {
 		tsp.logger.Warn(
 			"Error sending spans to destination",
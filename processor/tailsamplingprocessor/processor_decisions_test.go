@@ -11,11 +11,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	otelsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -694,3 +696,107 @@ func TestSampleOnFirstMatch(t *testing.T) {
 	// The final decision SHOULD be Sampled.
 	require.Equal(t, 1, nextConsumer.SpanCount())
 }
+
+func TestSamplingThresholdOfSolePolicy(t *testing.T) {
+	threshold, err := otelsampling.ProbabilityToThreshold(0.25)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		otherDecision    sampling.Decision
+		sampledCacheSize int
+		expected         string
+	}{
+		{
+			name:          "sole policy",
+			otherDecision: sampling.NotSampled,
+			expected:      "ot=th:c",
+		},
+		{
+			name:             "sole policy with decision cache",
+			otherDecision:    sampling.NotSampled,
+			sampledCacheSize: 200,
+			expected:         "ot=th:c",
+		},
+		{
+			name:          "also sampled by another policy",
+			otherDecision: sampling.Sampled,
+			expected:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextConsumer := new(consumertest.TracesSink)
+			idb := newSyncIDBatcher()
+
+			tpe := &thresholdPolicyEvaluator{threshold: threshold}
+			tpe.NextDecision = sampling.Sampled
+			mpe := &mockPolicyEvaluator{NextDecision: tt.otherDecision}
+			policies := []*policy{
+				{name: "threshold-policy", evaluator: tpe, attribute: metric.WithAttributes(attribute.String("policy", "threshold-policy"))},
+				{name: "mock-policy", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy"))},
+			}
+
+			cfg := Config{
+				DecisionWait: defaultTestDecisionWait * 10,
+				NumTraces:    defaultNumTraces,
+				DecisionCache: DecisionCacheConfig{
+					SampledCacheSize: tt.sampledCacheSize,
+				},
+				Options: []Option{
+					withDecisionBatcher(idb),
+					withPolicies(policies),
+				},
+			}
+			p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+			require.NoError(t, err)
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			traceID := uInt64ToTraceID(1)
+			spanIndexToTraces := func(spanIndex uint64) ptrace.Traces {
+				traces := ptrace.NewTraces()
+				span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+				span.SetTraceID(traceID)
+				span.SetSpanID(uInt64ToSpanID(spanIndex))
+				return traces
+			}
+
+			require.NoError(t, p.ConsumeTraces(context.Background(), spanIndexToTraces(1)))
+			tsp := p.(*tailSamplingSpanProcessor)
+			tsp.policyTicker.OnTick()
+			tsp.policyTicker.OnTick()
+			require.Equal(t, 1, nextConsumer.SpanCount())
+
+			_, inMemory := tsp.idToTrace.Load(traceID)
+			assert.Equal(t, tt.sampledCacheSize == 0, inMemory)
+
+			// The late span gets the threshold of the trace, whether it is in memory or in the decision cache.
+			require.NoError(t, p.ConsumeTraces(context.Background(), spanIndexToTraces(2)))
+			require.Equal(t, 2, nextConsumer.SpanCount())
+			for _, td := range nextConsumer.AllTraces() {
+				span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+				assert.Equal(t, tt.expected, span.TraceState().AsRaw())
+			}
+		})
+	}
+}
+
+// thresholdPolicyEvaluator samples the traces with a threshold, as the adaptive throughput policy does.
+type thresholdPolicyEvaluator struct {
+	mockPolicyEvaluator
+	threshold otelsampling.Threshold
+}
+
+func (e *thresholdPolicyEvaluator) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *sampling.TraceData) (sampling.Decision, error) {
+	decision, err := e.mockPolicyEvaluator.Evaluate(ctx, traceID, trace)
+	if decision == sampling.Sampled {
+		trace.Lock()
+		trace.Threshold = e.threshold
+		trace.Unlock()
+	}
+	return decision, err
+}
@@ -21,13 +21,14 @@ const (
 	SpanEvent ContextID = "spanevent"
 	Metric    ContextID = "metric"
 	DataPoint ContextID = "datapoint"
+	Exemplar  ContextID = "exemplar"
 	Log       ContextID = "log"
 )
 
 func (c *ContextID) UnmarshalText(text []byte) error {
 	str := ContextID(strings.ToLower(string(text)))
 	switch str {
-	case Resource, Scope, Span, SpanEvent, Metric, DataPoint, Log:
+	case Resource, Scope, Span, SpanEvent, Metric, DataPoint, Exemplar, Log:
 		*c = str
 		return nil
 	default:
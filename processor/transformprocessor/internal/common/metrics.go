@@ -14,6 +14,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlexemplar"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 )
 
@@ -163,6 +164,75 @@ func (d dataPointStatements) handleSummaryDataPoints(ctx context.Context, dps pm
 	return nil
 }
 
+type exemplarStatements struct {
+	ottl.StatementSequence[ottlexemplar.TransformContext]
+	expr.BoolExpr[ottlexemplar.TransformContext]
+}
+
+func (e exemplarStatements) Context() ContextID {
+	return Exemplar
+}
+
+func (e exemplarStatements) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rmetrics := md.ResourceMetrics().At(i)
+		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
+			smetrics := rmetrics.ScopeMetrics().At(j)
+			metrics := smetrics.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				var err error
+				//exhaustive:enforce
+				switch metric.Type() {
+				case pmetric.MetricTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len() && err == nil; l++ {
+						err = e.handleExemplars(ctx, dps.At(l).Exemplars(), dps.At(l), metric, metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics)
+					}
+				case pmetric.MetricTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len() && err == nil; l++ {
+						err = e.handleExemplars(ctx, dps.At(l).Exemplars(), dps.At(l), metric, metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics)
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len() && err == nil; l++ {
+						err = e.handleExemplars(ctx, dps.At(l).Exemplars(), dps.At(l), metric, metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len() && err == nil; l++ {
+						err = e.handleExemplars(ctx, dps.At(l).Exemplars(), dps.At(l), metric, metrics, smetrics.Scope(), rmetrics.Resource(), smetrics, rmetrics)
+					}
+				case pmetric.MetricTypeSummary:
+					// Summary data points have no exemplars.
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (e exemplarStatements) handleExemplars(ctx context.Context, exemplars pmetric.ExemplarSlice, dataPoint any, metric pmetric.Metric, metrics pmetric.MetricSlice, is pcommon.InstrumentationScope, resource pcommon.Resource, scopeMetrics pmetric.ScopeMetrics, resourceMetrics pmetric.ResourceMetrics) error {
+	for i := 0; i < exemplars.Len(); i++ {
+		tCtx := ottlexemplar.NewTransformContext(exemplars.At(i), dataPoint, metric, metrics, is, resource, scopeMetrics, resourceMetrics)
+		condition, err := e.Eval(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+		if condition {
+			err := e.Execute(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 type MetricParserCollection ottl.ParserCollection[MetricsConsumer]
 
 type MetricParserCollectionOption ottl.ParserCollectionOption[MetricsConsumer]
@@ -187,6 +257,16 @@ func WithDataPointParser(functions map[string]ottl.Factory[ottldatapoint.Transfo
 	}
 }
 
+func WithExemplarParser(functions map[string]ottl.Factory[ottlexemplar.TransformContext]) MetricParserCollectionOption {
+	return func(pc *ottl.ParserCollection[MetricsConsumer]) error {
+		exemplarParser, err := ottlexemplar.NewParser(functions, pc.Settings, ottlexemplar.EnablePathContextNames())
+		if err != nil {
+			return err
+		}
+		return ottl.WithParserCollectionContext(ottlexemplar.ContextName, &exemplarParser, ottl.WithStatementConverter(convertExemplarStatements))(pc)
+	}
+}
+
 func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption {
 	return MetricParserCollectionOption(ottl.WithParserCollectionErrorMode[MetricsConsumer](errorMode))
 }
@@ -252,6 +332,27 @@ func convertDataPointStatements(pc *ottl.ParserCollection[MetricsConsumer], stat
 	return dataPointStatements{dpStatements, globalExpr}, nil
 }
 
+func convertExemplarStatements(pc *ottl.ParserCollection[MetricsConsumer], statements ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottlexemplar.TransformContext]) (MetricsConsumer, error) {
+	contextStatements, err := toContextStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	errorMode := pc.ErrorMode
+	if contextStatements.ErrorMode != "" {
+		errorMode = contextStatements.ErrorMode
+	}
+	var parserOptions []ottl.Option[ottlexemplar.TransformContext]
+	if contextStatements.Context == "" {
+		parserOptions = append(parserOptions, ottlexemplar.EnablePathContextNames())
+	}
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForExemplarWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardExemplarFuncs(), parserOptions)
+	if errGlobalBoolExpr != nil {
+		return nil, errGlobalBoolExpr
+	}
+	eStatements := ottlexemplar.NewStatementSequence(parsedStatements, pc.Settings, ottlexemplar.WithStatementSequenceErrorMode(errorMode))
+	return exemplarStatements{eStatements, globalExpr}, nil
+}
+
 func (mpc *MetricParserCollection) ParseContextStatements(contextStatements ContextStatements) (MetricsConsumer, error) {
 	pc := ottl.ParserCollection[MetricsConsumer](*mpc)
 	if contextStatements.Context != "" {
@@ -1937,6 +1937,82 @@ func Test_ProcessMetrics_InferredContextFromConditions(t *testing.T) {
 	}
 }
 
+func Test_ProcessMetrics_ExemplarContext(t *testing.T) {
+	tests := []struct {
+		name              string
+		contextStatements []common.ContextStatements
+		want              func(td pmetric.Metrics)
+	}{
+		{
+			name: "exemplar context",
+			contextStatements: []common.ContextStatements{
+				{
+					Context:    "exemplar",
+					Statements: []string{`delete_key(filtered_attributes, "user.email")`},
+				},
+			},
+			want: func(td pmetric.Metrics) {
+				metrics := td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+				metrics.At(0).Sum().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().Remove("user.email")
+				metrics.At(1).Histogram().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().Remove("user.email")
+			},
+		},
+		{
+			name: "inferred exemplar context",
+			contextStatements: []common.ContextStatements{
+				{
+					Conditions: []string{`metric.name == "operationA"`},
+					Statements: []string{`set(exemplar.filtered_attributes["env"], datapoint.attributes["env"]) where exemplar.trace_id.string != "00000000000000000000000000000000"`},
+				},
+			},
+			want: func(td pmetric.Metrics) {
+				metrics := td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+				metrics.At(0).Sum().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().PutStr("env", "prod")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructMetricsWithExemplars()
+			processor, err := NewProcessor(tt.contextStatements, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			_, err = processor.ProcessMetrics(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructMetricsWithExemplars()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func constructMetricsWithExemplars() pmetric.Metrics {
+	td := pmetric.NewMetrics()
+	metrics := td.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("operationA")
+	dataPoint := sum.SetEmptySum().DataPoints().AppendEmpty()
+	dataPoint.Attributes().PutStr("env", "prod")
+	exemplar := dataPoint.Exemplars().AppendEmpty()
+	exemplar.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	exemplar.FilteredAttributes().PutStr("user.email", "user@example.com")
+	dataPoint.Exemplars().AppendEmpty().FilteredAttributes().PutStr("user.id", "42")
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("operationB")
+	exemplar = histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Exemplars().AppendEmpty()
+	exemplar.FilteredAttributes().PutStr("user.email", "user@example.com")
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("operationC")
+	summary.SetEmptySummary().DataPoints().AppendEmpty()
+	return td
+}
+
 func Test_NewProcessor_ConditionsParse(t *testing.T) {
 	type testCase struct {
 		name          string
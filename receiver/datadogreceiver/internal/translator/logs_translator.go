@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver/internal/translator"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+const (
+	logKeyMessage   = "message"
+	logKeyStatus    = "status"
+	logKeyTimestamp = "timestamp"
+	logKeyHostname  = "hostname"
+	logKeyService   = "service"
+	logKeyDDSource  = "ddsource"
+	logKeyDDTags    = "ddtags"
+
+	// AttributeDatadogLogSource is the resource attribute holding the ddsource of the logs, which
+	// has no equivalent in the OTel semantic conventions
+	AttributeDatadogLogSource = "datadog.log.source"
+)
+
+// See https://docs.datadoghq.com/logs/log_configuration/processors/#log-status-remapper
+var datadogLogStatuses = map[string]plog.SeverityNumber{
+	"emerg":     plog.SeverityNumberFatal4,
+	"emergency": plog.SeverityNumberFatal4,
+	"alert":     plog.SeverityNumberFatal3,
+	"fatal":     plog.SeverityNumberFatal,
+	"critical":  plog.SeverityNumberFatal,
+	"crit":      plog.SeverityNumberFatal,
+	"error":     plog.SeverityNumberError,
+	"err":       plog.SeverityNumberError,
+	"warning":   plog.SeverityNumberWarn,
+	"warn":      plog.SeverityNumberWarn,
+	"notice":    plog.SeverityNumberInfo2,
+	"info":      plog.SeverityNumberInfo,
+	"ok":        plog.SeverityNumberInfo,
+	"success":   plog.SeverityNumberInfo,
+	"debug":     plog.SeverityNumberDebug,
+	"trace":     plog.SeverityNumberTrace,
+}
+
+// DatadogLog is a log sent to the Datadog logs intake, see https://docs.datadoghq.com/api/latest/logs/#send-logs
+type DatadogLog struct {
+	Message string
+	Status  string
+	// Timestamp is the time of the log in milliseconds since the epoch, or 0 if unknown
+	Timestamp int64
+	Hostname  string
+	Service   string
+	DDSource  string
+	DDTags    string
+	// Attributes holds the other fields of the log
+	Attributes map[string]any
+}
+
+type LogsTranslator struct {
+	buildInfo  component.BuildInfo
+	stringPool *StringPool
+}
+
+func NewLogsTranslator(buildInfo component.BuildInfo) *LogsTranslator {
+	return &LogsTranslator{
+		buildInfo:  buildInfo,
+		stringPool: newStringPool(),
+	}
+}
+
+// HandleLogsPayload decodes the logs sent to the v2 logs endpoint or to the v1 input endpoint. The body is either a
+// JSON array of logs, a single JSON log, or one log message per line for text payloads. The ddsource, ddtags,
+// service and hostname query parameters apply to the logs which don't define them. The compressed payloads are
+// decompressed by the HTTP server based on their Content-Encoding.
+func (lt *LogsTranslator) HandleLogsPayload(req *http.Request) ([]DatadogLog, error) {
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	if _, err := io.Copy(buf, req.Body); err != nil {
+		return nil, err
+	}
+
+	var logs []DatadogLog
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "text/plain") {
+		logs = decodeTextLogs(buf.Bytes())
+	} else {
+		var err error
+		if logs, err = decodeJSONLogs(buf.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	query := req.URL.Query()
+	for i := range logs {
+		if logs[i].Hostname == "" {
+			logs[i].Hostname = query.Get(logKeyHostname)
+		}
+		if logs[i].Service == "" {
+			logs[i].Service = query.Get(logKeyService)
+		}
+		if logs[i].DDSource == "" {
+			logs[i].DDSource = query.Get(logKeyDDSource)
+		}
+		if tags := query.Get(logKeyDDTags); tags != "" {
+			if logs[i].DDTags == "" {
+				logs[i].DDTags = tags
+			} else {
+				logs[i].DDTags += "," + tags
+			}
+		}
+	}
+	return logs, nil
+}
+
+func decodeTextLogs(body []byte) []DatadogLog {
+	var logs []DatadogLog
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(body)+1)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			logs = append(logs, DatadogLog{Message: line})
+		}
+	}
+	return logs
+}
+
+func decodeJSONLogs(body []byte) ([]DatadogLog, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var rawLogs []map[string]any
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var rawLog map[string]any
+		if err := decoder.Decode(&rawLog); err != nil {
+			return nil, err
+		}
+		rawLogs = append(rawLogs, rawLog)
+	} else if err := decoder.Decode(&rawLogs); err != nil {
+		return nil, err
+	}
+
+	logs := make([]DatadogLog, 0, len(rawLogs))
+	for _, rawLog := range rawLogs {
+		log, err := newDatadogLog(rawLog)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+func newDatadogLog(rawLog map[string]any) (DatadogLog, error) {
+	log := DatadogLog{Attributes: make(map[string]any, len(rawLog))}
+	for key, value := range rawLog {
+		switch key {
+		case logKeyMessage:
+			log.Message = stringValue(value)
+		case logKeyStatus:
+			log.Status = stringValue(value)
+		case logKeyHostname:
+			log.Hostname = stringValue(value)
+		case logKeyService:
+			log.Service = stringValue(value)
+		case logKeyDDSource:
+			log.DDSource = stringValue(value)
+		case logKeyDDTags:
+			log.DDTags = stringValue(value)
+		case logKeyTimestamp:
+			timestamp, err := parseLogTimestamp(value)
+			if err != nil {
+				return log, err
+			}
+			log.Timestamp = timestamp
+		default:
+			log.Attributes[key] = rawValue(value)
+		}
+	}
+	return log, nil
+}
+
+// parseLogTimestamp returns the timestamp in milliseconds since the epoch, which is either a number of
+// milliseconds or an RFC 3339 date
+func parseLogTimestamp(value any) (int64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if ms, err := v.Int64(); err == nil {
+			return ms, nil
+		}
+		ms, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid log timestamp %q: %w", v, err)
+		}
+		return int64(ms), nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid log timestamp %q: %w", v, err)
+		}
+		return t.UnixMilli(), nil
+	case nil:
+		return 0, nil
+	default:
+		return 0, errors.New("invalid log timestamp type")
+	}
+}
+
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// rawValue converts the JSON numbers of a decoded value to the types supported by pcommon.Value
+func rawValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		for key, item := range v {
+			v[key] = rawValue(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = rawValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// TranslateLogs translates the Datadog logs into OTel logs, with one resource per hostname, service, ddsource
+// and ddtags. The tags known to be resource attributes are mapped to the OTel semantic conventions, the other
+// tags become log attributes.
+func (lt *LogsTranslator) TranslateLogs(logs []DatadogLog) plog.Logs {
+	result := plog.NewLogs()
+	scopeLogsByResource := map[[4]string]plog.ScopeLogs{}
+	observedTimestamp := pcommon.NewTimestampFromTime(time.Now())
+
+	for _, log := range logs {
+		var tags []string
+		for _, tag := range strings.Split(log.DDTags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		attrs := tagsToAttributes(tags, log.Hostname, lt.stringPool)
+
+		resourceKey := [4]string{log.Hostname, log.Service, log.DDSource, log.DDTags}
+		scopeLogs, ok := scopeLogsByResource[resourceKey]
+		if !ok {
+			resourceLogs := result.ResourceLogs().AppendEmpty()
+			resource := resourceLogs.Resource().Attributes()
+			attrs.resource.CopyTo(resource)
+			if log.Service != "" {
+				resource.PutStr(string(semconv.ServiceNameKey), log.Service)
+			}
+			if log.DDSource != "" {
+				resource.PutStr(AttributeDatadogLogSource, log.DDSource)
+			}
+
+			scopeLogs = resourceLogs.ScopeLogs().AppendEmpty()
+			scopeLogs.Scope().SetName("github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver/internal/translator")
+			scopeLogs.Scope().SetVersion(lt.buildInfo.Version)
+			scopeLogsByResource[resourceKey] = scopeLogs
+		}
+
+		logRecord := scopeLogs.LogRecords().AppendEmpty()
+		logRecord.SetObservedTimestamp(observedTimestamp)
+		if log.Timestamp != 0 {
+			logRecord.SetTimestamp(pcommon.Timestamp(log.Timestamp * time.Millisecond.Nanoseconds())) // OTel uses nanoseconds, while Datadog uses milliseconds
+		}
+		logRecord.Body().SetStr(log.Message)
+		if log.Status != "" {
+			logRecord.SetSeverityText(log.Status)
+			logRecord.SetSeverityNumber(datadogLogStatuses[strings.ToLower(log.Status)])
+		}
+
+		logAttrs := logRecord.Attributes()
+		attrs.dp.CopyTo(logAttrs)
+		for key, value := range log.Attributes {
+			if err := logAttrs.PutEmpty(key).FromRaw(value); err != nil {
+				logAttrs.PutStr(key, fmt.Sprint(value))
+			}
+		}
+	}
+	return result
+}
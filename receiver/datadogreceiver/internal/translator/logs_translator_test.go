@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func createLogsTranslator() *LogsTranslator {
+	return NewLogsTranslator(component.BuildInfo{
+		Command:     "otelcol",
+		Description: "OpenTelemetry Collector",
+		Version:     "latest",
+	})
+}
+
+func TestHandleLogsPayload(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		contentType  string
+		payload      string
+		expectedLogs []DatadogLog
+		expectedErr  string
+	}{
+		{
+			name:        "json array",
+			url:         "/api/v2/logs",
+			contentType: "application/json",
+			payload: `[
+				{
+					"message": "hello",
+					"status": "info",
+					"timestamp": 1700000000123,
+					"hostname": "hosta",
+					"service": "web",
+					"ddsource": "nginx",
+					"ddtags": "env:prod,team:a",
+					"http": {"status_code": 200, "duration": 1.5}
+				},
+				{
+					"message": "world"
+				}
+			]`,
+			expectedLogs: []DatadogLog{
+				{
+					Message:    "hello",
+					Status:     "info",
+					Timestamp:  1700000000123,
+					Hostname:   "hosta",
+					Service:    "web",
+					DDSource:   "nginx",
+					DDTags:     "env:prod,team:a",
+					Attributes: map[string]any{"http": map[string]any{"status_code": int64(200), "duration": 1.5}},
+				},
+				{
+					Message:    "world",
+					Attributes: map[string]any{},
+				},
+			},
+		},
+		{
+			name:        "single json log with query parameters",
+			url:         "/v1/input?ddsource=java&ddtags=region:eu&service=api&hostname=hostb",
+			contentType: "application/json",
+			payload:     `{"message": "hello", "ddtags": "env:prod", "timestamp": "2023-11-14T22:13:20.123Z"}`,
+			expectedLogs: []DatadogLog{
+				{
+					Message:    "hello",
+					Timestamp:  1700000000123,
+					Hostname:   "hostb",
+					Service:    "api",
+					DDSource:   "java",
+					DDTags:     "env:prod,region:eu",
+					Attributes: map[string]any{},
+				},
+			},
+		},
+		{
+			name:        "text lines",
+			url:         "/v1/input/apikey?ddsource=syslog",
+			contentType: "text/plain",
+			payload:     "first line\r\n\nsecond line\n",
+			expectedLogs: []DatadogLog{
+				{Message: "first line", DDSource: "syslog"},
+				{Message: "second line", DDSource: "syslog"},
+			},
+		},
+		{
+			name:        "invalid json",
+			url:         "/api/v2/logs",
+			contentType: "application/json",
+			payload:     `[{"message": }]`,
+			expectedErr: "invalid character",
+		},
+		{
+			name:        "invalid timestamp",
+			url:         "/api/v2/logs",
+			contentType: "application/json",
+			payload:     `[{"message": "hello", "timestamp": "yesterday"}]`,
+			expectedErr: "invalid log timestamp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, tt.url, bytes.NewReader([]byte(tt.payload)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", tt.contentType)
+
+			logs, err := createLogsTranslator().HandleLogsPayload(req)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedLogs, logs)
+		})
+	}
+}
+
+func TestTranslateLogs(t *testing.T) {
+	lt := createLogsTranslator()
+	result := lt.TranslateLogs([]DatadogLog{
+		{
+			Message:    "hello",
+			Status:     "Warning",
+			Timestamp:  1700000000123,
+			Hostname:   "hosta",
+			Service:    "web",
+			DDSource:   "nginx",
+			DDTags:     "env:prod, team:a,",
+			Attributes: map[string]any{"http": map[string]any{"status_code": int64(200)}},
+		},
+		{
+			Message:   "world",
+			Status:    "emerg",
+			Hostname:  "hosta",
+			Service:   "web",
+			DDSource:  "nginx",
+			DDTags:    "env:prod, team:a,",
+			Timestamp: 1700000000456,
+		},
+		{
+			Message: "no metadata",
+		},
+	})
+
+	require.Equal(t, 2, result.ResourceLogs().Len())
+
+	resourceLogs := result.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		"host.name":                   "hosta",
+		"service.name":                "web",
+		"deployment.environment.name": "prod",
+		"datadog.log.source":          "nginx",
+	}, resourceLogs.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, resourceLogs.ScopeLogs().Len())
+	scope := resourceLogs.ScopeLogs().At(0).Scope()
+	assert.Equal(t, "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver/internal/translator", scope.Name())
+	assert.Equal(t, "latest", scope.Version())
+
+	logRecords := resourceLogs.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, logRecords.Len())
+	first := logRecords.At(0)
+	assert.Equal(t, "hello", first.Body().Str())
+	assert.Equal(t, pcommon.Timestamp(1700000000123000000), first.Timestamp())
+	assert.NotZero(t, first.ObservedTimestamp())
+	assert.Equal(t, "Warning", first.SeverityText())
+	assert.Equal(t, plog.SeverityNumberWarn, first.SeverityNumber())
+	assert.Equal(t, map[string]any{
+		"team": "a",
+		"http": map[string]any{"status_code": int64(200)},
+	}, first.Attributes().AsRaw())
+	second := logRecords.At(1)
+	assert.Equal(t, "world", second.Body().Str())
+	assert.Equal(t, plog.SeverityNumberFatal4, second.SeverityNumber())
+
+	resourceLogs = result.ResourceLogs().At(1)
+	assert.Equal(t, 0, resourceLogs.Resource().Attributes().Len())
+	logRecord := resourceLogs.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "no metadata", logRecord.Body().Str())
+	assert.Zero(t, logRecord.Timestamp())
+	assert.Equal(t, plog.SeverityNumberUnspecified, logRecord.SeverityNumber())
+	assert.Equal(t, 0, logRecord.Attributes().Len())
+}
@@ -36,9 +36,11 @@ type datadogReceiver struct {
 
 	nextTracesConsumer  consumer.Traces
 	nextMetricsConsumer consumer.Metrics
+	nextLogsConsumer    consumer.Logs
 
 	metricsTranslator *translator.MetricsTranslator
 	statsTranslator   *translator.StatsTranslator
+	logsTranslator    *translator.LogsTranslator
 
 	server    *http.Server
 	tReceiver *receiverhelper.ObsReport
@@ -128,6 +130,23 @@ func (ddr *datadogReceiver) getEndpoints() []Endpoint {
 		}...)
 	}
 
+	if ddr.nextLogsConsumer != nil {
+		endpoints = append(endpoints, []Endpoint{
+			{
+				Pattern: "/api/v2/logs",
+				Handler: ddr.handleLogs,
+			},
+			{
+				Pattern: "/v1/input",
+				Handler: ddr.handleLogs,
+			},
+			{
+				Pattern: "/v1/input/",
+				Handler: ddr.handleLogs,
+			},
+		}...)
+	}
+
 	infoResponse, _ := ddr.buildInfoResponse(endpoints)
 
 	endpoints = append(endpoints, Endpoint{
@@ -163,6 +182,7 @@ func newDataDogReceiver(config *Config, params receiver.Settings) (component.Com
 		tReceiver:         instance,
 		metricsTranslator: translator.NewMetricsTranslator(params.BuildInfo),
 		statsTranslator:   translator.NewStatsTranslator(),
+		logsTranslator:    translator.NewLogsTranslator(params.BuildInfo),
 		traceIDCache:      cache,
 	}, nil
 }
@@ -497,3 +517,42 @@ func (ddr *datadogReceiver) handleStats(w http.ResponseWriter, req *http.Request
 
 	_, _ = w.Write([]byte("OK"))
 }
+
+// handleLogs handles the v2 logs endpoint https://docs.datadoghq.com/api/latest/logs/#send-logs
+// and the v1 input endpoint, used by the agents configured to send their logs over HTTP
+func (ddr *datadogReceiver) handleLogs(w http.ResponseWriter, req *http.Request) {
+	obsCtx := ddr.tReceiver.StartLogsOp(req.Context())
+	var err error
+	var logsCount int
+	defer func(logsCount *int) {
+		ddr.tReceiver.EndLogsOp(obsCtx, "datadog", *logsCount, err)
+	}(&logsCount)
+
+	var ddLogs []translator.DatadogLog
+	ddLogs, err = ddr.logsTranslator.HandleLogsPayload(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		ddr.params.Logger.Error(err.Error())
+		return
+	}
+
+	logs := ddr.logsTranslator.TranslateLogs(ddLogs)
+	logsCount = logs.LogRecordCount()
+
+	if logsCount > 0 {
+		err = ddr.nextLogsConsumer.ConsumeLogs(obsCtx, logs)
+		if err != nil {
+			errorutil.HTTPError(w, err)
+			ddr.params.Logger.Error("logs consumer errored out", zap.Error(err))
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if strings.HasPrefix(req.URL.Path, "/v1/input") {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	_, _ = w.Write([]byte("{}"))
+}
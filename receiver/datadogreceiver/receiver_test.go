@@ -5,6 +5,7 @@ package datadogreceiver
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -22,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/multierr"
@@ -58,6 +60,20 @@ func TestDatadogMetricsReceiver_Lifecycle(t *testing.T) {
 	assert.NoError(t, err, "Server should stop")
 }
 
+func TestDatadogLogsReceiver_Lifecycle(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	cfg.(*Config).Endpoint = "localhost:0"
+	ddr, err := factory.CreateLogs(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	assert.NoError(t, err, "Logs receiver should be created")
+
+	err = ddr.Start(context.Background(), componenttest.NewNopHost())
+	assert.NoError(t, err, "Server should start")
+
+	err = ddr.Shutdown(context.Background())
+	assert.NoError(t, err, "Server should stop")
+}
+
 func TestDatadogServer(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.Endpoint = "localhost:0" // Using a randomly assigned address
@@ -188,6 +204,7 @@ func TestDatadogInfoEndpoint(t *testing.T) {
 		name            string
 		tracesConsumer  consumer.Traces
 		metricsConsumer consumer.Metrics
+		logsConsumer    consumer.Logs
 
 		expectContent string
 	}{
@@ -247,6 +264,23 @@ func TestDatadogInfoEndpoint(t *testing.T) {
 	"span_meta_structs": false,
 	"long_running_spans": false,
 	"config": null
+}`,
+		},
+		{
+			name:         "Logs consumer only",
+			logsConsumer: consumertest.NewNop(),
+			expectContent: `{
+	"version": "datadogreceiver-otelcol-latest",
+	"endpoints": [
+		"/",
+		"/api/v2/logs",
+		"/v1/input",
+		"/v1/input/"
+	],
+	"client_drop_p0s": false,
+	"span_meta_structs": false,
+	"long_running_spans": false,
+	"config": null
 }`,
 		},
 		{
@@ -290,6 +324,7 @@ func TestDatadogInfoEndpoint(t *testing.T) {
 
 			dd.(*datadogReceiver).nextTracesConsumer = tc.tracesConsumer
 			dd.(*datadogReceiver).nextMetricsConsumer = tc.metricsConsumer
+			dd.(*datadogReceiver).nextLogsConsumer = tc.logsConsumer
 
 			ctx, cancel := context.WithCancel(context.Background())
 			t.Cleanup(cancel)
@@ -776,3 +811,143 @@ func TestDatadogServices_EndToEnd(t *testing.T) {
 	hostName, _ := got.ResourceMetrics().At(0).Resource().Attributes().Get("host.name")
 	assert.Equal(t, "hosta", hostName.AsString())
 }
+
+func TestDatadogLogs_EndToEnd(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0" // Using a randomly assigned address
+	sink := new(consumertest.LogsSink)
+
+	dd, err := newDataDogReceiver(
+		cfg,
+		receivertest.NewNopSettings(metadata.Type),
+	)
+	require.NoError(t, err, "Must not error when creating receiver")
+	dd.(*datadogReceiver).nextLogsConsumer = sink
+
+	require.NoError(t, dd.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dd.Shutdown(context.Background()))
+	}()
+
+	logsPayload := []byte(`[
+		{
+			"message": "GET /index.html 200",
+			"status": "info",
+			"timestamp": 1700000000123,
+			"hostname": "hosta",
+			"service": "web",
+			"ddsource": "nginx",
+			"ddtags": "env:test,team:a"
+		}
+	]`)
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, err = gzipWriter.Write(logsPayload)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("http://%s/api/v2/logs", dd.(*datadogReceiver).address),
+		io.NopCloser(&compressed),
+	)
+	require.NoError(t, err, "Must not error when creating request")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Must not error performing request")
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, multierr.Combine(err, resp.Body.Close()), "Must not error when reading body")
+	require.JSONEq(t, `{}`, string(body), "Expected JSON response to be `{}`, got %s", string(body))
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	require.Len(t, sink.AllLogs(), 1)
+	got := sink.AllLogs()[0]
+	require.Equal(t, 1, got.ResourceLogs().Len())
+	assert.Equal(t, map[string]any{
+		"host.name":                   "hosta",
+		"service.name":                "web",
+		"deployment.environment.name": "test",
+		"datadog.log.source":          "nginx",
+	}, got.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+	logRecords := got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 1, logRecords.Len())
+	logRecord := logRecords.At(0)
+	assert.Equal(t, "GET /index.html 200", logRecord.Body().Str())
+	assert.Equal(t, plog.SeverityNumberInfo, logRecord.SeverityNumber())
+	assert.Equal(t, pcommon.Timestamp(1700000000123000000), logRecord.Timestamp())
+	assert.Equal(t, map[string]any{"team": "a"}, logRecord.Attributes().AsRaw())
+}
+
+func TestDatadogLogsV1Input_EndToEnd(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0" // Using a randomly assigned address
+	sink := new(consumertest.LogsSink)
+
+	dd, err := newDataDogReceiver(
+		cfg,
+		receivertest.NewNopSettings(metadata.Type),
+	)
+	require.NoError(t, err, "Must not error when creating receiver")
+	dd.(*datadogReceiver).nextLogsConsumer = sink
+
+	require.NoError(t, dd.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dd.Shutdown(context.Background()))
+	}()
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("http://%s/v1/input/apikey?ddsource=syslog&service=cron&hostname=hostb", dd.(*datadogReceiver).address),
+		strings.NewReader("first line\nsecond line\n"),
+	)
+	require.NoError(t, err, "Must not error when creating request")
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Must not error performing request")
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Len(t, sink.AllLogs(), 1)
+	got := sink.AllLogs()[0]
+	assert.Equal(t, map[string]any{
+		"host.name":          "hostb",
+		"service.name":       "cron",
+		"datadog.log.source": "syslog",
+	}, got.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+	logRecords := got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, logRecords.Len())
+	assert.Equal(t, "first line", logRecords.At(0).Body().Str())
+	assert.Equal(t, "second line", logRecords.At(1).Body().Str())
+}
+
+func TestDatadogLogs_InvalidPayload(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0" // Using a randomly assigned address
+	sink := new(consumertest.LogsSink)
+
+	dd, err := newDataDogReceiver(
+		cfg,
+		receivertest.NewNopSettings(metadata.Type),
+	)
+	require.NoError(t, err, "Must not error when creating receiver")
+	dd.(*datadogReceiver).nextLogsConsumer = sink
+
+	require.NoError(t, dd.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, dd.Shutdown(context.Background()))
+	}()
+
+	resp, err := http.Post(
+		fmt.Sprintf("http://%s/api/v2/logs", dd.(*datadogReceiver).address),
+		"application/json",
+		strings.NewReader(`[{"message": }]`),
+	)
+	require.NoError(t, err, "Must not error performing request")
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Empty(t, sink.AllLogs())
+}
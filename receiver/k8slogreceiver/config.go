@@ -4,11 +4,18 @@
 package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"time"
 
 	"go.uber.org/multierr"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
 
 const (
@@ -16,23 +23,89 @@ const (
 )
 
 const (
-	DefaultMode        = ModeDaemonSetStdout
-	DefaultHostRoot    = "/host_root"
-	DefaultNodeFromEnv = "KUBE_NODE_NAME"
+	DefaultMode         = ModeDaemonSetStdout
+	DefaultHostRoot     = "/host_root"
+	DefaultNodeFromEnv  = "KUBE_NODE_NAME"
+	DefaultInterval     = 5 * time.Second
+	DefaultStartAt      = "end"
+	DefaultPollInterval = 200 * time.Millisecond
+	DefaultMaxLogSize   = 1024 * 1024
 )
 
+const (
+	metadataNamespaceName = "k8s.namespace.name"
+	metadataPodName       = "k8s.pod.name"
+	metadataPodUID        = "k8s.pod.uid"
+	metadataContainerName = "k8s.container.name"
+	metadataContainerID   = "container.id"
+	metadataImageName     = "container.image.name"
+	metadataNodeName      = "k8s.node.name"
+)
+
+const (
+	filterOpEquals     = "equals"
+	filterOpNotEquals  = "not-equals"
+	filterOpExists     = "exists"
+	filterOpNotExists  = "not-exists"
+	filterOpMatches    = "matches"
+	filterOpNotMatches = "not-matches"
+)
+
+// supportedMetadata is the list of metadata fields that can be extracted.
+var supportedMetadata = []string{
+	metadataNamespaceName,
+	metadataPodName,
+	metadataPodUID,
+	metadataContainerName,
+	metadataContainerID,
+	metadataImageName,
+	metadataNodeName,
+}
+
+// defaultMetadata is the list of metadata fields extracted by default.
+var defaultMetadata = []string{
+	metadataNamespaceName,
+	metadataPodName,
+	metadataPodUID,
+	metadataContainerName,
+	metadataContainerID,
+}
+
 // Config is the configuration of a k8slog receiver
 type Config struct {
+	adapter.BaseConfig `mapstructure:",squash"`
+
 	Discovery SourceConfig  `mapstructure:"discovery"`
 	Extract   ExtractConfig `mapstructure:"extract"`
 
-	// TODO: refactor fileconsumer and add it's config of k8s implementation here.
+	// StartAt represents where to start reading the logs of the containers found when the receiver starts.
+	// Valid values are "beginning" and "end" (default).
+	// The logs of containers created after the receiver started are always read from the beginning.
+	StartAt string `mapstructure:"start_at"`
+
+	// PollInterval represents how often the log files are checked for new data.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// MaxLogSize represents the maximum size of a log entry.
+	MaxLogSize helper.ByteSize `mapstructure:"max_log_size"`
+
+	// For mocking purposes only.
+	makeClient func(apiConf k8sconfig.APIConfig) (kubernetes.Interface, error)
+}
+
+func (c Config) getClient() (kubernetes.Interface, error) {
+	if c.makeClient != nil {
+		return c.makeClient(c.Discovery.K8sAPI)
+	}
+	return k8sconfig.MakeClient(c.Discovery.K8sAPI)
 }
 
 // ExtractConfig allows specifying how to extract resource attributes from pod.
 type ExtractConfig struct {
 	// Metadata represents the list of metadata fields to extract from pod.
-	// TODO: supported metadata fields and default values.
+	// Supported values are k8s.namespace.name, k8s.pod.name, k8s.pod.uid, k8s.container.name,
+	// container.id, container.image.name and k8s.node.name.
+	// By default, all of them but container.image.name and k8s.node.name are extracted.
 	Metadata []string `mapstructure:"metadata"`
 
 	// Annotations represents the rules to extract from pod annotations.
@@ -111,7 +184,61 @@ type FieldExtractConfig struct {
 }
 
 func (c Config) Validate() error {
-	return c.Discovery.Validate()
+	var err error
+	if c.StartAt != "beginning" && c.StartAt != "end" {
+		err = multierr.Append(err, fmt.Errorf("invalid start_at %q, must be one of \"beginning\" or \"end\"", c.StartAt))
+	}
+	if c.PollInterval <= 0 {
+		err = multierr.Append(err, errors.New("poll_interval must be greater than 0"))
+	}
+	if c.MaxLogSize <= 0 {
+		err = multierr.Append(err, errors.New("max_log_size must be greater than 0"))
+	}
+	err = multierr.Append(err, c.Discovery.Validate())
+	return multierr.Append(err, c.Extract.Validate())
+}
+
+func (c ExtractConfig) Validate() error {
+	var err error
+	for _, m := range c.Metadata {
+		if !slices.Contains(supportedMetadata, m) {
+			err = multierr.Append(err, fmt.Errorf("unsupported metadata %q", m))
+		}
+	}
+	for _, f := range c.Annotations {
+		err = multierr.Append(err, f.validate("annotations"))
+	}
+	for _, f := range c.Labels {
+		err = multierr.Append(err, f.validate("labels"))
+	}
+	for _, f := range c.Env {
+		err = multierr.Append(err, f.validate("env"))
+	}
+	return err
+}
+
+func (c FieldExtractConfig) validate(fieldType string) error {
+	if (c.Key == "") == (c.KeyRegex == "") {
+		return fmt.Errorf("exactly one of key or key_regex must be specified for %s extraction", fieldType)
+	}
+	if c.KeyRegex != "" {
+		if _, err := regexp.Compile(c.KeyRegex); err != nil {
+			return fmt.Errorf("invalid key_regex %q for %s extraction: %w", c.KeyRegex, fieldType, err)
+		}
+	}
+	if c.Regex != "" {
+		r, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q for %s extraction: %w", c.Regex, fieldType, err)
+		}
+		if r.SubexpIndex("value") < 0 {
+			return fmt.Errorf("regex %q for %s extraction must contain a named group \"value\"", c.Regex, fieldType)
+		}
+	}
+	if c.From != "" && c.From != "pod" {
+		return fmt.Errorf("invalid from %q for %s extraction, only \"pod\" is supported", c.From, fieldType)
+	}
+	return nil
 }
 
 // SourceConfig allows specifying how to discover containers to collect logs from.
@@ -135,8 +262,16 @@ type SourceConfig struct {
 	K8sAPI k8sconfig.APIConfig `mapstructure:"k8s_api"`
 
 	// RuntimeAPIs represents the configuration for the runtime APIs.
+	// They are tried in order, and the first one available is used to discover the containers.
+	// If none of them is available, the containers are discovered from the log files
+	// under <HOST_ROOT>/var/log/pods.
 	RuntimeAPIs []RuntimeAPIConfig `mapstructure:"runtime_apis"`
 
+	// Interval represents how often the containers are discovered.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Filter represents the rules to select the containers to collect logs from.
+	// A container is selected if it matches any of the filters.
 	Filter []FilterConfig `mapstructure:"filter"`
 }
 
@@ -148,15 +283,22 @@ func (c SourceConfig) Validate() error {
 	if c.HostRoot == "" {
 		err = multierr.Append(err, fmt.Errorf("host_root must be specified when mode is %q", c.Mode))
 	}
+	if c.Interval <= 0 {
+		err = multierr.Append(err, errors.New("interval must be greater than 0"))
+	}
 	err = multierr.Append(err, c.K8sAPI.Validate())
 	for _, r := range c.RuntimeAPIs {
 		err = multierr.Append(err, r.Validate())
 	}
+	for _, f := range c.Filter {
+		err = multierr.Append(err, f.Validate())
+	}
 	return err
 }
 
 // FilterConfig allows specifying how to filter containers to collect logs from.
 // By default, all containers are collected from.
+// A container matches a filter only if it matches all of its rules.
 type FilterConfig struct {
 	// Annotations represents the rules to filter containers based on pod annotations.
 	Annotations []MapFilterConfig `mapstructure:"annotations"`
@@ -177,9 +319,36 @@ type FilterConfig struct {
 	Pods []ValueFilterConfig `mapstructure:"pods"`
 }
 
+func (c FilterConfig) Validate() error {
+	var err error
+	for _, f := range slices.Concat(c.Annotations, c.Labels, c.Env) {
+		err = multierr.Append(err, validateFilterOp(f.Op, f.Value, true))
+	}
+	for _, f := range slices.Concat(c.Namespaces, c.Containers, c.Pods) {
+		err = multierr.Append(err, validateFilterOp(f.Op, f.Value, false))
+	}
+	return err
+}
+
+func validateFilterOp(op, value string, allowExists bool) error {
+	switch op {
+	case "", filterOpEquals, filterOpNotEquals:
+		return nil
+	case filterOpExists, filterOpNotExists:
+		if allowExists {
+			return nil
+		}
+	case filterOpMatches, filterOpNotMatches:
+		if _, err := regexp.Compile(value); err != nil {
+			return fmt.Errorf("invalid regular expression %q for filter op %q: %w", value, op, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid filter op %q", op)
+}
+
 // ValueFilterConfig allows specifying a filter rule to filter containers based on string values,
-// such as pod names, namespaces, container names or pod UIDs.
-// If any of the values match, this rule is considered to match.
+// such as pod names, namespaces or container names.
 type ValueFilterConfig struct {
 	// Op represents how to compare the value.
 	// Valid values are:
@@ -195,7 +364,6 @@ type ValueFilterConfig struct {
 
 // MapFilterConfig allows specifying a filter rule to filter containers based on key value pairs,
 // such as pod annotations, labels or environment variables.
-// Only if all the keys match, this rule is considered to match.
 type MapFilterConfig struct {
 	// Op represents how to compare the values.
 	// Valid values are:
@@ -212,6 +380,5 @@ type MapFilterConfig struct {
 
 	// Value represents the value to compare against.
 	// If Op is "exists" or "not-exists", this field is ignored.
-	// If any of the values match, this rule is considered to match.
 	Value string `mapstructure:"value"`
 }
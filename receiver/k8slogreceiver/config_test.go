@@ -6,6 +6,7 @@ package k8slogreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,7 +14,10 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver/internal/metadata"
 )
 
@@ -35,11 +39,16 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "ds-stdout"),
 			expected: &Config{
+				BaseConfig: adapter.BaseConfig{
+					Operators:      []operator.Config{},
+					RetryOnFailure: consumerretry.NewDefaultConfig(),
+				},
 				Discovery: SourceConfig{
 					K8sAPI:      k8sconfig.APIConfig{AuthType: "serviceAccount"},
 					Mode:        ModeDaemonSetStdout,
 					NodeFromEnv: DefaultNodeFromEnv,
 					HostRoot:    "/host_root",
+					Interval:    10 * time.Second,
 					RuntimeAPIs: []RuntimeAPIConfig{
 						{
 							&dockerConfig{
@@ -83,7 +92,16 @@ func TestLoadConfig(t *testing.T) {
 						"k8s.pod.uid",
 						"k8s.container.name",
 					},
+					Labels: []FieldExtractConfig{
+						{
+							TagName:  "$$1",
+							KeyRegex: "app.kubernetes.io/(.*)",
+						},
+					},
 				},
+				StartAt:      "beginning",
+				PollInterval: DefaultPollInterval,
+				MaxLogSize:   DefaultMaxLogSize,
 			},
 		},
 	}
@@ -101,3 +119,94 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name: "invalid start_at",
+			modify: func(cfg *Config) {
+				cfg.StartAt = "middle"
+			},
+			expectedErr: `invalid start_at "middle"`,
+		},
+		{
+			name: "invalid poll_interval",
+			modify: func(cfg *Config) {
+				cfg.PollInterval = 0
+			},
+			expectedErr: "poll_interval must be greater than 0",
+		},
+		{
+			name: "invalid discovery interval",
+			modify: func(cfg *Config) {
+				cfg.Discovery.Interval = 0
+			},
+			expectedErr: "interval must be greater than 0",
+		},
+		{
+			name: "unsupported metadata",
+			modify: func(cfg *Config) {
+				cfg.Extract.Metadata = []string{"k8s.deployment.name"}
+			},
+			expectedErr: `unsupported metadata "k8s.deployment.name"`,
+		},
+		{
+			name: "both key and key_regex",
+			modify: func(cfg *Config) {
+				cfg.Extract.Labels = []FieldExtractConfig{{Key: "app", KeyRegex: "app.*"}}
+			},
+			expectedErr: "exactly one of key or key_regex must be specified for labels extraction",
+		},
+		{
+			name: "regex without value group",
+			modify: func(cfg *Config) {
+				cfg.Extract.Annotations = []FieldExtractConfig{{Key: "change-cause", Regex: "GIT_SHA=(\\w+)"}}
+			},
+			expectedErr: "must contain a named group \"value\"",
+		},
+		{
+			name: "unsupported from",
+			modify: func(cfg *Config) {
+				cfg.Extract.Env = []FieldExtractConfig{{Key: "VERSION", From: "namespace"}}
+			},
+			expectedErr: `invalid from "namespace" for env extraction`,
+		},
+		{
+			name: "exists on value filter",
+			modify: func(cfg *Config) {
+				cfg.Discovery.Filter = []FilterConfig{{Namespaces: []ValueFilterConfig{{Op: "exists"}}}}
+			},
+			expectedErr: `invalid filter op "exists"`,
+		},
+		{
+			name: "invalid filter regex",
+			modify: func(cfg *Config) {
+				cfg.Discovery.Filter = []FilterConfig{{Labels: []MapFilterConfig{{Op: "matches", Key: "app", Value: "("}}}}
+			},
+			expectedErr: `invalid regular expression "("`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig()
+			tt.modify(cfg)
+			err := xconfmap.Validate(cfg)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
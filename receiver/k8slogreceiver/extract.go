@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// extractor computes the resource attributes of the logs of a container, following an ExtractConfig.
+type extractor struct {
+	metadata    []string
+	annotations []fieldExtractor
+	labels      []fieldExtractor
+	env         []fieldExtractor
+}
+
+func newExtractor(cfg ExtractConfig) (*extractor, error) {
+	e := &extractor{metadata: cfg.Metadata}
+	var err error
+	if e.annotations, err = newFieldExtractors("k8s.pod.annotations.%s", cfg.Annotations); err != nil {
+		return nil, err
+	}
+	if e.labels, err = newFieldExtractors("k8s.pod.labels.%s", cfg.Labels); err != nil {
+		return nil, err
+	}
+	if e.env, err = newFieldExtractors("k8s.pod.env.%s", cfg.Env); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// extract returns the resource attributes of the given container.
+func (e *extractor) extract(c *containerMeta) map[string]any {
+	attrs := map[string]any{}
+	for _, m := range e.metadata {
+		var value string
+		switch m {
+		case metadataNamespaceName:
+			value = c.podNamespace
+		case metadataPodName:
+			value = c.podName
+		case metadataPodUID:
+			value = c.podUID
+		case metadataContainerName:
+			value = c.containerName
+		case metadataContainerID:
+			value = c.containerID
+		case metadataImageName:
+			value = c.image
+		case metadataNodeName:
+			value = c.nodeName
+		}
+		if value != "" {
+			attrs[m] = value
+		}
+	}
+	for _, f := range e.annotations {
+		f.extract(c.annotations, attrs)
+	}
+	for _, f := range e.labels {
+		f.extract(c.labels, attrs)
+	}
+	for _, f := range e.env {
+		f.extract(c.env, attrs)
+	}
+	return attrs
+}
+
+// fieldExtractor is the compiled form of a FieldExtractConfig.
+type fieldExtractor struct {
+	tagName string
+	key     string
+	// keyRegex matches the whole key, when set instead of key.
+	keyRegex *regexp.Regexp
+	// regex extracts the "value" group from the values, if set.
+	regex *regexp.Regexp
+	// format is used to build the attribute name from the key when tagName is empty.
+	format string
+}
+
+func newFieldExtractors(format string, cfgs []FieldExtractConfig) ([]fieldExtractor, error) {
+	extractors := make([]fieldExtractor, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		f := fieldExtractor{tagName: cfg.TagName, key: cfg.Key, format: format}
+		var err error
+		if cfg.KeyRegex != "" {
+			if f.keyRegex, err = regexp.Compile("^(?:" + cfg.KeyRegex + ")$"); err != nil {
+				return nil, fmt.Errorf("invalid key_regex %q: %w", cfg.KeyRegex, err)
+			}
+		}
+		if cfg.Regex != "" {
+			if f.regex, err = regexp.Compile(cfg.Regex); err != nil {
+				return nil, fmt.Errorf("invalid regex %q: %w", cfg.Regex, err)
+			}
+		}
+		extractors = append(extractors, f)
+	}
+	return extractors, nil
+}
+
+func (f fieldExtractor) extract(values map[string]string, attrs map[string]any) {
+	if f.keyRegex == nil {
+		if v, ok := values[f.key]; ok {
+			f.set(attrs, f.name(f.key, nil), v)
+		}
+		return
+	}
+	for k, v := range values {
+		if match := f.keyRegex.FindStringSubmatchIndex(k); match != nil {
+			f.set(attrs, f.name(k, match), v)
+		}
+	}
+}
+
+// name returns the attribute name for the given key, expanding the references to the groups
+// of the key regex in the tag name.
+func (f fieldExtractor) name(key string, match []int) string {
+	switch {
+	case f.tagName == "":
+		return fmt.Sprintf(f.format, key)
+	case match != nil:
+		return string(f.keyRegex.ExpandString(nil, f.tagName, key, match))
+	default:
+		return f.tagName
+	}
+}
+
+func (f fieldExtractor) set(attrs map[string]any, name, value string) {
+	if f.regex != nil {
+		match := f.regex.FindStringSubmatch(value)
+		if match == nil {
+			return
+		}
+		value = match[f.regex.SubexpIndex("value")]
+	}
+	attrs[name] = value
+}
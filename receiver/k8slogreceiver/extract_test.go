@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractor(t *testing.T) {
+	meta := &containerMeta{
+		runtimeContainer: runtimeContainer{
+			containerID:   "3f4a1b",
+			podNamespace:  "default",
+			podName:       "mysql-0",
+			podUID:        "9a6c2c7e-0d0b-4b0e-8d3b-1f9a6f0c7a3e",
+			containerName: "mysql",
+		},
+		nodeName: "node-1",
+		image:    "mysql:5.7.21",
+		labels: map[string]string{
+			"app.kubernetes.io/component": "database",
+			"app.kubernetes.io/version":   "5.7.21",
+			"tier":                        "backend",
+		},
+		annotations: map[string]string{
+			"kubernetes.io/change-cause": "2019-08-28T18:34:33Z APP_NAME=my-app GIT_SHA=58a1e39 CI_BUILD=4120",
+		},
+		env: map[string]string{"MYSQL_DATABASE": "orders"},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      ExtractConfig
+		expected map[string]any
+	}{
+		{
+			name: "metadata",
+			cfg:  ExtractConfig{Metadata: supportedMetadata},
+			expected: map[string]any{
+				"k8s.namespace.name":   "default",
+				"k8s.pod.name":         "mysql-0",
+				"k8s.pod.uid":          "9a6c2c7e-0d0b-4b0e-8d3b-1f9a6f0c7a3e",
+				"k8s.container.name":   "mysql",
+				"container.id":         "3f4a1b",
+				"container.image.name": "mysql:5.7.21",
+				"k8s.node.name":        "node-1",
+			},
+		},
+		{
+			name: "default tag names",
+			cfg: ExtractConfig{
+				Labels: []FieldExtractConfig{{Key: "tier"}},
+				Env:    []FieldExtractConfig{{Key: "MYSQL_DATABASE"}, {Key: "MISSING"}},
+			},
+			expected: map[string]any{
+				"k8s.pod.labels.tier":        "backend",
+				"k8s.pod.env.MYSQL_DATABASE": "orders",
+			},
+		},
+		{
+			name: "key regex with back reference",
+			cfg: ExtractConfig{
+				Labels: []FieldExtractConfig{{TagName: "$1", KeyRegex: "app.kubernetes.io/(.*)"}},
+			},
+			expected: map[string]any{
+				"component": "database",
+				"version":   "5.7.21",
+			},
+		},
+		{
+			name: "value regex",
+			cfg: ExtractConfig{
+				Annotations: []FieldExtractConfig{
+					{TagName: "git.sha", Key: "kubernetes.io/change-cause", Regex: `GIT_SHA=(?P<value>\w+)`},
+					{TagName: "ci.build", Key: "kubernetes.io/change-cause", Regex: `JENKINS=(?P<value>\w+)`},
+				},
+			},
+			expected: map[string]any{
+				"git.sha": "58a1e39",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := newExtractor(tt.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, e.extract(meta))
+		})
+	}
+}
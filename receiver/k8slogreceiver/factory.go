@@ -2,35 +2,33 @@
 // SPDX-License-Identifier: Apache-2.0
 
 package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
-import (
-	"context"
-	"errors"
 
-	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/consumer"
+import (
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver/internal/metadata"
 )
 
+// NewFactory creates a factory for k8slog receiver
 func NewFactory() receiver.Factory {
-	return receiver.NewFactory(
-		metadata.Type,
-		createDefaultConfig,
-		receiver.WithLogs(
-			createLogsReceiver,
-			metadata.LogsStability,
-		),
-	)
+	return adapter.NewFactory(ReceiverType{}, metadata.LogsStability)
 }
 
-func createDefaultConfig() component.Config {
+func createDefaultConfig() *Config {
 	return &Config{
+		BaseConfig: adapter.BaseConfig{
+			Operators:      []operator.Config{},
+			RetryOnFailure: consumerretry.NewDefaultConfig(),
+		},
 		Discovery: SourceConfig{
 			Mode:        DefaultMode,
 			HostRoot:    DefaultHostRoot,
 			NodeFromEnv: DefaultNodeFromEnv,
+			Interval:    DefaultInterval,
 			K8sAPI:      k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
 			RuntimeAPIs: []RuntimeAPIConfig{
 				{
@@ -42,19 +40,11 @@ func createDefaultConfig() component.Config {
 				},
 			},
 		},
+		Extract: ExtractConfig{
+			Metadata: defaultMetadata,
+		},
+		StartAt:      DefaultStartAt,
+		PollInterval: DefaultPollInterval,
+		MaxLogSize:   DefaultMaxLogSize,
 	}
 }
-
-func createLogsReceiver(
-	_ context.Context,
-	settings receiver.Settings,
-	cfg component.Config,
-	consumer consumer.Logs,
-) (receiver.Logs, error) {
-	settings.Logger.Error("k8slogreceiver is not yet implemented")
-	rCfg, ok := cfg.(*Config)
-	if !ok {
-		return nil, errors.New("failed to cast config to k8slogreceiver.Config")
-	}
-	return newReceiver(settings, rCfg, consumer)
-}
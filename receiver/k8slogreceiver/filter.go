@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"regexp"
+)
+
+// containerFilter is the compiled form of a FilterConfig.
+type containerFilter struct {
+	annotations []mapMatcher
+	labels      []mapMatcher
+	env         []mapMatcher
+	namespaces  []valueMatcher
+	containers  []valueMatcher
+	pods        []valueMatcher
+}
+
+func newContainerFilter(cfg FilterConfig) (containerFilter, error) {
+	var f containerFilter
+	var err error
+	if f.annotations, err = newMapMatchers(cfg.Annotations); err != nil {
+		return f, err
+	}
+	if f.labels, err = newMapMatchers(cfg.Labels); err != nil {
+		return f, err
+	}
+	if f.env, err = newMapMatchers(cfg.Env); err != nil {
+		return f, err
+	}
+	if f.namespaces, err = newValueMatchers(cfg.Namespaces); err != nil {
+		return f, err
+	}
+	if f.containers, err = newValueMatchers(cfg.Containers); err != nil {
+		return f, err
+	}
+	f.pods, err = newValueMatchers(cfg.Pods)
+	return f, err
+}
+
+// match returns whether the container matches all the rules of the filter.
+func (f containerFilter) match(c *containerMeta) bool {
+	return matchAllMaps(f.annotations, c.annotations) &&
+		matchAllMaps(f.labels, c.labels) &&
+		matchAllMaps(f.env, c.env) &&
+		matchAllValues(f.namespaces, c.podNamespace) &&
+		matchAllValues(f.containers, c.containerName) &&
+		matchAllValues(f.pods, c.podName)
+}
+
+func matchAllMaps(matchers []mapMatcher, values map[string]string) bool {
+	for _, m := range matchers {
+		if !m.match(values) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAllValues(matchers []valueMatcher, value string) bool {
+	for _, m := range matchers {
+		if !m.match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+type valueMatcher struct {
+	op    string
+	value string
+	regex *regexp.Regexp
+}
+
+func newValueMatcher(op, value string) (valueMatcher, error) {
+	m := valueMatcher{op: op, value: value}
+	if m.op == "" {
+		m.op = filterOpEquals
+	}
+	if m.op == filterOpMatches || m.op == filterOpNotMatches {
+		var err error
+		if m.regex, err = regexp.Compile(value); err != nil {
+			return m, err
+		}
+	}
+	return m, nil
+}
+
+func newValueMatchers(cfgs []ValueFilterConfig) ([]valueMatcher, error) {
+	matchers := make([]valueMatcher, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		m, err := newValueMatcher(cfg.Op, cfg.Value)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+func (m valueMatcher) match(value string) bool {
+	switch m.op {
+	case filterOpNotEquals:
+		return value != m.value
+	case filterOpMatches:
+		return m.regex.MatchString(value)
+	case filterOpNotMatches:
+		return !m.regex.MatchString(value)
+	default:
+		return value == m.value
+	}
+}
+
+type mapMatcher struct {
+	key string
+	valueMatcher
+}
+
+func newMapMatchers(cfgs []MapFilterConfig) ([]mapMatcher, error) {
+	matchers := make([]mapMatcher, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		m, err := newValueMatcher(cfg.Op, cfg.Value)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, mapMatcher{key: cfg.Key, valueMatcher: m})
+	}
+	return matchers, nil
+}
+
+// match returns whether the value of the key matches. A missing key only matches
+// the "not-exists", "not-equals" and "not-matches" operations.
+func (m mapMatcher) match(values map[string]string) bool {
+	value, ok := values[m.key]
+	switch m.op {
+	case filterOpExists:
+		return ok
+	case filterOpNotExists:
+		return !ok
+	}
+	if !ok {
+		return m.op == filterOpNotEquals || m.op == filterOpNotMatches
+	}
+	return m.valueMatcher.match(value)
+}
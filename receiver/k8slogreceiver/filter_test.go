@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerFilter(t *testing.T) {
+	meta := &containerMeta{
+		runtimeContainer: runtimeContainer{
+			podNamespace:  "default",
+			podName:       "nginx-6799fc88d8-x9v7l",
+			containerName: "nginx",
+		},
+		labels:      map[string]string{"app": "nginx"},
+		annotations: map[string]string{"io.opentelemetry.collectlog": "true"},
+		env:         map[string]string{"LOG_LEVEL": "debug"},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      FilterConfig
+		expected bool
+	}{
+		{
+			name:     "empty",
+			cfg:      FilterConfig{},
+			expected: true,
+		},
+		{
+			name: "equals by default",
+			cfg: FilterConfig{
+				Namespaces: []ValueFilterConfig{{Value: "default"}},
+			},
+			expected: true,
+		},
+		{
+			name: "not-equals",
+			cfg: FilterConfig{
+				Namespaces: []ValueFilterConfig{{Op: "not-equals", Value: "default"}},
+			},
+			expected: false,
+		},
+		{
+			name: "matches",
+			cfg: FilterConfig{
+				Pods: []ValueFilterConfig{{Op: "matches", Value: "^nginx-"}},
+			},
+			expected: true,
+		},
+		{
+			name: "not-matches",
+			cfg: FilterConfig{
+				Containers: []ValueFilterConfig{{Op: "not-matches", Value: "^ngi"}},
+			},
+			expected: false,
+		},
+		{
+			name: "exists",
+			cfg: FilterConfig{
+				Annotations: []MapFilterConfig{{Op: "exists", Key: "io.opentelemetry.collectlog"}},
+			},
+			expected: true,
+		},
+		{
+			name: "not-exists",
+			cfg: FilterConfig{
+				Labels: []MapFilterConfig{{Op: "not-exists", Key: "app"}},
+			},
+			expected: false,
+		},
+		{
+			name: "missing key with equals",
+			cfg: FilterConfig{
+				Labels: []MapFilterConfig{{Key: "tier", Value: "web"}},
+			},
+			expected: false,
+		},
+		{
+			name: "missing key with not-equals",
+			cfg: FilterConfig{
+				Labels: []MapFilterConfig{{Op: "not-equals", Key: "tier", Value: "web"}},
+			},
+			expected: true,
+		},
+		{
+			name: "all rules must match",
+			cfg: FilterConfig{
+				Env:        []MapFilterConfig{{Key: "LOG_LEVEL", Value: "debug"}},
+				Namespaces: []ValueFilterConfig{{Value: "kube-system"}},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newContainerFilter(tt.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, f.match(meta))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	inputOperatorType = "k8slog_input"
+	startAtBeginning  = "beginning"
+	// knownFilesKey is the key of the offsets saved by the file consumer of a container, in the scope of the container.
+	knownFilesKey = "knownFiles"
+)
+
+// inputConfig is the configuration of the input operator discovering the containers and reading their logs.
+type inputConfig struct {
+	helper.InputConfig
+	cfg *Config
+}
+
+func newInputConfig(cfg *Config) *inputConfig {
+	return &inputConfig{
+		InputConfig: helper.NewInputConfig(inputOperatorType, inputOperatorType),
+		cfg:         cfg,
+	}
+}
+
+// Build will build the input operator from the supplied configuration
+func (c inputConfig) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+	return &input{
+		InputOperator: inputOperator,
+		cfg:           c.cfg,
+		set:           set,
+		tailers:       map[string]*fileconsumer.Manager{},
+	}, nil
+}
+
+// input is an operator discovering the containers of the node and reading their log files, with one
+// file consumer per container. The entries are enriched with the metadata of their container.
+type input struct {
+	helper.InputOperator
+	cfg *Config
+	set component.TelemetrySettings
+
+	persister operator.Persister
+	source    *source
+	informers informers.SharedInformerFactory
+	runtime   runtimeClient
+	// tailers holds the file consumers of the discovered containers, by container key
+	tailers map[string]*fileconsumer.Manager
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Start will start discovering the containers and reading their logs
+func (i *input) Start(persister operator.Persister) error {
+	nodeName := os.Getenv(i.cfg.Discovery.NodeFromEnv)
+	if nodeName == "" {
+		return fmt.Errorf("node name not found in environment variable %q", i.cfg.Discovery.NodeFromEnv)
+	}
+
+	client, err := i.cfg.getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	i.informers = informers.NewSharedInformerFactoryWithOptions(client, 0, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+		opts.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", nodeName).String()
+	}))
+	podInformer := i.informers.Core().V1().Pods()
+
+	i.runtime = i.newRuntimeClient()
+	i.source, err = newSource(i.Logger(), i.cfg, nodeName, i.runtime, podInformer.Lister())
+	if err != nil {
+		return err
+	}
+	i.persister = persister
+
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+	i.informers.Start(ctx.Done())
+
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		if !cache.WaitForCacheSync(ctx.Done(), podInformer.Informer().HasSynced) {
+			return
+		}
+		i.run(ctx)
+	}()
+	return nil
+}
+
+// newRuntimeClient returns the client of the first available runtime API, or a client
+// discovering the containers from their log files if none is available.
+func (i *input) newRuntimeClient() runtimeClient {
+	for _, api := range i.cfg.Discovery.RuntimeAPIs {
+		client, err := api.NewClient(i.Logger(), i.cfg.Discovery.HostRoot)
+		if err != nil {
+			i.Logger().Warn("runtime API not available", zap.String("type", api.Type()), zap.Error(err))
+			continue
+		}
+		i.Logger().Info("discovering containers from runtime API", zap.String("type", api.Type()))
+		return client
+	}
+	i.Logger().Info("no runtime API available, discovering containers from their log files")
+	return newFileClient(i.cfg.Discovery.HostRoot)
+}
+
+func (i *input) run(ctx context.Context) {
+	ticker := time.NewTicker(i.cfg.Discovery.Interval)
+	defer ticker.Stop()
+
+	// the logs of the containers created after the first discovery are read from the beginning
+	startAt := i.cfg.StartAt
+	for {
+		if i.sync(ctx, startAt) {
+			startAt = startAtBeginning
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync starts reading the logs of the new containers, and stops reading the logs of the containers
+// that are gone. It returns false if the containers couldn't be discovered.
+func (i *input) sync(ctx context.Context, startAt string) bool {
+	containers, err := i.source.discover(ctx)
+	if err != nil {
+		i.Logger().Error("failed to discover containers", zap.Error(err))
+		return false
+	}
+
+	discovered := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		discovered[c.key] = true
+		if _, ok := i.tailers[c.key]; ok {
+			continue
+		}
+		tailer, err := i.startTailer(c, startAt)
+		if err != nil {
+			i.Logger().Error("failed to read container logs", zap.String("container", c.key), zap.Error(err))
+			continue
+		}
+		i.tailers[c.key] = tailer
+	}
+
+	for key, tailer := range i.tailers {
+		if discovered[key] {
+			continue
+		}
+		if err := tailer.Stop(); err != nil {
+			i.Logger().Error("failed to stop reading container logs", zap.String("container", key), zap.Error(err))
+		}
+		// the container is gone, so the offsets of its log files are not needed anymore
+		if err := operator.NewScopedPersister(key, i.persister).Delete(ctx, knownFilesKey); err != nil {
+			i.Logger().Error("failed to delete the offsets of container logs", zap.String("container", key), zap.Error(err))
+		}
+		delete(i.tailers, key)
+	}
+	return true
+}
+
+func (i *input) startTailer(c discoveredContainer, startAt string) (*fileconsumer.Manager, error) {
+	cfg := fileconsumer.NewConfig()
+	cfg.Include = []string{c.include}
+	cfg.StartAt = startAt
+	cfg.PollInterval = i.cfg.PollInterval
+	cfg.MaxLogSize = i.cfg.MaxLogSize
+	cfg.IncludeFilePath = true
+
+	set := i.set
+	set.Logger = i.Logger().With(zap.String("container", c.key))
+	tailer, err := cfg.Build(set, i.emitFunc(c.resource))
+	if err != nil {
+		return nil, err
+	}
+	if err = tailer.Start(operator.NewScopedPersister(c.key, i.persister)); err != nil {
+		return nil, err
+	}
+	return tailer, nil
+}
+
+func (i *input) emitFunc(resource map[string]any) emit.Callback {
+	return func(ctx context.Context, tokens [][]byte, attributes map[string]any, _ int64) error {
+		entries := make([]*entry.Entry, 0, len(tokens))
+		for _, token := range tokens {
+			if len(token) == 0 {
+				continue
+			}
+			ent, err := i.NewEntry(string(token))
+			if err != nil {
+				i.Logger().Error("failed to create entry", zap.Error(err))
+				continue
+			}
+			if ent.Attributes == nil {
+				ent.Attributes = make(map[string]any, len(attributes))
+			}
+			for k, v := range attributes {
+				ent.Attributes[k] = v
+			}
+			if ent.Resource == nil {
+				ent.Resource = make(map[string]any, len(resource))
+			}
+			for k, v := range resource {
+				ent.Resource[k] = v
+			}
+			entries = append(entries, ent)
+		}
+		return i.WriteBatch(ctx, entries)
+	}
+}
+
+// Stop will stop discovering the containers and reading their logs
+func (i *input) Stop() error {
+	if i.cancel != nil {
+		i.cancel()
+	}
+	i.wg.Wait()
+
+	var err error
+	for key, tailer := range i.tailers {
+		err = multierr.Append(err, tailer.Stop())
+		delete(i.tailers, key)
+	}
+	if i.informers != nil {
+		i.informers.Shutdown()
+	}
+	if i.runtime != nil {
+		err = multierr.Append(err, i.runtime.close())
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestInputSyncDeletesOffsets(t *testing.T) {
+	hostRoot := t.TempDir()
+	logPath := filepath.Join(podLogsDir, "default_nginx_uid-1", "nginx", "0.log")
+	writeLog(t, filepath.Join(hostRoot, logPath), "2024-04-13T07:59:37.505201169Z stdout F hello\n")
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx", UID: "uid-1"}}
+	runtime := &fakeRuntimeClient{
+		containers: []runtimeContainer{
+			{
+				key:           "uid-1/nginx",
+				podNamespace:  "default",
+				podName:       "nginx",
+				podUID:        "uid-1",
+				containerName: "nginx",
+				logPath:       logPath,
+			},
+		},
+	}
+
+	cfg := createDefaultConfig()
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.Discovery.HostRoot = hostRoot
+	op, err := newInputConfig(cfg).Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	in := op.(*input)
+	in.source, err = newSource(zap.NewNop(), cfg, "node-1", runtime, newPodLister(t, pod))
+	require.NoError(t, err)
+	persister := testutil.NewUnscopedMockPersister()
+	in.persister = persister
+
+	ctx := context.Background()
+	require.True(t, in.sync(ctx, startAtBeginning))
+	require.Len(t, in.tailers, 1)
+	require.Eventually(t, func() bool {
+		offsets, err := persister.Get(ctx, "uid-1/nginx."+knownFilesKey)
+		return err == nil && offsets != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the offsets of a container that is gone are deleted
+	runtime.containers = nil
+	require.True(t, in.sync(ctx, startAtBeginning))
+	require.Empty(t, in.tailers)
+	offsets, err := persister.Get(ctx, "uid-1/nginx."+knownFilesKey)
+	require.NoError(t, err)
+	require.Nil(t, offsets)
+}
@@ -4,27 +4,44 @@
 package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
 
 import (
-	"context"
-
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/consumer"
-	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver/internal/metadata"
 )
 
-type k8slogReceiver struct{}
+const containerParserID = "k8slog_container_parser"
+
+// ReceiverType implements adapter.LogReceiverType
+// to create a Kubernetes container logs receiver
+type ReceiverType struct{}
 
-func (k *k8slogReceiver) Start(_ context.Context, _ component.Host) error {
-	return nil
+// Type is the receiver type
+func (f ReceiverType) Type() component.Type {
+	return metadata.Type
 }
 
-func (k *k8slogReceiver) Shutdown(_ context.Context) error {
-	return nil
+// CreateDefaultConfig creates a config with type and version
+func (f ReceiverType) CreateDefaultConfig() component.Config {
+	return createDefaultConfig()
+}
+
+// BaseConfig gets the base config from config. The logs are parsed from the CRI or
+// docker format before going through the configured operators.
+func (f ReceiverType) BaseConfig(cfg component.Config) adapter.BaseConfig {
+	rCfg := cfg.(*Config)
+	parserCfg := container.NewConfigWithID(containerParserID)
+	parserCfg.AddMetadataFromFilePath = false
+	parserCfg.MaxLogSize = rCfg.MaxLogSize
+
+	baseCfg := rCfg.BaseConfig
+	baseCfg.Operators = append([]operator.Config{operator.NewConfig(parserCfg)}, rCfg.Operators...)
+	return baseCfg
 }
 
-func newReceiver(
-	_ receiver.Settings,
-	_ *Config,
-	_ consumer.Logs,
-) (receiver.Logs, error) {
-	return &k8slogReceiver{}, nil
+// InputConfig unmarshals the input operator
+func (f ReceiverType) InputConfig(cfg component.Config) operator.Config {
+	return operator.NewConfig(newInputConfig(cfg.(*Config)))
 }
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver/internal/metadata"
+)
+
+func TestReceiver(t *testing.T) {
+	t.Setenv(DefaultNodeFromEnv, "node-1")
+
+	hostRoot := t.TempDir()
+	podDir := filepath.Join(hostRoot, podLogsDir, "default_nginx_uid-1")
+	writeLog(t, filepath.Join(podDir, "nginx", "0.log"),
+		"2024-04-13T07:59:37.505201169Z stdout P hello\n"+
+			"2024-04-13T07:59:37.505201169Z stdout F  world\n")
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "nginx",
+			UID:       "uid-1",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: "nginx:1.27"},
+				{Name: "sidecar", Image: "busybox:1.36"},
+			},
+		},
+	}
+
+	cfg := createDefaultConfig()
+	cfg.StartAt = "beginning"
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.Discovery.HostRoot = hostRoot
+	cfg.Discovery.Interval = 10 * time.Millisecond
+	cfg.Discovery.RuntimeAPIs = nil
+	cfg.Extract.Metadata = append(cfg.Extract.Metadata, metadataImageName)
+	cfg.makeClient = func(k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(pod), nil
+	}
+
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, rcvr.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assertLogRecord(t, sink.AllLogs()[0], "nginx", "nginx:1.27", "hello world")
+
+	// containers discovered later are read from the beginning
+	writeLog(t, filepath.Join(podDir, "sidecar", "0.log"),
+		"2024-04-13T07:59:38.505201169Z stderr F started\n")
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+	assertLogRecord(t, sink.AllLogs()[1], "sidecar", "busybox:1.36", "started")
+}
+
+func TestReceiverMissingNodeName(t *testing.T) {
+	t.Setenv(DefaultNodeFromEnv, "")
+
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(metadata.Type), createDefaultConfig(), sink)
+	require.NoError(t, err)
+	require.ErrorContains(t, rcvr.Start(context.Background(), componenttest.NewNopHost()), "node name not found")
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+}
+
+func writeLog(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func assertLogRecord(t *testing.T, logs plog.Logs, containerName, image, body string) {
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		"k8s.namespace.name":   "default",
+		"k8s.pod.name":         "nginx",
+		"k8s.pod.uid":          "uid-1",
+		"k8s.container.name":   containerName,
+		"container.image.name": image,
+	}, rl.Resource().Attributes().AsRaw())
+
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, body, lr.Body().Str())
+	assert.Contains(t, lr.Attributes().AsRaw(), "log.iostream")
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	labelPodName       = "io.kubernetes.pod.name"
+	labelPodNamespace  = "io.kubernetes.pod.namespace"
+	labelPodUID        = "io.kubernetes.pod.uid"
+	labelContainerName = "io.kubernetes.container.name"
+
+	// sandboxContainerName is the name given to the sandbox containers of pods by dockershim and cri-dockerd.
+	sandboxContainerName = "POD"
+
+	podLogsDir = "var/log/pods"
+)
+
+// runtimeClient discovers the containers of the node.
+type runtimeClient interface {
+	// listContainers returns the containers of Kubernetes pods running on the node.
+	listContainers(ctx context.Context) ([]runtimeContainer, error)
+	close() error
+}
+
+// runtimeContainer is a container of a Kubernetes pod, as reported by a runtimeClient.
+type runtimeContainer struct {
+	// key identifies the container among the ones returned by the runtimeClient.
+	key string
+	// containerID is the ID of the container in the runtime, or empty if it is unknown.
+	containerID   string
+	podNamespace  string
+	podName       string
+	podUID        string
+	containerName string
+	// logPath is the path of the log files of the container on the host, which may be a glob pattern.
+	logPath string
+	// env holds the environment variables of the container, or nil if they are unknown.
+	env map[string]string
+}
+
+// newRuntimeContainer creates a runtimeContainer from the labels set by the kubelet on containers,
+// returning false if the labels don't identify a container of a pod.
+func newRuntimeContainer(id string, labels map[string]string, logPath string, env []string) (runtimeContainer, bool) {
+	c := runtimeContainer{
+		key:           id,
+		containerID:   id,
+		podNamespace:  labels[labelPodNamespace],
+		podName:       labels[labelPodName],
+		podUID:        labels[labelPodUID],
+		containerName: labels[labelContainerName],
+		logPath:       logPath,
+	}
+	if c.podNamespace == "" || c.podName == "" || c.containerName == "" || c.containerName == sandboxContainerName || logPath == "" {
+		return runtimeContainer{}, false
+	}
+	if env != nil {
+		c.env = make(map[string]string, len(env))
+		for _, e := range env {
+			k, v, _ := strings.Cut(e, "=")
+			c.env[k] = v
+		}
+	}
+	return c, true
+}
+
+// fileClient discovers the containers from the directories created by the kubelet for their logs,
+// <HOST_ROOT>/var/log/pods/<namespace>_<pod name>_<pod uid>/<container name>. It is used when no
+// runtime API is available, and doesn't know the IDs nor the environment variables of the containers.
+type fileClient struct {
+	hostRoot string
+}
+
+func newFileClient(hostRoot string) *fileClient {
+	return &fileClient{hostRoot: hostRoot}
+}
+
+func (c *fileClient) listContainers(_ context.Context) ([]runtimeContainer, error) {
+	podDirs, err := os.ReadDir(filepath.Join(c.hostRoot, podLogsDir))
+	if err != nil {
+		return nil, err
+	}
+	var containers []runtimeContainer
+	for _, podDir := range podDirs {
+		if !podDir.IsDir() {
+			continue
+		}
+		parts := strings.Split(podDir.Name(), "_")
+		if len(parts) != 3 {
+			continue
+		}
+		containerDirs, err := os.ReadDir(filepath.Join(c.hostRoot, podLogsDir, podDir.Name()))
+		if err != nil {
+			continue
+		}
+		for _, containerDir := range containerDirs {
+			if !containerDir.IsDir() {
+				continue
+			}
+			containers = append(containers, runtimeContainer{
+				key:           parts[2] + "/" + containerDir.Name(),
+				podNamespace:  parts[0],
+				podName:       parts[1],
+				podUID:        parts[2],
+				containerName: containerDir.Name(),
+				logPath:       filepath.Join("/", podLogsDir, podDir.Name(), containerDir.Name(), "*.log"),
+			})
+		}
+	}
+	return containers, nil
+}
+
+func (c *fileClient) close() error {
+	return nil
+}
@@ -2,9 +2,11 @@
 // SPDX-License-Identifier: Apache-2.0
 
 package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.uber.org/zap"
@@ -52,7 +54,7 @@ func (c *RuntimeAPIConfig) Unmarshal(component *confmap.Conf) error {
 type runtimeAPIBuilder interface {
 	Validate() error
 	Type() string
-	NewClient(logger *zap.Logger, hostRoot string) (any, error)
+	NewClient(logger *zap.Logger, hostRoot string) (runtimeClient, error)
 }
 
 type baseRuntimeAPIConfig struct {
@@ -82,11 +84,12 @@ func (c *criConfig) Type() string {
 	return "cri"
 }
 
-func (c *criConfig) NewClient(logger *zap.Logger, hostRoot string) (any, error) {
-	_ = logger
-	_ = hostRoot
-	// TODO implement me
-	panic("implement me")
+func (c *criConfig) NewClient(logger *zap.Logger, hostRoot string) (runtimeClient, error) {
+	addr := c.Addr
+	if addr == "" {
+		addr = "unix://" + filepath.Join(hostRoot, "run/containerd/containerd.sock")
+	}
+	return newCRIClient(logger, addr)
 }
 
 // dockerConfig allows specifying how to connect to the Docker daemon.
@@ -113,9 +116,10 @@ func (c *dockerConfig) Type() string {
 	return "docker"
 }
 
-func (c *dockerConfig) NewClient(logger *zap.Logger, hostRoot string) (any, error) {
-	_ = logger
-	_ = hostRoot
-	// TODO implement me
-	panic("implement me")
+func (c *dockerConfig) NewClient(logger *zap.Logger, hostRoot string) (runtimeClient, error) {
+	addr := c.Addr
+	if addr == "" {
+		addr = "unix://" + filepath.Join(hostRoot, "var/run/docker.sock")
+	}
+	return newDockerClient(logger, addr)
 }
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+const runtimeAPITimeout = 5 * time.Second
+
+// criClient discovers the containers through the CRI runtime service, such as containerd's.
+type criClient struct {
+	logger *zap.Logger
+	conn   *grpc.ClientConn
+	client runtimeapi.RuntimeServiceClient
+
+	// cache holds the containers already inspected, by ID, as their log path and environment never change.
+	cache map[string]runtimeContainer
+}
+
+func newCRIClient(logger *zap.Logger, addr string) (*criClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CRI client for %q: %w", addr, err)
+	}
+	c := &criClient{
+		logger: logger,
+		conn:   conn,
+		client: runtimeapi.NewRuntimeServiceClient(conn),
+		cache:  map[string]runtimeContainer{},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), runtimeAPITimeout)
+	defer cancel()
+	if _, err = c.client.Version(ctx, &runtimeapi.VersionRequest{}); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to connect to CRI endpoint %q: %w", addr, err)
+	}
+	return c, nil
+}
+
+func (c *criClient) listContainers(ctx context.Context) ([]runtimeContainer, error) {
+	resp, err := c.client.ListContainers(ctx, &runtimeapi.ListContainersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]bool, len(resp.Containers))
+	containers := make([]runtimeContainer, 0, len(resp.Containers))
+	for _, ctr := range resp.Containers {
+		listed[ctr.Id] = true
+		if cached, ok := c.cache[ctr.Id]; ok {
+			containers = append(containers, cached)
+			continue
+		}
+
+		status, err := c.client.ContainerStatus(ctx, &runtimeapi.ContainerStatusRequest{ContainerId: ctr.Id, Verbose: true})
+		if err != nil {
+			c.logger.Debug("failed to get container status", zap.String("container.id", ctr.Id), zap.Error(err))
+			continue
+		}
+		container, ok := newRuntimeContainer(ctr.Id, ctr.Labels, status.GetStatus().GetLogPath(), criEnv(status.GetInfo()))
+		if !ok {
+			continue
+		}
+		c.cache[ctr.Id] = container
+		containers = append(containers, container)
+	}
+
+	for id := range c.cache {
+		if !listed[id] {
+			delete(c.cache, id)
+		}
+	}
+	return containers, nil
+}
+
+// criEnv returns the environment variables from the verbose information of a container status,
+// as reported by containerd, or nil if they are not available.
+func criEnv(info map[string]string) []string {
+	var parsed struct {
+		RuntimeSpec struct {
+			Process struct {
+				Env []string `json:"env"`
+			} `json:"process"`
+		} `json:"runtimeSpec"`
+	}
+	if err := json.Unmarshal([]byte(info["info"]), &parsed); err != nil {
+		return nil
+	}
+	return parsed.RuntimeSpec.Process.Env
+}
+
+func (c *criClient) close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	docker "github.com/docker/docker/client"
+	"go.uber.org/zap"
+)
+
+// dockerClient discovers the containers through the Docker Engine API, when Kubernetes uses Docker
+// through cri-dockerd.
+type dockerClient struct {
+	logger *zap.Logger
+	client *docker.Client
+
+	// cache holds the containers already inspected, by ID, as their log path and environment never change.
+	cache map[string]runtimeContainer
+}
+
+func newDockerClient(logger *zap.Logger, addr string) (*dockerClient, error) {
+	client, err := docker.NewClientWithOpts(docker.WithHost(addr), docker.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client for %q: %w", addr, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), runtimeAPITimeout)
+	defer cancel()
+	if _, err = client.Ping(ctx); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to Docker endpoint %q: %w", addr, err)
+	}
+	return &dockerClient{
+		logger: logger,
+		client: client,
+		cache:  map[string]runtimeContainer{},
+	}, nil
+}
+
+func (c *dockerClient) listContainers(ctx context.Context) ([]runtimeContainer, error) {
+	summaries, err := c.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]bool, len(summaries))
+	containers := make([]runtimeContainer, 0, len(summaries))
+	for _, summary := range summaries {
+		listed[summary.ID] = true
+		if cached, ok := c.cache[summary.ID]; ok {
+			containers = append(containers, cached)
+			continue
+		}
+		if summary.Labels[labelContainerName] == "" || summary.Labels[labelContainerName] == sandboxContainerName {
+			continue
+		}
+
+		inspect, err := c.client.ContainerInspect(ctx, summary.ID)
+		if err != nil {
+			c.logger.Debug("failed to inspect container", zap.String("container.id", summary.ID), zap.Error(err))
+			continue
+		}
+		var logPath string
+		if inspect.ContainerJSONBase != nil {
+			logPath = inspect.LogPath
+		}
+		var env []string
+		if inspect.Config != nil {
+			env = inspect.Config.Env
+		}
+		ctr, ok := newRuntimeContainer(summary.ID, summary.Labels, logPath, env)
+		if !ok {
+			continue
+		}
+		c.cache[summary.ID] = ctr
+		containers = append(containers, ctr)
+	}
+
+	for id := range c.cache {
+		if !listed[id] {
+			delete(c.cache, id)
+		}
+	}
+	return containers, nil
+}
+
+func (c *dockerClient) close() error {
+	return c.client.Close()
+}
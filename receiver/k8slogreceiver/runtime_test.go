@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+func TestNewRuntimeContainer(t *testing.T) {
+	labels := map[string]string{
+		labelPodNamespace:  "default",
+		labelPodName:       "nginx",
+		labelPodUID:        "uid-1",
+		labelContainerName: "nginx",
+	}
+	c, ok := newRuntimeContainer("abc", labels, "/var/log/pods/default_nginx_uid-1/nginx/0.log", []string{"A=1", "B=x=y", "C"})
+	require.True(t, ok)
+	assert.Equal(t, runtimeContainer{
+		key:           "abc",
+		containerID:   "abc",
+		podNamespace:  "default",
+		podName:       "nginx",
+		podUID:        "uid-1",
+		containerName: "nginx",
+		logPath:       "/var/log/pods/default_nginx_uid-1/nginx/0.log",
+		env:           map[string]string{"A": "1", "B": "x=y", "C": ""},
+	}, c)
+
+	_, ok = newRuntimeContainer("abc", labels, "", nil)
+	assert.False(t, ok, "container without log path")
+
+	labels[labelContainerName] = sandboxContainerName
+	_, ok = newRuntimeContainer("abc", labels, "/var/lib/docker/containers/abc/abc-json.log", nil)
+	assert.False(t, ok, "sandbox container")
+
+	_, ok = newRuntimeContainer("abc", map[string]string{}, "/var/log/containers/abc.log", nil)
+	assert.False(t, ok, "container not managed by the kubelet")
+}
+
+func TestFileClient(t *testing.T) {
+	hostRoot := t.TempDir()
+	for _, dir := range []string{
+		"default_nginx_uid-1/nginx",
+		"default_nginx_uid-1/init",
+		"kube-system_coredns_uid-2/coredns",
+		"invalid/container",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(hostRoot, podLogsDir, dir), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(hostRoot, podLogsDir, "file.log"), nil, 0o600))
+
+	containers, err := newFileClient(hostRoot).listContainers(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []runtimeContainer{
+		{
+			key:           "uid-1/init",
+			podNamespace:  "default",
+			podName:       "nginx",
+			podUID:        "uid-1",
+			containerName: "init",
+			logPath:       "/var/log/pods/default_nginx_uid-1/init/*.log",
+		},
+		{
+			key:           "uid-1/nginx",
+			podNamespace:  "default",
+			podName:       "nginx",
+			podUID:        "uid-1",
+			containerName: "nginx",
+			logPath:       "/var/log/pods/default_nginx_uid-1/nginx/*.log",
+		},
+		{
+			key:           "uid-2/coredns",
+			podNamespace:  "kube-system",
+			podName:       "coredns",
+			podUID:        "uid-2",
+			containerName: "coredns",
+			logPath:       "/var/log/pods/kube-system_coredns_uid-2/coredns/*.log",
+		},
+	}, containers)
+
+	_, err = newFileClient(t.TempDir()).listContainers(context.Background())
+	assert.Error(t, err)
+}
+
+type fakeRuntimeService struct {
+	runtimeapi.UnimplementedRuntimeServiceServer
+	mu         sync.Mutex
+	containers []*runtimeapi.Container
+	statuses   map[string]*runtimeapi.ContainerStatusResponse
+}
+
+func (s *fakeRuntimeService) Version(context.Context, *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
+	return &runtimeapi.VersionResponse{RuntimeName: "fake"}, nil
+}
+
+func (s *fakeRuntimeService) ListContainers(context.Context, *runtimeapi.ListContainersRequest) (*runtimeapi.ListContainersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &runtimeapi.ListContainersResponse{Containers: s.containers}, nil
+}
+
+func (s *fakeRuntimeService) ContainerStatus(_ context.Context, req *runtimeapi.ContainerStatusRequest) (*runtimeapi.ContainerStatusResponse, error) {
+	return s.statuses[req.ContainerId], nil
+}
+
+func TestCRIClient(t *testing.T) {
+	// unix socket paths are limited in length, so t.TempDir() can't be used
+	dir, err := os.MkdirTemp("", "cri")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "cri.sock")
+
+	labels := map[string]string{
+		labelPodNamespace:  "default",
+		labelPodName:       "nginx",
+		labelPodUID:        "uid-1",
+		labelContainerName: "nginx",
+	}
+	service := &fakeRuntimeService{
+		containers: []*runtimeapi.Container{
+			{Id: "abc", Labels: labels},
+			{Id: "sandbox", Labels: map[string]string{}},
+		},
+		statuses: map[string]*runtimeapi.ContainerStatusResponse{
+			"abc": {
+				Status: &runtimeapi.ContainerStatus{Id: "abc", LogPath: "/var/log/pods/default_nginx_uid-1/nginx/0.log"},
+				Info:   map[string]string{"info": `{"runtimeSpec":{"process":{"env":["PATH=/usr/bin","LOG_LEVEL=debug"]}}}`},
+			},
+			"sandbox": {
+				Status: &runtimeapi.ContainerStatus{Id: "sandbox"},
+			},
+		},
+	}
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	runtimeapi.RegisterRuntimeServiceServer(server, service)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	client, err := newCRIClient(zap.NewNop(), "unix://"+socket)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, client.close()) }()
+
+	containers, err := client.listContainers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []runtimeContainer{
+		{
+			key:           "abc",
+			containerID:   "abc",
+			podNamespace:  "default",
+			podName:       "nginx",
+			podUID:        "uid-1",
+			containerName: "nginx",
+			logPath:       "/var/log/pods/default_nginx_uid-1/nginx/0.log",
+			env:           map[string]string{"PATH": "/usr/bin", "LOG_LEVEL": "debug"},
+		},
+	}, containers)
+	assert.Contains(t, client.cache, "abc")
+
+	service.mu.Lock()
+	service.containers = nil
+	service.mu.Unlock()
+	containers, err = client.listContainers(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, containers)
+	assert.Empty(t, client.cache)
+}
+
+func TestCriEnv(t *testing.T) {
+	assert.Nil(t, criEnv(nil))
+	assert.Nil(t, criEnv(map[string]string{"info": "not json"}))
+	assert.Equal(t, []string{"A=1"}, criEnv(map[string]string{"info": `{"runtimeSpec":{"process":{"env":["A=1"]}}}`}))
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// source discovers the containers to collect logs from, associating the containers reported by
+// the runtime with the metadata of their pods from the Kubernetes API.
+type source struct {
+	logger    *zap.Logger
+	nodeName  string
+	hostRoot  string
+	runtime   runtimeClient
+	pods      corelisters.PodLister
+	filters   []containerFilter
+	extractor *extractor
+}
+
+// containerMeta is everything known about a container, used to filter it and to extract its metadata.
+type containerMeta struct {
+	runtimeContainer
+	nodeName    string
+	image       string
+	labels      map[string]string
+	annotations map[string]string
+	// env holds the environment variables from the runtime if known, or the ones from the pod spec.
+	env map[string]string
+}
+
+// discoveredContainer is a container to collect logs from.
+type discoveredContainer struct {
+	key string
+	// include is the glob pattern matching the log files of the container, within the host root.
+	include string
+	// resource holds the resource attributes of the logs of the container.
+	resource map[string]any
+}
+
+func newSource(logger *zap.Logger, cfg *Config, nodeName string, runtime runtimeClient, pods corelisters.PodLister) (*source, error) {
+	e, err := newExtractor(cfg.Extract)
+	if err != nil {
+		return nil, err
+	}
+	s := &source{
+		logger:    logger,
+		nodeName:  nodeName,
+		hostRoot:  cfg.Discovery.HostRoot,
+		runtime:   runtime,
+		pods:      pods,
+		extractor: e,
+	}
+	for _, f := range cfg.Discovery.Filter {
+		filter, err := newContainerFilter(f)
+		if err != nil {
+			return nil, err
+		}
+		s.filters = append(s.filters, filter)
+	}
+	return s, nil
+}
+
+// discover returns the containers of the node matching the filters.
+func (s *source) discover(ctx context.Context) ([]discoveredContainer, error) {
+	containers, err := s.runtime.listContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := make([]discoveredContainer, 0, len(containers))
+	for _, c := range containers {
+		pod, err := s.pods.Pods(c.podNamespace).Get(c.podName)
+		if err != nil {
+			// the pod may not be known yet, it will be retried on the next discovery
+			s.logger.Debug("pod of container not found", zap.String("k8s.namespace.name", c.podNamespace),
+				zap.String("k8s.pod.name", c.podName), zap.Error(err))
+			continue
+		}
+		if c.podUID != "" && string(pod.UID) != c.podUID {
+			// the container belongs to a previous pod with the same name
+			continue
+		}
+
+		meta := s.newContainerMeta(c, pod)
+		if !s.match(meta) {
+			continue
+		}
+		discovered = append(discovered, discoveredContainer{
+			key:      c.key,
+			include:  filepath.Join(s.hostRoot, c.logPath),
+			resource: s.extractor.extract(meta),
+		})
+	}
+	return discovered, nil
+}
+
+func (s *source) newContainerMeta(c runtimeContainer, pod *corev1.Pod) *containerMeta {
+	meta := &containerMeta{
+		runtimeContainer: c,
+		nodeName:         s.nodeName,
+		labels:           pod.Labels,
+		annotations:      pod.Annotations,
+		env:              c.env,
+	}
+	for _, spec := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		if spec.Name != c.containerName {
+			continue
+		}
+		meta.image = spec.Image
+		if meta.env == nil {
+			meta.env = make(map[string]string, len(spec.Env))
+			for _, env := range spec.Env {
+				if env.ValueFrom == nil {
+					meta.env[env.Name] = env.Value
+				}
+			}
+		}
+		break
+	}
+	return meta
+}
+
+// match returns whether the container matches any of the filters, or true if there are no filters.
+func (s *source) match(c *containerMeta) bool {
+	if len(s.filters) == 0 {
+		return true
+	}
+	for _, f := range s.filters {
+		if f.match(c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8slogreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+type fakeRuntimeClient struct {
+	containers []runtimeContainer
+	err        error
+}
+
+func (c *fakeRuntimeClient) listContainers(context.Context) ([]runtimeContainer, error) {
+	return c.containers, c.err
+}
+
+func (c *fakeRuntimeClient) close() error {
+	return nil
+}
+
+func newPodLister(t *testing.T, pods ...*corev1.Pod) corelisters.PodLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pod := range pods {
+		require.NoError(t, indexer.Add(pod))
+	}
+	return corelisters.NewPodLister(indexer)
+}
+
+func TestSourceDiscover(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "nginx",
+			UID:         "uid-1",
+			Labels:      map[string]string{"app": "nginx"},
+			Annotations: map[string]string{"io.opentelemetry.collectlog": "true"},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name:  "init",
+					Image: "busybox:1.36",
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  "nginx",
+					Image: "nginx:1.27",
+					Env: []corev1.EnvVar{
+						{Name: "LOG_LEVEL", Value: "debug"},
+						{Name: "SECRET", ValueFrom: &corev1.EnvVarSource{}},
+					},
+				},
+			},
+		},
+	}
+	runtime := &fakeRuntimeClient{
+		containers: []runtimeContainer{
+			{
+				key:           "uid-1/nginx",
+				podNamespace:  "default",
+				podName:       "nginx",
+				podUID:        "uid-1",
+				containerName: "nginx",
+				logPath:       "/var/log/pods/default_nginx_uid-1/nginx/*.log",
+			},
+			{
+				key:           "init-id",
+				containerID:   "init-id",
+				podNamespace:  "default",
+				podName:       "nginx",
+				podUID:        "uid-1",
+				containerName: "init",
+				logPath:       "/var/log/pods/default_nginx_uid-1/init/0.log",
+				env:           map[string]string{"LOG_LEVEL": "info"},
+			},
+			{
+				key:           "uid-0/nginx",
+				podNamespace:  "default",
+				podName:       "nginx",
+				podUID:        "uid-0",
+				containerName: "nginx",
+				logPath:       "/var/log/pods/default_nginx_uid-0/nginx/*.log",
+			},
+			{
+				key:           "uid-2/coredns",
+				podNamespace:  "kube-system",
+				podName:       "coredns",
+				podUID:        "uid-2",
+				containerName: "coredns",
+				logPath:       "/var/log/pods/kube-system_coredns_uid-2/coredns/*.log",
+			},
+		},
+	}
+
+	cfg := createDefaultConfig()
+	cfg.Discovery.HostRoot = "/host_root"
+	cfg.Extract.Metadata = []string{metadataPodName, metadataContainerName, metadataContainerID, metadataImageName, metadataNodeName}
+	cfg.Extract.Env = []FieldExtractConfig{{Key: "LOG_LEVEL"}, {Key: "SECRET"}}
+
+	s, err := newSource(zap.NewNop(), cfg, "node-1", runtime, newPodLister(t, pod))
+	require.NoError(t, err)
+
+	containers, err := s.discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []discoveredContainer{
+		{
+			key:     "uid-1/nginx",
+			include: "/host_root/var/log/pods/default_nginx_uid-1/nginx/*.log",
+			resource: map[string]any{
+				"k8s.pod.name":          "nginx",
+				"k8s.container.name":    "nginx",
+				"container.image.name":  "nginx:1.27",
+				"k8s.node.name":         "node-1",
+				"k8s.pod.env.LOG_LEVEL": "debug",
+			},
+		},
+		{
+			key:     "init-id",
+			include: "/host_root/var/log/pods/default_nginx_uid-1/init/0.log",
+			resource: map[string]any{
+				"k8s.pod.name":          "nginx",
+				"k8s.container.name":    "init",
+				"container.id":          "init-id",
+				"container.image.name":  "busybox:1.36",
+				"k8s.node.name":         "node-1",
+				"k8s.pod.env.LOG_LEVEL": "info",
+			},
+		},
+	}, containers)
+
+	cfg.Discovery.Filter = []FilterConfig{
+		{Containers: []ValueFilterConfig{{Value: "init"}}},
+		{Env: []MapFilterConfig{{Key: "LOG_LEVEL", Value: "debug"}}},
+	}
+	s, err = newSource(zap.NewNop(), cfg, "node-1", runtime, newPodLister(t, pod))
+	require.NoError(t, err)
+	containers, err = s.discover(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, containers, 2)
+
+	cfg.Discovery.Filter = []FilterConfig{
+		{Labels: []MapFilterConfig{{Key: "app", Value: "nginx"}}, Containers: []ValueFilterConfig{{Value: "nginx"}}},
+	}
+	s, err = newSource(zap.NewNop(), cfg, "node-1", runtime, newPodLister(t, pod))
+	require.NoError(t, err)
+	containers, err = s.discover(context.Background())
+	require.NoError(t, err)
+	require.Len(t, containers, 1)
+	assert.Equal(t, "uid-1/nginx", containers[0].key)
+
+	runtime.err = errors.New("connection refused")
+	_, err = s.discover(context.Background())
+	assert.ErrorContains(t, err, "connection refused")
+}
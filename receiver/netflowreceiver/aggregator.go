@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
+)
+
+// attributeMetricOverflow is set on the data points of the flows that exceeded the maximum number of groups,
+// as done by the OpenTelemetry SDKs when the cardinality limit is reached
+const attributeMetricOverflow = "otel.metric.overflow"
+
+// flowGroup holds the sums of the flows with the same key attributes
+type flowGroup struct {
+	attributes pcommon.Map
+	bytes      int64
+	packets    int64
+}
+
+// flowAggregator sums the bytes and packets of the flow records grouped by the key attributes
+type flowAggregator struct {
+	keys      []string
+	maxGroups int
+
+	mu     sync.Mutex
+	groups map[string]*flowGroup
+	// overflow holds the sums of the flows of the new groups once the maximum number of groups is reached
+	overflow  *flowGroup
+	startTime pcommon.Timestamp
+}
+
+func newFlowAggregator(keys []string, maxGroups int) *flowAggregator {
+	return &flowAggregator{
+		keys:      keys,
+		maxGroups: maxGroups,
+		groups:    map[string]*flowGroup{},
+		startTime: pcommon.NewTimestampFromTime(time.Now()),
+	}
+}
+
+// add adds the bytes and packets of a flow record with the given attributes
+func (a *flowAggregator) add(attrs pcommon.Map) {
+	var key strings.Builder
+	for _, k := range a.keys {
+		// The missing attributes are distinguished from the empty ones
+		if v, ok := attrs.Get(k); ok {
+			key.WriteByte('+')
+			key.WriteString(v.AsString())
+		}
+		key.WriteByte(0)
+	}
+
+	var bytes, packets int64
+	if v, ok := attrs.Get(attributeFlowBytes); ok {
+		bytes = v.Int()
+	}
+	if v, ok := attrs.Get(attributeFlowPackets); ok {
+		packets = v.Int()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	group, ok := a.groups[key.String()]
+	switch {
+	case ok:
+	case len(a.groups) >= a.maxGroups:
+		if a.overflow == nil {
+			a.overflow = &flowGroup{attributes: pcommon.NewMap()}
+			a.overflow.attributes.PutBool(attributeMetricOverflow, true)
+		}
+		group = a.overflow
+	default:
+		group = &flowGroup{attributes: pcommon.NewMap()}
+		for _, k := range a.keys {
+			if v, ok := attrs.Get(k); ok {
+				v.CopyTo(group.attributes.PutEmpty(k))
+			}
+		}
+		a.groups[key.String()] = group
+	}
+	group.bytes += bytes
+	group.packets += packets
+}
+
+// flush returns the sums of the flows added since the previous flush as delta metrics, and resets them.
+// It returns false if no flow was added.
+func (a *flowAggregator) flush(now time.Time) (pmetric.Metrics, bool) {
+	a.mu.Lock()
+	groups := a.groups
+	overflow := a.overflow
+	startTime := a.startTime
+	a.groups = map[string]*flowGroup{}
+	a.overflow = nil
+	a.startTime = pcommon.NewTimestampFromTime(now)
+	a.mu.Unlock()
+
+	if len(groups) == 0 {
+		return pmetric.Metrics{}, false
+	}
+
+	metrics := pmetric.NewMetrics()
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	scopeMetrics.Scope().SetName(metadata.ScopeName)
+	scopeMetrics.Scope().Attributes().PutStr("receiver", metadata.Type.String())
+
+	bytesSum := newDeltaSum(scopeMetrics.Metrics().AppendEmpty(), attributeFlowBytes, "The number of bytes of the flows.", "By")
+	packetsSum := newDeltaSum(scopeMetrics.Metrics().AppendEmpty(), attributeFlowPackets, "The number of packets of the flows.", "{packet}")
+	timestamp := pcommon.NewTimestampFromTime(now)
+	for _, group := range groups {
+		appendDataPoint(bytesSum, group.attributes, startTime, timestamp, group.bytes)
+		appendDataPoint(packetsSum, group.attributes, startTime, timestamp, group.packets)
+	}
+	if overflow != nil {
+		appendDataPoint(bytesSum, overflow.attributes, startTime, timestamp, overflow.bytes)
+		appendDataPoint(packetsSum, overflow.attributes, startTime, timestamp, overflow.packets)
+	}
+	return metrics, true
+}
+
+func appendDataPoint(sum pmetric.Sum, attrs pcommon.Map, startTime, timestamp pcommon.Timestamp, value int64) {
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(startTime)
+	dp.SetTimestamp(timestamp)
+	dp.SetIntValue(value)
+	attrs.CopyTo(dp.Attributes())
+}
+
+func newDeltaSum(metric pmetric.Metric, name, description, unit string) pmetric.Sum {
+	metric.SetName(name)
+	metric.SetDescription(description)
+	metric.SetUnit(unit)
+	sum := metric.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	return sum
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestFlowAggregator(t *testing.T) {
+	aggregator := newFlowAggregator([]string{"destination.address", "destination.port"}, 10)
+
+	_, ok := aggregator.flush(time.Now())
+	assert.False(t, ok)
+
+	newFlow := func(address string, port, bytes, packets int64) pcommon.Map {
+		attrs := pcommon.NewMap()
+		if address != "" {
+			attrs.PutStr("destination.address", address)
+		}
+		attrs.PutInt("destination.port", port)
+		attrs.PutStr("source.address", "10.0.0.1")
+		attrs.PutInt("flow.io.bytes", bytes)
+		attrs.PutInt("flow.io.packets", packets)
+		return attrs
+	}
+	aggregator.add(newFlow("10.0.0.2", 443, 100, 1))
+	aggregator.add(newFlow("10.0.0.2", 443, 200, 2))
+	aggregator.add(newFlow("10.0.0.2", 80, 50, 1))
+	aggregator.add(newFlow("", 80, 10, 1))
+
+	now := time.Now()
+	metrics, ok := aggregator.flush(now)
+	require.True(t, ok)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	scopeMetrics := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, "otelcol/netflowreceiver", scopeMetrics.Scope().Name())
+	require.Equal(t, 2, scopeMetrics.Metrics().Len())
+
+	bytesMetric := scopeMetrics.Metrics().At(0)
+	assert.Equal(t, "flow.io.bytes", bytesMetric.Name())
+	assert.Equal(t, "By", bytesMetric.Unit())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, bytesMetric.Sum().AggregationTemporality())
+	assert.True(t, bytesMetric.Sum().IsMonotonic())
+	packetsMetric := scopeMetrics.Metrics().At(1)
+	assert.Equal(t, "flow.io.packets", packetsMetric.Name())
+	assert.Equal(t, "{packet}", packetsMetric.Unit())
+
+	type sums struct{ bytes, packets int64 }
+	actual := map[string]sums{}
+	for i := 0; i < bytesMetric.Sum().DataPoints().Len(); i++ {
+		bytesDP := bytesMetric.Sum().DataPoints().At(i)
+		packetsDP := packetsMetric.Sum().DataPoints().At(i)
+		assert.Equal(t, bytesDP.Attributes().AsRaw(), packetsDP.Attributes().AsRaw())
+		assert.Equal(t, pcommon.NewTimestampFromTime(now), bytesDP.Timestamp())
+		assert.Less(t, bytesDP.StartTimestamp(), bytesDP.Timestamp())
+
+		var address string
+		if v, ok := bytesDP.Attributes().Get("destination.address"); ok {
+			address = v.Str()
+		}
+		port, _ := bytesDP.Attributes().Get("destination.port")
+		actual[address+":"+port.AsString()] = sums{bytesDP.IntValue(), packetsDP.IntValue()}
+	}
+	assert.Equal(t, map[string]sums{
+		"10.0.0.2:443": {300, 3},
+		"10.0.0.2:80":  {50, 1},
+		":80":          {10, 1},
+	}, actual)
+
+	// The sums are reset after each flush
+	aggregator.add(newFlow("10.0.0.2", 443, 100, 1))
+	metrics, ok = aggregator.flush(now.Add(time.Minute))
+	require.True(t, ok)
+	dp := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(100), dp.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), dp.StartTimestamp())
+}
+
+func TestFlowAggregatorMaxGroups(t *testing.T) {
+	aggregator := newFlowAggregator([]string{"destination.port"}, 2)
+
+	newFlow := func(port, bytes int64) pcommon.Map {
+		attrs := pcommon.NewMap()
+		attrs.PutInt("destination.port", port)
+		attrs.PutInt("flow.io.bytes", bytes)
+		attrs.PutInt("flow.io.packets", 1)
+		return attrs
+	}
+	aggregator.add(newFlow(443, 100))
+	aggregator.add(newFlow(80, 50))
+	// The new groups beyond the maximum are summed in the overflow group, the existing ones are still updated
+	aggregator.add(newFlow(22, 10))
+	aggregator.add(newFlow(53, 20))
+	aggregator.add(newFlow(443, 100))
+
+	metrics, ok := aggregator.flush(time.Now())
+	require.True(t, ok)
+	bytesSum := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+	actual := map[string]int64{}
+	for i := 0; i < bytesSum.DataPoints().Len(); i++ {
+		dp := bytesSum.DataPoints().At(i)
+		if _, ok := dp.Attributes().Get("otel.metric.overflow"); ok {
+			assert.Equal(t, map[string]any{"otel.metric.overflow": true}, dp.Attributes().AsRaw())
+			actual["overflow"] = dp.IntValue()
+			continue
+		}
+		port, _ := dp.Attributes().Get("destination.port")
+		actual[port.AsString()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"443": 200, "80": 50, "overflow": 30}, actual)
+
+	// The groups are counted again from the next interval
+	aggregator.add(newFlow(22, 10))
+	metrics, ok = aggregator.flush(time.Now())
+	require.True(t, ok)
+	dp := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, map[string]any{"destination.port": int64(22)}, dp.Attributes().AsRaw())
+}
@@ -5,12 +5,28 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
+)
+
+const (
+	schemeNetflow = "netflow"
+	schemeSflow   = "sflow"
+	schemeIPFIX   = "ipfix"
+
+	ipfixFieldTypeInt    = "int"
+	ipfixFieldTypeString = "string"
+	ipfixFieldTypeBytes  = "bytes"
 )
 
 // Config represents the receiver config settings within the collector's config.yaml
 type Config struct {
 	// The scheme defines the type of flow data that the listener will receive
-	// The scheme must be one of sflow, netflow, or flow
+	// The scheme must be one of sflow, netflow, or ipfix
 	Scheme string `mapstructure:"scheme"`
 
 	// The hostname or IP address that the listener will bind to
@@ -33,11 +49,71 @@ type Config struct {
 
 	// SendRaw determines whether to send raw flow messages instead of parsing them
 	SendRaw bool `mapstructure:"send_raw"`
+
+	// IPFIX defines the additional IPFIX fields to decode, for the netflow and ipfix schemes
+	IPFIX IPFIXConfig `mapstructure:"ipfix"`
+
+	// Enrichment defines the attributes added to the flow records
+	Enrichment EnrichmentConfig `mapstructure:"enrichment"`
+
+	// Aggregation defines how the flow records are aggregated into metrics by the metrics receiver
+	Aggregation AggregationConfig `mapstructure:"aggregation"`
+}
+
+// IPFIXConfig defines the IPFIX information elements decoded in addition to the default ones
+type IPFIXConfig struct {
+	// Fields are the information elements to decode into attributes, including enterprise-specific ones
+	Fields []IPFIXFieldConfig `mapstructure:"fields"`
+}
+
+// IPFIXFieldConfig defines an IPFIX information element to decode into an attribute
+type IPFIXFieldConfig struct {
+	// The name of the attribute holding the value of the information element
+	Name string `mapstructure:"name"`
+
+	// The private enterprise number of an enterprise-specific information element,
+	// 0 for the information elements defined by IANA
+	PEN uint32 `mapstructure:"pen"`
+
+	// The identifier of the information element
+	ID uint16 `mapstructure:"id"`
+
+	// The type of the attribute value, one of int, string or bytes
+	// By default it will be bytes
+	Type string `mapstructure:"type"`
+}
+
+// EnrichmentConfig defines the attributes added to the flow records in addition to the flow fields
+type EnrichmentConfig struct {
+	// PortNames adds the well-known service names of the source and destination ports
+	PortNames bool `mapstructure:"port_names"`
+
+	// GeoIP configures the geoIP providers used to add the geographical location and
+	// autonomous system attributes of the source and destination addresses
+	GeoIP *GeoIPConfig `mapstructure:"geoip"`
+}
+
+// GeoIPConfig defines the geoIP providers, with the same `providers` section as the geoIP processor
+type GeoIPConfig struct {
+	// Providers specifies the sources to extract geographical information about a given IP
+	Providers map[string]provider.Config `mapstructure:"-"`
+}
+
+// AggregationConfig defines how the metrics receiver aggregates the flow records
+type AggregationConfig struct {
+	// The interval at which the aggregated flow bytes and packets are sent
+	Interval time.Duration `mapstructure:"interval"`
+
+	// The attributes of the flow records used to group the flows, and set on the data points
+	Keys []string `mapstructure:"keys"`
+
+	// The maximum number of groups per interval, the flows of the other groups are summed in a single overflow group
+	MaxGroups int `mapstructure:"max_groups"`
 }
 
 // Validate checks if the receiver configuration is valid
 func (cfg *Config) Validate() error {
-	validSchemes := [2]string{"sflow", "netflow"}
+	validSchemes := [3]string{schemeSflow, schemeNetflow, schemeIPFIX}
 
 	validScheme := false
 	for _, scheme := range validSchemes {
@@ -47,7 +123,7 @@ func (cfg *Config) Validate() error {
 		}
 	}
 	if !validScheme {
-		return errors.New("scheme must be netflow or sflow")
+		return errors.New("scheme must be netflow, sflow or ipfix")
 	}
 
 	if cfg.Sockets <= 0 {
@@ -66,5 +142,77 @@ func (cfg *Config) Validate() error {
 		return errors.New("port must be greater than 0")
 	}
 
+	if err := cfg.IPFIX.validate(cfg.Scheme); err != nil {
+		return err
+	}
+
+	if cfg.Aggregation.Interval <= 0 {
+		return errors.New("aggregation interval must be greater than 0")
+	}
+
+	if len(cfg.Aggregation.Keys) == 0 {
+		return errors.New("aggregation keys must not be empty")
+	}
+
+	if cfg.Aggregation.MaxGroups <= 0 {
+		return errors.New("aggregation max_groups must be greater than 0")
+	}
+
+	return nil
+}
+
+// Validate checks if the geoIP providers configuration is valid
+func (cfg *GeoIPConfig) Validate() error {
+	if len(cfg.Providers) == 0 {
+		return errors.New("must specify at least one geo IP data provider")
+	}
+	return provider.ValidateConfigs(cfg.Providers)
+}
+
+// Unmarshal loads the configuration of each provider, based on the provider type of its key
+func (cfg *GeoIPConfig) Unmarshal(componentParser *confmap.Conf) error {
+	if componentParser == nil {
+		return nil
+	}
+
+	providersSection, err := componentParser.Sub("providers")
+	if err != nil {
+		return err
+	}
+	cfg.Providers, err = provider.UnmarshalConfigs(providersSection, geoIPProviderFactories)
+	return err
+}
+
+func (cfg *IPFIXConfig) validate(scheme string) error {
+	if len(cfg.Fields) == 0 {
+		return nil
+	}
+
+	if scheme == schemeSflow {
+		return errors.New("ipfix fields are not supported with the sflow scheme")
+	}
+
+	names := make(map[string]bool, len(cfg.Fields))
+	for _, field := range cfg.Fields {
+		if field.Name == "" {
+			return errors.New("ipfix field name must not be empty")
+		}
+		if names[field.Name] {
+			return fmt.Errorf("duplicate ipfix field name %q", field.Name)
+		}
+		names[field.Name] = true
+
+		// The most significant bit of the identifier is the enterprise bit in the templates
+		if field.ID == 0 || field.ID > 0x7fff {
+			return fmt.Errorf("ipfix field %q id must be between 1 and 32767", field.Name)
+		}
+
+		switch field.Type {
+		case "", ipfixFieldTypeInt, ipfixFieldTypeString, ipfixFieldTypeBytes:
+		default:
+			return fmt.Errorf("ipfix field %q type must be int, string or bytes", field.Name)
+		}
+	}
+
 	return nil
 }
@@ -6,6 +6,7 @@ package netflowreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,6 +23,8 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	defaultAggregation := createDefaultConfig().(*Config).Aggregation
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -33,42 +36,79 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "one_listener"),
 			expected: &Config{
-				Scheme:    "netflow",
-				Port:      2055,
-				Sockets:   1,
-				Workers:   1,
-				QueueSize: 1000,
+				Scheme:      "netflow",
+				Port:        2055,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				Aggregation: defaultAggregation,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "zero_queue"),
 			expected: &Config{
-				Scheme:    "netflow",
-				Port:      2055,
-				Sockets:   1,
-				Workers:   1,
-				QueueSize: 1000,
+				Scheme:      "netflow",
+				Port:        2055,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				Aggregation: defaultAggregation,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "sflow"),
 			expected: &Config{
-				Scheme:    "sflow",
-				Port:      6343,
+				Scheme:      "sflow",
+				Port:        6343,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				Aggregation: defaultAggregation,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "raw_logs"),
+			expected: &Config{
+				Scheme:      "netflow",
+				Port:        2055,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				SendRaw:     true,
+				Aggregation: defaultAggregation,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ipfix"),
+			expected: &Config{
+				Scheme:    "ipfix",
+				Port:      4739,
 				Sockets:   1,
-				Workers:   1,
+				Workers:   2,
 				QueueSize: 1000,
+				IPFIX: IPFIXConfig{
+					Fields: []IPFIXFieldConfig{
+						{Name: "application.name", ID: 96, Type: "string"},
+						{Name: "vendor.app.id", PEN: 9999, ID: 1, Type: "int"},
+						{Name: "vendor.raw", PEN: 9999, ID: 2},
+					},
+				},
+				Aggregation: defaultAggregation,
 			},
 		},
 		{
-			id: component.NewIDWithName(metadata.Type, "raw_logs"),
+			id: component.NewIDWithName(metadata.Type, "aggregation"),
 			expected: &Config{
 				Scheme:    "netflow",
 				Port:      2055,
 				Sockets:   1,
-				Workers:   1,
+				Workers:   2,
 				QueueSize: 1000,
-				SendRaw:   true,
+				Aggregation: AggregationConfig{
+					Interval:  10 * time.Second,
+					Keys:      []string{"source.address", "destination.as.number"},
+					MaxGroups: 1000,
+				},
 			},
 		},
 	}
@@ -88,6 +128,23 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestLoadEnrichmentConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "enrichment").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	assert.True(t, cfg.Enrichment.PortNames)
+	require.NotNil(t, cfg.Enrichment.GeoIP)
+	assert.Len(t, cfg.Enrichment.GeoIP.Providers, 2)
+	assert.Contains(t, cfg.Enrichment.GeoIP.Providers, "maxmind/city")
+	assert.Contains(t, cfg.Enrichment.GeoIP.Providers, "maxmind/asn")
+}
+
 func TestInvalidConfig(t *testing.T) {
 	t.Parallel()
 
@@ -100,7 +157,7 @@ func TestInvalidConfig(t *testing.T) {
 	}{
 		{
 			id:  component.NewIDWithName(metadata.Type, "invalid_schema"),
-			err: "scheme must be netflow or sflow",
+			err: "scheme must be netflow, sflow or ipfix",
 		},
 		{
 			id:  component.NewIDWithName(metadata.Type, "invalid_port"),
@@ -114,6 +171,42 @@ func TestInvalidConfig(t *testing.T) {
 			id:  component.NewIDWithName(metadata.Type, "zero_workers"),
 			err: "workers must be greater than 0",
 		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "sflow_ipfix_fields"),
+			err: "ipfix fields are not supported with the sflow scheme",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "ipfix_field_no_name"),
+			err: "ipfix field name must not be empty",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "ipfix_field_duplicate_name"),
+			err: `duplicate ipfix field name "vendor.app.id"`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "ipfix_field_invalid_id"),
+			err: `ipfix field "vendor.app.id" id must be between 1 and 32767`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "ipfix_field_invalid_type"),
+			err: `ipfix field "vendor.app.id" type must be int, string or bytes`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "geoip_no_providers"),
+			err: "must specify at least one geo IP data provider",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "zero_aggregation_interval"),
+			err: "aggregation interval must be greater than 0",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "empty_aggregation_keys"),
+			err: "aggregation keys must not be empty",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "zero_aggregation_max_groups"),
+			err: "aggregation max_groups must be greater than 0",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
+	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider/maxmindprovider"
+)
+
+const (
+	attributeSourcePortName      = "flow.source.port_name"
+	attributeDestinationPortName = "flow.destination.port_name"
+
+	sourcePrefix      = "source."
+	destinationPrefix = "destination."
+)
+
+var (
+	// Transport protocols using ports, see transportProtocolNames
+	portProtocols = map[uint32]bool{
+		6:   true, // tcp
+		17:  true, // udp
+		132: true, // sctp
+	}
+
+	// https://www.iana.org/assignments/service-names-port-numbers/service-names-port-numbers.xhtml
+	portNames = map[uint32]string{
+		20:    "ftp-data",
+		21:    "ftp",
+		22:    "ssh",
+		23:    "telnet",
+		25:    "smtp",
+		53:    "domain",
+		67:    "bootps",
+		68:    "bootpc",
+		69:    "tftp",
+		80:    "http",
+		88:    "kerberos",
+		110:   "pop3",
+		111:   "sunrpc",
+		123:   "ntp",
+		135:   "epmap",
+		137:   "netbios-ns",
+		138:   "netbios-dgm",
+		139:   "netbios-ssn",
+		143:   "imap",
+		161:   "snmp",
+		162:   "snmptrap",
+		179:   "bgp",
+		389:   "ldap",
+		443:   "https",
+		445:   "microsoft-ds",
+		465:   "submissions",
+		500:   "isakmp",
+		514:   "syslog",
+		587:   "submission",
+		636:   "ldaps",
+		853:   "domain-s",
+		873:   "rsync",
+		993:   "imaps",
+		995:   "pop3s",
+		1194:  "openvpn",
+		1433:  "ms-sql-s",
+		1701:  "l2tp",
+		1812:  "radius",
+		1813:  "radius-acct",
+		2049:  "nfs",
+		3306:  "mysql",
+		3389:  "ms-wbt-server",
+		4739:  "ipfix",
+		4789:  "vxlan",
+		5060:  "sip",
+		5061:  "sips",
+		5353:  "mdns",
+		5432:  "postgresql",
+		5671:  "amqps",
+		5672:  "amqp",
+		6343:  "sflow",
+		6379:  "redis",
+		8080:  "http-alt",
+		11211: "memcache",
+		27017: "mongodb",
+	}
+)
+
+// geoIPProviderFactories holds the factories of the geoIP providers, keyed by the provider type
+var geoIPProviderFactories = map[string]provider.GeoIPProviderFactory{
+	maxmind.TypeStr: &maxmind.Factory{},
+}
+
+// flowEnricher adds the attributes which are not part of the flow messages
+type flowEnricher struct {
+	portNames      bool
+	geoIPProviders []provider.GeoIPProvider
+}
+
+// enrich adds the port names and the geoIP attributes of the source and destination addresses
+func (e *flowEnricher) enrich(ctx context.Context, pm *protoproducer.ProtoProducerMessage, attrs pcommon.Map) error {
+	if e.portNames && portProtocols[pm.Proto] {
+		if name, ok := portNames[pm.SrcPort]; ok {
+			attrs.PutStr(attributeSourcePortName, name)
+		}
+		if name, ok := portNames[pm.DstPort]; ok {
+			attrs.PutStr(attributeDestinationPortName, name)
+		}
+	}
+
+	if len(e.geoIPProviders) == 0 {
+		return nil
+	}
+	return multierr.Append(
+		e.putLocation(ctx, pm.SrcAddr, sourcePrefix, attrs),
+		e.putLocation(ctx, pm.DstAddr, destinationPrefix, attrs),
+	)
+}
+
+// putLocation adds the attributes of the providers for the address, with their keys prefixed
+func (e *flowEnricher) putLocation(ctx context.Context, addr []byte, prefix string, attrs pcommon.Map) error {
+	ip := net.IP(addr)
+	if (len(addr) != net.IPv4len && len(addr) != net.IPv6len) || ip.IsUnspecified() {
+		return nil
+	}
+
+	for _, geoIPProvider := range e.geoIPProviders {
+		location, err := geoIPProvider.Location(ctx, ip)
+		if errors.Is(err, provider.ErrNoMetadataFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, kv := range location.ToSlice() {
+			key := prefix + string(kv.Key)
+			switch kv.Value.Type() {
+			case attribute.STRING:
+				attrs.PutStr(key, kv.Value.AsString())
+			case attribute.INT64:
+				attrs.PutInt(key, kv.Value.AsInt64())
+			case attribute.FLOAT64:
+				attrs.PutDouble(key, kv.Value.AsFloat64())
+			case attribute.BOOL:
+				attrs.PutBool(key, kv.Value.AsBool())
+			}
+		}
+	}
+	return nil
+}
+
+// close closes the geoIP providers
+func (e *flowEnricher) close(ctx context.Context) error {
+	var errs error
+	for _, provider := range e.geoIPProviders {
+		errs = multierr.Append(errs, provider.Close(ctx))
+	}
+	return errs
+}
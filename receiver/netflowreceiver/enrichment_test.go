@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	flowpb "github.com/netsampler/goflow2/v2/pb"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
+)
+
+type providerMock struct {
+	locations map[string]attribute.Set
+	err       error
+	closed    bool
+}
+
+func (p *providerMock) Location(_ context.Context, ip net.IP) (attribute.Set, error) {
+	if p.err != nil {
+		return attribute.Set{}, p.err
+	}
+	if location, ok := p.locations[ip.String()]; ok {
+		return location, nil
+	}
+	return attribute.Set{}, provider.ErrNoMetadataFound
+}
+
+func (p *providerMock) Close(context.Context) error {
+	p.closed = true
+	return nil
+}
+
+func TestEnrichPortNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		proto    uint32
+		srcPort  uint32
+		dstPort  uint32
+		expected map[string]any
+	}{
+		{
+			name:    "tcp",
+			proto:   6,
+			srcPort: 51000,
+			dstPort: 443,
+			expected: map[string]any{
+				"flow.destination.port_name": "https",
+			},
+		},
+		{
+			name:    "udp",
+			proto:   17,
+			srcPort: 53,
+			dstPort: 123,
+			expected: map[string]any{
+				"flow.source.port_name":      "domain",
+				"flow.destination.port_name": "ntp",
+			},
+		},
+		{
+			name:     "icmp",
+			proto:    1,
+			srcPort:  0,
+			dstPort:  80,
+			expected: map[string]any{},
+		},
+	}
+
+	enricher := &flowEnricher{portNames: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := &protoproducer.ProtoProducerMessage{
+				FlowMessage: flowpb.FlowMessage{Proto: tt.proto, SrcPort: tt.srcPort, DstPort: tt.dstPort},
+			}
+			attrs := pcommon.NewMap()
+			assert.NoError(t, enricher.enrich(context.Background(), pm, attrs))
+			assert.Equal(t, tt.expected, attrs.AsRaw())
+		})
+	}
+}
+
+func TestEnrichGeoIP(t *testing.T) {
+	cityProvider := &providerMock{locations: map[string]attribute.Set{
+		"1.2.3.4": attribute.NewSet(
+			attribute.String("geo.country_iso_code", "GB"),
+			attribute.Float64("geo.location.lat", 51.5),
+		),
+	}}
+	asnProvider := &providerMock{locations: map[string]attribute.Set{
+		"1.2.3.4": attribute.NewSet(attribute.Int64("as.number", 1221)),
+		"5.6.7.8": attribute.NewSet(attribute.Int64("as.number", 3320)),
+	}}
+	enricher := &flowEnricher{geoIPProviders: []provider.GeoIPProvider{cityProvider, asnProvider}}
+
+	pm := &protoproducer.ProtoProducerMessage{
+		FlowMessage: flowpb.FlowMessage{
+			SrcAddr: netip.MustParseAddr("1.2.3.4").AsSlice(),
+			DstAddr: netip.MustParseAddr("5.6.7.8").AsSlice(),
+		},
+	}
+	attrs := pcommon.NewMap()
+	assert.NoError(t, enricher.enrich(context.Background(), pm, attrs))
+	assert.Equal(t, map[string]any{
+		"source.geo.country_iso_code": "GB",
+		"source.geo.location.lat":     51.5,
+		"source.as.number":            int64(1221),
+		"destination.as.number":       int64(3320),
+	}, attrs.AsRaw())
+
+	// The missing and unspecified addresses are not looked up
+	attrs = pcommon.NewMap()
+	assert.NoError(t, enricher.enrich(context.Background(), &protoproducer.ProtoProducerMessage{
+		FlowMessage: flowpb.FlowMessage{DstAddr: netip.MustParseAddr("::").AsSlice()},
+	}, attrs))
+	assert.Equal(t, 0, attrs.Len())
+
+	assert.NoError(t, enricher.close(context.Background()))
+	assert.True(t, cityProvider.closed)
+	assert.True(t, asnProvider.closed)
+
+	enricher = &flowEnricher{geoIPProviders: []provider.GeoIPProvider{&providerMock{err: errors.New("lookup failed")}}}
+	assert.EqualError(t, enricher.enrich(context.Background(), pm, pcommon.NewMap()), "lookup failed; lookup failed")
+}
@@ -5,11 +5,15 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -20,25 +24,41 @@ const (
 	// that for a full queue of 1000 messages, the size in memory will be 9MB.
 	// Source: https://github.com/netsampler/goflow2/blob/v2.2.1/README.md#security-notes-and-assumptions
 	defaultQueueSize = 1_000
+
+	defaultAggregationInterval  = time.Minute
+	defaultAggregationMaxGroups = 10_000
 )
 
+var errSendRawMetrics = errors.New("send_raw is not supported by the metrics receiver")
+
 // NewFactory creates a factory for netflow receiver.
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
 }
 
 // Config defines configuration for netflow receiver.
 // By default we listen for netflow traffic on port 2055
 func createDefaultConfig() component.Config {
 	return &Config{
-		Scheme:    "netflow",
+		Scheme:    schemeNetflow,
 		Port:      2055,
 		Sockets:   defaultSockets,
 		Workers:   defaultWorkers,
 		QueueSize: defaultQueueSize,
+		Aggregation: AggregationConfig{
+			Interval:  defaultAggregationInterval,
+			MaxGroups: defaultAggregationMaxGroups,
+			Keys: []string{
+				string(semconv.SourceAddressKey),
+				string(semconv.DestinationAddressKey),
+				string(semconv.DestinationPortKey),
+				string(semconv.NetworkTransportKey),
+			},
+		},
 	}
 }
 
@@ -46,12 +66,47 @@ func createDefaultConfig() component.Config {
 // We also create the UDP receiver, which is the piece of software that actually listens
 // for incoming netflow traffic on an UDP port.
 func createLogsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
-	conf := *(cfg.(*Config))
+	r, err := getOrCreateReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Unwrap().(*netflowReceiver).logConsumer = consumer
+	return r, nil
+}
 
-	nr, err := newNetflowLogsReceiver(params, conf, consumer)
+// createMetricsReceiver creates a netflow receiver sending the bytes and packets of the flows
+// aggregated by the configured attributes.
+func createMetricsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+	if cfg.(*Config).SendRaw {
+		return nil, errSendRawMetrics
+	}
+
+	r, err := getOrCreateReceiver(params, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return nr, nil
+	r.Unwrap().(*netflowReceiver).metricsConsumer = consumer
+	return r, nil
 }
+
+// getOrCreateReceiver returns the receiver of the config, which is shared by the logs and metrics
+// receivers to listen only once on the UDP port.
+func getOrCreateReceiver(params receiver.Settings, cfg component.Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var nr *netflowReceiver
+		nr, err = newNetflowReceiver(params, *(cfg.(*Config)))
+		return nr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// This is the map of already created netflow receivers for particular configurations.
+// The logs and metrics receivers of a configuration must use the same netflowReceiver,
+// as the UDP port can only be listened on once.
+var receivers = sharedcomponent.NewSharedComponents()
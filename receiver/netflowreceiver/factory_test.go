@@ -30,3 +30,16 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, receiver, "receiver creation failed")
 }
+
+func TestCreateMetricsReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := receivertest.NewNopSettings(metadata.Type)
+	receiver, err := factory.CreateMetrics(context.Background(), set, cfg, consumertest.NewNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.NotNil(t, receiver, "receiver creation failed")
+
+	cfg.(*Config).SendRaw = true
+	_, err = factory.CreateMetrics(context.Background(), set, cfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, errSendRawMetrics)
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"fmt"
+
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ipfixFieldsIndex is the protobuf field number of the first configured IPFIX field. The fields which are
+// not part of the flow message are stored as unknown protobuf fields by the GoFlow2 proto producer,
+// so their numbers must not collide with the flow message ones.
+const ipfixFieldsIndex = 1000
+
+// newProducerConfig returns the proto producer configuration decoding the configured IPFIX fields
+func newProducerConfig(fields []IPFIXFieldConfig) *protoproducer.ProducerConfig {
+	cfgProducer := &protoproducer.ProducerConfig{}
+	for i, field := range fields {
+		destination := fmt.Sprintf("ipfix_field_%d", i)
+		protoType := "bytes"
+		if field.Type == ipfixFieldTypeInt {
+			protoType = "varint"
+		}
+
+		cfgProducer.Formatter.Protobuf = append(cfgProducer.Formatter.Protobuf, protoproducer.ProtobufFormatterConfig{
+			Name:  destination,
+			Index: int32(ipfixFieldsIndex + i),
+			Type:  protoType,
+		})
+		cfgProducer.IPFIX.Mapping = append(cfgProducer.IPFIX.Mapping, protoproducer.NetFlowMapField{
+			PenProvided: field.PEN != 0,
+			Pen:         field.PEN,
+			Type:        field.ID,
+			Destination: destination,
+		})
+	}
+	return cfgProducer
+}
+
+// putIPFIXFieldAttributes adds the values of the configured IPFIX fields found in the message to the attributes
+func putIPFIXFieldAttributes(pm *protoproducer.ProtoProducerMessage, fields []IPFIXFieldConfig, attrs pcommon.Map) error {
+	unknown := pm.ProtoReflect().GetUnknown()
+	for len(unknown) > 0 {
+		num, wireType, length := protowire.ConsumeTag(unknown)
+		if length < 0 {
+			return protowire.ParseError(length)
+		}
+		unknown = unknown[length:]
+
+		i := int(num) - ipfixFieldsIndex
+		if i < 0 || i >= len(fields) {
+			length = protowire.ConsumeFieldValue(num, wireType, unknown)
+			if length < 0 {
+				return protowire.ParseError(length)
+			}
+			unknown = unknown[length:]
+			continue
+		}
+
+		field := fields[i]
+		switch wireType {
+		case protowire.VarintType:
+			value, n := protowire.ConsumeVarint(unknown)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			attrs.PutInt(field.Name, int64(value))
+			length = n
+		case protowire.BytesType:
+			value, n := protowire.ConsumeBytes(unknown)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if field.Type == ipfixFieldTypeString {
+				attrs.PutStr(field.Name, string(value))
+			} else {
+				attrs.PutEmptyBytes(field.Name).FromRaw(value)
+			}
+			length = n
+		default:
+			return fmt.Errorf("unexpected wire type %d for ipfix field %q", wireType, field.Name)
+		}
+		unknown = unknown[length:]
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"testing"
+
+	"github.com/netsampler/goflow2/v2/decoders/netflow"
+	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestIPFIXFields(t *testing.T) {
+	fields := []IPFIXFieldConfig{
+		{Name: "application.id", ID: 95},
+		{Name: "vendor.app.id", PEN: 9999, ID: 1, Type: "int"},
+		{Name: "vendor.app.name", PEN: 9999, ID: 2, Type: "string"},
+		{Name: "vendor.missing", PEN: 9999, ID: 3, Type: "string"},
+	}
+	cfgm, err := newProducerConfig(fields).Compile()
+	require.NoError(t, err)
+	protoProducer, err := protoproducer.CreateProtoProducer(cfgm, protoproducer.CreateSamplingSystem)
+	require.NoError(t, err)
+
+	message := &netflow.IPFIXPacket{
+		Version: 10,
+		FlowSets: []any{
+			netflow.DataFlowSet{
+				FlowSetHeader: netflow.FlowSetHeader{Id: 256},
+				Records: []netflow.DataRecord{
+					{
+						Values: []netflow.DataField{
+							{Type: 2, Value: []byte{0x00, 0x00, 0x00, 0x05}},
+							{Type: 95, Value: []byte{0x03, 0x00, 0x00, 0x50}},
+							{PenProvided: true, Pen: 9999, Type: 1, Value: []byte{0x00, 0x2a}},
+							{PenProvided: true, Pen: 9999, Type: 2, Value: []byte("hello")},
+							// A field of another enterprise with the same identifier is ignored
+							{PenProvided: true, Pen: 1234, Type: 1, Value: []byte{0x00, 0x01}},
+						},
+					},
+				},
+			},
+		},
+	}
+	messages, err := protoProducer.Produce(message, &producer.ProduceArgs{})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	pm, ok := messages[0].(*protoproducer.ProtoProducerMessage)
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), pm.Packets)
+
+	attrs := pcommon.NewMap()
+	require.NoError(t, putIPFIXFieldAttributes(pm, fields, attrs))
+	assert.Equal(t, map[string]any{
+		"application.id":  []byte{0x03, 0x00, 0x00, 0x50},
+		"vendor.app.id":   int64(42),
+		"vendor.app.name": "hello",
+	}, attrs.AsRaw())
+}
@@ -15,6 +15,11 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
 )
 
+const (
+	attributeFlowBytes   = "flow.io.bytes"
+	attributeFlowPackets = "flow.io.packets"
+)
+
 var (
 
 	// https://www.iana.org/assignments/ieee-802-numbers/ieee-802-numbers.xhtml#ieee-802-numbers-1
@@ -214,44 +219,67 @@ func getFlowTypeName(flowType int32) string {
 
 // addMessageAttributes parses the message attributes and adds them to the log record
 func addMessageAttributes(m producer.ProducerMessage, r *plog.LogRecord) error {
+	pm, err := toProtoProducerMessage(m)
+	if err != nil {
+		return err
+	}
+
+	// Time the receiver received the message
+	receivedTime := time.Unix(0, int64(pm.TimeReceivedNs))
+	startTime := time.Unix(0, int64(pm.TimeFlowStartNs))
+
+	r.SetObservedTimestamp(pcommon.NewTimestampFromTime(receivedTime))
+	r.SetTimestamp(pcommon.NewTimestampFromTime(startTime))
+
+	putFlowAttributes(pm, r.Attributes())
+	return nil
+}
+
+// putMessageAttributes parses the message attributes and adds them to the attributes map
+func putMessageAttributes(m producer.ProducerMessage, attrs pcommon.Map) error {
+	pm, err := toProtoProducerMessage(m)
+	if err != nil {
+		return err
+	}
+	putFlowAttributes(pm, attrs)
+	return nil
+}
+
+// toProtoProducerMessage returns the flow message as a ProtoProducerMessage
+func toProtoProducerMessage(m producer.ProducerMessage) (*protoproducer.ProtoProducerMessage, error) {
 	// we know msg is ProtoProducerMessage because that is the parent producer
 	pm, ok := m.(*protoproducer.ProtoProducerMessage)
 	if !ok {
-		return errors.New("this flow message is not ProtoProducerMessage, this is not expected")
+		return nil, errors.New("this flow message is not ProtoProducerMessage, this is not expected")
 	}
+	return pm, nil
+}
 
+// putFlowAttributes parses the message attributes and adds them to the attributes map
+func putFlowAttributes(pm *protoproducer.ProtoProducerMessage, attrs pcommon.Map) {
 	// Parse IP addresses bytes to netip.Addr
 	srcAddr, _ := netip.AddrFromSlice(pm.SrcAddr)
 	dstAddr, _ := netip.AddrFromSlice(pm.DstAddr)
 	samplerAddr, _ := netip.AddrFromSlice(pm.SamplerAddress)
 
-	// Time the receiver received the message
-	receivedTime := time.Unix(0, int64(pm.TimeReceivedNs))
-	startTime := time.Unix(0, int64(pm.TimeFlowStartNs))
-
-	r.SetObservedTimestamp(pcommon.NewTimestampFromTime(receivedTime))
-	r.SetTimestamp(pcommon.NewTimestampFromTime(startTime))
-
 	// Source and destination attributes
-	r.Attributes().PutStr(string(semconv.SourceAddressKey), srcAddr.String())
-	r.Attributes().PutInt(string(semconv.SourcePortKey), int64(pm.SrcPort))
-	r.Attributes().PutStr(string(semconv.DestinationAddressKey), dstAddr.String())
-	r.Attributes().PutInt(string(semconv.DestinationPortKey), int64(pm.DstPort))
+	attrs.PutStr(string(semconv.SourceAddressKey), srcAddr.String())
+	attrs.PutInt(string(semconv.SourcePortKey), int64(pm.SrcPort))
+	attrs.PutStr(string(semconv.DestinationAddressKey), dstAddr.String())
+	attrs.PutInt(string(semconv.DestinationPortKey), int64(pm.DstPort))
 
 	// Network attributes
-	r.Attributes().PutStr(string(semconv.NetworkTransportKey), getTransportName(pm.Proto))
-	r.Attributes().PutStr(string(semconv.NetworkTypeKey), getEtypeName(pm.Etype))
+	attrs.PutStr(string(semconv.NetworkTransportKey), getTransportName(pm.Proto))
+	attrs.PutStr(string(semconv.NetworkTypeKey), getEtypeName(pm.Etype))
 
 	// There is no semconv as of today for these
-	r.Attributes().PutInt("flow.io.bytes", int64(pm.Bytes))
-	r.Attributes().PutInt("flow.io.packets", int64(pm.Packets))
-	r.Attributes().PutStr("flow.type", getFlowTypeName(int32(pm.Type)))
-	r.Attributes().PutInt("flow.sequence_num", int64(pm.SequenceNum))
-	r.Attributes().PutInt("flow.time_received", int64(pm.TimeReceivedNs))
-	r.Attributes().PutInt("flow.start", int64(pm.TimeFlowStartNs))
-	r.Attributes().PutInt("flow.end", int64(pm.TimeFlowEndNs))
-	r.Attributes().PutInt("flow.sampling_rate", int64(pm.SamplingRate))
-	r.Attributes().PutStr("flow.sampler_address", samplerAddr.String())
-
-	return nil
+	attrs.PutInt(attributeFlowBytes, int64(pm.Bytes))
+	attrs.PutInt(attributeFlowPackets, int64(pm.Packets))
+	attrs.PutStr("flow.type", getFlowTypeName(int32(pm.Type)))
+	attrs.PutInt("flow.sequence_num", int64(pm.SequenceNum))
+	attrs.PutInt("flow.time_received", int64(pm.TimeReceivedNs))
+	attrs.PutInt("flow.start", int64(pm.TimeFlowStartNs))
+	attrs.PutInt("flow.end", int64(pm.TimeFlowEndNs))
+	attrs.PutInt("flow.sampling_rate", int64(pm.SamplingRate))
+	attrs.PutStr("flow.sampler_address", samplerAddr.String())
 }
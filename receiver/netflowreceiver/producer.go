@@ -9,22 +9,27 @@ import (
 
 	"github.com/netsampler/goflow2/v2/producer"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
-// otelLogsProducerWrapper is a wrapper around a producer.ProducerInterface that sends the messages to a log consumer
-type otelLogsProducerWrapper struct {
+// otelProducerWrapper is a wrapper around a producer.ProducerInterface that sends the messages to a log consumer,
+// and adds them to the aggregator of the metrics
+type otelProducerWrapper struct {
 	wrapped     producer.ProducerInterface
 	logConsumer consumer.Logs
+	aggregator  *flowAggregator
+	enricher    *flowEnricher
+	ipfixFields []IPFIXFieldConfig
 	logger      *zap.Logger
 	sendRaw     bool
 }
 
 // Produce converts the message into a list log records and sends them to log consumer
-func (o *otelLogsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) ([]producer.ProducerMessage, error) {
+func (o *otelProducerWrapper) Produce(msg any, args *producer.ProduceArgs) ([]producer.ProducerMessage, error) {
 	defer func() {
 		if pErr := recover(); pErr != nil {
 			errMessage, _ := pErr.(string)
@@ -48,15 +53,30 @@ func (o *otelLogsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) (
 
 	// A single netflow packet can contain multiple flow messages
 	for _, msg := range flowMessageSet {
-		logRecord := logRecords.AppendEmpty()
 		if o.sendRaw {
-			logRecord.Body().SetStr(fmt.Sprintf("%+v", msg))
-		} else {
+			logRecords.AppendEmpty().Body().SetStr(fmt.Sprintf("%+v", msg))
+			continue
+		}
+
+		var attrs pcommon.Map
+		if o.logConsumer != nil {
 			// Parse the message and add the attributes to the log record
+			logRecord := logRecords.AppendEmpty()
 			err = addMessageAttributes(msg, &logRecord)
-			if err != nil {
-				o.logger.Error("error adding message attributes", zap.Error(err))
-			}
+			attrs = logRecord.Attributes()
+		} else {
+			attrs = pcommon.NewMap()
+			err = putMessageAttributes(msg, attrs)
+		}
+		if err == nil {
+			err = o.putAdditionalAttributes(msg, attrs)
+		}
+		if err != nil {
+			o.logger.Error("error adding message attributes", zap.Error(err))
+		}
+
+		if o.aggregator != nil {
+			o.aggregator.add(attrs)
 		}
 	}
 
@@ -64,6 +84,10 @@ func (o *otelLogsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) (
 		o.logger.Info("received a packet with no flow messages from", zap.String("agent", args.SamplerAddress.String()))
 	}
 
+	if o.logConsumer == nil {
+		return flowMessageSet, nil
+	}
+
 	err = o.logConsumer.ConsumeLogs(context.Background(), log)
 	if err != nil {
 		return flowMessageSet, err
@@ -72,19 +96,43 @@ func (o *otelLogsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) (
 	return flowMessageSet, nil
 }
 
-func (o *otelLogsProducerWrapper) Close() {
+// putAdditionalAttributes adds the configured IPFIX fields and the enrichment attributes
+func (o *otelProducerWrapper) putAdditionalAttributes(msg producer.ProducerMessage, attrs pcommon.Map) error {
+	pm, err := toProtoProducerMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	if len(o.ipfixFields) > 0 {
+		if err := putIPFIXFieldAttributes(pm, o.ipfixFields, attrs); err != nil {
+			return err
+		}
+	}
+
+	if o.enricher != nil {
+		return o.enricher.enrich(context.Background(), pm, attrs)
+	}
+	return nil
+}
+
+func (o *otelProducerWrapper) Close() {
 	o.wrapped.Close()
 }
 
-func (o *otelLogsProducerWrapper) Commit(flowMessageSet []producer.ProducerMessage) {
+func (o *otelProducerWrapper) Commit(flowMessageSet []producer.ProducerMessage) {
 	o.wrapped.Commit(flowMessageSet)
 }
 
-func newOtelLogsProducer(wrapped producer.ProducerInterface, logConsumer consumer.Logs, logger *zap.Logger, sendRaw bool) producer.ProducerInterface {
-	return &otelLogsProducerWrapper{
+// newOtelProducer creates the producer wrapper, the log consumer and the aggregator are nil when
+// there is no logs or metrics pipeline respectively
+func newOtelProducer(wrapped producer.ProducerInterface, cfg *Config, logConsumer consumer.Logs, aggregator *flowAggregator, enricher *flowEnricher, logger *zap.Logger) producer.ProducerInterface {
+	return &otelProducerWrapper{
 		wrapped:     wrapped,
 		logConsumer: logConsumer,
+		aggregator:  aggregator,
+		enricher:    enricher,
+		ipfixFields: cfg.IPFIX.Fields,
 		logger:      logger,
-		sendRaw:     sendRaw,
+		sendRaw:     cfg.SendRaw,
 	}
 }
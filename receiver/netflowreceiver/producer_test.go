@@ -57,7 +57,7 @@ func TestProduce(t *testing.T) {
 	protoProducer, err := protoproducer.CreateProtoProducer(cfgm, protoproducer.CreateSamplingSystem)
 	require.NoError(t, err)
 
-	otelLogsProducer := newOtelLogsProducer(protoProducer, consumertest.NewNop(), zap.NewNop(), false)
+	otelLogsProducer := newOtelProducer(protoProducer, &Config{}, consumertest.NewNop(), nil, nil, zap.NewNop())
 	messages, err := otelLogsProducer.Produce(message, &producer.ProduceArgs{})
 	require.NoError(t, err)
 	require.NotNil(t, messages)
@@ -130,7 +130,7 @@ func TestProduceRaw(t *testing.T) {
 	require.NoError(t, err)
 
 	sink := &consumertest.LogsSink{}
-	otelLogsProducer := newOtelLogsProducer(protoProducer, sink, zap.NewNop(), true)
+	otelLogsProducer := newOtelProducer(protoProducer, &Config{SendRaw: true}, sink, nil, nil, zap.NewNop())
 
 	messages, err := otelLogsProducer.Produce(message, &producer.ProduceArgs{})
 	require.NoError(t, err)
@@ -169,8 +169,8 @@ func TestProducerPanic(t *testing.T) {
 	// Create a mock consumer
 	mockConsumer := consumertest.NewNop()
 
-	// Wrap a panicProducer (instead of ProtoProducer) in the otelLogsProducerWrapper
-	wrapper := newOtelLogsProducer(&panicProducer{}, mockConsumer, logger, false)
+	// Wrap a panicProducer (instead of ProtoProducer) in the otelProducerWrapper
+	wrapper := newOtelProducer(&panicProducer{}, &Config{}, mockConsumer, nil, nil, logger)
 
 	// Call Produce which should recover from panic
 	messages, err := wrapper.Produce(nil, &producer.ProduceArgs{
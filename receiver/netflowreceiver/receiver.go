@@ -5,9 +5,12 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/netsampler/goflow2/v2/decoders/netflow"
 	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
@@ -16,8 +19,15 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/geoip/provider"
 )
 
+// ipfixVersion is the version number in the header of the IPFIX packets
+const ipfixVersion = 10
+
+var errNotIPFIX = errors.New("received a packet which is not an IPFIX packet")
+
 var _ utils.ReceiverCallback = (*dropHandler)(nil)
 
 type dropHandler struct {
@@ -29,13 +39,19 @@ func (d dropHandler) Dropped(msg utils.Message) {
 }
 
 type netflowReceiver struct {
-	config      Config
-	logger      *zap.Logger
-	udpReceiver *utils.UDPReceiver
-	logConsumer consumer.Logs
+	config          Config
+	settings        receiver.Settings
+	logger          *zap.Logger
+	udpReceiver     *utils.UDPReceiver
+	logConsumer     consumer.Logs
+	metricsConsumer consumer.Metrics
+	aggregator      *flowAggregator
+	enricher        *flowEnricher
+	shutdownWG      sync.WaitGroup
+	done            chan struct{}
 }
 
-func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
+func newNetflowReceiver(params receiver.Settings, cfg Config) (*netflowReceiver, error) {
 	// UDP receiver configuration
 	udpCfg := &utils.UDPReceiverConfig{
 		Sockets:   cfg.Sockets,
@@ -53,15 +69,28 @@ func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consu
 
 	nr := &netflowReceiver{
 		logger:      params.Logger,
+		settings:    params,
 		config:      cfg,
-		logConsumer: consumer,
 		udpReceiver: udpReceiver,
 	}
 
 	return nr, nil
 }
 
-func (nr *netflowReceiver) Start(_ context.Context, _ component.Host) error {
+func (nr *netflowReceiver) Start(ctx context.Context, _ component.Host) error {
+	nr.enricher = &flowEnricher{portNames: nr.config.Enrichment.PortNames}
+	if nr.config.Enrichment.GeoIP != nil {
+		providers, err := provider.CreateGeoIPProviders(ctx, nr.settings.TelemetrySettings, nr.config.Enrichment.GeoIP.Providers, geoIPProviderFactories)
+		if err != nil {
+			return fmt.Errorf("failed to create the geoIP providers: %w", err)
+		}
+		nr.enricher.geoIPProviders = providers
+	}
+
+	if nr.metricsConsumer != nil {
+		nr.aggregator = newFlowAggregator(nr.config.Aggregation.Keys, nr.config.Aggregation.MaxGroups)
+	}
+
 	// The function that will decode packets
 	decodeFunc, err := nr.buildDecodeFunc()
 	if err != nil {
@@ -74,12 +103,19 @@ func (nr *netflowReceiver) Start(_ context.Context, _ component.Host) error {
 	}
 
 	// This runs until the receiver is stoppped, consuming from an error channel
+	nr.done = make(chan struct{})
+	nr.shutdownWG.Add(1)
 	go nr.handleErrors()
 
+	if nr.aggregator != nil {
+		nr.shutdownWG.Add(1)
+		go nr.sendAggregatedMetrics()
+	}
+
 	return nil
 }
 
-func (nr *netflowReceiver) Shutdown(context.Context) error {
+func (nr *netflowReceiver) Shutdown(ctx context.Context) error {
 	if nr.udpReceiver == nil {
 		return nil
 	}
@@ -87,15 +123,54 @@ func (nr *netflowReceiver) Shutdown(context.Context) error {
 	if err != nil {
 		nr.logger.Warn("Error stopping UDP receiver", zap.Error(err))
 	}
+
+	// Stop the error handler, and send the flows aggregated since the last interval
+	if nr.done != nil {
+		close(nr.done)
+		nr.shutdownWG.Wait()
+		nr.done = nil
+	}
+
+	if nr.enricher != nil {
+		return nr.enricher.close(ctx)
+	}
 	return nil
 }
 
+// sendAggregatedMetrics sends the aggregated flows at every interval, and when the receiver is stopped
+func (nr *netflowReceiver) sendAggregatedMetrics() {
+	defer nr.shutdownWG.Done()
+
+	ticker := time.NewTicker(nr.config.Aggregation.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			nr.flushAggregatedMetrics(now)
+		case <-nr.done:
+			nr.flushAggregatedMetrics(time.Now())
+			return
+		}
+	}
+}
+
+func (nr *netflowReceiver) flushAggregatedMetrics(now time.Time) {
+	metrics, ok := nr.aggregator.flush(now)
+	if !ok {
+		return
+	}
+	if err := nr.metricsConsumer.ConsumeMetrics(context.Background(), metrics); err != nil {
+		nr.logger.Error("error sending the aggregated flow metrics", zap.Error(err))
+	}
+}
+
 // buildDecodeFunc creates a decode function based on the scheme
 // This is the fuction that will be invoked for every netflow packet received
-// The function depends on the type of schema (netflow, sflow, flow)
+// The function depends on the type of schema (netflow, sflow, ipfix)
 func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
-	// Eventually this can be used to configure mappings
-	cfgProducer := &protoproducer.ProducerConfig{}
+	// The configured IPFIX fields are mapped to protobuf fields of the flow messages
+	cfgProducer := newProducerConfig(nr.config.IPFIX.Fields)
 	cfgm, err := cfgProducer.Compile() // converts configuration into a format that can be used by a protobuf producer
 	if err != nil {
 		return nil, err
@@ -106,30 +181,53 @@ func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
 		return nil, err
 	}
 
-	// the otel log producer converts those messages into OpenTelemetry logs
+	// the otel producer converts those messages into OpenTelemetry logs and aggregated metrics
 	// it is a wrapper around the protobuf producer
-	otelLogsProducer := newOtelLogsProducer(protoProducer, nr.logConsumer, nr.logger, nr.config.SendRaw)
+	otelProducer := newOtelProducer(protoProducer, &nr.config, nr.logConsumer, nr.aggregator, nr.enricher, nr.logger)
 
 	cfgPipe := &utils.PipeConfig{
-		Producer: otelLogsProducer,
+		Producer: otelProducer,
 	}
 
-	var p utils.FlowPipe
 	switch nr.config.Scheme {
-	case "sflow":
-		p = utils.NewSFlowPipe(cfgPipe)
-	case "netflow":
-		p = utils.NewNetFlowPipe(cfgPipe)
+	case schemeSflow:
+		return utils.NewSFlowPipe(cfgPipe).DecodeFlow, nil
+	case schemeNetflow:
+		return utils.NewNetFlowPipe(cfgPipe).DecodeFlow, nil
+	case schemeIPFIX:
+		return ipfixOnly(utils.NewNetFlowPipe(cfgPipe).DecodeFlow), nil
 	default:
 		return nil, fmt.Errorf("scheme does not exist: %s", nr.config.Scheme)
 	}
-	return p.DecodeFlow, nil
+}
+
+// ipfixOnly wraps the netflow decode function to reject the packets which are not IPFIX packets,
+// the netflow pipe decoding all of the Netflow V5, V9 and IPFIX packets
+func ipfixOnly(decodeFunc utils.DecoderFunc) utils.DecoderFunc {
+	return func(msg any) error {
+		if pkt, ok := msg.(*utils.Message); ok {
+			if len(pkt.Payload) < 2 || binary.BigEndian.Uint16(pkt.Payload) != ipfixVersion {
+				return fmt.Errorf("%w from %s", errNotIPFIX, pkt.Src)
+			}
+		}
+		return decodeFunc(msg)
+	}
 }
 
 // handleErrors handles errors from the listener
 // We don't want the receiver to stop if there is an error processing a packet
+// The error channel is never closed, so the handler also stops when the receiver is shut down
 func (nr *netflowReceiver) handleErrors() {
-	for err := range nr.udpReceiver.Errors() {
+	defer nr.shutdownWG.Done()
+
+	for {
+		var err error
+		select {
+		case <-nr.done:
+			return
+		case err = <-nr.udpReceiver.Errors():
+		}
+
 		switch {
 		case errors.Is(err, net.ErrClosed):
 			nr.logger.Info("UDP receiver closed, exiting error handler")
@@ -4,13 +4,22 @@
 package netflowreceiver
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"net"
+	"net/netip"
 	"testing"
+	"time"
 
+	"github.com/netsampler/goflow2/v2/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -21,5 +30,139 @@ func TestCreateValidDefaultReceiver(t *testing.T) {
 	receiver, err := factory.CreateLogs(context.Background(), set, cfg, consumertest.NewNop())
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, receiver, "receiver creation failed")
-	assert.NotNil(t, receiver.(*netflowReceiver).udpReceiver)
+	assert.NotNil(t, receiver.(*sharedcomponent.SharedComponent).Unwrap().(*netflowReceiver).udpReceiver)
+}
+
+func TestIPFIXReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Scheme = "ipfix"
+	cfg.Hostname = "127.0.0.1"
+	cfg.Port = availableUDPPort(t)
+	cfg.Workers = 1
+	cfg.IPFIX.Fields = []IPFIXFieldConfig{
+		{Name: "vendor.app.id", PEN: 9999, ID: 1, Type: "int"},
+		{Name: "vendor.app.name", PEN: 9999, ID: 2, Type: "string"},
+	}
+	cfg.Enrichment.PortNames = true
+	cfg.Aggregation.Keys = []string{"destination.address", "flow.destination.port_name", "vendor.app.name"}
+
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	set := receivertest.NewNopSettings(metadata.Type)
+	logsReceiver, err := factory.CreateLogs(context.Background(), set, cfg, logsSink)
+	require.NoError(t, err)
+	metricsReceiver, err := factory.CreateMetrics(context.Background(), set, cfg, metricsSink)
+	require.NoError(t, err)
+	assert.Same(t, logsReceiver, metricsReceiver)
+
+	require.NoError(t, logsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, metricsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := net.Dial("udp", netip.AddrPortFrom(netip.MustParseAddr("127.0.0.1"), uint16(cfg.Port)).String())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write(ipfixPacket())
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	attrs := logsSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, "10.0.0.1", attrs["source.address"])
+	assert.Equal(t, "10.0.0.2", attrs["destination.address"])
+	assert.Equal(t, int64(443), attrs["destination.port"])
+	assert.Equal(t, "tcp", attrs["network.transport"])
+	assert.Equal(t, "ipfix", attrs["flow.type"])
+	assert.Equal(t, int64(1500), attrs["flow.io.bytes"])
+	assert.Equal(t, int64(3), attrs["flow.io.packets"])
+	assert.Equal(t, "https", attrs["flow.destination.port_name"])
+	assert.NotContains(t, attrs, "flow.source.port_name")
+	assert.Equal(t, int64(42), attrs["vendor.app.id"])
+	assert.Equal(t, "hello", attrs["vendor.app.name"])
+
+	// The aggregated flows are sent when the receiver is stopped
+	require.NoError(t, logsReceiver.Shutdown(context.Background()))
+	require.NoError(t, metricsReceiver.Shutdown(context.Background()))
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	metrics := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "flow.io.bytes", metrics.At(0).Name())
+	assert.Equal(t, int64(1500), metrics.At(0).Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]any{
+		"destination.address":        "10.0.0.2",
+		"flow.destination.port_name": "https",
+		"vendor.app.name":            "hello",
+	}, metrics.At(0).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, "flow.io.packets", metrics.At(1).Name())
+	assert.Equal(t, int64(3), metrics.At(1).Sum().DataPoints().At(0).IntValue())
+}
+
+func TestIPFIXOnly(t *testing.T) {
+	var decoded int
+	decodeFunc := ipfixOnly(func(any) error {
+		decoded++
+		return nil
+	})
+
+	src := netip.MustParseAddrPort("127.0.0.1:4739")
+	assert.NoError(t, decodeFunc(&utils.Message{Src: src, Payload: ipfixPacket()}))
+	assert.ErrorIs(t, decodeFunc(&utils.Message{Src: src, Payload: []byte{0x00, 0x09, 0x00, 0x01}}), errNotIPFIX)
+	assert.ErrorIs(t, decodeFunc(&utils.Message{Src: src, Payload: []byte{0x00}}), errNotIPFIX)
+	assert.Equal(t, 1, decoded)
+}
+
+func availableUDPPort(t *testing.T) int {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+// ipfixPacket returns an IPFIX packet with a template and a data record using it,
+// including two enterprise-specific fields
+func ipfixPacket() []byte {
+	type field struct {
+		id     uint16
+		length uint16
+		pen    uint32
+		value  any
+	}
+	fields := []field{
+		{id: 8, length: 4, value: [4]byte{10, 0, 0, 1}},  // sourceIPv4Address
+		{id: 12, length: 4, value: [4]byte{10, 0, 0, 2}}, // destinationIPv4Address
+		{id: 7, length: 2, value: uint16(52000)},         // sourceTransportPort
+		{id: 11, length: 2, value: uint16(443)},          // destinationTransportPort
+		{id: 4, length: 1, value: uint8(6)},              // protocolIdentifier
+		{id: 1, length: 8, value: uint64(1500)},          // octetDeltaCount
+		{id: 2, length: 8, value: uint64(3)},             // packetDeltaCount
+		{id: 1, length: 4, pen: 9999, value: uint32(42)},
+		{id: 2, length: 5, pen: 9999, value: [5]byte{'h', 'e', 'l', 'l', 'o'}},
+	}
+
+	var template, data bytes.Buffer
+	_ = binary.Write(&template, binary.BigEndian, []uint16{256, uint16(len(fields))})
+	for _, f := range fields {
+		if f.pen != 0 {
+			_ = binary.Write(&template, binary.BigEndian, []uint16{f.id | 0x8000, f.length})
+			_ = binary.Write(&template, binary.BigEndian, f.pen)
+		} else {
+			_ = binary.Write(&template, binary.BigEndian, []uint16{f.id, f.length})
+		}
+		_ = binary.Write(&data, binary.BigEndian, f.value)
+	}
+
+	var sets bytes.Buffer
+	_ = binary.Write(&sets, binary.BigEndian, []uint16{2, uint16(4 + template.Len())})
+	sets.Write(template.Bytes())
+	_ = binary.Write(&sets, binary.BigEndian, []uint16{256, uint16(4 + data.Len())})
+	sets.Write(data.Bytes())
+
+	var packet bytes.Buffer
+	_ = binary.Write(&packet, binary.BigEndian, []uint16{ipfixVersion, uint16(16 + sets.Len())})
+	_ = binary.Write(&packet, binary.BigEndian, []uint32{uint32(time.Now().Unix()), 1, 0})
+	packet.Write(sets.Bytes())
+	return packet.Bytes()
 }
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package osqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/osqueryreceiver"
+
+import (
+	"context"
+	"time"
+
+	"github.com/osquery/osquery-go"
+)
+
+// defaultSocketOpenTimeout is used to connect to the extensions socket when
+// the receiver has no timeout configured.
+const defaultSocketOpenTimeout = 10 * time.Second
+
+// osqueryClient runs queries on the osquery daemon. It is satisfied by
+// *osquery.ExtensionManagerClient and allows a fake daemon to be used in tests.
+type osqueryClient interface {
+	QueryRowsContext(ctx context.Context, sql string) ([]map[string]string, error)
+	Close()
+}
+
+type newClientFunc func(socket string, timeout time.Duration) (osqueryClient, error)
+
+// newThriftClient connects to the Thrift extensions socket of the osquery daemon.
+func newThriftClient(socket string, timeout time.Duration) (osqueryClient, error) {
+	if timeout <= 0 {
+		timeout = defaultSocketOpenTimeout
+	}
+	client, err := osquery.NewClient(socket, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -5,6 +5,7 @@ package osqueryreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -27,13 +28,50 @@ func createDefaultConfig() component.Config {
 
 type Config struct {
 	scraperhelper.ControllerConfig `mapstructure:",squash"`
-	ExtensionsSocket               string   `mapstructure:"extensions_socket"`
-	Queries                        []string `mapstructure:"queries"`
+	ExtensionsSocket               string        `mapstructure:"extensions_socket"`
+	Queries                        []QueryConfig `mapstructure:"queries"`
+}
+
+// QueryConfig configures a single query run on the osquery daemon.
+// A query may also be configured with a plain string holding the SQL.
+type QueryConfig struct {
+	// Name identifies the query in the emitted log records. Defaults to the SQL of the query.
+	Name string `mapstructure:"name"`
+	// SQL is the query run on the osquery daemon.
+	SQL string `mapstructure:"sql"`
+	// Interval is how often the query is run. Defaults to the collection interval of the receiver.
+	Interval time.Duration `mapstructure:"interval"`
+	// Differential only emits the rows added or removed since the previous run of the query.
+	Differential bool `mapstructure:"differential"`
+}
+
+// UnmarshalText allows a query to be configured with its SQL only.
+func (q *QueryConfig) UnmarshalText(text []byte) error {
+	q.SQL = string(text)
+	return nil
+}
+
+func (q QueryConfig) name() string {
+	if q.Name != "" {
+		return q.Name
+	}
+	return q.SQL
 }
 
 func (c Config) Validate() error {
 	if len(c.Queries) == 0 {
 		return errors.New("queries cannot be empty")
 	}
-	return nil
+	var errs []error
+	for i, q := range c.Queries {
+		if q.SQL == "" {
+			errs = append(errs, fmt.Errorf("queries[%d]: sql cannot be empty", i))
+		}
+		if q.Interval < 0 {
+			errs = append(errs, fmt.Errorf("queries[%d]: interval cannot be negative", i))
+		} else if q.Interval > 0 && q.Interval < c.CollectionInterval {
+			errs = append(errs, fmt.Errorf("queries[%d]: interval %s cannot be shorter than collection_interval %s", i, q.Interval, c.CollectionInterval))
+		}
+	}
+	return errors.Join(errs...)
 }
@@ -4,9 +4,16 @@
 package osqueryreceiver
 
 import (
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/osqueryreceiver/internal/metadata"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -14,6 +21,39 @@ func TestConfig_Validate(t *testing.T) {
 	rc := cfg.(*Config)
 	assert.Error(t, rc.Validate())
 
-	rc.Queries = []string{"select * from certificates"}
+	rc.Queries = []QueryConfig{{SQL: "select * from certificates"}}
 	assert.NoError(t, rc.Validate())
+
+	rc.Queries = []QueryConfig{{Name: "empty"}}
+	assert.EqualError(t, rc.Validate(), "queries[0]: sql cannot be empty")
+
+	rc.Queries = []QueryConfig{{SQL: "select * from certificates", Interval: -time.Second}}
+	assert.EqualError(t, rc.Validate(), "queries[0]: interval cannot be negative")
+
+	rc.Queries = []QueryConfig{{SQL: "select * from certificates", Interval: 10 * time.Second}}
+	assert.EqualError(t, rc.Validate(), "queries[0]: interval 10s cannot be shorter than collection_interval 30s")
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.NoError(t, cfg.(*Config).Validate())
+
+	expected := createDefaultConfig().(*Config)
+	expected.CollectionInterval = 10 * time.Second
+	expected.Queries = []QueryConfig{
+		{SQL: "select * from certificates"},
+		{
+			Name:         "listening_ports",
+			SQL:          "select pid, port, protocol from listening_ports",
+			Interval:     time.Minute,
+			Differential: true,
+		},
+	}
+	assert.Equal(t, expected, cfg)
 }
@@ -4,7 +4,13 @@
 package osqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/osqueryreceiver"
 
 import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/osqueryreceiver/internal/metadata"
 )
@@ -13,5 +19,29 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
 	)
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	rConf component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	cfg := rConf.(*Config)
+
+	oqs := newOsqueryScraper(params, cfg, newThriftClient)
+	s, err := scraper.NewLogs(oqs.scrape, scraper.WithShutdown(oqs.shutdown))
+	if err != nil {
+		return nil, err
+	}
+
+	opt := scraperhelper.AddFactoryWithConfig(
+		scraper.NewFactory(metadata.Type, nil,
+			scraper.WithLogs(func(context.Context, scraper.Settings, component.Config) (scraper.Logs, error) {
+				return s, nil
+			}, component.StabilityLevelDevelopment)), nil)
+
+	return scraperhelper.NewLogsController(&cfg.ControllerConfig, params, consumer, opt)
+}
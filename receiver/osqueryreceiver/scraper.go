@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package osqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/osqueryreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/osqueryreceiver/internal/metadata"
+)
+
+const (
+	queryNameAttribute = "osquery.query.name"
+	actionAttribute    = "osquery.action"
+
+	actionSnapshot = "snapshot"
+	actionAdded    = "added"
+	actionRemoved  = "removed"
+)
+
+type osqueryScraper struct {
+	settings  component.TelemetrySettings
+	cfg       *Config
+	lb        *metadata.LogsBuilder
+	newClient newClientFunc
+	client    osqueryClient
+	queries   []*queryState
+	now       func() time.Time
+}
+
+// queryState tracks the schedule of a query and, in differential mode,
+// the rows returned by its previous run.
+type queryState struct {
+	QueryConfig
+	lastRun  time.Time
+	previous map[string]map[string]string
+}
+
+func newOsqueryScraper(settings receiver.Settings, cfg *Config, newClient newClientFunc) *osqueryScraper {
+	queries := make([]*queryState, 0, len(cfg.Queries))
+	for _, q := range cfg.Queries {
+		queries = append(queries, &queryState{QueryConfig: q})
+	}
+	return &osqueryScraper{
+		settings:  settings.TelemetrySettings,
+		cfg:       cfg,
+		lb:        metadata.NewLogsBuilder(settings),
+		newClient: newClient,
+		queries:   queries,
+		now:       time.Now,
+	}
+}
+
+func (s *osqueryScraper) scrape(ctx context.Context) (plog.Logs, error) {
+	if s.client == nil {
+		client, err := s.newClient(s.cfg.ExtensionsSocket, s.cfg.Timeout)
+		if err != nil {
+			return plog.NewLogs(), fmt.Errorf("failed to connect to osquery extensions socket %q: %w", s.cfg.ExtensionsSocket, err)
+		}
+		s.client = client
+	}
+
+	now := s.now()
+	var errs scrapererror.ScrapeErrors
+	for _, q := range s.queries {
+		if !s.due(q, now) {
+			continue
+		}
+		rows, err := s.client.QueryRowsContext(ctx, q.SQL)
+		if err != nil {
+			s.settings.Logger.Debug("Failed to run osquery query", zap.String("query", q.name()), zap.Error(err))
+			errs.AddPartial(1, fmt.Errorf("failed to run query %q: %w", q.name(), err))
+			// The Thrift transport cannot recover from a broken connection,
+			// reconnect on the next scrape.
+			s.client.Close()
+			s.client = nil
+			break
+		}
+		q.lastRun = now
+		s.record(q, rows, pcommon.NewTimestampFromTime(now))
+	}
+
+	return s.lb.Emit(), errs.Combine()
+}
+
+func (s *osqueryScraper) shutdown(context.Context) error {
+	if s.client != nil {
+		s.client.Close()
+		s.client = nil
+	}
+	return nil
+}
+
+// due reports whether the query should run at now. Half a collection interval
+// is tolerated so that ticker jitter does not delay a query by a whole interval.
+func (s *osqueryScraper) due(q *queryState, now time.Time) bool {
+	if q.lastRun.IsZero() || q.Interval <= s.cfg.CollectionInterval {
+		return true
+	}
+	return now.Sub(q.lastRun)+s.cfg.CollectionInterval/2 >= q.Interval
+}
+
+func (s *osqueryScraper) record(q *queryState, rows []map[string]string, ts pcommon.Timestamp) {
+	if !q.Differential {
+		for _, row := range rows {
+			s.appendRow(q, row, actionSnapshot, ts)
+		}
+		return
+	}
+
+	current := make(map[string]map[string]string, len(rows))
+	for _, row := range rows {
+		current[rowKey(row)] = row
+	}
+	for key, row := range current {
+		if _, ok := q.previous[key]; !ok {
+			s.appendRow(q, row, actionAdded, ts)
+		}
+	}
+	for key, row := range q.previous {
+		if _, ok := current[key]; !ok {
+			s.appendRow(q, row, actionRemoved, ts)
+		}
+	}
+	q.previous = current
+}
+
+func (s *osqueryScraper) appendRow(q *queryState, row map[string]string, action string, ts pcommon.Timestamp) {
+	lr := plog.NewLogRecord()
+	lr.SetTimestamp(ts)
+	lr.SetObservedTimestamp(ts)
+	attrs := lr.Attributes()
+	attrs.EnsureCapacity(len(row) + 2)
+	attrs.PutStr(queryNameAttribute, q.name())
+	attrs.PutStr(actionAttribute, action)
+	for column, value := range row {
+		attrs.PutStr(column, value)
+	}
+	s.lb.AppendLogRecord(lr)
+}
+
+// rowKey identifies a row by all of its columns. encoding/json sorts map
+// keys, so equal rows always produce the same key.
+func rowKey(row map[string]string) string {
+	key, _ := json.Marshal(row)
+	return string(key)
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package osqueryreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/osqueryreceiver/internal/metadata"
+)
+
+// fakeDaemon answers queries with canned rows in place of the osquery daemon.
+type fakeDaemon struct {
+	rows    map[string][]map[string]string
+	err     error
+	queries []string
+	closed  bool
+}
+
+func (f *fakeDaemon) QueryRowsContext(_ context.Context, sql string) ([]map[string]string, error) {
+	f.queries = append(f.queries, sql)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.rows[sql], nil
+}
+
+func (f *fakeDaemon) Close() {
+	f.closed = true
+}
+
+func newTestScraper(cfg *Config, daemon *fakeDaemon) *osqueryScraper {
+	return newOsqueryScraper(receivertest.NewNopSettings(metadata.Type), cfg, func(string, time.Duration) (osqueryClient, error) {
+		return daemon, nil
+	})
+}
+
+type record struct {
+	query  string
+	action string
+	row    map[string]string
+}
+
+func records(t *testing.T, logs plog.Logs) []record {
+	var out []record
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				assert.NotZero(t, lr.Timestamp())
+				attrs := lr.Attributes().AsRaw()
+				r := record{
+					query:  attrs[queryNameAttribute].(string),
+					action: attrs[actionAttribute].(string),
+					row:    map[string]string{},
+				}
+				for key, value := range attrs {
+					if key != queryNameAttribute && key != actionAttribute {
+						r.row[key] = value.(string)
+					}
+				}
+				out = append(out, r)
+			}
+		}
+	}
+	return out
+}
+
+func TestScrapeSnapshot(t *testing.T) {
+	daemon := &fakeDaemon{rows: map[string][]map[string]string{
+		"select * from os_version": {{"name": "Ubuntu", "version": "24.04"}},
+		"select * from users":      {{"username": "root"}, {"username": "otel"}},
+	}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Queries = []QueryConfig{
+		{SQL: "select * from os_version"},
+		{Name: "users", SQL: "select * from users"},
+	}
+	s := newTestScraper(cfg, daemon)
+
+	logs, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []record{
+		{query: "select * from os_version", action: actionSnapshot, row: map[string]string{"name": "Ubuntu", "version": "24.04"}},
+		{query: "users", action: actionSnapshot, row: map[string]string{"username": "root"}},
+		{query: "users", action: actionSnapshot, row: map[string]string{"username": "otel"}},
+	}, records(t, logs))
+
+	require.NoError(t, s.shutdown(context.Background()))
+	assert.True(t, daemon.closed)
+}
+
+func TestScrapeDifferential(t *testing.T) {
+	const sql = "select pid, port from listening_ports"
+	daemon := &fakeDaemon{rows: map[string][]map[string]string{
+		sql: {{"pid": "1", "port": "22"}, {"pid": "2", "port": "80"}},
+	}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Queries = []QueryConfig{{Name: "ports", SQL: sql, Differential: true}}
+	s := newTestScraper(cfg, daemon)
+
+	// The first run reports all rows as added.
+	logs, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []record{
+		{query: "ports", action: actionAdded, row: map[string]string{"pid": "1", "port": "22"}},
+		{query: "ports", action: actionAdded, row: map[string]string{"pid": "2", "port": "80"}},
+	}, records(t, logs))
+
+	// Unchanged rows are not reported again.
+	logs, err = s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, logs.LogRecordCount())
+
+	daemon.rows[sql] = []map[string]string{{"pid": "1", "port": "22"}, {"pid": "3", "port": "443"}}
+	logs, err = s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []record{
+		{query: "ports", action: actionAdded, row: map[string]string{"pid": "3", "port": "443"}},
+		{query: "ports", action: actionRemoved, row: map[string]string{"pid": "2", "port": "80"}},
+	}, records(t, logs))
+}
+
+func TestScrapeQueryInterval(t *testing.T) {
+	daemon := &fakeDaemon{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 10 * time.Second
+	cfg.Queries = []QueryConfig{
+		{SQL: "select * from processes"},
+		{SQL: "select * from certificates", Interval: 30 * time.Second},
+	}
+	s := newTestScraper(cfg, daemon)
+
+	now := time.Unix(1700000000, 0)
+	s.now = func() time.Time { return now }
+	for i := 0; i < 7; i++ {
+		_, err := s.scrape(context.Background())
+		require.NoError(t, err)
+		// Simulate a ticker firing slightly early.
+		now = now.Add(cfg.CollectionInterval - time.Millisecond)
+	}
+
+	var certificates int
+	for _, q := range daemon.queries {
+		if q == "select * from certificates" {
+			certificates++
+		}
+	}
+	assert.Len(t, daemon.queries, 7+certificates)
+	assert.Equal(t, 3, certificates)
+}
+
+func TestScrapeErrors(t *testing.T) {
+	t.Run("connect", func(t *testing.T) {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Queries = []QueryConfig{{SQL: "select * from users"}}
+		s := newOsqueryScraper(receivertest.NewNopSettings(metadata.Type), cfg, func(string, time.Duration) (osqueryClient, error) {
+			return nil, errors.New("dial unix: no such file or directory")
+		})
+		_, err := s.scrape(context.Background())
+		assert.EqualError(t, err, `failed to connect to osquery extensions socket "/var/osquery/osquery.em": dial unix: no such file or directory`)
+		require.NoError(t, s.shutdown(context.Background()))
+	})
+
+	t.Run("query", func(t *testing.T) {
+		daemon := &fakeDaemon{err: errors.New("broken pipe")}
+		cfg := createDefaultConfig().(*Config)
+		cfg.Queries = []QueryConfig{{SQL: "select * from users"}}
+		s := newTestScraper(cfg, daemon)
+
+		_, err := s.scrape(context.Background())
+		assert.True(t, scrapererror.IsPartialScrapeError(err))
+		assert.ErrorContains(t, err, `failed to run query "select * from users": broken pipe`)
+		assert.True(t, daemon.closed)
+		assert.Nil(t, s.client)
+
+		daemon.err = nil
+		_, err = s.scrape(context.Background())
+		require.NoError(t, err)
+	})
+}
@@ -3,8 +3,82 @@
 
 package pprofreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pprofreceiver"
 
-import "go.opentelemetry.io/collector/config/confighttp"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// profilePaths maps the supported profile types to their path under /debug/pprof/.
+var profilePaths = map[string]string{
+	"cpu":          "profile",
+	"heap":         "heap",
+	"allocs":       "allocs",
+	"goroutine":    "goroutine",
+	"mutex":        "mutex",
+	"block":        "block",
+	"threadcreate": "threadcreate",
+}
 
 type Config struct {
-	confighttp.ClientConfig `mapstructure:",squash"`
+	// CollectionInterval is how often the targets are profiled and the files are read.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// Targets are the Go programs exposing the net/http/pprof endpoints.
+	// The endpoint of a target is its base URL, e.g. http://localhost:6060.
+	Targets []confighttp.ClientConfig `mapstructure:"targets"`
+	// Profiles are the profile types pulled from the targets.
+	Profiles []string `mapstructure:"profiles"`
+	// CPUDuration is how long the CPU profile of a target is recorded for.
+	// It is truncated to whole seconds, and must be at least 1s.
+	CPUDuration time.Duration `mapstructure:"cpu_duration"`
+	// Include is a list of glob patterns of pprof files to read.
+	// A file is read again only when it has been modified.
+	Include []string `mapstructure:"include"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.CollectionInterval <= 0 {
+		errs = append(errs, errors.New("collection_interval must be positive"))
+	}
+	if len(cfg.Targets) == 0 && len(cfg.Include) == 0 {
+		errs = append(errs, errors.New("at least one target or include pattern must be specified"))
+	}
+	for i, target := range cfg.Targets {
+		if target.Endpoint == "" {
+			errs = append(errs, fmt.Errorf("targets[%d]: endpoint must be specified", i))
+		} else if u, err := url.Parse(target.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("targets[%d]: invalid endpoint %q", i, target.Endpoint))
+		}
+	}
+	if len(cfg.Targets) > 0 && len(cfg.Profiles) == 0 {
+		errs = append(errs, errors.New("at least one profile type must be specified"))
+	}
+	for _, p := range cfg.Profiles {
+		if _, ok := profilePaths[p]; !ok {
+			errs = append(errs, fmt.Errorf("unknown profile type %q", p))
+		}
+	}
+	if slices.Contains(cfg.Profiles, "cpu") {
+		// The duration is sent to the target in whole seconds, and pprof records 30 seconds when it is 0.
+		if cfg.CPUDuration < time.Second {
+			errs = append(errs, errors.New("cpu_duration must be at least 1s"))
+		} else if cfg.CPUDuration >= cfg.CollectionInterval {
+			errs = append(errs, errors.New("cpu_duration must be shorter than collection_interval"))
+		}
+	}
+	for _, pattern := range cfg.Include {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid include pattern %q: %w", pattern, err))
+		}
+	}
+	return errors.Join(errs...)
 }
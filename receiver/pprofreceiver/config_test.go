@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pprofreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	first := confighttp.ClientConfig{Endpoint: "http://localhost:6060"}
+	second := confighttp.ClientConfig{Endpoint: "https://app.example.com:8443"}
+	second.TLS.InsecureSkipVerify = true
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				CollectionInterval: 30 * time.Second,
+				Targets:            []confighttp.ClientConfig{first, second},
+				Profiles:           []string{"cpu", "heap", "goroutine"},
+				CPUDuration:        5 * time.Second,
+				Include:            []string{"/var/lib/profiles/*.pprof"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	target := func(endpoint string) []confighttp.ClientConfig {
+		cfg := confighttp.NewDefaultClientConfig()
+		cfg.Endpoint = endpoint
+		return []confighttp.ClientConfig{cfg}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) { cfg.Targets = target("http://localhost:6060") },
+		},
+		{
+			name:   "files only",
+			modify: func(cfg *Config) { cfg.Include = []string{"/tmp/*.pprof"} },
+		},
+		{
+			name:        "no sources",
+			modify:      func(*Config) {},
+			expectedErr: "at least one target or include pattern must be specified",
+		},
+		{
+			name: "zero collection interval",
+			modify: func(cfg *Config) {
+				cfg.Targets = target("http://localhost:6060")
+				cfg.CollectionInterval = 0
+			},
+			expectedErr: "collection_interval must be positive",
+		},
+		{
+			name:        "missing endpoint",
+			modify:      func(cfg *Config) { cfg.Targets = target("") },
+			expectedErr: "targets[0]: endpoint must be specified",
+		},
+		{
+			name:        "invalid endpoint",
+			modify:      func(cfg *Config) { cfg.Targets = target("localhost:6060") },
+			expectedErr: `targets[0]: invalid endpoint "localhost:6060"`,
+		},
+		{
+			name: "unknown profile",
+			modify: func(cfg *Config) {
+				cfg.Targets = target("http://localhost:6060")
+				cfg.Profiles = []string{"heap", "trace"}
+			},
+			expectedErr: `unknown profile type "trace"`,
+		},
+		{
+			name: "no profiles",
+			modify: func(cfg *Config) {
+				cfg.Targets = target("http://localhost:6060")
+				cfg.Profiles = nil
+			},
+			expectedErr: "at least one profile type must be specified",
+		},
+		{
+			name: "cpu duration too long",
+			modify: func(cfg *Config) {
+				cfg.Targets = target("http://localhost:6060")
+				cfg.CPUDuration = cfg.CollectionInterval
+			},
+			expectedErr: "cpu_duration must be shorter than collection_interval",
+		},
+		{
+			name: "cpu duration shorter than a second",
+			modify: func(cfg *Config) {
+				cfg.Targets = target("http://localhost:6060")
+				cfg.CPUDuration = 500 * time.Millisecond
+			},
+			expectedErr: "cpu_duration must be at least 1s",
+		},
+		{
+			name: "cpu duration without cpu profile",
+			modify: func(cfg *Config) {
+				cfg.Targets = target("http://localhost:6060")
+				cfg.Profiles = []string{"heap"}
+				cfg.CPUDuration = 0
+			},
+		},
+		{
+			name:        "invalid include pattern",
+			modify:      func(cfg *Config) { cfg.Include = []string{"[a-"} },
+			expectedErr: `invalid include pattern "[a-"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := xconfmap.Validate(cfg)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -5,10 +5,9 @@ package pprofreceiver // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"context"
-	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/xreceiver"
@@ -25,25 +24,17 @@ func NewFactory() receiver.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		ClientConfig: confighttp.NewDefaultClientConfig(),
+		CollectionInterval: time.Minute,
+		Profiles:           []string{"cpu", "heap", "goroutine", "mutex", "block"},
+		CPUDuration:        10 * time.Second,
 	}
 }
 
 func createProfilesReceiver(
 	_ context.Context,
-	_ receiver.Settings,
-	_ component.Config,
-	_ xconsumer.Profiles,
+	set receiver.Settings,
+	cfg component.Config,
+	consumer xconsumer.Profiles,
 ) (xreceiver.Profiles, error) {
-	return &rcvr{}, errors.New("not implemented")
-}
-
-type rcvr struct{}
-
-func (p rcvr) Start(_ context.Context, _ component.Host) error {
-	return nil
-}
-
-func (p rcvr) Shutdown(_ context.Context) error {
-	return nil
+	return newPprofReceiver(cfg.(*Config), set, consumer), nil
 }
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package translator converts pprof profiles into OTLP profiles.
+package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pprofreceiver/internal/translator"
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/google/pprof/profile"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+// Translator converts pprof profiles into profiles sharing the same
+// pprofile.ProfilesDictionary. Strings, functions and attributes are
+// deduplicated across all the profiles written by a Translator.
+type Translator struct {
+	dict       pprofile.ProfilesDictionary
+	strings    map[string]int32
+	functions  map[functionKey]int32
+	attributes map[attributeKey]int32
+}
+
+type functionKey struct {
+	name, systemName, filename string
+	startLine                  int64
+}
+
+type attributeKey struct {
+	key, value string
+}
+
+// New returns a Translator writing to the given dictionary.
+func New(dict pprofile.ProfilesDictionary) *Translator {
+	t := &Translator{
+		dict:       dict,
+		strings:    map[string]int32{},
+		functions:  map[functionKey]int32{},
+		attributes: map[attributeKey]int32{},
+	}
+	// The first entry of the string table must always be the empty string.
+	t.stringIndex("")
+	return t
+}
+
+// Translate converts src into dst.
+func (t *Translator) Translate(src *profile.Profile, dst pprofile.Profile) error {
+	if err := src.CheckValid(); err != nil {
+		return fmt.Errorf("invalid pprof profile: %w", err)
+	}
+	if len(src.SampleType) == 0 {
+		return errors.New("invalid pprof profile: no sample types")
+	}
+
+	dst.SetProfileID(pprofile.ProfileID(uuid.New()))
+	dst.SetTime(pcommon.Timestamp(src.TimeNanos))
+	dst.SetStartTime(pcommon.Timestamp(src.TimeNanos))
+	dst.SetDuration(pcommon.Timestamp(src.DurationNanos))
+	dst.SetPeriod(src.Period)
+	if src.PeriodType != nil {
+		t.valueType(src.PeriodType, dst.PeriodType())
+	}
+	for _, comment := range src.Comments {
+		dst.CommentStrindices().Append(t.stringIndex(comment))
+	}
+	for i, st := range src.SampleType {
+		t.valueType(st, dst.SampleType().AppendEmpty())
+		if st.Type == src.DefaultSampleType {
+			dst.SetDefaultSampleTypeIndex(int32(i))
+		}
+	}
+
+	mappings := make(map[uint64]int32, len(src.Mapping))
+	for _, m := range src.Mapping {
+		mappings[m.ID] = t.mapping(m)
+	}
+	locations := make(map[uint64]int32, len(src.Location))
+	for _, loc := range src.Location {
+		locations[loc.ID] = t.location(loc, mappings)
+	}
+
+	for _, s := range src.Sample {
+		sample := dst.Sample().AppendEmpty()
+		sample.SetLocationsStartIndex(int32(dst.LocationIndices().Len()))
+		sample.SetLocationsLength(int32(len(s.Location)))
+		for _, loc := range s.Location {
+			dst.LocationIndices().Append(locations[loc.ID])
+		}
+		sample.Value().FromRaw(s.Value)
+		for _, key := range slices.Sorted(maps.Keys(s.Label)) {
+			for _, value := range s.Label[key] {
+				sample.AttributeIndices().Append(t.attributeIndex(key, value))
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(s.NumLabel)) {
+			for _, value := range s.NumLabel[key] {
+				sample.AttributeIndices().Append(t.attributeIndex(key, strconv.FormatInt(value, 10)))
+			}
+		}
+	}
+	return nil
+}
+
+func (t *Translator) valueType(src *profile.ValueType, dst pprofile.ValueType) {
+	dst.SetTypeStrindex(t.stringIndex(src.Type))
+	dst.SetUnitStrindex(t.stringIndex(src.Unit))
+}
+
+func (t *Translator) mapping(src *profile.Mapping) int32 {
+	m := t.dict.MappingTable().AppendEmpty()
+	m.SetMemoryStart(src.Start)
+	m.SetMemoryLimit(src.Limit)
+	m.SetFileOffset(src.Offset)
+	m.SetFilenameStrindex(t.stringIndex(src.File))
+	m.SetHasFunctions(src.HasFunctions)
+	m.SetHasFilenames(src.HasFilenames)
+	m.SetHasLineNumbers(src.HasLineNumbers)
+	m.SetHasInlineFrames(src.HasInlineFrames)
+	if src.BuildID != "" {
+		m.AttributeIndices().Append(t.attributeIndex("process.executable.build_id.gnu", src.BuildID))
+	}
+	return int32(t.dict.MappingTable().Len() - 1)
+}
+
+func (t *Translator) location(src *profile.Location, mappings map[uint64]int32) int32 {
+	loc := t.dict.LocationTable().AppendEmpty()
+	if src.Mapping != nil {
+		loc.SetMappingIndex(mappings[src.Mapping.ID])
+	}
+	loc.SetAddress(src.Address)
+	loc.SetIsFolded(src.IsFolded)
+	for _, l := range src.Line {
+		line := loc.Line().AppendEmpty()
+		if l.Function != nil {
+			line.SetFunctionIndex(t.functionIndex(l.Function))
+		}
+		line.SetLine(l.Line)
+		line.SetColumn(l.Column)
+	}
+	return int32(t.dict.LocationTable().Len() - 1)
+}
+
+func (t *Translator) functionIndex(src *profile.Function) int32 {
+	key := functionKey{name: src.Name, systemName: src.SystemName, filename: src.Filename, startLine: src.StartLine}
+	if idx, ok := t.functions[key]; ok {
+		return idx
+	}
+	fn := t.dict.FunctionTable().AppendEmpty()
+	fn.SetNameStrindex(t.stringIndex(src.Name))
+	fn.SetSystemNameStrindex(t.stringIndex(src.SystemName))
+	fn.SetFilenameStrindex(t.stringIndex(src.Filename))
+	fn.SetStartLine(src.StartLine)
+	idx := int32(t.dict.FunctionTable().Len() - 1)
+	t.functions[key] = idx
+	return idx
+}
+
+func (t *Translator) attributeIndex(key, value string) int32 {
+	ak := attributeKey{key: key, value: value}
+	if idx, ok := t.attributes[ak]; ok {
+		return idx
+	}
+	attr := t.dict.AttributeTable().AppendEmpty()
+	attr.SetKey(key)
+	attr.Value().SetStr(value)
+	idx := int32(t.dict.AttributeTable().Len() - 1)
+	t.attributes[ak] = idx
+	return idx
+}
+
+func (t *Translator) stringIndex(s string) int32 {
+	if idx, ok := t.strings[s]; ok {
+		return idx
+	}
+	t.dict.StringTable().Append(s)
+	idx := int32(t.dict.StringTable().Len() - 1)
+	t.strings[s] = idx
+	return idx
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+func newProfile() *profile.Profile {
+	mapping := &profile.Mapping{ID: 1, Start: 0x400000, Limit: 0x800000, File: "/usr/bin/app", BuildID: "abc123", HasFunctions: true}
+	mainFn := &profile.Function{ID: 1, Name: "main.main", SystemName: "main.main", Filename: "main.go", StartLine: 10}
+	workFn := &profile.Function{ID: 2, Name: "main.work", SystemName: "main.work", Filename: "main.go", StartLine: 20}
+	mainLoc := &profile.Location{ID: 1, Mapping: mapping, Address: 0x401000, Line: []profile.Line{{Function: mainFn, Line: 12}}}
+	workLoc := &profile.Location{ID: 2, Mapping: mapping, Address: 0x402000, Line: []profile.Line{{Function: workFn, Line: 25}}}
+	return &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "samples", Unit: "count"},
+			{Type: "cpu", Unit: "nanoseconds"},
+		},
+		DefaultSampleType: "cpu",
+		PeriodType:        &profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+		Period:            10000000,
+		TimeNanos:         1700000000000000000,
+		DurationNanos:     10000000000,
+		Comments:          []string{"test profile"},
+		Mapping:           []*profile.Mapping{mapping},
+		Function:          []*profile.Function{mainFn, workFn},
+		Location:          []*profile.Location{mainLoc, workLoc},
+		Sample: []*profile.Sample{
+			{Location: []*profile.Location{workLoc, mainLoc}, Value: []int64{3, 30000000}, Label: map[string][]string{"goroutine": {"worker"}}},
+			{Location: []*profile.Location{mainLoc}, Value: []int64{1, 10000000}, NumLabel: map[string][]int64{"bytes": {512}}},
+		},
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	profiles := pprofile.NewProfiles()
+	dict := profiles.ProfilesDictionary()
+	tr := New(dict)
+
+	dst := pprofile.NewProfile()
+	require.NoError(t, tr.Translate(newProfile(), dst))
+
+	str := func(idx int32) string { return dict.StringTable().At(int(idx)) }
+	assert.Empty(t, str(0))
+	assert.False(t, dst.ProfileID().IsEmpty())
+	assert.EqualValues(t, 1700000000000000000, dst.Time())
+	assert.EqualValues(t, 10000000000, dst.Duration())
+	assert.EqualValues(t, 10000000, dst.Period())
+	assert.Equal(t, "cpu", str(dst.PeriodType().TypeStrindex()))
+	assert.Equal(t, "test profile", str(dst.CommentStrindices().At(0)))
+	require.Equal(t, 2, dst.SampleType().Len())
+	assert.Equal(t, "samples", str(dst.SampleType().At(0).TypeStrindex()))
+	assert.Equal(t, "nanoseconds", str(dst.SampleType().At(1).UnitStrindex()))
+	assert.EqualValues(t, 1, dst.DefaultSampleTypeIndex())
+
+	require.Equal(t, 1, dict.MappingTable().Len())
+	mapping := dict.MappingTable().At(0)
+	assert.Equal(t, "/usr/bin/app", str(mapping.FilenameStrindex()))
+	assert.EqualValues(t, 0x400000, mapping.MemoryStart())
+	assert.Equal(t, map[string]any{"process.executable.build_id.gnu": "abc123"}, pprofile.FromAttributeIndices(dict.AttributeTable(), mapping).AsRaw())
+
+	require.Equal(t, 2, dst.Sample().Len())
+	stack := func(s pprofile.Sample) []string {
+		var names []string
+		for i := s.LocationsStartIndex(); i < s.LocationsStartIndex()+s.LocationsLength(); i++ {
+			loc := dict.LocationTable().At(int(dst.LocationIndices().At(int(i))))
+			assert.True(t, loc.HasMappingIndex())
+			names = append(names, str(dict.FunctionTable().At(int(loc.Line().At(0).FunctionIndex())).NameStrindex()))
+		}
+		return names
+	}
+	first, second := dst.Sample().At(0), dst.Sample().At(1)
+	assert.Equal(t, []string{"main.work", "main.main"}, stack(first))
+	assert.Equal(t, []int64{3, 30000000}, first.Value().AsRaw())
+	assert.Equal(t, map[string]any{"goroutine": "worker"}, pprofile.FromAttributeIndices(dict.AttributeTable(), first).AsRaw())
+	assert.Equal(t, []string{"main.main"}, stack(second))
+	assert.Equal(t, map[string]any{"bytes": "512"}, pprofile.FromAttributeIndices(dict.AttributeTable(), second).AsRaw())
+}
+
+func TestTranslateSharesDictionary(t *testing.T) {
+	profiles := pprofile.NewProfiles()
+	dict := profiles.ProfilesDictionary()
+	tr := New(dict)
+
+	require.NoError(t, tr.Translate(newProfile(), pprofile.NewProfile()))
+	strings, functions := dict.StringTable().Len(), dict.FunctionTable().Len()
+	require.NoError(t, tr.Translate(newProfile(), pprofile.NewProfile()))
+
+	assert.Equal(t, strings, dict.StringTable().Len())
+	assert.Equal(t, functions, dict.FunctionTable().Len())
+	// Locations and mappings are not shared between profiles.
+	assert.Equal(t, 4, dict.LocationTable().Len())
+	assert.Equal(t, 2, dict.MappingTable().Len())
+}
+
+func TestTranslateInvalid(t *testing.T) {
+	tr := New(pprofile.NewProfiles().ProfilesDictionary())
+
+	p := newProfile()
+	p.Sample[0].Value = []int64{1}
+	assert.ErrorContains(t, tr.Translate(p, pprofile.NewProfile()), "invalid pprof profile")
+
+	assert.EqualError(t, tr.Translate(&profile.Profile{}, pprofile.NewProfile()), "invalid pprof profile: no sample types")
+}
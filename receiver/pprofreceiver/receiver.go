@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pprofreceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pprofreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pprofreceiver/internal/translator"
+)
+
+const (
+	serverAddressAttribute = "server.address"
+	serverPortAttribute    = "server.port"
+	filePathAttribute      = "file.path"
+)
+
+type pprofReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	consumer xconsumer.Profiles
+
+	clients []*http.Client
+	// modTimes holds the modification time of the files already read.
+	modTimes map[string]time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newPprofReceiver(cfg *Config, set receiver.Settings, consumer xconsumer.Profiles) *pprofReceiver {
+	return &pprofReceiver{
+		cfg:      cfg,
+		settings: set,
+		consumer: consumer,
+		modTimes: map[string]time.Time{},
+	}
+}
+
+func (r *pprofReceiver) Start(ctx context.Context, host component.Host) error {
+	for _, target := range r.cfg.Targets {
+		client, err := target.ToClient(ctx, host, r.settings.TelemetrySettings)
+		if err != nil {
+			return err
+		}
+		r.clients = append(r.clients, client)
+	}
+
+	ctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.cfg.CollectionInterval)
+		defer ticker.Stop()
+		for {
+			r.collect(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *pprofReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// collect profiles all the targets concurrently and reads the modified files.
+func (r *pprofReceiver) collect(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i, target := range r.cfg.Targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.consume(ctx, r.collectTarget(ctx, target.Endpoint, r.clients[i]))
+		}()
+	}
+	r.consume(ctx, r.collectFiles())
+	wg.Wait()
+}
+
+func (r *pprofReceiver) consume(ctx context.Context, profiles pprofile.Profiles) {
+	if profiles.ResourceProfiles().Len() == 0 {
+		return
+	}
+	if err := r.consumer.ConsumeProfiles(ctx, profiles); err != nil {
+		r.settings.Logger.Error("Failed to consume profiles", zap.Error(err))
+	}
+}
+
+func (r *pprofReceiver) collectTarget(ctx context.Context, endpoint string, client *http.Client) pprofile.Profiles {
+	profiles := pprofile.NewProfiles()
+	tr := translator.New(profiles.ProfilesDictionary())
+	rp := profiles.ResourceProfiles().AppendEmpty()
+	setServerAttributes(rp.Resource(), endpoint)
+	sp := rp.ScopeProfiles().AppendEmpty()
+	sp.Scope().SetName(metadata.ScopeName)
+	sp.Scope().SetVersion(r.settings.BuildInfo.Version)
+
+	for _, name := range r.cfg.Profiles {
+		p, err := r.fetch(ctx, client, endpoint, name)
+		if err != nil {
+			r.settings.Logger.Warn("Failed to fetch profile", zap.String("endpoint", endpoint), zap.String("profile", name), zap.Error(err))
+			continue
+		}
+		dst := pprofile.NewProfile()
+		if err := tr.Translate(p, dst); err != nil {
+			r.settings.Logger.Warn("Failed to translate profile", zap.String("endpoint", endpoint), zap.String("profile", name), zap.Error(err))
+			continue
+		}
+		dst.MoveTo(sp.Profiles().AppendEmpty())
+	}
+	if sp.Profiles().Len() == 0 {
+		return pprofile.NewProfiles()
+	}
+	return profiles
+}
+
+func (r *pprofReceiver) fetch(ctx context.Context, client *http.Client, endpoint, name string) (*profile.Profile, error) {
+	u, err := url.JoinPath(endpoint, "debug", "pprof", profilePaths[name])
+	if err != nil {
+		return nil, err
+	}
+	if name == "cpu" {
+		u += "?seconds=" + strconv.Itoa(int(r.cfg.CPUDuration.Seconds()))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, u)
+	}
+	return profile.Parse(resp.Body)
+}
+
+// collectFiles reads the files matching the include patterns that were
+// modified since they were last read. Each file is reported as its own resource.
+func (r *pprofReceiver) collectFiles() pprofile.Profiles {
+	profiles := pprofile.NewProfiles()
+	tr := translator.New(profiles.ProfilesDictionary())
+	// Only keep the modification times of the files matched by this collection.
+	// A file that cannot be read or translated, e.g. because it is still being
+	// written, has no modification time, so that it is read again next time.
+	modTimes := make(map[string]time.Time, len(r.modTimes))
+	defer func() {
+		r.modTimes = modTimes
+	}()
+	seen := make(map[string]struct{})
+	for _, pattern := range r.cfg.Include {
+		// Patterns are validated in Config.Validate.
+		matches, _ := filepath.Glob(pattern)
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() {
+				continue
+			}
+			if _, ok := seen[path]; ok {
+				// The file is matched by several patterns.
+				continue
+			}
+			seen[path] = struct{}{}
+			if modTime, ok := r.modTimes[path]; ok && modTime.Equal(info.ModTime()) {
+				modTimes[path] = modTime
+				continue
+			}
+
+			p, err := readProfile(path)
+			if err != nil {
+				r.settings.Logger.Warn("Failed to read profile file", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			dst := pprofile.NewProfile()
+			if err := tr.Translate(p, dst); err != nil {
+				r.settings.Logger.Warn("Failed to translate profile file", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			modTimes[path] = info.ModTime()
+			rp := profiles.ResourceProfiles().AppendEmpty()
+			rp.Resource().Attributes().PutStr(filePathAttribute, path)
+			sp := rp.ScopeProfiles().AppendEmpty()
+			sp.Scope().SetName(metadata.ScopeName)
+			sp.Scope().SetVersion(r.settings.BuildInfo.Version)
+			dst.MoveTo(sp.Profiles().AppendEmpty())
+		}
+	}
+	return profiles
+}
+
+func readProfile(path string) (*profile.Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return profile.Parse(f)
+}
+
+func setServerAttributes(res pcommon.Resource, endpoint string) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return
+	}
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		host = u.Hostname()
+	}
+	res.Attributes().PutStr(serverAddressAttribute, host)
+	if p, err := strconv.ParseInt(port, 10, 64); err == nil {
+		res.Attributes().PutInt(serverPortAttribute, p)
+	}
+}
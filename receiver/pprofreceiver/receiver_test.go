@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofreceiver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pprofreceiver/internal/metadata"
+)
+
+func newTestProfile() *profile.Profile {
+	fn := &profile.Function{ID: 1, Name: "main.main", SystemName: "main.main", Filename: "main.go"}
+	loc := &profile.Location{ID: 1, Address: 0x1000, Line: []profile.Line{{Function: fn, Line: 3}}}
+	return &profile.Profile{
+		SampleType:    []*profile.ValueType{{Type: "inuse_space", Unit: "bytes"}},
+		TimeNanos:     1700000000000000000,
+		DurationNanos: int64(time.Second),
+		Function:      []*profile.Function{fn},
+		Location:      []*profile.Location{loc},
+		Sample:        []*profile.Sample{{Location: []*profile.Location{loc}, Value: []int64{1024}}},
+	}
+}
+
+func newPprofServer(t *testing.T) (*httptest.Server, func() []string) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestProfile().Write(&buf))
+
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requests = append(requests, req.URL.RequestURI())
+		mu.Unlock()
+		if req.URL.Path == "/debug/pprof/mutex" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(buf.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		sort.Strings(requests)
+		return requests
+	}
+}
+
+func TestCollectTargets(t *testing.T) {
+	srv, requests := newPprofServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	target := confighttp.NewDefaultClientConfig()
+	target.Endpoint = srv.URL
+	cfg.Targets = []confighttp.ClientConfig{target}
+	cfg.CPUDuration = 2 * time.Second
+
+	sink := new(consumertest.ProfilesSink)
+	r := newPprofReceiver(cfg, receivertest.NewNopSettings(metadata.Type), sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return len(sink.AllProfiles()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{
+		"/debug/pprof/block",
+		"/debug/pprof/goroutine",
+		"/debug/pprof/heap",
+		"/debug/pprof/mutex",
+		"/debug/pprof/profile?seconds=2",
+	}, requests())
+
+	profiles := sink.AllProfiles()[0]
+	require.Equal(t, 1, profiles.ResourceProfiles().Len())
+	rp := profiles.ResourceProfiles().At(0)
+	host, _ := rp.Resource().Attributes().Get(serverAddressAttribute)
+	assert.Equal(t, "127.0.0.1", host.Str())
+	_, ok := rp.Resource().Attributes().Get(serverPortAttribute)
+	assert.True(t, ok)
+	sp := rp.ScopeProfiles().At(0)
+	assert.Equal(t, metadata.ScopeName, sp.Scope().Name())
+	// The mutex profile failed to be fetched.
+	assert.Equal(t, 4, sp.Profiles().Len())
+	assert.Equal(t, 4, profiles.SampleCount())
+}
+
+func TestCollectFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "heap.pprof")
+	writeProfile := func() {
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		require.NoError(t, newTestProfile().Write(f))
+		require.NoError(t, f.Close())
+	}
+	writeProfile()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.pprof"), []byte("not a profile"), 0o600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "*.pprof")}
+	r := newPprofReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+
+	profiles := r.collectFiles()
+	require.Equal(t, 1, profiles.ResourceProfiles().Len())
+	rp := profiles.ResourceProfiles().At(0)
+	filePath, _ := rp.Resource().Attributes().Get(filePathAttribute)
+	assert.Equal(t, path, filePath.Str())
+	assert.Equal(t, 1, profiles.SampleCount())
+	assertFunctionNames(t, profiles, "main.main")
+
+	// Files are not read again until they are modified.
+	assert.Equal(t, 0, r.collectFiles().ResourceProfiles().Len())
+	writeProfile()
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	assert.Equal(t, 1, r.collectFiles().ResourceProfiles().Len())
+	// The invalid file is not recorded, so that it is read again once fixed.
+	assert.Len(t, r.modTimes, 1)
+	assert.Contains(t, r.modTimes, path)
+
+	// The files that are no longer matched are forgotten.
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "heap.old")))
+	assert.Equal(t, 0, r.collectFiles().ResourceProfiles().Len())
+	assert.Empty(t, r.modTimes)
+}
+
+func TestCollectFilesTruncated(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cpu.pprof")
+	var buf bytes.Buffer
+	require.NoError(t, newTestProfile().Write(&buf))
+	modTime := time.Now().Truncate(time.Second)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "*.pprof")}
+	r := newPprofReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+
+	// The file is collected while it is being written.
+	require.NoError(t, os.WriteFile(path, buf.Bytes()[:buf.Len()/2], 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	assert.Equal(t, 0, r.collectFiles().ResourceProfiles().Len())
+	assert.Empty(t, r.modTimes)
+
+	// The completed file is read, even if its modification time did not change.
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	profiles := r.collectFiles()
+	require.Equal(t, 1, profiles.ResourceProfiles().Len())
+	assert.Equal(t, 1, profiles.SampleCount())
+	assert.Equal(t, 0, r.collectFiles().ResourceProfiles().Len())
+}
+
+func TestCollectTestdata(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join("testdata", "*.pprof")}
+	r := newPprofReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+
+	profiles := r.collectFiles()
+	require.Equal(t, 1, profiles.ResourceProfiles().Len())
+	assertFunctionNames(t, profiles, "main.main")
+}
+
+func assertFunctionNames(t *testing.T, profiles pprofile.Profiles, expected ...string) {
+	dict := profiles.ProfilesDictionary()
+	var names []string
+	for i := 0; i < dict.FunctionTable().Len(); i++ {
+		names = append(names, dict.StringTable().At(int(dict.FunctionTable().At(i).NameStrindex())))
+	}
+	assert.Equal(t, expected, names)
+}
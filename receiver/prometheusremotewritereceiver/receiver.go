@@ -4,10 +4,15 @@ package prometheusremotewritereceiver // import "github.com/open-telemetry/opent
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +22,8 @@ import (
 	lru "github.com/hashicorp/golang-lru/v2"
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	promremote "github.com/prometheus/prometheus/storage/remote"
 	"go.opentelemetry.io/collector/component"
@@ -31,11 +38,24 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
 )
 
+const (
+	quantileLabel = "quantile"
+	traceIDKey    = "trace_id"
+	spanIDKey     = "span_id"
+
+	// metadataCacheSize is the maximum number of metric families whose remote-write v1 metadata is cached.
+	metadataCacheSize = 10000
+)
+
 func newRemoteWriteReceiver(settings receiver.Settings, cfg *Config, nextConsumer consumer.Metrics) (receiver.Metrics, error) {
 	cache, err := lru.New[uint64, pmetric.ResourceMetrics](1000)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
 	}
+	metadataCache, err := lru.New[string, prompb.MetricMetadata](metadataCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata LRU cache: %w", err)
+	}
 
 	return &prometheusRemoteWriteReceiver{
 		settings:     settings,
@@ -44,7 +64,8 @@ func newRemoteWriteReceiver(settings receiver.Settings, cfg *Config, nextConsume
 		server: &http.Server{
 			ReadTimeout: 60 * time.Second,
 		},
-		rmCache: cache,
+		rmCache:       cache,
+		metadataCache: metadataCache,
 	}, nil
 }
 
@@ -57,7 +78,10 @@ type prometheusRemoteWriteReceiver struct {
 	wg     sync.WaitGroup
 
 	rmCache *lru.Cache[uint64, pmetric.ResourceMetrics]
-	obsrecv *receiverhelper.ObsReport
+	// metadataCache holds the remote-write v1 metadata by metric family name. Senders send the
+	// metadata periodically, and not necessarily in the same requests as the series.
+	metadataCache *lru.Cache[string, prompb.MetricMetadata]
+	obsrecv       *receiverhelper.ObsReport
 }
 
 // metricIdentity contains all the components that uniquely identify a metric
@@ -69,11 +93,11 @@ type metricIdentity struct {
 	ScopeVersion string
 	MetricName   string
 	Unit         string
-	Type         writev2.Metadata_MetricType
+	Type         pmetric.MetricType
 }
 
 // createMetricIdentity creates a metricIdentity struct from the required components
-func createMetricIdentity(resourceID, scopeName, scopeVersion, metricName, unit string, metricType writev2.Metadata_MetricType) metricIdentity {
+func createMetricIdentity(resourceID, scopeName, scopeVersion, metricName, unit string, metricType pmetric.MetricType) metricIdentity {
 	return metricIdentity{
 		ResourceID:   resourceID,
 		ScopeName:    scopeName,
@@ -159,11 +183,6 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
 		return
 	}
-	if msgType != promconfig.RemoteWriteProtoMsgV2 {
-		prw.settings.Logger.Warn("message received with unsupported proto version, rejecting")
-		http.Error(w, "Unsupported proto version", http.StatusUnsupportedMediaType)
-		return
-	}
 
 	// After parsing the content-type header, the next step would be to handle content-encoding.
 	// Luckly confighttp's Server has middleware that already decompress the request body for us.
@@ -175,14 +194,33 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		return
 	}
 
-	var prw2Req writev2.Request
-	if err = proto.Unmarshal(body, &prw2Req); err != nil {
-		prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	var (
+		m     pmetric.Metrics
+		stats promremote.WriteResponseStats
+	)
+	switch msgType {
+	case promconfig.RemoteWriteProtoMsgV1:
+		var prw1Req prompb.WriteRequest
+		if err = proto.Unmarshal(body, &prw1Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		m, stats, err = prw.translateV1(req.Context(), &prw1Req)
+	case promconfig.RemoteWriteProtoMsgV2:
+		var prw2Req writev2.Request
+		if err = proto.Unmarshal(body, &prw2Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		m, stats, err = prw.translateV2(req.Context(), &prw2Req)
+	default:
+		prw.settings.Logger.Warn("message received with unsupported proto version, rejecting")
+		http.Error(w, "Unsupported proto version", http.StatusUnsupportedMediaType)
 		return
 	}
 
-	m, stats, err := prw.translateV2(req.Context(), &prw2Req)
 	stats.SetHeaders(w)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest) // Following instructions at https://prometheus.io/docs/specs/remote_write_spec_2_0/#invalid-samples
@@ -229,21 +267,142 @@ func (prw *prometheusRemoteWriteReceiver) parseProto(contentType string) (promco
 	return promconfig.RemoteWriteProtoMsgV1, nil
 }
 
+// translateV1 translates a v1 remote-write request into OTLP metrics.
+// The request is first converted into its v2 equivalent, so both protocol versions share the same translation.
+func (prw *prometheusRemoteWriteReceiver) translateV1(ctx context.Context, req *prompb.WriteRequest) (pmetric.Metrics, promremote.WriteResponseStats, error) {
+	return prw.translateV2(ctx, prw.convertV1ToV2(req))
+}
+
+// convertV1ToV2 converts a v1 remote-write request into a v2 one. Remote-write v1 doesn't send the metric
+// metadata along with the series, so the metric type, unit and help are looked up by metric family name in
+// the request metadata, or in the metadata received in previous requests.
+func (prw *prometheusRemoteWriteReceiver) convertV1ToV2(req *prompb.WriteRequest) *writev2.Request {
+	metadata := make(map[string]prompb.MetricMetadata, len(req.Metadata))
+	for _, md := range req.Metadata {
+		metadata[md.MetricFamilyName] = md
+		prw.metadataCache.Add(md.MetricFamilyName, md)
+	}
+	getMetadata := func(name string) (prompb.MetricMetadata, bool) {
+		if md, ok := metadata[name]; ok {
+			return md, true
+		}
+		return prw.metadataCache.Get(name)
+	}
+
+	var (
+		symbols       = writev2.NewSymbolTable()
+		labelsBuilder = labels.NewScratchBuilder(0)
+		timeseries    = make([]writev2.TimeSeries, 0, len(req.Timeseries))
+	)
+	for _, ts := range req.Timeseries {
+		ls := ts.ToLabels(&labelsBuilder, nil)
+		md, found := lookupV1Metadata(getMetadata, ls.Get(labels.MetricName))
+
+		v2ts := writev2.TimeSeries{
+			LabelsRefs: symbols.SymbolizeLabels(ls, nil),
+			Metadata: writev2.Metadata{
+				Type:    v1MetricType(md.Type, found, len(ts.Histograms) > 0),
+				HelpRef: symbols.Symbolize(md.Help),
+				UnitRef: symbols.Symbolize(md.Unit),
+			},
+		}
+
+		if len(ts.Samples) > 0 {
+			v2ts.Samples = make([]writev2.Sample, 0, len(ts.Samples))
+			for _, sample := range ts.Samples {
+				v2ts.Samples = append(v2ts.Samples, writev2.Sample{Value: sample.Value, Timestamp: sample.Timestamp})
+			}
+		}
+
+		if len(ts.Histograms) > 0 {
+			v2ts.Histograms = make([]writev2.Histogram, 0, len(ts.Histograms))
+			for _, h := range ts.Histograms {
+				if h.IsFloatHistogram() {
+					v2ts.Histograms = append(v2ts.Histograms, writev2.FromFloatHistogram(h.Timestamp, h.ToFloatHistogram()))
+				} else {
+					v2ts.Histograms = append(v2ts.Histograms, writev2.FromIntHistogram(h.Timestamp, h.ToIntHistogram()))
+				}
+			}
+		}
+
+		if len(ts.Exemplars) > 0 {
+			v2ts.Exemplars = make([]writev2.Exemplar, 0, len(ts.Exemplars))
+			for _, e := range ts.Exemplars {
+				ex := e.ToExemplar(&labelsBuilder, nil)
+				v2ts.Exemplars = append(v2ts.Exemplars, writev2.Exemplar{
+					LabelsRefs: symbols.SymbolizeLabels(ex.Labels, nil),
+					Value:      ex.Value,
+					Timestamp:  ex.Ts,
+				})
+			}
+		}
+
+		timeseries = append(timeseries, v2ts)
+	}
+
+	return &writev2.Request{
+		Symbols:    symbols.Symbols(),
+		Timeseries: timeseries,
+	}
+}
+
+// lookupV1Metadata finds the metadata of the metric family a series belongs to. Depending on the exposition
+// format, the family name may or may not include the suffixes Prometheus appends to the series names.
+func lookupV1Metadata(getMetadata func(string) (prompb.MetricMetadata, bool), metricName string) (prompb.MetricMetadata, bool) {
+	if md, ok := getMetadata(metricName); ok {
+		return md, true
+	}
+	for _, suffix := range []string{"_bucket", "_sum", "_count", "_total"} {
+		if name, found := strings.CutSuffix(metricName, suffix); found {
+			if md, ok := getMetadata(name); ok {
+				return md, true
+			}
+		}
+	}
+	return prompb.MetricMetadata{}, false
+}
+
+// v1MetricType maps the v1 metadata type into the v2 one. Series without metadata are translated as gauges,
+// unless they carry native histograms. Gauge histograms, which have no OpenTelemetry equivalent, are
+// translated the same way.
+// Ref: https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#unknown-typed
+func v1MetricType(metricType prompb.MetricMetadata_MetricType, found, hasHistograms bool) writev2.Metadata_MetricType {
+	if !found || metricType == prompb.MetricMetadata_GAUGEHISTOGRAM {
+		if hasHistograms {
+			return writev2.Metadata_METRIC_TYPE_HISTOGRAM
+		}
+		return writev2.Metadata_METRIC_TYPE_GAUGE
+	}
+
+	switch metricType {
+	case prompb.MetricMetadata_COUNTER:
+		return writev2.Metadata_METRIC_TYPE_COUNTER
+	case prompb.MetricMetadata_HISTOGRAM:
+		return writev2.Metadata_METRIC_TYPE_HISTOGRAM
+	case prompb.MetricMetadata_SUMMARY:
+		return writev2.Metadata_METRIC_TYPE_SUMMARY
+	default:
+		// Gauges, unknown, info and stateset metrics are all translated as gauges.
+		return writev2.Metadata_METRIC_TYPE_GAUGE
+	}
+}
+
 // translateV2 translates a v2 remote-write request into OTLP metrics.
-// translate is not feature complete.
 func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *writev2.Request) (pmetric.Metrics, promremote.WriteResponseStats, error) {
 	var (
 		badRequestErrors error
 		otelMetrics      = pmetric.NewMetrics()
 		labelsBuilder    = labels.NewScratchBuilder(0)
 		// More about stats: https://github.com/prometheus/docs/blob/main/docs/specs/prw/remote_write_spec_2_0.md#required-written-response-headers
-		// TODO: add histograms and exemplars to the stats. Histograms can be added after this PR be merged. Ref #39864
-		// Exemplars should be implemented to add them to the stats.
 		stats = promremote.WriteResponseStats{
 			Confirmed: true,
 		}
 		// The key is composed by: resource_hash:scope_name:scope_version:metric_name:unit:type
 		metricCache = make(map[uint64]pmetric.Metric)
+		// Classic histograms and summaries are split across several series (_bucket, _sum, _count and quantiles),
+		// so their datapoints are assembled across the whole request before being finalized.
+		histogramCache = make(map[datapointKey]*classicHistogram)
+		summaryCache   = make(map[datapointKey]pmetric.SummaryDataPoint)
 	)
 
 	for _, ts := range req.Timeseries {
@@ -281,6 +440,23 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 			continue
 		}
 
+		metricName := ls.Get(labels.MetricName)
+		metricType := otelMetricType(ts)
+		if metricType == pmetric.MetricTypeEmpty {
+			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unsupported metric type %q for metric %q", ts.Metadata.Type, metricName))
+			continue
+		}
+
+		if ts.Metadata.UnitRef >= uint32(len(req.Symbols)) {
+			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unit ref %d is out of bounds of symbolsTable", ts.Metadata.UnitRef))
+			continue
+		}
+
+		if ts.Metadata.HelpRef >= uint32(len(req.Symbols)) {
+			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("help ref %d is out of bounds of symbolsTable", ts.Metadata.HelpRef))
+			continue
+		}
+
 		// For metrics other than target_info, we need to follow the standard process of creating a metric.
 		var rm pmetric.ResourceMetrics
 		hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
@@ -294,17 +470,7 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		}
 
 		scopeName, scopeVersion := prw.extractScopeInfo(ls)
-		metricName := ls.Get(labels.MetricName)
-		if ts.Metadata.UnitRef >= uint32(len(req.Symbols)) {
-			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unit ref %d is out of bounds of symbolsTable", ts.Metadata.UnitRef))
-			continue
-		}
-
-		if ts.Metadata.HelpRef >= uint32(len(req.Symbols)) {
-			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("help ref %d is out of bounds of symbolsTable", ts.Metadata.HelpRef))
-			continue
-		}
-
+		familyName := metricFamilyName(metricName, metricType)
 		unit := req.Symbols[ts.Metadata.UnitRef]
 		description := req.Symbols[ts.Metadata.HelpRef]
 
@@ -314,9 +480,9 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 			resourceID.String(), // Resource identity
 			scopeName,           // Scope name
 			scopeVersion,        // Scope version
-			metricName,          // Metric name
+			familyName,          // Metric name
 			unit,                // Unit
-			ts.Metadata.Type,    // Metric type
+			metricType,          // Metric type
 		)
 
 		metricKey := metricIdentity.Hash()
@@ -341,20 +507,22 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		// If the metric does not exist, we create an empty metric and add it to the cache.
 		if !exists {
 			metric = scope.Metrics().AppendEmpty()
-			metric.SetName(metricName)
+			metric.SetName(familyName)
 			metric.SetUnit(unit)
 			metric.SetDescription(description)
 
-			switch ts.Metadata.Type {
-			case writev2.Metadata_METRIC_TYPE_GAUGE:
+			switch metricType {
+			case pmetric.MetricTypeGauge:
 				metric.SetEmptyGauge()
-			case writev2.Metadata_METRIC_TYPE_COUNTER:
+			case pmetric.MetricTypeSum:
 				sum := metric.SetEmptySum()
 				sum.SetIsMonotonic(true)
 				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
-			case writev2.Metadata_METRIC_TYPE_HISTOGRAM:
-				metric.SetEmptyHistogram()
-			case writev2.Metadata_METRIC_TYPE_SUMMARY:
+			case pmetric.MetricTypeHistogram:
+				metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			case pmetric.MetricTypeExponentialHistogram:
+				metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			case pmetric.MetricTypeSummary:
 				metric.SetEmptySummary()
 			}
 
@@ -368,23 +536,75 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		}
 
 		// Otherwise, we append the samples to the existing metric.
-		switch ts.Metadata.Type {
-		case writev2.Metadata_METRIC_TYPE_GAUGE:
-			addNumberDatapoints(metric.Gauge().DataPoints(), ls, ts, &stats)
-		case writev2.Metadata_METRIC_TYPE_COUNTER:
-			addNumberDatapoints(metric.Sum().DataPoints(), ls, ts, &stats)
-		case writev2.Metadata_METRIC_TYPE_HISTOGRAM:
-			addHistogramDatapoints(metric.Histogram().DataPoints(), ls, ts)
-		case writev2.Metadata_METRIC_TYPE_SUMMARY:
-			addSummaryDatapoints(metric.Summary().DataPoints(), ls, ts)
-		default:
-			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unsupported metric type %q for metric %q", ts.Metadata.Type, metricName))
+		var err error
+		switch metricType {
+		case pmetric.MetricTypeGauge:
+			err = addNumberDatapoints(metric.Gauge().DataPoints(), ls, ts, req.Symbols, &stats)
+		case pmetric.MetricTypeSum:
+			err = addNumberDatapoints(metric.Sum().DataPoints(), ls, ts, req.Symbols, &stats)
+		case pmetric.MetricTypeHistogram:
+			err = addHistogramDatapoints(histogramCache, metricKey, metric.Histogram().DataPoints(), ls, ts, req.Symbols, &stats)
+		case pmetric.MetricTypeExponentialHistogram:
+			err = addExponentialHistogramDatapoints(metric.ExponentialHistogram().DataPoints(), ls, ts, req.Symbols, &stats)
+		case pmetric.MetricTypeSummary:
+			err = addSummaryDatapoints(summaryCache, metricKey, metric.Summary().DataPoints(), ls, ts, &stats)
+		}
+		if err != nil {
+			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("metric %q: %w", metricName, err))
 		}
 	}
 
+	for _, h := range histogramCache {
+		h.finalize()
+	}
+	for _, dp := range summaryCache {
+		dp.QuantileValues().Sort(func(a, b pmetric.SummaryDataPointValueAtQuantile) bool {
+			return a.Quantile() < b.Quantile()
+		})
+	}
+
 	return otelMetrics, stats, badRequestErrors
 }
 
+// otelMetricType returns the OpenTelemetry metric type a remote-write v2 timeseries translates to.
+// Histograms carrying native histogram samples become exponential histograms, while the ones
+// carrying float samples are classic histograms.
+func otelMetricType(ts writev2.TimeSeries) pmetric.MetricType {
+	switch ts.Metadata.Type {
+	case writev2.Metadata_METRIC_TYPE_GAUGE:
+		return pmetric.MetricTypeGauge
+	case writev2.Metadata_METRIC_TYPE_COUNTER:
+		return pmetric.MetricTypeSum
+	case writev2.Metadata_METRIC_TYPE_HISTOGRAM:
+		if len(ts.Histograms) > 0 {
+			return pmetric.MetricTypeExponentialHistogram
+		}
+		return pmetric.MetricTypeHistogram
+	case writev2.Metadata_METRIC_TYPE_SUMMARY:
+		return pmetric.MetricTypeSummary
+	default:
+		return pmetric.MetricTypeEmpty
+	}
+}
+
+// metricFamilyName strips the suffixes Prometheus uses to split classic histograms and summaries
+// into several series, so all of them are translated into the same OpenTelemetry metric.
+func metricFamilyName(metricName string, metricType pmetric.MetricType) string {
+	var suffixes []string
+	switch metricType {
+	case pmetric.MetricTypeHistogram:
+		suffixes = []string{"_bucket", "_sum", "_count"}
+	case pmetric.MetricTypeSummary:
+		suffixes = []string{"_sum", "_count"}
+	}
+	for _, suffix := range suffixes {
+		if name, found := strings.CutSuffix(metricName, suffix); found {
+			return name
+		}
+	}
+	return metricName
+}
+
 // parseJobAndInstance turns the job and instance labels service resource attributes.
 // Following the specification at https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/
 func parseJobAndInstance(dest pcommon.Map, job, instance string) {
@@ -403,7 +623,7 @@ func parseJobAndInstance(dest pcommon.Map, job, instance string) {
 }
 
 // addNumberDatapoints adds the labels to the datapoints attributes.
-func addNumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labels, ts writev2.TimeSeries, stats *promremote.WriteResponseStats) {
+func addNumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labels, ts writev2.TimeSeries, symbols []string, stats *promremote.WriteResponseStats) error {
 	// Add samples from the timeseries
 	for _, sample := range ts.Samples {
 		dp := datapoints.AppendEmpty()
@@ -412,25 +632,311 @@ func addNumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labe
 		dp.SetTimestamp(pcommon.Timestamp(sample.Timestamp * int64(time.Millisecond)))
 		dp.SetDoubleValue(sample.Value)
 
-		attributes := dp.Attributes()
-		for _, l := range ls {
-			if l.Name == "instance" || l.Name == "job" || // Become resource attributes
-				l.Name == labels.MetricName || // Becomes metric name
-				l.Name == "otel_scope_name" || l.Name == "otel_scope_version" { // Becomes scope name and version
-				continue
-			}
-			attributes.PutStr(l.Name, l.Value)
+		addDatapointAttributes(dp.Attributes(), ls)
+		stats.Samples++
+	}
+
+	// Exemplars are not bound to a specific sample, so they are attached to the most recent datapoint.
+	if len(ts.Exemplars) > 0 && len(ts.Samples) > 0 {
+		return addExemplars(datapoints.At(datapoints.Len()-1).Exemplars(), ts.Exemplars, symbols, stats)
+	}
+	return nil
+}
+
+// addDatapointAttributes adds the labels to the datapoint attributes, skipping the ones that are translated
+// into other OTLP fields and the ones listed in ignored.
+func addDatapointAttributes(attributes pcommon.Map, ls labels.Labels, ignored ...string) {
+	for _, l := range ls {
+		if l.Name == "instance" || l.Name == "job" || // Become resource attributes
+			l.Name == labels.MetricName || // Becomes metric name
+			l.Name == "otel_scope_name" || l.Name == "otel_scope_version" || // Becomes scope name and version
+			slices.Contains(ignored, l.Name) {
+			continue
+		}
+		attributes.PutStr(l.Name, l.Value)
+	}
+}
+
+// datapointKey identifies a single datapoint of a classic histogram or summary, which is
+// spread across several series that share the same labels and timestamps.
+type datapointKey struct {
+	metricKey  uint64
+	labelsHash uint64
+	timestamp  int64
+}
+
+// classicBucket is a single "le" bucket of a classic histogram, holding a cumulative count.
+type classicBucket struct {
+	upperBound float64
+	count      float64
+}
+
+// classicHistogram accumulates the _bucket, _sum and _count series of a classic histogram
+// until all series of the request have been read.
+type classicHistogram struct {
+	dp       pmetric.HistogramDataPoint
+	buckets  []classicBucket
+	count    float64
+	hasCount bool
+}
+
+// finalize converts the cumulative Prometheus buckets into OTLP explicit bounds and bucket counts.
+func (h *classicHistogram) finalize() {
+	sort.Slice(h.buckets, func(i, j int) bool {
+		return h.buckets[i].upperBound < h.buckets[j].upperBound
+	})
+
+	var previous float64
+	hasInf := false
+	for _, b := range h.buckets {
+		if math.IsInf(b.upperBound, 1) {
+			hasInf = true
+		} else {
+			h.dp.ExplicitBounds().Append(b.upperBound)
+		}
+		h.dp.BucketCounts().Append(uint64(math.Max(b.count-previous, 0)))
+		previous = math.Max(b.count, previous)
+	}
+
+	total := previous
+	if h.hasCount {
+		total = h.count
+	}
+	// The +Inf bucket is implicit in OTLP, but it must always be present in the bucket counts.
+	if len(h.buckets) > 0 && !hasInf {
+		h.dp.BucketCounts().Append(uint64(math.Max(total-previous, 0)))
+	}
+	h.dp.SetCount(uint64(total))
+}
+
+// addHistogramDatapoints collects the samples of a classic histogram series into the datapoints it belongs to.
+// Buckets are only converted once the whole request has been read, see classicHistogram.finalize.
+func addHistogramDatapoints(histograms map[datapointKey]*classicHistogram, metricKey uint64, datapoints pmetric.HistogramDataPointSlice, ls labels.Labels, ts writev2.TimeSeries, symbols []string, stats *promremote.WriteResponseStats) error {
+	metricName := ls.Get(labels.MetricName)
+
+	var upperBound float64
+	isBucket := strings.HasSuffix(metricName, "_bucket")
+	if isBucket {
+		le := ls.Get(labels.BucketLabel)
+		if le == "" {
+			return fmt.Errorf("missing %q label in histogram bucket", labels.BucketLabel)
+		}
+		var err error
+		if upperBound, err = strconv.ParseFloat(le, 64); err != nil {
+			return fmt.Errorf("invalid %q label value %q: %w", labels.BucketLabel, le, err)
+		}
+	} else if !strings.HasSuffix(metricName, "_sum") && !strings.HasSuffix(metricName, "_count") {
+		return errors.New("classic histogram series must have a _bucket, _sum or _count suffix")
+	}
+
+	labelsHash, _ := ls.HashWithoutLabels(nil, labels.BucketLabel)
+	var last *classicHistogram
+	for _, sample := range ts.Samples {
+		key := datapointKey{metricKey: metricKey, labelsHash: labelsHash, timestamp: sample.Timestamp}
+		h, ok := histograms[key]
+		if !ok {
+			dp := datapoints.AppendEmpty()
+			dp.SetStartTimestamp(pcommon.Timestamp(ts.CreatedTimestamp * int64(time.Millisecond)))
+			dp.SetTimestamp(pcommon.Timestamp(sample.Timestamp * int64(time.Millisecond)))
+			addDatapointAttributes(dp.Attributes(), ls, labels.BucketLabel)
+			h = &classicHistogram{dp: dp}
+			histograms[key] = h
+		}
+
+		switch {
+		case isBucket:
+			h.buckets = append(h.buckets, classicBucket{upperBound: upperBound, count: sample.Value})
+		case strings.HasSuffix(metricName, "_sum"):
+			h.dp.SetSum(sample.Value)
+		default:
+			h.count = sample.Value
+			h.hasCount = true
 		}
 		stats.Samples++
+		last = h
+	}
+
+	if len(ts.Exemplars) > 0 && last != nil {
+		return addExemplars(last.dp.Exemplars(), ts.Exemplars, symbols, stats)
+	}
+	return nil
+}
+
+// addExponentialHistogramDatapoints translates native histograms into exponential histogram datapoints.
+// Based on https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#exponential-histograms
+func addExponentialHistogramDatapoints(datapoints pmetric.ExponentialHistogramDataPointSlice, ls labels.Labels, ts writev2.TimeSeries, symbols []string, stats *promremote.WriteResponseStats) error {
+	for _, h := range ts.Histograms {
+		// Native histograms with custom buckets (schema -53) can't be represented as exponential histograms.
+		if h.Schema < -4 || h.Schema > 8 {
+			return fmt.Errorf("unsupported native histogram schema %d", h.Schema)
+		}
+
+		dp := datapoints.AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(ts.CreatedTimestamp * int64(time.Millisecond)))
+		dp.SetTimestamp(pcommon.Timestamp(h.Timestamp * int64(time.Millisecond)))
+		addDatapointAttributes(dp.Attributes(), ls)
+		stats.Histograms++
+
+		if value.IsStaleNaN(h.Sum) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			continue
+		}
+
+		dp.SetScale(h.Schema)
+		dp.SetSum(h.Sum)
+		dp.SetZeroThreshold(h.ZeroThreshold)
+
+		var err error
+		if h.IsFloatHistogram() {
+			dp.SetCount(uint64(h.GetCountFloat()))
+			dp.SetZeroCount(uint64(h.GetZeroCountFloat()))
+			err = errors.Join(
+				convertNativeBuckets(h.PositiveSpans, h.PositiveCounts, false, dp.Positive()),
+				convertNativeBuckets(h.NegativeSpans, h.NegativeCounts, false, dp.Negative()),
+			)
+		} else {
+			dp.SetCount(h.GetCountInt())
+			dp.SetZeroCount(h.GetZeroCountInt())
+			err = errors.Join(
+				convertNativeBuckets(h.PositiveSpans, h.PositiveDeltas, true, dp.Positive()),
+				convertNativeBuckets(h.NegativeSpans, h.NegativeDeltas, true, dp.Negative()),
+			)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(ts.Exemplars) > 0 && datapoints.Len() > 0 && len(ts.Histograms) > 0 {
+		return addExemplars(datapoints.At(datapoints.Len()-1).Exemplars(), ts.Exemplars, symbols, stats)
 	}
+	return nil
 }
 
-func addSummaryDatapoints(_ pmetric.SummaryDataPointSlice, _ labels.Labels, _ writev2.TimeSeries) {
-	// TODO: Implement this function
+// convertNativeBuckets converts the sparse native histogram buckets into dense OTLP exponential buckets.
+// Integer native histograms encode each bucket as a delta to the previous one, float ones use absolute counts.
+func convertNativeBuckets[T int64 | float64](spans []writev2.BucketSpan, values []T, isDelta bool, dest pmetric.ExponentialHistogramDataPointBuckets) error {
+	var expected int
+	for _, span := range spans {
+		expected += int(span.Length)
+	}
+	if expected != len(values) {
+		return fmt.Errorf("native histogram spans describe %d buckets, but %d were sent", expected, len(values))
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	// -1 because OTEL offset are for the lower bound, not the upper bound
+	dest.SetOffset(spans[0].Offset - 1)
+	buckets := dest.BucketCounts()
+	buckets.EnsureCapacity(len(values))
+
+	var idx int
+	var current T
+	for spanIdx, span := range spans {
+		if spanIdx > 0 {
+			for i := int32(0); i < span.Offset; i++ {
+				buckets.Append(0)
+			}
+		}
+		for i := uint32(0); i < span.Length; i++ {
+			if isDelta {
+				current += values[idx]
+			} else {
+				current = values[idx]
+			}
+			buckets.Append(uint64(current))
+			idx++
+		}
+	}
+	return nil
 }
 
-func addHistogramDatapoints(_ pmetric.HistogramDataPointSlice, _ labels.Labels, _ writev2.TimeSeries) {
-	// TODO: Implement this function
+// addSummaryDatapoints collects the samples of a summary series into the datapoints it belongs to.
+func addSummaryDatapoints(summaries map[datapointKey]pmetric.SummaryDataPoint, metricKey uint64, datapoints pmetric.SummaryDataPointSlice, ls labels.Labels, ts writev2.TimeSeries, stats *promremote.WriteResponseStats) error {
+	metricName := ls.Get(labels.MetricName)
+	isSum := strings.HasSuffix(metricName, "_sum")
+	isCount := strings.HasSuffix(metricName, "_count")
+
+	var quantile float64
+	if !isSum && !isCount {
+		q := ls.Get(quantileLabel)
+		if q == "" {
+			return fmt.Errorf("missing %q label in summary", quantileLabel)
+		}
+		var err error
+		if quantile, err = strconv.ParseFloat(q, 64); err != nil {
+			return fmt.Errorf("invalid %q label value %q: %w", quantileLabel, q, err)
+		}
+	}
+
+	labelsHash, _ := ls.HashWithoutLabels(nil, quantileLabel)
+	for _, sample := range ts.Samples {
+		key := datapointKey{metricKey: metricKey, labelsHash: labelsHash, timestamp: sample.Timestamp}
+		dp, ok := summaries[key]
+		if !ok {
+			dp = datapoints.AppendEmpty()
+			dp.SetStartTimestamp(pcommon.Timestamp(ts.CreatedTimestamp * int64(time.Millisecond)))
+			dp.SetTimestamp(pcommon.Timestamp(sample.Timestamp * int64(time.Millisecond)))
+			addDatapointAttributes(dp.Attributes(), ls, quantileLabel)
+			summaries[key] = dp
+		}
+
+		switch {
+		case isSum:
+			dp.SetSum(sample.Value)
+		case isCount:
+			dp.SetCount(uint64(sample.Value))
+		default:
+			qv := dp.QuantileValues().AppendEmpty()
+			qv.SetQuantile(quantile)
+			qv.SetValue(sample.Value)
+		}
+		stats.Samples++
+	}
+	return nil
+}
+
+// addExemplars translates the remote-write exemplars into OTLP exemplars. The trace_id and span_id labels
+// become the exemplar trace and span IDs, the remaining labels are added as filtered attributes.
+func addExemplars(dest pmetric.ExemplarSlice, exemplars []writev2.Exemplar, symbols []string, stats *promremote.WriteResponseStats) error {
+	labelsBuilder := labels.NewScratchBuilder(0)
+	for _, e := range exemplars {
+		for _, ref := range e.LabelsRefs {
+			if ref >= uint32(len(symbols)) {
+				return fmt.Errorf("exemplar label ref %d is out of bounds of symbolsTable", ref)
+			}
+		}
+		ex := e.ToExemplar(&labelsBuilder, symbols)
+
+		exemplar := dest.AppendEmpty()
+		exemplar.SetDoubleValue(ex.Value)
+		exemplar.SetTimestamp(pcommon.Timestamp(ex.Ts * int64(time.Millisecond)))
+		for _, l := range ex.Labels {
+			switch l.Name {
+			case traceIDKey:
+				var traceID pcommon.TraceID
+				if len(l.Value) == hex.EncodedLen(len(traceID)) {
+					if _, err := hex.Decode(traceID[:], []byte(l.Value)); err == nil {
+						exemplar.SetTraceID(traceID)
+						continue
+					}
+				}
+			case spanIDKey:
+				var spanID pcommon.SpanID
+				if len(l.Value) == hex.EncodedLen(len(spanID)) {
+					if _, err := hex.Decode(spanID[:], []byte(l.Value)); err == nil {
+						exemplar.SetSpanID(spanID)
+						continue
+					}
+				}
+			}
+			exemplar.FilteredAttributes().PutStr(l.Name, l.Value)
+		}
+		stats.Exemplars++
+	}
+	return nil
 }
 
 // extractScopeInfo extracts the scope name and version from the labels. If the labels do not contain the scope name/version,
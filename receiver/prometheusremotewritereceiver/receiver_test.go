@@ -17,9 +17,11 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	promconfig "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/prompb"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/prometheus/prometheus/storage/remote"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -80,6 +82,7 @@ func setupMetricsReceiver(t *testing.T) *prometheusRemoteWriteReceiver {
 	// Add cleanup to ensure LRU cache is properly purged
 	t.Cleanup(func() {
 		writeReceiver.rmCache.Purge()
+		writeReceiver.metadataCache.Purge()
 	})
 
 	return writeReceiver
@@ -117,9 +120,9 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/no proto parameter",
 			contentType:  "application/x-protobuf",
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
-				Confirmed:  false,
+				Confirmed:  true,
 				Samples:    0,
 				Histograms: 0,
 				Exemplars:  0,
@@ -128,9 +131,9 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/v1 proto parameter",
 			contentType:  fmt.Sprintf("application/x-protobuf;proto=%s", promconfig.RemoteWriteProtoMsgV1),
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
-				Confirmed:  false,
+				Confirmed:  true,
 				Samples:    0,
 				Histograms: 0,
 				Exemplars:  0,
@@ -554,6 +557,259 @@ func TestTranslateV2(t *testing.T) {
 				Exemplars:  0,
 			},
 		},
+		{
+			name: "classic histogram",
+			request: &writev2.Request{
+				Symbols: []string{
+					"",
+					"__name__", "test_hist_bucket", // 1, 2
+					"job", "service-x/test", // 3, 4
+					"instance", "107cn001", // 5, 6
+					"le", "1", // 7, 8
+					"le", "+Inf", // 9, 10
+					"__name__", "test_hist_sum", // 11, 12
+					"__name__", "test_hist_count", // 13, 14
+					"d", "e", // 15, 16
+					"trace_id", "0102030405060708090a0b0c0d0e0f10", // 17, 18
+					"span_id", "0102030405060708", // 19, 20
+				},
+				Timeseries: []writev2.TimeSeries{
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs: []uint32{1, 2, 15, 16, 3, 4, 5, 6, 9, 10},
+						Samples:    []writev2.Sample{{Value: 5, Timestamp: 1}},
+					},
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs: []uint32{1, 2, 15, 16, 3, 4, 5, 6, 7, 8},
+						Samples:    []writev2.Sample{{Value: 3, Timestamp: 1}},
+						Exemplars:  []writev2.Exemplar{{LabelsRefs: []uint32{19, 20, 17, 18, 15, 16}, Value: 0.5, Timestamp: 1}},
+					},
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs: []uint32{11, 12, 15, 16, 3, 4, 5, 6},
+						Samples:    []writev2.Sample{{Value: 7.5, Timestamp: 1}},
+					},
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs: []uint32{13, 14, 15, 16, 3, 4, 5, 6},
+						Samples:    []writev2.Sample{{Value: 5, Timestamp: 1}},
+					},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				rm := expected.ResourceMetrics().AppendEmpty()
+				attrs := rm.Resource().Attributes()
+				attrs.PutStr("service.namespace", "service-x")
+				attrs.PutStr("service.name", "test")
+				attrs.PutStr("service.instance.id", "107cn001")
+
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("test_hist")
+				hist := m.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetCount(5)
+				dp.SetSum(7.5)
+				dp.ExplicitBounds().FromRaw([]float64{1})
+				dp.BucketCounts().FromRaw([]uint64{3, 2})
+				dp.Attributes().PutStr("d", "e")
+
+				ex := dp.Exemplars().AppendEmpty()
+				ex.SetDoubleValue(0.5)
+				ex.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				ex.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+				ex.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+				ex.FilteredAttributes().PutStr("d", "e")
+
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed:  true,
+				Samples:    4,
+				Histograms: 0,
+				Exemplars:  1,
+			},
+		},
+		{
+			name: "native histogram",
+			request: &writev2.Request{
+				Symbols: []string{
+					"",
+					"__name__", "test_native_hist", // 1, 2
+					"job", "service-x/test", // 3, 4
+					"instance", "107cn001", // 5, 6
+				},
+				Timeseries: []writev2.TimeSeries{
+					{
+						Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs:       []uint32{1, 2, 3, 4, 5, 6},
+						CreatedTimestamp: 1,
+						Histograms: []writev2.Histogram{
+							{
+								Count:          &writev2.Histogram_CountInt{CountInt: 9},
+								Sum:            12.5,
+								Schema:         1,
+								ZeroThreshold:  0.001,
+								ZeroCount:      &writev2.Histogram_ZeroCountInt{ZeroCountInt: 1},
+								PositiveSpans:  []writev2.BucketSpan{{Offset: 1, Length: 2}, {Offset: 2, Length: 1}},
+								PositiveDeltas: []int64{2, 1, -2},
+								NegativeSpans:  []writev2.BucketSpan{{Offset: 0, Length: 1}},
+								NegativeDeltas: []int64{2},
+								Timestamp:      2,
+							},
+						},
+					},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				rm := expected.ResourceMetrics().AppendEmpty()
+				attrs := rm.Resource().Attributes()
+				attrs.PutStr("service.namespace", "service-x")
+				attrs.PutStr("service.name", "test")
+				attrs.PutStr("service.instance.id", "107cn001")
+
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("test_native_hist")
+				hist := m.SetEmptyExponentialHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+				dp.SetScale(1)
+				dp.SetCount(9)
+				dp.SetSum(12.5)
+				dp.SetZeroThreshold(0.001)
+				dp.SetZeroCount(1)
+				dp.Positive().SetOffset(0)
+				dp.Positive().BucketCounts().FromRaw([]uint64{2, 3, 0, 0, 1})
+				dp.Negative().SetOffset(-1)
+				dp.Negative().BucketCounts().FromRaw([]uint64{2})
+
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed:  true,
+				Samples:    0,
+				Histograms: 1,
+				Exemplars:  0,
+			},
+		},
+		{
+			name: "native histogram with custom buckets",
+			request: &writev2.Request{
+				Symbols: []string{"", "__name__", "test_native_hist"},
+				Timeseries: []writev2.TimeSeries{
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs: []uint32{1, 2},
+						Histograms: []writev2.Histogram{{Schema: -53, CustomValues: []float64{1, 2}}},
+					},
+				},
+			},
+			expectError: "unsupported native histogram schema -53",
+		},
+		{
+			name: "native histogram with mismatched spans",
+			request: &writev2.Request{
+				Symbols: []string{"", "__name__", "test_native_hist"},
+				Timeseries: []writev2.TimeSeries{
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM},
+						LabelsRefs: []uint32{1, 2},
+						Histograms: []writev2.Histogram{
+							{
+								Count:          &writev2.Histogram_CountInt{CountInt: 1},
+								PositiveSpans:  []writev2.BucketSpan{{Offset: 0, Length: 2}},
+								PositiveDeltas: []int64{1},
+							},
+						},
+					},
+				},
+			},
+			expectError: "native histogram spans describe 2 buckets, but 1 were sent",
+		},
+		{
+			name: "summary",
+			request: &writev2.Request{
+				Symbols: []string{
+					"",
+					"__name__", "test_summary", // 1, 2
+					"job", "service-x/test", // 3, 4
+					"instance", "107cn001", // 5, 6
+					"quantile", "0.99", // 7, 8
+					"quantile", "0.5", // 9, 10
+					"__name__", "test_summary_sum", // 11, 12
+					"__name__", "test_summary_count", // 13, 14
+				},
+				Timeseries: []writev2.TimeSeries{
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs: []uint32{1, 2, 3, 4, 5, 6, 7, 8},
+						Samples:    []writev2.Sample{{Value: 10, Timestamp: 1}},
+					},
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs: []uint32{1, 2, 3, 4, 5, 6, 9, 10},
+						Samples:    []writev2.Sample{{Value: 2, Timestamp: 1}},
+					},
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs: []uint32{11, 12, 3, 4, 5, 6},
+						Samples:    []writev2.Sample{{Value: 30, Timestamp: 1}},
+					},
+					{
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_SUMMARY},
+						LabelsRefs: []uint32{13, 14, 3, 4, 5, 6},
+						Samples:    []writev2.Sample{{Value: 6, Timestamp: 1}},
+					},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				rm := expected.ResourceMetrics().AppendEmpty()
+				attrs := rm.Resource().Attributes()
+				attrs.PutStr("service.namespace", "service-x")
+				attrs.PutStr("service.name", "test")
+				attrs.PutStr("service.instance.id", "107cn001")
+
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("test_summary")
+
+				dp := m.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetCount(6)
+				dp.SetSum(30)
+				q1 := dp.QuantileValues().AppendEmpty()
+				q1.SetQuantile(0.5)
+				q1.SetValue(2)
+				q2 := dp.QuantileValues().AppendEmpty()
+				q2.SetQuantile(0.99)
+				q2.SetValue(10)
+
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed:  true,
+				Samples:    4,
+				Histograms: 0,
+				Exemplars:  0,
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			// since we are using the rmCache to store values across requests, we need to clear it after each test, otherwise it will affect the next test
@@ -571,6 +827,270 @@ func TestTranslateV2(t *testing.T) {
 	}
 }
 
+func TestTranslateV1(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	for _, tc := range []struct {
+		name            string
+		request         *prompb.WriteRequest
+		expectError     string
+		expectedMetrics pmetric.Metrics
+		expectedStats   remote.WriteResponseStats
+	}{
+		{
+			name: "missing metric name",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:  []prompb.Label{{Name: "foo", Value: "bar"}},
+						Samples: []prompb.Sample{{Value: 1, Timestamp: 1}},
+					},
+				},
+			},
+			expectError: "missing metric name in labels",
+		},
+		{
+			name: "types inferred from metadata",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: []prompb.Label{
+							{Name: "__name__", Value: "target_info"},
+							{Name: "job", Value: "service-x/test"},
+							{Name: "instance", Value: "107cn001"},
+							{Name: "region", Value: "us-central1"},
+						},
+						Samples: []prompb.Sample{{Value: 1, Timestamp: 1}},
+					},
+					{
+						Labels: []prompb.Label{
+							{Name: "__name__", Value: "http_requests_total"},
+							{Name: "job", Value: "service-x/test"},
+							{Name: "instance", Value: "107cn001"},
+							{Name: "code", Value: "200"},
+						},
+						Samples: []prompb.Sample{{Value: 10, Timestamp: 1}},
+					},
+					{
+						Labels: []prompb.Label{
+							{Name: "__name__", Value: "unknown_metric"},
+							{Name: "job", Value: "service-x/test"},
+							{Name: "instance", Value: "107cn001"},
+						},
+						Samples: []prompb.Sample{{Value: 3, Timestamp: 1}},
+					},
+					{
+						Labels: []prompb.Label{
+							{Name: "__name__", Value: "latency_bucket"},
+							{Name: "job", Value: "service-x/test"},
+							{Name: "instance", Value: "107cn001"},
+							{Name: "le", Value: "+Inf"},
+						},
+						Samples: []prompb.Sample{{Value: 2, Timestamp: 1}},
+					},
+					{
+						Labels: []prompb.Label{
+							{Name: "__name__", Value: "latency_sum"},
+							{Name: "job", Value: "service-x/test"},
+							{Name: "instance", Value: "107cn001"},
+						},
+						Samples: []prompb.Sample{{Value: 0.5, Timestamp: 1}},
+					},
+					{
+						Labels: []prompb.Label{
+							{Name: "__name__", Value: "latency_count"},
+							{Name: "job", Value: "service-x/test"},
+							{Name: "instance", Value: "107cn001"},
+						},
+						Samples: []prompb.Sample{{Value: 2, Timestamp: 1}},
+					},
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "http_requests", Help: "Total HTTP requests"},
+					{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency", Unit: "seconds"},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				rm := expected.ResourceMetrics().AppendEmpty()
+				attrs := rm.Resource().Attributes()
+				attrs.PutStr("service.namespace", "service-x")
+				attrs.PutStr("service.name", "test")
+				attrs.PutStr("service.instance.id", "107cn001")
+				attrs.PutStr("region", "us-central1")
+
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+
+				counter := sm.Metrics().AppendEmpty()
+				counter.SetName("http_requests_total")
+				counter.SetDescription("Total HTTP requests")
+				sum := counter.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp1 := sum.DataPoints().AppendEmpty()
+				dp1.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp1.SetDoubleValue(10)
+				dp1.Attributes().PutStr("code", "200")
+
+				gauge := sm.Metrics().AppendEmpty()
+				gauge.SetName("unknown_metric")
+				dp2 := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp2.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp2.SetDoubleValue(3)
+
+				hist := sm.Metrics().AppendEmpty()
+				hist.SetName("latency")
+				hist.SetUnit("seconds")
+				h := hist.SetEmptyHistogram()
+				h.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp3 := h.DataPoints().AppendEmpty()
+				dp3.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp3.SetCount(2)
+				dp3.SetSum(0.5)
+				dp3.BucketCounts().FromRaw([]uint64{2})
+
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed:  true,
+				Samples:    5,
+				Histograms: 0,
+				Exemplars:  0,
+			},
+		},
+		{
+			name: "native histogram without metadata",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: []prompb.Label{{Name: "__name__", Value: "native"}},
+						Histograms: []prompb.Histogram{
+							{
+								Count:          &prompb.Histogram_CountInt{CountInt: 3},
+								Sum:            4,
+								Schema:         0,
+								ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 0},
+								PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+								PositiveDeltas: []int64{3},
+								Timestamp:      1,
+							},
+						},
+					},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				rm := expected.ResourceMetrics().AppendEmpty()
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("native")
+				h := m.SetEmptyExponentialHistogram()
+				h.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := h.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetCount(3)
+				dp.SetSum(4)
+				dp.Positive().SetOffset(0)
+				dp.Positive().BucketCounts().FromRaw([]uint64{3})
+
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed:  true,
+				Samples:    0,
+				Histograms: 1,
+				Exemplars:  0,
+			},
+		},
+		{
+			name: "gauge histogram translated as gauges",
+			request: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: []prompb.Label{
+							{Name: "__name__", Value: "queue_size_bucket"},
+							{Name: "le", Value: "+Inf"},
+						},
+						Samples: []prompb.Sample{{Value: 2, Timestamp: 1}},
+					},
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_GAUGEHISTOGRAM, MetricFamilyName: "queue_size"},
+				},
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				expected := pmetric.NewMetrics()
+				rm := expected.ResourceMetrics().AppendEmpty()
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("queue_size_bucket")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetDoubleValue(2)
+				dp.Attributes().PutStr("le", "+Inf")
+
+				return expected
+			}(),
+			expectedStats: remote.WriteResponseStats{
+				Confirmed:  true,
+				Samples:    1,
+				Histograms: 0,
+				Exemplars:  0,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			prwReceiver.rmCache.Purge()
+			prwReceiver.metadataCache.Purge()
+			metrics, stats, err := prwReceiver.translateV1(ctx, tc.request)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, pmetrictest.CompareMetrics(tc.expectedMetrics, metrics))
+			assert.Equal(t, tc.expectedStats, stats)
+		})
+	}
+}
+
+func TestTranslateV1MetadataFromPreviousRequest(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+
+	// Senders send the metadata periodically, in requests without any series.
+	_, _, err := prwReceiver.translateV1(context.Background(), &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "http_requests", Help: "Total HTTP requests"},
+		},
+	})
+	require.NoError(t, err)
+
+	metrics, _, err := prwReceiver.translateV1(context.Background(), &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "http_requests_total"}},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 1}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.MetricCount())
+	m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pmetric.MetricTypeSum, m.Type())
+	assert.Equal(t, "Total HTTP requests", m.Description())
+}
+
 type nonMutatingConsumer struct{}
 
 // Capabilities returns the base consumer capabilities.
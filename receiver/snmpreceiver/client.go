@@ -113,6 +113,13 @@ func newClient(cfg *Config, logger *zap.Logger) (client, error) {
 // setV3ClientConfigs sets SNMP v3 related configurations on gosnmp client based on config
 func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 	client.SetSecurityModel(gosnmp.UserSecurityModel)
+	securityParams, msgFlags := newUsmSecurityParameters(cfg)
+	client.SetMsgFlags(msgFlags)
+	client.SetSecurityParameters(securityParams)
+}
+
+// newUsmSecurityParameters creates the gosnmp user security parameters and message flags based on config
+func newUsmSecurityParameters(cfg *Config) (*gosnmp.UsmSecurityParameters, gosnmp.SnmpV3MsgFlags) {
 	// Set goSNMP user based on config
 	securityParams := &gosnmp.UsmSecurityParameters{
 		UserName: cfg.User,
@@ -120,13 +127,11 @@ func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 	// Set goSNMP security level & auth/privacy details based on config
 	switch strings.ToUpper(cfg.SecurityLevel) {
 	case "AUTH_NO_PRIV":
-		client.SetMsgFlags(gosnmp.AuthNoPriv)
 		protocol := getAuthProtocol(cfg.AuthType)
 		securityParams.AuthenticationProtocol = protocol
 		securityParams.AuthenticationPassphrase = string(cfg.AuthPassword)
+		return securityParams, gosnmp.AuthNoPriv
 	case "AUTH_PRIV":
-		client.SetMsgFlags(gosnmp.AuthPriv)
-
 		authProtocol := getAuthProtocol(cfg.AuthType)
 		securityParams.AuthenticationProtocol = authProtocol
 		securityParams.AuthenticationPassphrase = string(cfg.AuthPassword)
@@ -134,10 +139,10 @@ func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 		privProtocol := getPrivacyProtocol(cfg.PrivacyType)
 		securityParams.PrivacyProtocol = privProtocol
 		securityParams.PrivacyPassphrase = string(cfg.PrivacyPassword)
+		return securityParams, gosnmp.AuthPriv
 	default:
-		client.SetMsgFlags(gosnmp.NoAuthNoPriv)
+		return securityParams, gosnmp.NoAuthNoPriv
 	}
-	client.SetSecurityParameters(securityParams)
 }
 
 // getAuthProtocol gets gosnmp auth protocol based on config auth type
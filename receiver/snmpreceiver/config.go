@@ -3,6 +3,7 @@
 package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
@@ -23,6 +24,7 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultTrapsEndpoint      = "udp://0.0.0.0:162"
 )
 
 var (
@@ -68,6 +70,9 @@ var (
 	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
 	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
 	errMetricRequired       = errors.New("must have at least one config under metrics")
+	errTrapsRequired        = errors.New("traps must be configured to receive logs")
+	errTrapsEndpointScheme  = errors.New("traps endpoint scheme must be udp")
+	errBadEngineID          = errors.New("traps engine_id must be a hexadecimal string of 5 to 32 bytes")
 )
 
 // Config defines the configuration for the various elements of the receiver.
@@ -131,6 +136,33 @@ type Config struct {
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
 	// names along with their metric configurations
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
+
+	// Traps is required only to receive logs.
+	// It configures the listener converting SNMP traps and informs into log records. The connection
+	// configs above are used to authenticate them: the community for v1 and v2c, and the user and
+	// security configs for v3.
+	Traps *TrapsConfig `mapstructure:"traps"`
+}
+
+// TrapsConfig contains config info about the SNMP trap listener
+type TrapsConfig struct {
+	// Endpoint is the address to listen on for traps and informs. Must be formatted as [udp://]{host}:[{port}].
+	// Default: udp://0.0.0.0:162
+	// If no scheme is given, udp is assumed.
+	// If no port is given, 162 is assumed.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// EngineID is the hexadecimal SNMP engine ID of the listener, which v3 informs are sent to.
+	// Only valid for version "v3"
+	// Default: an engine ID derived from the text "otelcol"
+	EngineID string `mapstructure:"engine_id"`
+
+	// MIBPaths is optional and contains MIB files, or directories of MIB files, used to translate
+	// the OIDs of the traps and their variable bindings to names
+	MIBPaths []string `mapstructure:"mib_paths"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
 }
 
 // ResourceAttributeConfig contains config info about all of the resource attributes that will be used by this receiver.
@@ -261,7 +293,39 @@ func (cfg *Config) Validate() error {
 	if strings.ToUpper(cfg.Version) == "V3" {
 		combinedErr = errors.Join(combinedErr, validateSecurity(cfg))
 	}
-	combinedErr = errors.Join(combinedErr, validateMetricConfigs(cfg))
+	// Metrics are only required when not receiving traps
+	if cfg.Traps == nil || len(cfg.Metrics) > 0 {
+		combinedErr = errors.Join(combinedErr, validateMetricConfigs(cfg))
+	}
+	if cfg.Traps != nil {
+		combinedErr = errors.Join(combinedErr, validateTraps(cfg.Traps))
+	}
+
+	return combinedErr
+}
+
+// validateTraps validates the TrapsConfig
+func validateTraps(traps *TrapsConfig) error {
+	var combinedErr error
+
+	// The missing parts of the endpoint are added when creating the receiver
+	endpoint := withTrapsEndpointDefaults(traps.Endpoint)
+	u, err := url.Parse(endpoint)
+	switch {
+	case err != nil:
+		combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidEndpointWError, traps.Endpoint, err))
+	case u.Host == "" || u.Port() == "":
+		combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidEndpoint, traps.Endpoint))
+	case u.Scheme != "udp":
+		combinedErr = errors.Join(combinedErr, errTrapsEndpointScheme)
+	}
+
+	if traps.EngineID != "" {
+		engineID, err := hex.DecodeString(traps.EngineID)
+		if err != nil || len(engineID) < 5 || len(engineID) > 32 {
+			combinedErr = errors.Join(combinedErr, errBadEngineID)
+		}
+	}
 
 	return combinedErr
 }
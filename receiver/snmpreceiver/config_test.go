@@ -131,6 +131,23 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 	expectedConfigV3NoPrivacyPassword.AuthPassword = "p"
 	expectedConfigV3NoPrivacyPassword.Metrics = metrics
 
+	expectedConfigTraps := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTraps.Traps = &TrapsConfig{
+		Endpoint: "0.0.0.0:9162",
+		EngineID: "800000000401020304",
+		MIBPaths: []string{"./mibs"},
+	}
+
+	expectedConfigTrapsBadEndpointScheme := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsBadEndpointScheme.Traps = &TrapsConfig{
+		Endpoint: "tcp://0.0.0.0:9162",
+	}
+
+	expectedConfigTrapsBadEngineID := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsBadEngineID.Traps = &TrapsConfig{
+		EngineID: "0102",
+	}
+
 	testCases := []testCase{
 		{
 			name:        "NoEndpointUsesDefault",
@@ -246,6 +263,24 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 			expectedCfg: expectedConfigV3Simple,
 			expectedErr: "",
 		},
+		{
+			name:        "GoodTrapsWithoutMetricsNoErrors",
+			nameVal:     "traps_good",
+			expectedCfg: expectedConfigTraps,
+			expectedErr: "",
+		},
+		{
+			name:        "TrapsBadEndpointSchemeErrors",
+			nameVal:     "traps_bad_endpoint_scheme",
+			expectedCfg: expectedConfigTrapsBadEndpointScheme,
+			expectedErr: errTrapsEndpointScheme.Error(),
+		},
+		{
+			name:        "TrapsBadEngineIDErrors",
+			nameVal:     "traps_bad_engine_id",
+			expectedCfg: expectedConfigTrapsBadEngineID,
+			expectedErr: errBadEngineID.Error(),
+		},
 	}
 
 	for _, test := range testCases {
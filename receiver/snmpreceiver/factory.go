@@ -27,7 +27,8 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
 }
 
 // createDefaultConfig creates a config for SNMP with as many default values as possible
@@ -62,6 +63,11 @@ func createMetricsReceiver(
 		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
 	}
 
+	// Metrics are optional in config when receiving traps
+	if len(snmpConfig.Metrics) == 0 {
+		return nil, errMetricRequired
+	}
+
 	snmpScraper := newScraper(params.Logger, snmpConfig, params)
 	s, err := scraper.NewMetrics(snmpScraper.scrape, scraper.WithStart(snmpScraper.start))
 	if err != nil {
@@ -71,6 +77,49 @@ func createMetricsReceiver(
 	return scraperhelper.NewMetricsController(&snmpConfig.ControllerConfig, params, consumer, scraperhelper.AddScraper(metadata.Type, s))
 }
 
+// createLogsReceiver creates the logs receiver for SNMP traps
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	config component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	snmpConfig, ok := config.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMP
+	}
+
+	if snmpConfig.Traps == nil {
+		return nil, errTrapsRequired
+	}
+
+	if err := addMissingConfigDefaults(snmpConfig); err != nil {
+		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
+	}
+
+	return newTrapReceiver(snmpConfig, params, consumer)
+}
+
+// withTrapsEndpointDefaults returns the traps endpoint with the default schema prefix and port
+// if it doesn't contain them, or the default traps endpoint if it is empty
+func withTrapsEndpointDefaults(endpoint string) string {
+	if endpoint == "" {
+		return defaultTrapsEndpoint
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "udp://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err == nil && u.Port() == "" {
+		portSuffix := "162"
+		if endpoint[len(endpoint)-1:] != ":" {
+			portSuffix = ":" + portSuffix
+		}
+		endpoint += portSuffix
+	}
+	return endpoint
+}
+
 // addMissingConfigDefaults adds any missing config parameters that have defaults
 func addMissingConfigDefaults(cfg *Config) error {
 	// Add the schema prefix to the endpoint if it doesn't contain one
@@ -88,6 +137,11 @@ func addMissingConfigDefaults(cfg *Config) error {
 		cfg.Endpoint += portSuffix
 	}
 
+	// Add the default traps endpoint, or its missing schema prefix and port
+	if cfg.Traps != nil {
+		cfg.Traps.Endpoint = withTrapsEndpointDefaults(cfg.Traps.Endpoint)
+	}
+
 	// Set defaults for metric configs
 	for _, metricCfg := range cfg.Metrics {
 		if metricCfg.Unit == "" {
@@ -260,6 +260,69 @@ func TestNewFactory(t *testing.T) {
 				require.Equal(t, "1", snmpCfg.Metrics["m1"].Unit)
 			},
 		},
+		{
+			desc: "CreateMetrics returns error without metrics when receiving traps",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{}
+				_, err := factory.CreateMetrics(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errMetricRequired)
+			},
+		},
+		{
+			desc: "creates a new factory and CreateLogs returns no error",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{}
+				_, err := factory.CreateLogs(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, "udp://0.0.0.0:162", snmpCfg.Traps.Endpoint)
+			},
+		},
+		{
+			desc: "CreateLogs returns error without traps config",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				_, err := factory.CreateLogs(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					factory.CreateDefaultConfig(),
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errTrapsRequired)
+			},
+		},
+		{
+			desc: "CreateLogs adds missing scheme and port to traps endpoint",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{Endpoint: "localhost"}
+				_, err := factory.CreateLogs(
+					context.Background(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, "udp://localhost:162", snmpCfg.Traps.Endpoint)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
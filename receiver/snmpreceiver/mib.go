@@ -0,0 +1,287 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// baseMIBNodes are the well known nodes, mostly from SNMPv2-SMI and SNMPv2-MIB, which are
+// available even when no MIB file is loaded
+var baseMIBNodes = map[string]string{
+	"1":                   "iso",
+	"1.3":                 "org",
+	"1.3.6":               "dod",
+	"1.3.6.1":             "internet",
+	"1.3.6.1.1":           "directory",
+	"1.3.6.1.2":           "mgmt",
+	"1.3.6.1.2.1":         "mib-2",
+	"1.3.6.1.2.1.1":       "system",
+	"1.3.6.1.2.1.1.3":     "sysUpTime",
+	"1.3.6.1.2.1.10":      "transmission",
+	"1.3.6.1.3":           "experimental",
+	"1.3.6.1.4":           "private",
+	"1.3.6.1.4.1":         "enterprises",
+	"1.3.6.1.5":           "security",
+	"1.3.6.1.6":           "snmpV2",
+	"1.3.6.1.6.1":         "snmpDomains",
+	"1.3.6.1.6.2":         "snmpProxys",
+	"1.3.6.1.6.3":         "snmpModules",
+	"1.3.6.1.6.3.1.1.4.1": "snmpTrapOID",
+	"1.3.6.1.6.3.1.1.4.3": "snmpTrapEnterprise",
+	"1.3.6.1.6.3.1.1.5.1": "coldStart",
+	"1.3.6.1.6.3.1.1.5.2": "warmStart",
+	"1.3.6.1.6.3.1.1.5.3": "linkDown",
+	"1.3.6.1.6.3.1.1.5.4": "linkUp",
+	"1.3.6.1.6.3.1.1.5.5": "authenticationFailure",
+	"1.3.6.1.6.3.1.1.5.6": "egpNeighborLoss",
+	"1.3.6.1.6.3.18.1.3":  "snmpTrapAddress",
+	"1.3.6.1.6.3.18.1.4":  "snmpTrapCommunity",
+}
+
+// mibMacros are the macros whose values are the OIDs of the objects they define
+var mibMacros = map[string]bool{
+	"OBJECT-TYPE":        true,
+	"OBJECT-IDENTITY":    true,
+	"MODULE-IDENTITY":    true,
+	"NOTIFICATION-TYPE":  true,
+	"TRAP-TYPE":          true,
+	"OBJECT-GROUP":       true,
+	"NOTIFICATION-GROUP": true,
+	"MODULE-COMPLIANCE":  true,
+	"AGENT-CAPABILITIES": true,
+}
+
+// mibNode is an object defined in a MIB file, relative to its parent
+type mibNode struct {
+	parent string
+	subIDs []string
+}
+
+// mibTranslator translates OIDs to the names of the objects defined in MIB files
+type mibTranslator struct {
+	// names contains the names of the objects, by numeric OID without leading dot
+	names map[string]string
+}
+
+// newMIBTranslator creates a mibTranslator from MIB files, or directories of MIB files
+func newMIBTranslator(paths []string) (*mibTranslator, error) {
+	nodes := map[string]mibNode{}
+	for _, path := range paths {
+		files, err := mibFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read MIB file '%s': %w", file, err)
+			}
+			parseMIB(string(data), nodes)
+		}
+	}
+
+	t := &mibTranslator{names: make(map[string]string, len(baseMIBNodes)+len(nodes))}
+	oids := make(map[string]string, len(baseMIBNodes)+len(nodes))
+	for oid, name := range baseMIBNodes {
+		t.names[oid] = name
+		oids[name] = oid
+	}
+	for name := range nodes {
+		if oid, ok := resolveMIBNode(name, nodes, oids, map[string]bool{}); ok {
+			t.names[oid] = name
+		}
+	}
+	return t, nil
+}
+
+// mibFiles returns the given file, or the files of the given directory
+func mibFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MIBs from '%s': %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MIBs from '%s': %w", path, err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		files = append(files, filepath.Join(path, entry.Name()))
+	}
+	return files, nil
+}
+
+// resolveMIBNode returns the numeric OID of the named node, resolving its ancestors as needed
+func resolveMIBNode(name string, nodes map[string]mibNode, oids map[string]string, visiting map[string]bool) (string, bool) {
+	if oid, ok := oids[name]; ok {
+		return oid, true
+	}
+	node, ok := nodes[name]
+	if !ok || visiting[name] {
+		return "", false
+	}
+	visiting[name] = true
+
+	parentOID := ""
+	if node.parent != "" {
+		if parentOID, ok = resolveMIBNode(node.parent, nodes, oids, visiting); !ok {
+			return "", false
+		}
+	}
+	oid := strings.Join(append([]string{parentOID}, node.subIDs...), ".")
+	oid = strings.TrimPrefix(oid, ".")
+	oids[name] = oid
+	return oid, true
+}
+
+// parseMIB adds the objects defined in the given MIB module to nodes. The parsing is lenient: only
+// the value assignments of the objects are parsed, everything else is ignored.
+func parseMIB(data string, nodes map[string]mibNode) {
+	tokens := tokenizeMIB(data)
+
+	var name, macro, enterprise string
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case isMIBValueName(token) && i+1 < len(tokens) && mibMacros[tokens[i+1]]:
+			name, macro, enterprise = token, tokens[i+1], ""
+			i++
+		case isMIBValueName(token) && i+2 < len(tokens) && tokens[i+1] == "OBJECT" && tokens[i+2] == "IDENTIFIER":
+			name, macro, enterprise = token, "OBJECT IDENTIFIER", ""
+			i += 2
+		case token == "ENTERPRISE" && macro == "TRAP-TYPE" && i+1 < len(tokens):
+			enterprise = tokens[i+1]
+			i++
+		case token == "::=" && name != "" && i+1 < len(tokens):
+			if macro == "TRAP-TYPE" {
+				// SMIv1 traps are translated to notifications as defined in RFC 3584
+				if enterprise != "" && isMIBNumber(tokens[i+1]) {
+					nodes[name] = mibNode{parent: enterprise, subIDs: []string{"0", tokens[i+1]}}
+				}
+			} else if tokens[i+1] == "{" {
+				i = parseMIBValue(name, tokens, i+2, nodes)
+			}
+			name, macro, enterprise = "", "", ""
+		}
+	}
+}
+
+// parseMIBValue parses an OID value starting at tokens[i], such as "{ iso org(3) dod(6) 1 }", adding
+// the named node and any named intermediate nodes to nodes. It returns the index of the closing brace.
+func parseMIBValue(name string, tokens []string, i int, nodes map[string]mibNode) int {
+	var parent string
+	var subIDs []string
+	for ; i < len(tokens) && tokens[i] != "}"; i++ {
+		token := tokens[i]
+		switch {
+		case isMIBNumber(token):
+			subIDs = append(subIDs, token)
+		case i+3 < len(tokens) && tokens[i+1] == "(" && isMIBNumber(tokens[i+2]) && tokens[i+3] == ")":
+			// named number, such as "org(3)"
+			subIDs = append(subIDs, tokens[i+2])
+			if _, ok := nodes[token]; !ok {
+				nodes[token] = mibNode{parent: parent, subIDs: append([]string(nil), subIDs...)}
+			}
+			i += 3
+		case parent == "" && len(subIDs) == 0:
+			parent = token
+		default:
+			// not a valid OID value
+			return i
+		}
+	}
+	if parent != "" || len(subIDs) > 0 {
+		nodes[name] = mibNode{parent: parent, subIDs: subIDs}
+	}
+	return i
+}
+
+// tokenizeMIB splits a MIB module into tokens, skipping comments and quoted strings
+func tokenizeMIB(data string) []string {
+	var tokens []string
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case strings.HasPrefix(data[i:], "--"):
+			// comments end at the end of the line or at the next "--"
+			end := i + 2
+			for end < len(data) && data[end] != '\n' && !strings.HasPrefix(data[end:], "--") {
+				end++
+			}
+			i = end + 1
+			if strings.HasPrefix(data[end:], "--") {
+				i = end + 2
+			}
+		case c == '"':
+			end := strings.IndexByte(data[i+1:], '"')
+			if end < 0 {
+				return tokens
+			}
+			i += end + 2
+		case strings.HasPrefix(data[i:], "::="):
+			tokens = append(tokens, "::=")
+			i += 3
+		case isMIBIdentifierChar(c):
+			end := i + 1
+			for end < len(data) && isMIBIdentifierChar(data[end]) && !strings.HasPrefix(data[end:], "--") {
+				end++
+			}
+			tokens = append(tokens, data[i:end])
+			i = end
+		case unicode.IsSpace(rune(c)):
+			i++
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+func isMIBIdentifierChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_'
+}
+
+// isMIBValueName returns whether the token can be the name of a value, which starts with a lowercase letter
+func isMIBValueName(token string) bool {
+	return token != "" && token[0] >= 'a' && token[0] <= 'z'
+}
+
+func isMIBNumber(token string) bool {
+	for i := 0; i < len(token); i++ {
+		if token[i] < '0' || token[i] > '9' {
+			return false
+		}
+	}
+	return token != ""
+}
+
+// translate returns the name of the given numeric OID, based on the longest OID prefix with a name
+// (such as "ifDescr.2" for "1.3.6.1.2.1.2.2.1.2.2"), or the OID itself if none of its prefixes has a name
+func (t *mibTranslator) translate(oid string) string {
+	oid = strings.TrimPrefix(oid, ".")
+	for prefix := oid; prefix != ""; {
+		if name, ok := t.names[prefix]; ok {
+			return name + oid[len(prefix):]
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return oid
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMIBTranslator(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		paths    []string
+		oid      string
+		expected string
+	}{
+		{
+			desc:     "translates base nodes without MIB files",
+			oid:      "1.3.6.1.6.3.1.1.5.3",
+			expected: "linkDown",
+		},
+		{
+			desc:     "translates instances of base nodes without MIB files",
+			oid:      ".1.3.6.1.2.1.1.3.0",
+			expected: "sysUpTime.0",
+		},
+		{
+			desc:     "returns unknown OIDs unchanged",
+			oid:      "2.25.1",
+			expected: "2.25.1",
+		},
+		{
+			desc:     "translates nodes with the longest known prefix",
+			oid:      "1.3.6.1.4.1.99999.1.1.1.2.5",
+			expected: "enterprises.99999.1.1.1.2.5",
+		},
+		{
+			desc:     "translates module identities from MIB files",
+			paths:    []string{filepath.Join("testdata", "mibs", "OTEL-TEST-MIB.txt")},
+			oid:      "1.3.6.1.4.1.99999",
+			expected: "otelTestMIB",
+		},
+		{
+			desc:     "translates object instances from MIB directories",
+			paths:    []string{filepath.Join("testdata", "mibs")},
+			oid:      "1.3.6.1.4.1.99999.1.1.1.2.5",
+			expected: "otelTestName.5",
+		},
+		{
+			desc:     "translates notifications from MIB files",
+			paths:    []string{filepath.Join("testdata", "mibs")},
+			oid:      "1.3.6.1.4.1.99999.2.1",
+			expected: "otelTestAlarm",
+		},
+		{
+			desc:     "translates v1 traps from MIB files",
+			paths:    []string{filepath.Join("testdata", "mibs")},
+			oid:      "1.3.6.1.4.1.99999.0.7",
+			expected: "otelTestV1Alarm",
+		},
+		{
+			desc:     "translates values with named numbers from MIB files",
+			paths:    []string{filepath.Join("testdata", "mibs")},
+			oid:      "1.3.6.1.4.1.99999.3",
+			expected: "otelTestNamedNumbers",
+		},
+		{
+			desc:     "ignores definitions in comments",
+			paths:    []string{filepath.Join("testdata", "mibs")},
+			oid:      "1.3.6.1.4.1.99999.9",
+			expected: "otelTestMIB.9",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			translator, err := newMIBTranslator(tc.paths)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, translator.translate(tc.oid))
+		})
+	}
+}
+
+func TestMIBTranslatorMissingPath(t *testing.T) {
+	_, err := newMIBTranslator([]string{filepath.Join("testdata", "mibs", "MISSING-MIB.txt")})
+	require.ErrorContains(t, err, "failed to load MIBs")
+}
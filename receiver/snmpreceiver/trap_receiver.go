@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
+)
+
+const (
+	// snmpTrapOIDInstance is the OID of the variable binding containing the OID of a v2c or v3 trap
+	snmpTrapOIDInstance = "1.3.6.1.6.3.1.1.4.1.0"
+	// snmpTrapsPrefix is the OID prefix of the generic traps, as defined in RFC 3584
+	snmpTrapsPrefix = "1.3.6.1.6.3.1.1.5."
+	// enterpriseSpecificTrap is the generic trap value of the v1 enterprise specific traps
+	enterpriseSpecificTrap = 6
+
+	// varbindAttributePrefix is the prefix of the attributes containing the variable bindings of the traps
+	varbindAttributePrefix = "snmp.varbind."
+)
+
+// defaultEngineID is the default engine ID of the trap listener, in the text format defined in RFC 3411
+var defaultEngineID = string([]byte{0x80, 0x00, 0x00, 0x00, 0x04}) + "otelcol"
+
+// trapReceiver listens for SNMP traps and informs and converts them into logs
+type trapReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	consumer consumer.Logs
+	obsrecv  *receiverhelper.ObsReport
+
+	translator *mibTranslator
+	listener   *gosnmp.TrapListener
+	wg         sync.WaitGroup
+}
+
+// newTrapReceiver creates the trap receiver
+// Relies on config being validated thoroughly
+func newTrapReceiver(cfg *Config, settings receiver.Settings, consumer consumer.Logs) (*trapReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "udp",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &trapReceiver{
+		cfg:      cfg,
+		settings: settings,
+		consumer: consumer,
+		obsrecv:  obsrecv,
+	}, nil
+}
+
+// Start loads the MIB files and starts listening for traps
+func (r *trapReceiver) Start(_ context.Context, _ component.Host) error {
+	translator, err := newMIBTranslator(r.cfg.Traps.MIBPaths)
+	if err != nil {
+		return err
+	}
+	r.translator = translator
+
+	r.listener = gosnmp.NewTrapListener()
+	r.listener.Params = r.listenerParams()
+	r.listener.OnNewTrap = r.handleTrap
+
+	errs := make(chan error, 1)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		errs <- r.listener.Listen(r.cfg.Traps.Endpoint)
+	}()
+
+	select {
+	case <-r.listener.Listening():
+		return nil
+	case err = <-errs:
+		return fmt.Errorf("failed to listen for traps on '%s': %w", r.cfg.Traps.Endpoint, err)
+	}
+}
+
+// Shutdown stops listening for traps
+func (r *trapReceiver) Shutdown(_ context.Context) error {
+	if r.listener != nil {
+		r.listener.Close()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// listenerParams creates the gosnmp parameters used to decode and authenticate the traps based on config
+func (r *trapReceiver) listenerParams() *gosnmp.GoSNMP {
+	params := &gosnmp.GoSNMP{
+		Version:   gosnmp.Version2c,
+		Community: r.cfg.Community,
+		Logger:    gosnmp.NewLogger(gosnmpLogger{r.settings.Logger}),
+	}
+	if strings.EqualFold(r.cfg.Version, "v3") {
+		securityParams, msgFlags := newUsmSecurityParameters(r.cfg)
+		securityParams.AuthoritativeEngineID = defaultEngineID
+		if r.cfg.Traps.EngineID != "" {
+			// Checked in config
+			engineID, _ := hex.DecodeString(r.cfg.Traps.EngineID)
+			securityParams.AuthoritativeEngineID = string(engineID)
+		}
+		params.Version = gosnmp.Version3
+		params.SecurityModel = gosnmp.UserSecurityModel
+		params.MsgFlags = msgFlags
+		params.SecurityParameters = securityParams
+	}
+	return params
+}
+
+// handleTrap converts a trap or inform into logs and sends them to the next consumer
+// The response to informs is sent once this returns
+func (r *trapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	if !r.accept(packet) {
+		r.settings.Logger.Debug("Dropping SNMP trap not matching the configured version or credentials",
+			zap.Stringer("address", addr), zap.String("version", snmpVersion(packet.Version)))
+		return
+	}
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	err := r.consumer.ConsumeLogs(ctx, r.convert(packet, addr, time.Now()))
+	r.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 1, err)
+	if err != nil {
+		r.settings.Logger.Error("Failed to consume SNMP trap", zap.Error(err))
+	}
+}
+
+// accept returns whether the trap matches the configured version and credentials
+// v1 and v2c traps are accepted with the configured community, v3 traps with the configured user and
+// at least the configured security level, which gosnmp has already checked the traps against
+func (r *trapReceiver) accept(packet *gosnmp.SnmpPacket) bool {
+	if !strings.EqualFold(r.cfg.Version, "v3") {
+		return packet.Version != gosnmp.Version3 && packet.Community == r.cfg.Community
+	}
+	if packet.Version != gosnmp.Version3 {
+		return false
+	}
+	securityParams, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if !ok || securityParams.UserName != r.cfg.User {
+		return false
+	}
+	_, msgFlags := newUsmSecurityParameters(r.cfg)
+	return packet.MsgFlags&gosnmp.AuthPriv >= msgFlags
+}
+
+// convert creates a log record from a trap, with its variable bindings as attributes
+func (r *trapReceiver) convert(packet *gosnmp.SnmpPacket, addr *net.UDPAddr, now time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	scopeLogs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName(metadata.ScopeName)
+	scopeLogs.Scope().SetVersion(r.settings.BuildInfo.Version)
+
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(now))
+
+	attrs := logRecord.Attributes()
+	attrs.PutStr("snmp.version", snmpVersion(packet.Version))
+	if packet.PDUType == gosnmp.InformRequest {
+		attrs.PutStr("snmp.pdu.type", "inform")
+	} else {
+		attrs.PutStr("snmp.pdu.type", "trap")
+	}
+	if addr != nil {
+		attrs.PutStr("network.peer.address", addr.IP.String())
+		attrs.PutInt("network.peer.port", int64(addr.Port))
+	}
+
+	var trapOID string
+	if packet.PDUType == gosnmp.Trap {
+		// v1 trap
+		enterprise := strings.TrimPrefix(packet.Enterprise, ".")
+		trapOID = v1TrapOID(enterprise, packet.GenericTrap, packet.SpecificTrap)
+		attrs.PutStr("snmp.trap.enterprise", enterprise)
+		attrs.PutInt("snmp.trap.generic", int64(packet.GenericTrap))
+		attrs.PutInt("snmp.trap.specific", int64(packet.SpecificTrap))
+		attrs.PutStr("snmp.agent.address", packet.AgentAddress)
+		attrs.PutInt(varbindAttributePrefix+r.translator.translate("1.3.6.1.2.1.1.3.0"), int64(packet.Timestamp))
+	}
+
+	for _, variable := range packet.Variables {
+		oid := strings.TrimPrefix(variable.Name, ".")
+		if oid == snmpTrapOIDInstance {
+			if value, ok := variable.Value.(string); ok {
+				trapOID = strings.TrimPrefix(value, ".")
+			}
+			continue
+		}
+		putVarbind(attrs, varbindAttributePrefix+r.translator.translate(oid), variable)
+	}
+
+	attrs.PutStr("snmp.trap.oid", trapOID)
+	name := r.translator.translate(trapOID)
+	if name != trapOID {
+		attrs.PutStr("snmp.trap.name", name)
+	}
+	logRecord.Body().SetStr(name)
+
+	return logs
+}
+
+// v1TrapOID returns the OID of the notification corresponding to a v1 trap, as defined in RFC 3584
+func v1TrapOID(enterprise string, genericTrap, specificTrap int) string {
+	if genericTrap == enterpriseSpecificTrap {
+		return enterprise + ".0." + strconv.Itoa(specificTrap)
+	}
+	return snmpTrapsPrefix + strconv.Itoa(genericTrap+1)
+}
+
+// putVarbind puts the value of a variable binding into the attributes
+func putVarbind(attrs pcommon.Map, key string, variable gosnmp.SnmpPDU) {
+	switch variable.Type {
+	case gosnmp.Integer, gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		value := gosnmp.ToBigInt(variable.Value)
+		if value.IsInt64() {
+			attrs.PutInt(key, value.Int64())
+		} else {
+			attrs.PutStr(key, value.String())
+		}
+	case gosnmp.OpaqueFloat:
+		if value, ok := variable.Value.(float32); ok {
+			attrs.PutDouble(key, float64(value))
+		}
+	case gosnmp.OpaqueDouble:
+		if value, ok := variable.Value.(float64); ok {
+			attrs.PutDouble(key, value)
+		}
+	case gosnmp.OctetString:
+		value, _ := variable.Value.([]byte)
+		if isPrintable(value) {
+			attrs.PutStr(key, string(value))
+		} else {
+			attrs.PutStr(key, hex.EncodeToString(value))
+		}
+	case gosnmp.ObjectIdentifier:
+		value, _ := variable.Value.(string)
+		attrs.PutStr(key, strings.TrimPrefix(value, "."))
+	case gosnmp.Null, gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+		attrs.PutEmpty(key)
+	default:
+		attrs.PutStr(key, fmt.Sprint(variable.Value))
+	}
+}
+
+// isPrintable returns whether the octet string is printable text rather than binary data
+func isPrintable(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+	for _, r := range string(value) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// snmpVersion returns the SNMP version as configured
+func snmpVersion(version gosnmp.SnmpVersion) string {
+	switch version {
+	case gosnmp.Version1:
+		return "v1"
+	case gosnmp.Version3:
+		return "v3"
+	default:
+		return "v2c"
+	}
+}
+
+// gosnmpLogger writes the gosnmp logs, such as the traps which failed to be decoded or authenticated, as debug logs
+type gosnmpLogger struct {
+	logger *zap.Logger
+}
+
+func (l gosnmpLogger) Print(v ...any) {
+	l.logger.Debug(strings.TrimSpace(fmt.Sprint(v...)))
+}
+
+func (l gosnmpLogger) Printf(format string, v ...any) {
+	l.logger.Debug(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
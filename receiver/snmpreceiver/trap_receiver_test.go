@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
+)
+
+func TestTrapReceiver(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		cfg      func(cfg *Config)
+		client   func(client *gosnmp.GoSNMP)
+		trap     gosnmp.SnmpTrap
+		expected map[string]any
+	}{
+		{
+			desc: "receives v2c traps",
+			trap: gosnmp.SnmpTrap{
+				Variables: []gosnmp.SnmpPDU{
+					{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1000)},
+					{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.99999.2.1"},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.2.5", Type: gosnmp.OctetString, Value: []byte("eth0")},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.3.5", Type: gosnmp.OctetString, Value: []byte{0x00, 0xff}},
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.4.5", Type: gosnmp.Counter64, Value: uint64(42)},
+				},
+			},
+			expected: map[string]any{
+				"snmp.version":                   "v2c",
+				"snmp.pdu.type":                  "trap",
+				"snmp.trap.oid":                  "1.3.6.1.4.1.99999.2.1",
+				"snmp.trap.name":                 "otelTestAlarm",
+				"snmp.varbind.sysUpTime.0":       int64(1000),
+				"snmp.varbind.otelTestName.5":    "eth0",
+				"snmp.varbind.otelTestEntry.3.5": "00ff",
+				"snmp.varbind.otelTestEntry.4.5": int64(42),
+				"network.peer.address":           "127.0.0.1",
+			},
+		},
+		{
+			desc:   "receives v1 traps",
+			cfg:    func(cfg *Config) { cfg.Version = "v1" },
+			client: func(client *gosnmp.GoSNMP) { client.Version = gosnmp.Version1 },
+			trap: gosnmp.SnmpTrap{
+				Enterprise:   ".1.3.6.1.4.1.99999",
+				AgentAddress: "192.0.2.1",
+				GenericTrap:  6,
+				SpecificTrap: 7,
+				Timestamp:    300,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: ".1.3.6.1.4.1.99999.1.1.1.2.5", Type: gosnmp.OctetString, Value: []byte("eth0")},
+				},
+			},
+			expected: map[string]any{
+				"snmp.version":                "v1",
+				"snmp.pdu.type":               "trap",
+				"snmp.trap.oid":               "1.3.6.1.4.1.99999.0.7",
+				"snmp.trap.name":              "otelTestV1Alarm",
+				"snmp.trap.enterprise":        "1.3.6.1.4.1.99999",
+				"snmp.trap.generic":           int64(6),
+				"snmp.trap.specific":          int64(7),
+				"snmp.agent.address":          "192.0.2.1",
+				"snmp.varbind.sysUpTime.0":    int64(300),
+				"snmp.varbind.otelTestName.5": "eth0",
+				"network.peer.address":        "127.0.0.1",
+			},
+		},
+		{
+			desc: "receives v3 informs",
+			cfg: func(cfg *Config) {
+				cfg.Version = "v3"
+				cfg.User = "otel"
+				cfg.SecurityLevel = "auth_priv"
+				cfg.AuthType = "SHA"
+				cfg.AuthPassword = "authpassword"
+				cfg.PrivacyType = "AES"
+				cfg.PrivacyPassword = "privacypassword"
+			},
+			client: func(client *gosnmp.GoSNMP) {
+				client.Version = gosnmp.Version3
+				client.SecurityModel = gosnmp.UserSecurityModel
+				client.MsgFlags = gosnmp.AuthPriv
+				client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+					UserName:                 "otel",
+					AuthenticationProtocol:   gosnmp.SHA,
+					AuthenticationPassphrase: "authpassword",
+					PrivacyProtocol:          gosnmp.AES,
+					PrivacyPassphrase:        "privacypassword",
+				}
+			},
+			trap: gosnmp.SnmpTrap{
+				IsInform: true,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1000)},
+					{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+					{Name: ".1.3.6.1.2.1.2.2.1.1.2", Type: gosnmp.Integer, Value: 2},
+				},
+			},
+			expected: map[string]any{
+				"snmp.version":                 "v3",
+				"snmp.pdu.type":                "inform",
+				"snmp.trap.oid":                "1.3.6.1.6.3.1.1.5.3",
+				"snmp.trap.name":               "linkDown",
+				"snmp.varbind.sysUpTime.0":     int64(1000),
+				"snmp.varbind.mib-2.2.2.1.1.2": int64(2),
+				"network.peer.address":         "127.0.0.1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			endpoint := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+			cfg := createDefaultConfig().(*Config)
+			cfg.Traps = &TrapsConfig{
+				Endpoint: "udp://" + endpoint,
+				MIBPaths: []string{filepath.Join("testdata", "mibs")},
+			}
+			if tc.cfg != nil {
+				tc.cfg(cfg)
+			}
+
+			sink := new(consumertest.LogsSink)
+			rcvr, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, rcvr.Shutdown(context.Background()))
+			}()
+
+			host, port, err := net.SplitHostPort(endpoint)
+			require.NoError(t, err)
+			portNum, err := strconv.ParseUint(port, 10, 16)
+			require.NoError(t, err)
+			client := &gosnmp.GoSNMP{
+				Target:    host,
+				Port:      uint16(portNum),
+				Version:   gosnmp.Version2c,
+				Community: "public",
+				Timeout:   2 * time.Second,
+				Retries:   3,
+			}
+			if tc.client != nil {
+				tc.client(client)
+			}
+			require.NoError(t, client.Connect())
+			defer client.Conn.Close()
+
+			_, err = client.SendTrap(tc.trap)
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool {
+				return sink.LogRecordCount() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			logRecord := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, tc.expected["snmp.trap.name"], logRecord.Body().Str())
+			attrs := logRecord.Attributes().AsRaw()
+			assert.Contains(t, attrs, "network.peer.port")
+			delete(attrs, "network.peer.port")
+			assert.Equal(t, tc.expected, attrs)
+		})
+	}
+}
+
+func TestTrapReceiverDropsUnauthenticatedTraps(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traps = &TrapsConfig{}
+	rcvr, err := newTrapReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+
+	assert.True(t, rcvr.accept(&gosnmp.SnmpPacket{Version: gosnmp.Version2c, Community: "public"}))
+	assert.False(t, rcvr.accept(&gosnmp.SnmpPacket{Version: gosnmp.Version2c, Community: "private"}))
+	assert.False(t, rcvr.accept(&gosnmp.SnmpPacket{Version: gosnmp.Version3}))
+
+	cfg.Version = "v3"
+	cfg.User = "otel"
+	cfg.SecurityLevel = "auth_no_priv"
+	assert.True(t, rcvr.accept(&gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		MsgFlags:           gosnmp.AuthPriv,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "otel"},
+	}))
+	assert.False(t, rcvr.accept(&gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		MsgFlags:           gosnmp.NoAuthNoPriv,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "otel"},
+	}))
+	assert.False(t, rcvr.accept(&gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		MsgFlags:           gosnmp.AuthPriv,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "other"},
+	}))
+	assert.False(t, rcvr.accept(&gosnmp.SnmpPacket{Version: gosnmp.Version2c, Community: "public"}))
+}
+
+func TestPutVarbind(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		variable gosnmp.SnmpPDU
+		expected any
+	}{
+		{
+			desc:     "integer",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.Integer, Value: -3},
+			expected: int64(-3),
+		},
+		{
+			desc:     "counter64 overflowing int64",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.Counter64, Value: uint64(1) << 63},
+			expected: "9223372036854775808",
+		},
+		{
+			desc:     "opaque float",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.OpaqueFloat, Value: float32(1.5)},
+			expected: 1.5,
+		},
+		{
+			desc:     "printable octet string",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.OctetString, Value: []byte("link down\n")},
+			expected: "link down\n",
+		},
+		{
+			desc:     "binary octet string",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.OctetString, Value: []byte{0x00, 0xff}},
+			expected: "00ff",
+		},
+		{
+			desc:     "object identifier",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1"},
+			expected: "1.3.6.1",
+		},
+		{
+			desc:     "ip address",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.IPAddress, Value: "192.0.2.1"},
+			expected: "192.0.2.1",
+		},
+		{
+			desc:     "null",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.Null},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			attrs := pcommon.NewMap()
+			putVarbind(attrs, "key", tc.variable)
+			require.Equal(t, map[string]any{"key": tc.expected}, attrs.AsRaw())
+		})
+	}
+}
+
+func TestConvertNonConfiguredTrapName(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traps = &TrapsConfig{}
+	rcvr, err := newTrapReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	rcvr.translator, err = newMIBTranslator(nil)
+	require.NoError(t, err)
+
+	logs := rcvr.convert(&gosnmp.SnmpPacket{
+		Version: gosnmp.Version2c,
+		PDUType: gosnmp.SNMPv2Trap,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".2.25.1"},
+		},
+	}, nil, time.Unix(1, 0))
+
+	logRecord := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "2.25.1", logRecord.Body().Str())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1, 0)), logRecord.Timestamp())
+	assert.Equal(t, map[string]any{
+		"snmp.version":  "v2c",
+		"snmp.pdu.type": "trap",
+		"snmp.trap.oid": "2.25.1",
+	}, logRecord.Attributes().AsRaw())
+	assert.Equal(t, metadata.ScopeName, logs.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+}
@@ -102,7 +102,7 @@ func TestLoadConfig(t *testing.T) {
 		{
 			fname:        "config-invalid-missing-logs-metrics.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
-			errorMessage: "at least one of 'query.logs' and 'query.metrics' must not be empty",
+			errorMessage: "at least one of 'query.logs', 'query.metrics' and 'query.traces' must not be empty",
 		},
 		{
 			fname:        "config-invalid-missing-datasource.yaml",
@@ -141,6 +141,41 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, ""),
 			errorMessage: "'body_column' must not be empty",
 		},
+		{
+			fname: "config-traces.yaml",
+			id:    component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				Config: sqlquery.Config{
+					ControllerConfig: scraperhelper.ControllerConfig{
+						CollectionInterval: 10 * time.Second,
+						InitialDelay:       time.Second,
+					},
+					Driver:     "mydriver",
+					DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+					Queries: []sqlquery.Query{
+						{
+							SQL:                "select * from request_audit where audit_id > ? order by audit_id",
+							TrackingColumn:     "audit_id",
+							TrackingStartValue: "10",
+							Traces: []sqlquery.TracesCfg{
+								{
+									TraceIDColumn:       "correlation_id",
+									SpanIDColumn:        "request_id",
+									ParentSpanIDColumn:  "parent_request_id",
+									NameColumn:          "operation",
+									StartTsColumn:       "started_at",
+									EndTsColumn:         "finished_at",
+									StatusCodeColumn:    "status",
+									StatusMessageColumn: "error_message",
+									Kind:                sqlquery.SpanKindServer,
+									AttributeColumns:    []string{"user_id", "http_route"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			fname:        "config-unnecessary-aggregation.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
@@ -193,3 +228,24 @@ func TestConfig_Validate_Multierr(t *testing.T) {
 	assert.ErrorContains(t, err, "metric config has unsupported data_type: 'xgauge'")
 	assert.ErrorContains(t, err, "metric config has unsupported aggregation: 'xcumulative'")
 }
+
+func TestConfig_Validate_TracesMissingColumns(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config-traces-missing-columns.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	err = xconfmap.Validate(cfg)
+
+	assert.ErrorContains(t, err, "'trace_id_column' must not be empty")
+	assert.ErrorContains(t, err, "'span_id_column' must not be empty")
+	assert.ErrorContains(t, err, "'name_column' must not be empty")
+	assert.ErrorContains(t, err, "'start_ts_column' must not be empty")
+	assert.ErrorContains(t, err, "'end_ts_column' must not be empty")
+	assert.ErrorContains(t, err, "traces config has unsupported kind: 'xyzserver'")
+}
@@ -18,5 +18,6 @@ func NewFactory() receiver.Factory {
 		createDefaultConfig,
 		receiver.WithLogs(createLogsReceiverFunc(sql.Open, sqlquery.NewDbClient), metadata.LogsStability),
 		receiver.WithMetrics(createMetricsReceiverFunc(sql.Open, sqlquery.NewDbClient), metadata.MetricsStability),
+		receiver.WithTraces(createTracesReceiverFunc(sql.Open, sqlquery.NewDbClient), metadata.TracesStability),
 	)
 }
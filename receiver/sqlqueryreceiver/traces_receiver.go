@@ -0,0 +1,448 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
+)
+
+// tracesStorageClientName is the name of the storage client used by the traces receiver,
+// so that it does not share its storage with the logs receiver created from the same configuration.
+const tracesStorageClientName = "traces"
+
+type tracesReceiver struct {
+	config           *Config
+	settings         receiver.Settings
+	createConnection sqlquery.DbProviderFunc
+	createClient     sqlquery.ClientProviderFunc
+	queryReceivers   []*tracesQueryReceiver
+	nextConsumer     consumer.Traces
+
+	isStarted                bool
+	collectionIntervalTicker *time.Ticker
+	shutdownRequested        chan struct{}
+
+	storageClient storage.Client
+	obsrecv       *receiverhelper.ObsReport
+}
+
+func newTracesReceiver(
+	config *Config,
+	settings receiver.Settings,
+	sqlOpenerFunc sqlquery.SQLOpenerFunc,
+	createClient sqlquery.ClientProviderFunc,
+	nextConsumer consumer.Traces,
+) (*tracesReceiver, error) {
+	obsr, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracesReceiver{
+		config:   config,
+		settings: settings,
+		createConnection: func() (*sql.DB, error) {
+			return sqlOpenerFunc(config.Driver, config.DataSource)
+		},
+		createClient:      createClient,
+		nextConsumer:      nextConsumer,
+		shutdownRequested: make(chan struct{}),
+		obsrecv:           obsr,
+	}, nil
+}
+
+func (receiver *tracesReceiver) Start(ctx context.Context, host component.Host) error {
+	if receiver.isStarted {
+		receiver.settings.Logger.Debug("requested start, but already started, ignoring.")
+		return nil
+	}
+	receiver.settings.Logger.Debug("starting...")
+	receiver.isStarted = true
+
+	var err error
+	receiver.storageClient, err = getStorageClient(ctx, host, receiver.config.StorageID, receiver.settings.ID, tracesStorageClientName)
+	if err != nil {
+		return fmt.Errorf("error connecting to storage: %w", err)
+	}
+
+	receiver.queryReceivers = nil
+	for i, query := range receiver.config.Queries {
+		if len(query.Traces) == 0 {
+			continue
+		}
+		id := fmt.Sprintf("query-%d: %s", i, query.SQL)
+		queryReceiver := newTracesQueryReceiver(
+			id,
+			query,
+			receiver.createConnection,
+			receiver.createClient,
+			receiver.settings.Logger,
+			receiver.config.Telemetry,
+			receiver.storageClient,
+		)
+		if err := queryReceiver.start(ctx); err != nil {
+			return err
+		}
+		receiver.queryReceivers = append(receiver.queryReceivers, queryReceiver)
+	}
+
+	receiver.startCollecting()
+	receiver.settings.Logger.Debug("started.")
+	return nil
+}
+
+func (receiver *tracesReceiver) startCollecting() {
+	receiver.collectionIntervalTicker = time.NewTicker(receiver.config.CollectionInterval)
+
+	go func() {
+		for {
+			select {
+			case <-receiver.collectionIntervalTicker.C:
+				receiver.collect()
+			case <-receiver.shutdownRequested:
+				return
+			}
+		}
+	}()
+}
+
+func (receiver *tracesReceiver) collect() {
+	tracesChannel := make(chan ptrace.Traces)
+	for _, queryReceiver := range receiver.queryReceivers {
+		go func(queryReceiver *tracesQueryReceiver) {
+			traces, err := queryReceiver.collect(context.Background())
+			if err != nil {
+				receiver.settings.Logger.Error("error collecting traces", zap.Error(err), zap.String("query", queryReceiver.ID()))
+			}
+			tracesChannel <- traces
+		}(queryReceiver)
+	}
+
+	allTraces := ptrace.NewTraces()
+	for range receiver.queryReceivers {
+		traces := <-tracesChannel
+		traces.ResourceSpans().MoveAndAppendTo(allTraces.ResourceSpans())
+	}
+
+	spanCount := allTraces.SpanCount()
+	if spanCount > 0 {
+		ctx := receiver.obsrecv.StartTracesOp(context.Background())
+		err := receiver.nextConsumer.ConsumeTraces(context.Background(), allTraces)
+		receiver.obsrecv.EndTracesOp(ctx, metadata.Type.String(), spanCount, err)
+		if err != nil {
+			receiver.settings.Logger.Error("failed to send traces", zap.Error(err))
+		}
+	}
+}
+
+func (receiver *tracesReceiver) Shutdown(ctx context.Context) error {
+	if !receiver.isStarted {
+		receiver.settings.Logger.Debug("Requested shutdown, but not started, ignoring.")
+		return nil
+	}
+
+	var errs []error
+	receiver.settings.Logger.Debug("stopping...")
+	if receiver.collectionIntervalTicker != nil {
+		receiver.collectionIntervalTicker.Stop()
+	}
+	close(receiver.shutdownRequested)
+	for _, queryReceiver := range receiver.queryReceivers {
+		errs = append(errs, queryReceiver.shutdown(ctx))
+	}
+
+	if receiver.storageClient != nil {
+		errs = append(errs, receiver.storageClient.Close(ctx))
+	}
+
+	receiver.isStarted = false
+	receiver.settings.Logger.Debug("stopped.")
+
+	return errors.Join(errs...)
+}
+
+// getStorageClient returns a client of the configured storage extension, or a no-op client if storage is not configured.
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, name string) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, name)
+}
+
+type tracesQueryReceiver struct {
+	id           string
+	query        sqlquery.Query
+	createDb     sqlquery.DbProviderFunc
+	createClient sqlquery.ClientProviderFunc
+	logger       *zap.Logger
+	telemetry    sqlquery.TelemetryConfig
+
+	db                      *sql.DB
+	client                  sqlquery.DbClient
+	trackingValue           string
+	storageClient           storage.Client
+	trackingValueStorageKey string
+}
+
+func newTracesQueryReceiver(
+	id string,
+	query sqlquery.Query,
+	dbProviderFunc sqlquery.DbProviderFunc,
+	clientProviderFunc sqlquery.ClientProviderFunc,
+	logger *zap.Logger,
+	telemetry sqlquery.TelemetryConfig,
+	storageClient storage.Client,
+) *tracesQueryReceiver {
+	return &tracesQueryReceiver{
+		id:                      id,
+		query:                   query,
+		createDb:                dbProviderFunc,
+		createClient:            clientProviderFunc,
+		logger:                  logger,
+		telemetry:               telemetry,
+		trackingValue:           query.TrackingStartValue,
+		storageClient:           storageClient,
+		trackingValueStorageKey: fmt.Sprintf("%s.%s", id, "trackingValue"),
+	}
+}
+
+func (queryReceiver *tracesQueryReceiver) ID() string {
+	return queryReceiver.id
+}
+
+func (queryReceiver *tracesQueryReceiver) start(ctx context.Context) error {
+	var err error
+	queryReceiver.db, err = queryReceiver.createDb()
+	if err != nil {
+		return fmt.Errorf("failed to open db connection: %w", err)
+	}
+	queryReceiver.client = queryReceiver.createClient(sqlquery.DbWrapper{Db: queryReceiver.db}, queryReceiver.query.SQL, queryReceiver.logger, queryReceiver.telemetry)
+
+	// Like the logs receiver, fall back to `tracking_start_value` when no tracking value was stored.
+	if queryReceiver.storageClient != nil {
+		if stored, err := queryReceiver.storageClient.Get(ctx, queryReceiver.trackingValueStorageKey); err == nil && stored != nil {
+			queryReceiver.trackingValue = string(stored)
+		}
+	}
+	return nil
+}
+
+func (queryReceiver *tracesQueryReceiver) collect(ctx context.Context) (ptrace.Traces, error) {
+	traces := ptrace.NewTraces()
+
+	var rows []sqlquery.StringMap
+	var err error
+	if queryReceiver.query.TrackingColumn != "" {
+		rows, err = queryReceiver.client.QueryRows(ctx, queryReceiver.trackingValue)
+	} else {
+		rows, err = queryReceiver.client.QueryRows(ctx)
+	}
+	if err != nil {
+		// NULL values are expected for optional columns such as the parent span ID of root spans.
+		if !errors.Is(err, sqlquery.ErrNullValueWarning) {
+			return traces, fmt.Errorf("error getting rows: %w", err)
+		}
+		queryReceiver.logger.Warn("problems encountered getting span rows", zap.Error(err))
+	}
+
+	var errs []error
+	scope := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	scope.Scope().SetName(metadata.ScopeName)
+	spans := scope.Spans()
+	for _, tracesConfig := range queryReceiver.query.Traces {
+		for i, row := range rows {
+			// The span is only added once the row is known to be valid, so that invalid rows don't
+			// produce spans with missing IDs or timestamps.
+			span := ptrace.NewSpan()
+			if err := rowToSpan(row, tracesConfig, span); err != nil {
+				errs = append(errs, fmt.Errorf("row %d: %w", i, err))
+				continue
+			}
+			span.MoveTo(spans.AppendEmpty())
+		}
+	}
+	if len(rows) > 0 && queryReceiver.query.TrackingColumn != "" {
+		errs = append(errs, queryReceiver.storeTrackingValue(ctx, rows[len(rows)-1]))
+	}
+	return traces, errors.Join(errs...)
+}
+
+func (queryReceiver *tracesQueryReceiver) storeTrackingValue(ctx context.Context, row sqlquery.StringMap) error {
+	queryReceiver.trackingValue = row[queryReceiver.query.TrackingColumn]
+	if queryReceiver.storageClient == nil {
+		return nil
+	}
+	return queryReceiver.storageClient.Set(ctx, queryReceiver.trackingValueStorageKey, []byte(queryReceiver.trackingValue))
+}
+
+func (queryReceiver *tracesQueryReceiver) shutdown(_ context.Context) error {
+	if queryReceiver.db == nil {
+		return nil
+	}
+
+	return queryReceiver.db.Close()
+}
+
+func rowToSpan(row sqlquery.StringMap, config sqlquery.TracesCfg, span ptrace.Span) error {
+	var errs []error
+	if value, found := row[config.TraceIDColumn]; !found {
+		errs = append(errs, fmt.Errorf("rowToSpan: trace_id_column '%s' not found in result set", config.TraceIDColumn))
+	} else if traceID, err := parseID(value, 16); err != nil {
+		errs = append(errs, fmt.Errorf("rowToSpan: trace_id_column '%s': %w", config.TraceIDColumn, err))
+	} else {
+		span.SetTraceID(pcommon.TraceID(traceID))
+	}
+
+	if value, found := row[config.SpanIDColumn]; !found {
+		errs = append(errs, fmt.Errorf("rowToSpan: span_id_column '%s' not found in result set", config.SpanIDColumn))
+	} else if spanID, err := parseID(value, 8); err != nil {
+		errs = append(errs, fmt.Errorf("rowToSpan: span_id_column '%s': %w", config.SpanIDColumn, err))
+	} else {
+		span.SetSpanID(pcommon.SpanID(spanID))
+	}
+
+	// A missing or empty parent span ID denotes a root span.
+	if value := row[config.ParentSpanIDColumn]; config.ParentSpanIDColumn != "" && value != "" {
+		if parentSpanID, err := parseID(value, 8); err != nil {
+			errs = append(errs, fmt.Errorf("rowToSpan: parent_span_id_column '%s': %w", config.ParentSpanIDColumn, err))
+		} else {
+			span.SetParentSpanID(pcommon.SpanID(parentSpanID))
+		}
+	}
+
+	if value, found := row[config.NameColumn]; !found {
+		errs = append(errs, fmt.Errorf("rowToSpan: name_column '%s' not found in result set", config.NameColumn))
+	} else {
+		span.SetName(value)
+	}
+
+	if value, found := row[config.StartTsColumn]; !found {
+		errs = append(errs, fmt.Errorf("rowToSpan: start_ts_column '%s' not found in result set", config.StartTsColumn))
+	} else if ts, err := parseTimestamp(value); err != nil {
+		errs = append(errs, fmt.Errorf("rowToSpan: start_ts_column '%s': %w", config.StartTsColumn, err))
+	} else {
+		span.SetStartTimestamp(ts)
+	}
+
+	if value, found := row[config.EndTsColumn]; !found {
+		errs = append(errs, fmt.Errorf("rowToSpan: end_ts_column '%s' not found in result set", config.EndTsColumn))
+	} else if ts, err := parseTimestamp(value); err != nil {
+		errs = append(errs, fmt.Errorf("rowToSpan: end_ts_column '%s': %w", config.EndTsColumn, err))
+	} else {
+		span.SetEndTimestamp(ts)
+	}
+
+	if config.StatusCodeColumn != "" {
+		if value, found := row[config.StatusCodeColumn]; !found {
+			errs = append(errs, fmt.Errorf("rowToSpan: status_code_column '%s' not found in result set", config.StatusCodeColumn))
+		} else if code, err := parseStatusCode(value); err != nil {
+			errs = append(errs, fmt.Errorf("rowToSpan: status_code_column '%s': %w", config.StatusCodeColumn, err))
+		} else {
+			span.Status().SetCode(code)
+		}
+	}
+	if config.StatusMessageColumn != "" {
+		if value, found := row[config.StatusMessageColumn]; found {
+			span.Status().SetMessage(value)
+		}
+	}
+
+	span.SetKind(spanKind(config.Kind))
+
+	attrs := span.Attributes()
+	for _, columnName := range config.AttributeColumns {
+		if attrVal, found := row[columnName]; found {
+			attrs.PutStr(columnName, attrVal)
+		} else {
+			errs = append(errs, fmt.Errorf("rowToSpan: attribute_column '%s' not found in result set", columnName))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// parseID decodes a hex encoded ID of the given size. Dashes are ignored so that UUIDs can be used as trace IDs.
+func parseID(value string, size int) ([]byte, error) {
+	id, err := hex.DecodeString(strings.ReplaceAll(value, "-", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID %q: %w", value, err)
+	}
+	if len(id) != size {
+		return nil, fmt.Errorf("invalid ID %q: expected %d bytes, got %d", value, size, len(id))
+	}
+	return id, nil
+}
+
+// parseTimestamp parses either an integer number of nanoseconds since the Unix epoch,
+// or an RFC 3339 timestamp as returned for the database time types.
+func parseTimestamp(value string) (pcommon.Timestamp, error) {
+	if nanos, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return pcommon.Timestamp(nanos), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q: expected nanoseconds since the Unix epoch or RFC 3339", value)
+	}
+	return pcommon.NewTimestampFromTime(t), nil
+}
+
+func parseStatusCode(value string) (ptrace.StatusCode, error) {
+	switch strings.ToLower(value) {
+	case "", "0", "unset":
+		return ptrace.StatusCodeUnset, nil
+	case "1", "ok":
+		return ptrace.StatusCodeOk, nil
+	case "2", "error":
+		return ptrace.StatusCodeError, nil
+	}
+	return ptrace.StatusCodeUnset, fmt.Errorf("invalid status code %q: expected one of unset, ok, error", value)
+}
+
+func spanKind(kind sqlquery.SpanKind) ptrace.SpanKind {
+	switch kind {
+	case sqlquery.SpanKindInternal:
+		return ptrace.SpanKindInternal
+	case sqlquery.SpanKindServer:
+		return ptrace.SpanKindServer
+	case sqlquery.SpanKindClient:
+		return ptrace.SpanKindClient
+	case sqlquery.SpanKindProducer:
+		return ptrace.SpanKindProducer
+	case sqlquery.SpanKindConsumer:
+		return ptrace.SpanKindConsumer
+	}
+	return ptrace.SpanKindUnspecified
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
+)
+
+var testTracesCfg = sqlquery.TracesCfg{
+	TraceIDColumn:       "correlation_id",
+	SpanIDColumn:        "request_id",
+	ParentSpanIDColumn:  "parent_request_id",
+	NameColumn:          "operation",
+	StartTsColumn:       "started_at",
+	EndTsColumn:         "finished_at",
+	StatusCodeColumn:    "status",
+	StatusMessageColumn: "error_message",
+	Kind:                sqlquery.SpanKindServer,
+	AttributeColumns:    []string{"user_id"},
+}
+
+func TestTracesQueryReceiver_Collect(t *testing.T) {
+	fakeClient := &sqlquery.FakeDBClient{
+		StringMaps: [][]sqlquery.StringMap{
+			{
+				{
+					"audit_id":       "11",
+					"correlation_id": "5b8efff7-98a1-4d27-9c53-34a4d5f1b5a9",
+					"request_id":     "0102030405060708",
+					"operation":      "GET /orders",
+					"started_at":     "2025-06-01T10:00:00.5Z",
+					"finished_at":    "2025-06-01T10:00:01Z",
+					"status":         "OK",
+					"error_message":  "",
+					"user_id":        "alice",
+				},
+				{
+					"audit_id":          "12",
+					"correlation_id":    "5b8efff798a14d279c5334a4d5f1b5a9",
+					"request_id":        "1112131415161718",
+					"parent_request_id": "0102030405060708",
+					"operation":         "select orders",
+					"started_at":        "1748772000600000000",
+					"finished_at":       "1748772000900000000",
+					"status":            "2",
+					"error_message":     "timeout",
+					"user_id":           "alice",
+				},
+			},
+		},
+	}
+	queryReceiver := tracesQueryReceiver{
+		client: fakeClient,
+		query: sqlquery.Query{
+			TrackingColumn: "audit_id",
+			Traces:         []sqlquery.TracesCfg{testTracesCfg},
+		},
+	}
+	traces, err := queryReceiver.collect(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, traces.SpanCount())
+	assert.Equal(t, "12", queryReceiver.trackingValue)
+
+	traceID := pcommon.TraceID([16]byte{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0xa1, 0x4d, 0x27, 0x9c, 0x53, 0x34, 0xa4, 0xd5, 0xf1, 0xb5, 0xa9})
+	spans := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+
+	root := spans.At(0)
+	assert.Equal(t, traceID, root.TraceID())
+	assert.Equal(t, pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), root.SpanID())
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, "GET /orders", root.Name())
+	assert.Equal(t, ptrace.SpanKindServer, root.Kind())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Date(2025, 6, 1, 10, 0, 0, 500_000_000, time.UTC)), root.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Date(2025, 6, 1, 10, 0, 1, 0, time.UTC)), root.EndTimestamp())
+	assert.Equal(t, ptrace.StatusCodeOk, root.Status().Code())
+	assert.Equal(t, map[string]any{"user_id": "alice"}, root.Attributes().AsRaw())
+
+	child := spans.At(1)
+	assert.Equal(t, traceID, child.TraceID())
+	assert.Equal(t, root.SpanID(), child.ParentSpanID())
+	assert.Equal(t, pcommon.Timestamp(1748772000600000000), child.StartTimestamp())
+	assert.Equal(t, ptrace.StatusCodeError, child.Status().Code())
+	assert.Equal(t, "timeout", child.Status().Message())
+}
+
+func TestTracesQueryReceiver_TrackingValueStorage(t *testing.T) {
+	storageClient := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("sqlquery"), tracesStorageClientName)
+	fakeClient := &sqlquery.FakeDBClient{
+		StringMaps: [][]sqlquery.StringMap{
+			{{"id": "3"}, {"id": "5"}},
+		},
+	}
+	query := sqlquery.Query{
+		SQL:                "select * from audit where id > ?",
+		TrackingColumn:     "id",
+		TrackingStartValue: "0",
+		Traces:             []sqlquery.TracesCfg{{TraceIDColumn: "id"}},
+	}
+	queryReceiver := newTracesQueryReceiver("query-0", query, nil, nil, zap.NewNop(), sqlquery.TelemetryConfig{}, storageClient)
+	queryReceiver.client = fakeClient
+	assert.Equal(t, "0", queryReceiver.trackingValue)
+
+	_, err := queryReceiver.collect(context.Background())
+	// The rows are not valid spans, but the tracking value is stored regardless.
+	assert.Error(t, err)
+	stored, err := storageClient.Get(context.Background(), "query-0.trackingValue")
+	require.NoError(t, err)
+	assert.Equal(t, "5", string(stored))
+
+	restarted := newTracesQueryReceiver("query-0", query, func() (*sql.DB, error) { return fakeDBConnect("", "") }, mkFakeClient, zap.NewNop(), sqlquery.TelemetryConfig{}, storageClient)
+	require.NoError(t, restarted.start(context.Background()))
+	assert.Equal(t, "5", restarted.trackingValue)
+	require.NoError(t, restarted.shutdown(context.Background()))
+}
+
+func TestTracesQueryReceiver_MissingColumnInResultSet(t *testing.T) {
+	fakeClient := &sqlquery.FakeDBClient{
+		StringMaps: [][]sqlquery.StringMap{
+			{{"col1": "42"}},
+		},
+	}
+	queryReceiver := tracesQueryReceiver{
+		client: fakeClient,
+		query: sqlquery.Query{
+			Traces: []sqlquery.TracesCfg{testTracesCfg},
+		},
+	}
+	_, err := queryReceiver.collect(context.Background())
+	assert.ErrorContains(t, err, "rowToSpan: trace_id_column 'correlation_id' not found in result set")
+	assert.ErrorContains(t, err, "rowToSpan: span_id_column 'request_id' not found in result set")
+	assert.ErrorContains(t, err, "rowToSpan: name_column 'operation' not found in result set")
+	assert.ErrorContains(t, err, "rowToSpan: start_ts_column 'started_at' not found in result set")
+	assert.ErrorContains(t, err, "rowToSpan: end_ts_column 'finished_at' not found in result set")
+	assert.ErrorContains(t, err, "rowToSpan: status_code_column 'status' not found in result set")
+	assert.ErrorContains(t, err, "rowToSpan: attribute_column 'user_id' not found in result set")
+}
+
+func TestTracesQueryReceiver_InvalidRowProducesNoSpan(t *testing.T) {
+	fakeClient := &sqlquery.FakeDBClient{
+		StringMaps: [][]sqlquery.StringMap{
+			{
+				{
+					"correlation_id": "not-hex",
+					"request_id":     "0102030405060708",
+					"operation":      "GET /orders",
+					"started_at":     "2025-06-01T10:00:00Z",
+					"finished_at":    "2025-06-01T10:00:01Z",
+					"status":         "OK",
+					"user_id":        "alice",
+				},
+				{
+					"correlation_id": "5b8efff798a14d279c5334a4d5f1b5a9",
+					"request_id":     "1112131415161718",
+					"operation":      "select orders",
+					"started_at":     "2025-06-01T10:00:00Z",
+					"finished_at":    "2025-06-01T10:00:01Z",
+					"status":         "OK",
+					"user_id":        "alice",
+				},
+			},
+		},
+	}
+	queryReceiver := tracesQueryReceiver{
+		client: fakeClient,
+		query: sqlquery.Query{
+			Traces: []sqlquery.TracesCfg{testTracesCfg},
+		},
+	}
+	traces, err := queryReceiver.collect(context.Background())
+	assert.ErrorContains(t, err, `row 0: rowToSpan: trace_id_column 'correlation_id': invalid ID "not-hex"`)
+	spans := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 1, spans.Len())
+	assert.Equal(t, "select orders", spans.At(0).Name())
+}
+
+func TestRowToSpan_InvalidValues(t *testing.T) {
+	row := sqlquery.StringMap{
+		"correlation_id":    "not-hex",
+		"request_id":        "0102",
+		"parent_request_id": "zz",
+		"operation":         "op",
+		"started_at":        "yesterday",
+		"finished_at":       "2025-06-01 10:00:00",
+		"status":            "failed",
+		"user_id":           "alice",
+	}
+	err := rowToSpan(row, testTracesCfg, ptrace.NewSpan())
+	assert.ErrorContains(t, err, `trace_id_column 'correlation_id': invalid ID "not-hex"`)
+	assert.ErrorContains(t, err, `span_id_column 'request_id': invalid ID "0102": expected 8 bytes, got 2`)
+	assert.ErrorContains(t, err, `parent_span_id_column 'parent_request_id': invalid ID "zz"`)
+	assert.ErrorContains(t, err, `start_ts_column 'started_at': invalid timestamp "yesterday"`)
+	assert.ErrorContains(t, err, `end_ts_column 'finished_at': invalid timestamp "2025-06-01 10:00:00"`)
+	assert.ErrorContains(t, err, `status_code_column 'status': invalid status code "failed"`)
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package systemdreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/systemdreceiver"
+
+import (
+	"context"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+)
+
+// dbusClient is the subset of the systemd D-Bus API used by the scraper.
+// It is satisfied by *dbus.Conn and allows a fake bus to be used in tests.
+type dbusClient interface {
+	ListUnitsByPatternsContext(ctx context.Context, states, patterns []string) ([]dbus.UnitStatus, error)
+	GetUnitPropertiesContext(ctx context.Context, unit string) (map[string]any, error)
+	GetUnitTypePropertiesContext(ctx context.Context, unit, unitType string) (map[string]any, error)
+	Close()
+}
+
+type newClientFunc func(ctx context.Context, scope string) (dbusClient, error)
+
+// newDBusClient connects to the system or user instance of systemd.
+func newDBusClient(ctx context.Context, scope string) (dbusClient, error) {
+	var (
+		conn *dbus.Conn
+		err  error
+	)
+	if scope == scopeUser {
+		conn, err = dbus.NewUserConnectionContext(ctx)
+	} else {
+		conn, err = dbus.NewSystemConnectionContext(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
@@ -3,4 +3,72 @@
 
 package systemdreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/systemdreceiver"
 
-type Config struct{}
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/systemdreceiver/internal/metadata"
+)
+
+const (
+	scopeSystem = "system"
+	scopeUser   = "user"
+)
+
+// unitTypes lists the unit types known to systemd, see systemd.unit(5).
+var unitTypes = []string{
+	"automount",
+	"device",
+	"mount",
+	"path",
+	"scope",
+	"service",
+	"slice",
+	"socket",
+	"swap",
+	"target",
+	"timer",
+}
+
+type Config struct {
+	scraperhelper.ControllerConfig `mapstructure:",squash"`
+	MetricsBuilderConfig           metadata.MetricsBuilderConfig `mapstructure:",squash"`
+
+	// Scope selects the systemd instance to connect to, either "system" or "user".
+	Scope string `mapstructure:"scope"`
+	// Include is the list of unit name globs to scrape. The globs are evaluated by systemd.
+	Include []string `mapstructure:"include"`
+	// Exclude is the list of unit name globs to drop from the included units.
+	Exclude []string `mapstructure:"exclude"`
+	// UnitTypes restricts scraping to units of the given types, e.g. "service" or "socket".
+	// All types are scraped when empty.
+	UnitTypes []string `mapstructure:"unit_types"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.Scope != scopeSystem && cfg.Scope != scopeUser {
+		errs = append(errs, fmt.Errorf("invalid scope %q, must be %q or %q", cfg.Scope, scopeSystem, scopeUser))
+	}
+	if len(cfg.Include) == 0 {
+		errs = append(errs, errors.New("at least one include pattern must be specified"))
+	}
+	for _, pattern := range slices.Concat(cfg.Include, cfg.Exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid unit pattern %q: %w", pattern, err))
+		}
+	}
+	for _, unitType := range cfg.UnitTypes {
+		if !slices.Contains(unitTypes, unitType) {
+			errs = append(errs, fmt.Errorf("unknown unit type %q", unitType))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package systemdreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/systemdreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected func() *Config
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: func() *Config {
+				return createDefaultConfig().(*Config)
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.CollectionInterval = 30 * time.Second
+				cfg.Scope = scopeUser
+				cfg.Include = []string{"*.service", "*.socket"}
+				cfg.Exclude = []string{"systemd-*"}
+				cfg.UnitTypes = []string{"service"}
+				return cfg
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected(), cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:        "invalid scope",
+			modify:      func(cfg *Config) { cfg.Scope = "session" },
+			expectedErr: `invalid scope "session"`,
+		},
+		{
+			name:        "no include patterns",
+			modify:      func(cfg *Config) { cfg.Include = nil },
+			expectedErr: "at least one include pattern must be specified",
+		},
+		{
+			name:        "invalid exclude pattern",
+			modify:      func(cfg *Config) { cfg.Exclude = []string{"foo[.service"} },
+			expectedErr: `invalid unit pattern "foo[.service"`,
+		},
+		{
+			name:        "unknown unit type",
+			modify:      func(cfg *Config) { cfg.UnitTypes = []string{"service", "widget"} },
+			expectedErr: `unknown unit type "widget"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.ErrorContains(t, xconfmap.Validate(cfg), tt.expectedErr)
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/systemdreceiver/internal/metadata"
 )
@@ -22,14 +24,30 @@ func NewFactory() receiver.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Scope:                scopeSystem,
+		Include:              []string{"*.service"},
+	}
 }
 
 func createMetricsReceiver(
 	_ context.Context,
-	_ receiver.Settings,
-	_ component.Config,
-	_ consumer.Metrics,
+	params receiver.Settings,
+	rConf component.Config,
+	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
-	return systemdReceiver{}, nil
+	cfg := rConf.(*Config)
+
+	ss := newSystemdScraper(params, cfg, newDBusClient)
+	s, err := scraper.NewMetrics(ss.scrape, scraper.WithShutdown(ss.shutdown))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraperhelper.NewMetricsController(
+		&cfg.ControllerConfig, params, consumer,
+		scraperhelper.AddScraper(metadata.Type, s),
+	)
 }
@@ -0,0 +1,120 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/filter"
+)
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for systemd metrics.
+type MetricsConfig struct {
+	SystemdServiceRestarts     MetricConfig `mapstructure:"systemd.service.restarts"`
+	SystemdUnitCPUTime         MetricConfig `mapstructure:"systemd.unit.cpu.time"`
+	SystemdUnitMemoryUsage     MetricConfig `mapstructure:"systemd.unit.memory.usage"`
+	SystemdUnitState           MetricConfig `mapstructure:"systemd.unit.state"`
+	SystemdUnitStateLastChange MetricConfig `mapstructure:"systemd.unit.state.last_change"`
+	SystemdUnitSubState        MetricConfig `mapstructure:"systemd.unit.sub_state"`
+	SystemdUnitTasks           MetricConfig `mapstructure:"systemd.unit.tasks"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		SystemdServiceRestarts: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitCPUTime: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitMemoryUsage: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitState: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitStateLastChange: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitSubState: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitTasks: MetricConfig{
+			Enabled: false,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Experimental: MetricsInclude defines a list of filters for attribute values.
+	// If the list is not empty, only metrics with matching resource attribute values will be emitted.
+	MetricsInclude []filter.Config `mapstructure:"metrics_include"`
+	// Experimental: MetricsExclude defines a list of filters for attribute values.
+	// If the list is not empty, metrics with matching resource attribute values will not be emitted.
+	// MetricsInclude has higher priority than MetricsExclude.
+	MetricsExclude []filter.Config `mapstructure:"metrics_exclude"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for systemd resource attributes.
+type ResourceAttributesConfig struct {
+	SystemdUnitName ResourceAttributeConfig `mapstructure:"systemd.unit.name"`
+	SystemdUnitType ResourceAttributeConfig `mapstructure:"systemd.unit.type"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		SystemdUnitName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		SystemdUnitType: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for systemd metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,671 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// AttributeActivityState specifies the value activity_state attribute.
+type AttributeActivityState int
+
+const (
+	_ AttributeActivityState = iota
+	AttributeActivityStateActive
+	AttributeActivityStateReloading
+	AttributeActivityStateInactive
+	AttributeActivityStateFailed
+	AttributeActivityStateActivating
+	AttributeActivityStateDeactivating
+	AttributeActivityStateMaintenance
+)
+
+// String returns the string representation of the AttributeActivityState.
+func (av AttributeActivityState) String() string {
+	switch av {
+	case AttributeActivityStateActive:
+		return "active"
+	case AttributeActivityStateReloading:
+		return "reloading"
+	case AttributeActivityStateInactive:
+		return "inactive"
+	case AttributeActivityStateFailed:
+		return "failed"
+	case AttributeActivityStateActivating:
+		return "activating"
+	case AttributeActivityStateDeactivating:
+		return "deactivating"
+	case AttributeActivityStateMaintenance:
+		return "maintenance"
+	}
+	return ""
+}
+
+// MapAttributeActivityState is a helper map of string to AttributeActivityState attribute value.
+var MapAttributeActivityState = map[string]AttributeActivityState{
+	"active":       AttributeActivityStateActive,
+	"reloading":    AttributeActivityStateReloading,
+	"inactive":     AttributeActivityStateInactive,
+	"failed":       AttributeActivityStateFailed,
+	"activating":   AttributeActivityStateActivating,
+	"deactivating": AttributeActivityStateDeactivating,
+	"maintenance":  AttributeActivityStateMaintenance,
+}
+
+var MetricsInfo = metricsInfo{
+	SystemdServiceRestarts: metricInfo{
+		Name: "systemd.service.restarts",
+	},
+	SystemdUnitCPUTime: metricInfo{
+		Name: "systemd.unit.cpu.time",
+	},
+	SystemdUnitMemoryUsage: metricInfo{
+		Name: "systemd.unit.memory.usage",
+	},
+	SystemdUnitState: metricInfo{
+		Name: "systemd.unit.state",
+	},
+	SystemdUnitStateLastChange: metricInfo{
+		Name: "systemd.unit.state.last_change",
+	},
+	SystemdUnitSubState: metricInfo{
+		Name: "systemd.unit.sub_state",
+	},
+	SystemdUnitTasks: metricInfo{
+		Name: "systemd.unit.tasks",
+	},
+}
+
+type metricsInfo struct {
+	SystemdServiceRestarts     metricInfo
+	SystemdUnitCPUTime         metricInfo
+	SystemdUnitMemoryUsage     metricInfo
+	SystemdUnitState           metricInfo
+	SystemdUnitStateLastChange metricInfo
+	SystemdUnitSubState        metricInfo
+	SystemdUnitTasks           metricInfo
+}
+
+type metricInfo struct {
+	Name string
+}
+
+type metricSystemdServiceRestarts struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.service.restarts metric with initial data.
+func (m *metricSystemdServiceRestarts) init() {
+	m.data.SetName("systemd.service.restarts")
+	m.data.SetDescription("Number of automatic restarts of the service by systemd (NRestarts).")
+	m.data.SetUnit("{restarts}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdServiceRestarts) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdServiceRestarts) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdServiceRestarts) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdServiceRestarts(cfg MetricConfig) metricSystemdServiceRestarts {
+	m := metricSystemdServiceRestarts{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitCPUTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.cpu.time metric with initial data.
+func (m *metricSystemdUnitCPUTime) init() {
+	m.data.SetName("systemd.unit.cpu.time")
+	m.data.SetDescription("Total CPU time consumed by the unit's control group. Only reported when CPU accounting is enabled for the unit.")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdUnitCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitCPUTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitCPUTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitCPUTime(cfg MetricConfig) metricSystemdUnitCPUTime {
+	m := metricSystemdUnitCPUTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitMemoryUsage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.memory.usage metric with initial data.
+func (m *metricSystemdUnitMemoryUsage) init() {
+	m.data.SetName("systemd.unit.memory.usage")
+	m.data.SetDescription("Current memory usage of the unit's control group. Only reported when memory accounting is enabled for the unit.")
+	m.data.SetUnit("By")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdUnitMemoryUsage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitMemoryUsage) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitMemoryUsage) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitMemoryUsage(cfg MetricConfig) metricSystemdUnitMemoryUsage {
+	m := metricSystemdUnitMemoryUsage{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.state metric with initial data.
+func (m *metricSystemdUnitState) init() {
+	m.data.SetName("systemd.unit.state")
+	m.data.SetDescription("Current activity state of the systemd unit. The datapoint of the current state is 1, the others are 0.")
+	m.data.SetUnit("{state}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemdUnitState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, activityStateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("activity_state", activityStateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitState) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitState) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitState(cfg MetricConfig) metricSystemdUnitState {
+	m := metricSystemdUnitState{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitStateLastChange struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.state.last_change metric with initial data.
+func (m *metricSystemdUnitStateLastChange) init() {
+	m.data.SetName("systemd.unit.state.last_change")
+	m.data.SetDescription("Time of the last activity state change of the systemd unit, as seconds since the Unix epoch.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricSystemdUnitStateLastChange) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitStateLastChange) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitStateLastChange) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitStateLastChange(cfg MetricConfig) metricSystemdUnitStateLastChange {
+	m := metricSystemdUnitStateLastChange{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitSubState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.sub_state metric with initial data.
+func (m *metricSystemdUnitSubState) init() {
+	m.data.SetName("systemd.unit.sub_state")
+	m.data.SetDescription("Current sub state of the systemd unit. Only the current sub state is reported, with a value of 1.")
+	m.data.SetUnit("{state}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemdUnitSubState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, subStateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("sub_state", subStateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitSubState) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitSubState) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitSubState(cfg MetricConfig) metricSystemdUnitSubState {
+	m := metricSystemdUnitSubState{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitTasks struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.tasks metric with initial data.
+func (m *metricSystemdUnitTasks) init() {
+	m.data.SetName("systemd.unit.tasks")
+	m.data.SetDescription("Current number of tasks in the unit's control group. Only reported when tasks accounting is enabled for the unit.")
+	m.data.SetUnit("{tasks}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdUnitTasks) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitTasks) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitTasks) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitTasks(cfg MetricConfig) metricSystemdUnitTasks {
+	m := metricSystemdUnitTasks{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user config.
+type MetricsBuilder struct {
+	config                           MetricsBuilderConfig // config of the metrics builder.
+	startTime                        pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity                  int                  // maximum observed number of metrics per resource.
+	metricsBuffer                    pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                        component.BuildInfo  // contains version information.
+	resourceAttributeIncludeFilter   map[string]filter.Filter
+	resourceAttributeExcludeFilter   map[string]filter.Filter
+	metricSystemdServiceRestarts     metricSystemdServiceRestarts
+	metricSystemdUnitCPUTime         metricSystemdUnitCPUTime
+	metricSystemdUnitMemoryUsage     metricSystemdUnitMemoryUsage
+	metricSystemdUnitState           metricSystemdUnitState
+	metricSystemdUnitStateLastChange metricSystemdUnitStateLastChange
+	metricSystemdUnitSubState        metricSystemdUnitSubState
+	metricSystemdUnitTasks           metricSystemdUnitTasks
+}
+
+// MetricBuilderOption applies changes to default metrics builder.
+type MetricBuilderOption interface {
+	apply(*MetricsBuilder)
+}
+
+type metricBuilderOptionFunc func(mb *MetricsBuilder)
+
+func (mbof metricBuilderOptionFunc) apply(mb *MetricsBuilder) {
+	mbof(mb)
+}
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
+	return metricBuilderOptionFunc(func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	})
+}
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, options ...MetricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		config:                           mbc,
+		startTime:                        pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                    pmetric.NewMetrics(),
+		buildInfo:                        settings.BuildInfo,
+		metricSystemdServiceRestarts:     newMetricSystemdServiceRestarts(mbc.Metrics.SystemdServiceRestarts),
+		metricSystemdUnitCPUTime:         newMetricSystemdUnitCPUTime(mbc.Metrics.SystemdUnitCPUTime),
+		metricSystemdUnitMemoryUsage:     newMetricSystemdUnitMemoryUsage(mbc.Metrics.SystemdUnitMemoryUsage),
+		metricSystemdUnitState:           newMetricSystemdUnitState(mbc.Metrics.SystemdUnitState),
+		metricSystemdUnitStateLastChange: newMetricSystemdUnitStateLastChange(mbc.Metrics.SystemdUnitStateLastChange),
+		metricSystemdUnitSubState:        newMetricSystemdUnitSubState(mbc.Metrics.SystemdUnitSubState),
+		metricSystemdUnitTasks:           newMetricSystemdUnitTasks(mbc.Metrics.SystemdUnitTasks),
+		resourceAttributeIncludeFilter:   make(map[string]filter.Filter),
+		resourceAttributeExcludeFilter:   make(map[string]filter.Filter),
+	}
+	if mbc.ResourceAttributes.SystemdUnitName.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["systemd.unit.name"] = filter.CreateFilter(mbc.ResourceAttributes.SystemdUnitName.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.SystemdUnitName.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["systemd.unit.name"] = filter.CreateFilter(mbc.ResourceAttributes.SystemdUnitName.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.SystemdUnitType.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["systemd.unit.type"] = filter.CreateFilter(mbc.ResourceAttributes.SystemdUnitType.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.SystemdUnitType.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["systemd.unit.type"] = filter.CreateFilter(mbc.ResourceAttributes.SystemdUnitType.MetricsExclude)
+	}
+
+	for _, op := range options {
+		op.apply(mb)
+	}
+	return mb
+}
+
+// NewResourceBuilder returns a new resource builder that should be used to build a resource associated with for the emitted metrics.
+func (mb *MetricsBuilder) NewResourceBuilder() *ResourceBuilder {
+	return NewResourceBuilder(mb.config.ResourceAttributes)
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption interface {
+	apply(pmetric.ResourceMetrics)
+}
+
+type resourceMetricsOptionFunc func(pmetric.ResourceMetrics)
+
+func (rmof resourceMetricsOptionFunc) apply(rm pmetric.ResourceMetrics) {
+	rmof(rm)
+}
+
+// WithResource sets the provided resource on the emitted ResourceMetrics.
+// It's recommended to use ResourceBuilder to create the resource.
+func WithResource(res pcommon.Resource) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		res.CopyTo(rm.Resource())
+	})
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	})
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName(ScopeName)
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricSystemdServiceRestarts.emit(ils.Metrics())
+	mb.metricSystemdUnitCPUTime.emit(ils.Metrics())
+	mb.metricSystemdUnitMemoryUsage.emit(ils.Metrics())
+	mb.metricSystemdUnitState.emit(ils.Metrics())
+	mb.metricSystemdUnitStateLastChange.emit(ils.Metrics())
+	mb.metricSystemdUnitSubState.emit(ils.Metrics())
+	mb.metricSystemdUnitTasks.emit(ils.Metrics())
+
+	for _, op := range options {
+		op.apply(rm)
+	}
+	for attr, filter := range mb.resourceAttributeIncludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && !filter.Matches(val.AsString()) {
+			return
+		}
+	}
+	for attr, filter := range mb.resourceAttributeExcludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && filter.Matches(val.AsString()) {
+			return
+		}
+	}
+
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user config, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(options...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordSystemdServiceRestartsDataPoint adds a data point to systemd.service.restarts metric.
+func (mb *MetricsBuilder) RecordSystemdServiceRestartsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdServiceRestarts.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdUnitCPUTimeDataPoint adds a data point to systemd.unit.cpu.time metric.
+func (mb *MetricsBuilder) RecordSystemdUnitCPUTimeDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricSystemdUnitCPUTime.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdUnitMemoryUsageDataPoint adds a data point to systemd.unit.memory.usage metric.
+func (mb *MetricsBuilder) RecordSystemdUnitMemoryUsageDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdUnitMemoryUsage.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdUnitStateDataPoint adds a data point to systemd.unit.state metric.
+func (mb *MetricsBuilder) RecordSystemdUnitStateDataPoint(ts pcommon.Timestamp, val int64, activityStateAttributeValue AttributeActivityState) {
+	mb.metricSystemdUnitState.recordDataPoint(mb.startTime, ts, val, activityStateAttributeValue.String())
+}
+
+// RecordSystemdUnitStateLastChangeDataPoint adds a data point to systemd.unit.state.last_change metric.
+func (mb *MetricsBuilder) RecordSystemdUnitStateLastChangeDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdUnitStateLastChange.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdUnitSubStateDataPoint adds a data point to systemd.unit.sub_state metric.
+func (mb *MetricsBuilder) RecordSystemdUnitSubStateDataPoint(ts pcommon.Timestamp, val int64, subStateAttributeValue string) {
+	mb.metricSystemdUnitSubState.recordDataPoint(mb.startTime, ts, val, subStateAttributeValue)
+}
+
+// RecordSystemdUnitTasksDataPoint adds a data point to systemd.unit.tasks metric.
+func (mb *MetricsBuilder) RecordSystemdUnitTasksDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdUnitTasks.recordDataPoint(mb.startTime, ts, val)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...MetricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op.apply(mb)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package systemdreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/systemdreceiver"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/systemdreceiver/internal/metadata"
+)
+
+// cgroupUnitTypes are the unit types exposing resource accounting properties
+// on their type specific D-Bus interface.
+var cgroupUnitTypes = []string{"mount", "scope", "service", "slice", "socket", "swap"}
+
+type systemdScraper struct {
+	settings  component.TelemetrySettings
+	cfg       *Config
+	mb        *metadata.MetricsBuilder
+	newClient newClientFunc
+	client    dbusClient
+}
+
+func newSystemdScraper(
+	settings receiver.Settings,
+	cfg *Config,
+	newClient newClientFunc,
+) *systemdScraper {
+	return &systemdScraper{
+		settings:  settings.TelemetrySettings,
+		cfg:       cfg,
+		mb:        metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+		newClient: newClient,
+	}
+}
+
+func (s *systemdScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if s.client == nil {
+		client, err := s.newClient(ctx, s.cfg.Scope)
+		if err != nil {
+			return pmetric.NewMetrics(), fmt.Errorf("failed to connect to systemd: %w", err)
+		}
+		s.client = client
+	}
+
+	units, err := s.client.ListUnitsByPatternsContext(ctx, nil, s.cfg.Include)
+	if err != nil {
+		// The connection may have been dropped, e.g. on a daemon re-exec,
+		// reconnect on the next scrape.
+		s.client.Close()
+		s.client = nil
+		return pmetric.NewMetrics(), fmt.Errorf("failed to list systemd units: %w", err)
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	var errs scrapererror.ScrapeErrors
+	for _, unit := range units {
+		unitType := unitTypeOf(unit.Name)
+		if !s.includeUnit(unit.Name, unitType) {
+			continue
+		}
+		if err := s.recordUnit(ctx, now, unit, unitType); err != nil {
+			s.settings.Logger.Debug("Failed to read systemd unit properties", zap.String("unit", unit.Name), zap.Error(err))
+			errs.AddPartial(1, err)
+		}
+
+		rb := s.mb.NewResourceBuilder()
+		rb.SetSystemdUnitName(unit.Name)
+		rb.SetSystemdUnitType(unitType)
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *systemdScraper) shutdown(context.Context) error {
+	if s.client != nil {
+		s.client.Close()
+		s.client = nil
+	}
+	return nil
+}
+
+func (s *systemdScraper) includeUnit(name, unitType string) bool {
+	if len(s.cfg.UnitTypes) > 0 && !slices.Contains(s.cfg.UnitTypes, unitType) {
+		return false
+	}
+	for _, pattern := range s.cfg.Exclude {
+		// Patterns are validated in Config.Validate.
+		if matched, _ := path.Match(pattern, name); matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *systemdScraper) recordUnit(ctx context.Context, now pcommon.Timestamp, unit dbus.UnitStatus, unitType string) error {
+	for state, value := range metadata.MapAttributeActivityState {
+		var v int64
+		if state == unit.ActiveState {
+			v = 1
+		}
+		s.mb.RecordSystemdUnitStateDataPoint(now, v, value)
+	}
+	s.mb.RecordSystemdUnitSubStateDataPoint(now, 1, unit.SubState)
+
+	props, err := s.client.GetUnitPropertiesContext(ctx, unit.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get properties of unit %q: %w", unit.Name, err)
+	}
+	if ts, ok := uint64Property(props, "StateChangeTimestamp"); ok && ts > 0 {
+		s.mb.RecordSystemdUnitStateLastChangeDataPoint(now, int64(ts/uint64(time.Second/time.Microsecond)))
+	}
+
+	if !slices.Contains(cgroupUnitTypes, unitType) {
+		return nil
+	}
+	props, err = s.client.GetUnitTypePropertiesContext(ctx, unit.Name, typeInterface(unitType))
+	if err != nil {
+		return fmt.Errorf("failed to get %s properties of unit %q: %w", unitType, unit.Name, err)
+	}
+	if unitType == "service" {
+		if restarts, ok := uint64Property(props, "NRestarts"); ok {
+			s.mb.RecordSystemdServiceRestartsDataPoint(now, int64(restarts))
+		}
+	}
+	if memory, ok := uint64Property(props, "MemoryCurrent"); ok {
+		s.mb.RecordSystemdUnitMemoryUsageDataPoint(now, int64(memory))
+	}
+	if cpu, ok := uint64Property(props, "CPUUsageNSec"); ok {
+		s.mb.RecordSystemdUnitCPUTimeDataPoint(now, float64(cpu)/float64(time.Second))
+	}
+	if tasks, ok := uint64Property(props, "TasksCurrent"); ok {
+		s.mb.RecordSystemdUnitTasksDataPoint(now, int64(tasks))
+	}
+	return nil
+}
+
+// unitTypeOf returns the type of the unit, which is the suffix of its name.
+func unitTypeOf(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+// typeInterface returns the suffix of the D-Bus interface holding the type
+// specific properties of a unit, e.g. "Service" for org.freedesktop.systemd1.Service.
+func typeInterface(unitType string) string {
+	if unitType == "" {
+		return ""
+	}
+	return strings.ToUpper(unitType[:1]) + unitType[1:]
+}
+
+// uint64Property reads an unsigned integer property. systemd reports
+// accounting values as math.MaxUint64 when the accounting is disabled.
+func uint64Property(props map[string]any, name string) (uint64, bool) {
+	var v uint64
+	switch val := props[name].(type) {
+	case uint64:
+		v = val
+	case uint32:
+		v = uint64(val)
+	default:
+		return 0, false
+	}
+	if v == math.MaxUint64 {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package systemdreceiver
+
+import (
+	"context"
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/systemdreceiver/internal/metadata"
+)
+
+// fakeBus is an in-memory stand-in for the systemd D-Bus API.
+type fakeBus struct {
+	units     []dbus.UnitStatus
+	props     map[string]map[string]any
+	typeProps map[string]map[string]any
+	listErr   error
+	closed    bool
+}
+
+func (f *fakeBus) ListUnitsByPatternsContext(_ context.Context, _, patterns []string) ([]dbus.UnitStatus, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	var units []dbus.UnitStatus
+	for _, unit := range f.units {
+		for _, pattern := range patterns {
+			if matched, _ := filepath.Match(pattern, unit.Name); matched {
+				units = append(units, unit)
+				break
+			}
+		}
+	}
+	return units, nil
+}
+
+func (f *fakeBus) GetUnitPropertiesContext(_ context.Context, unit string) (map[string]any, error) {
+	props, ok := f.props[unit]
+	if !ok {
+		return nil, errors.New("unit not found")
+	}
+	return props, nil
+}
+
+func (f *fakeBus) GetUnitTypePropertiesContext(_ context.Context, unit, _ string) (map[string]any, error) {
+	props, ok := f.typeProps[unit]
+	if !ok {
+		return nil, errors.New("unit not found")
+	}
+	return props, nil
+}
+
+func (f *fakeBus) Close() {
+	f.closed = true
+}
+
+func newFakeBus() *fakeBus {
+	return &fakeBus{
+		units: []dbus.UnitStatus{
+			{Name: "sshd.service", ActiveState: "active", SubState: "running"},
+			{Name: "backup.service", ActiveState: "failed", SubState: "failed"},
+			{Name: "systemd-journald.service", ActiveState: "active", SubState: "running"},
+			{Name: "sshd.socket", ActiveState: "active", SubState: "listening"},
+			{Name: "backup.timer", ActiveState: "active", SubState: "waiting"},
+		},
+		props: map[string]map[string]any{
+			"sshd.service":             {"StateChangeTimestamp": uint64(1700000000123456)},
+			"backup.service":           {"StateChangeTimestamp": uint64(1700000100000000)},
+			"systemd-journald.service": {"StateChangeTimestamp": uint64(1700000000000000)},
+			"sshd.socket":              {"StateChangeTimestamp": uint64(1700000000000000)},
+			"backup.timer":             {"StateChangeTimestamp": uint64(0)},
+		},
+		typeProps: map[string]map[string]any{
+			"sshd.service": {
+				"NRestarts":     uint32(2),
+				"MemoryCurrent": uint64(4194304),
+				"CPUUsageNSec":  uint64(1500000000),
+				"TasksCurrent":  uint64(3),
+			},
+			"backup.service": {
+				"NRestarts":     uint32(5),
+				"MemoryCurrent": uint64(math.MaxUint64),
+				"CPUUsageNSec":  uint64(math.MaxUint64),
+				"TasksCurrent":  uint64(math.MaxUint64),
+			},
+			"systemd-journald.service": {
+				"NRestarts":     uint32(0),
+				"MemoryCurrent": uint64(8388608),
+			},
+			"sshd.socket": {
+				"MemoryCurrent": uint64(16384),
+			},
+		},
+	}
+}
+
+func newTestScraper(cfg *Config, bus dbusClient) *systemdScraper {
+	return newSystemdScraper(receivertest.NewNopSettings(metadata.Type), cfg, func(context.Context, string) (dbusClient, error) {
+		return bus, nil
+	})
+}
+
+func TestScraper(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(*Config)
+		expectedFile string
+	}{
+		{
+			name:         "default",
+			modify:       func(*Config) {},
+			expectedFile: "expected.yaml",
+		},
+		{
+			name: "filtered",
+			modify: func(cfg *Config) {
+				cfg.Include = []string{"*"}
+				cfg.Exclude = []string{"systemd-*"}
+				cfg.UnitTypes = []string{"service", "socket"}
+				cfg.MetricsBuilderConfig.Metrics.SystemdUnitTasks.Enabled = true
+			},
+			expectedFile: "expected_filtered.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			s := newTestScraper(cfg, newFakeBus())
+
+			actualMetrics, err := s.scrape(context.Background())
+			require.NoError(t, err)
+
+			expectedMetrics, err := golden.ReadMetrics(filepath.Join("testdata", "scraper", tt.expectedFile))
+			require.NoError(t, err)
+			require.NoError(t, pmetrictest.CompareMetrics(expectedMetrics, actualMetrics,
+				pmetrictest.IgnoreStartTimestamp(),
+				pmetrictest.IgnoreTimestamp(),
+				pmetrictest.IgnoreMetricsOrder(),
+				pmetrictest.IgnoreMetricDataPointsOrder(),
+				pmetrictest.IgnoreResourceMetricsOrder()))
+		})
+	}
+}
+
+func TestScraperPartialError(t *testing.T) {
+	bus := newFakeBus()
+	delete(bus.typeProps, "sshd.service")
+	s := newTestScraper(createDefaultConfig().(*Config), bus)
+
+	metrics, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, `failed to get service properties of unit "sshd.service"`)
+	// The states of the unit are still reported.
+	assert.Equal(t, 3, metrics.ResourceMetrics().Len())
+}
+
+func TestScraperConnectError(t *testing.T) {
+	s := newSystemdScraper(receivertest.NewNopSettings(metadata.Type), createDefaultConfig().(*Config), func(context.Context, string) (dbusClient, error) {
+		return nil, errors.New("no such file or directory")
+	})
+
+	_, err := s.scrape(context.Background())
+	assert.EqualError(t, err, "failed to connect to systemd: no such file or directory")
+	require.NoError(t, s.shutdown(context.Background()))
+}
+
+func TestScraperReconnect(t *testing.T) {
+	bus := newFakeBus()
+	bus.listErr = errors.New("connection closed")
+	connects := 0
+	s := newSystemdScraper(receivertest.NewNopSettings(metadata.Type), createDefaultConfig().(*Config), func(context.Context, string) (dbusClient, error) {
+		connects++
+		return bus, nil
+	})
+
+	_, err := s.scrape(context.Background())
+	assert.EqualError(t, err, "failed to list systemd units: connection closed")
+	assert.True(t, bus.closed)
+
+	bus.listErr = nil
+	_, err = s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, connects)
+
+	require.NoError(t, s.shutdown(context.Background()))
+	assert.True(t, bus.closed)
+}
+
+func TestUnitTypeOf(t *testing.T) {
+	assert.Equal(t, "service", unitTypeOf("sshd.service"))
+	assert.Equal(t, "slice", unitTypeOf("user-1000.slice"))
+	assert.Equal(t, "Service", typeInterface("service"))
+	assert.Empty(t, unitTypeOf("invalid"))
+}